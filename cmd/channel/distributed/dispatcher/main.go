@@ -2,15 +2,19 @@ package main
 
 import (
 	"flag"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
@@ -21,10 +25,16 @@ import (
 	dispatcherhealth "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/health"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	"knative.dev/eventing-kafka/pkg/client/informers/externalversions"
+	"knative.dev/eventing-kafka/pkg/common/admin"
+	"knative.dev/eventing-kafka/pkg/common/credentials"
+	commonhealth "knative.dev/eventing-kafka/pkg/common/health"
+	eventingclientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions"
 	kncontroller "knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	eventingmetrics "knative.dev/pkg/metrics"
 	"knative.dev/pkg/signals"
+	"knative.dev/pkg/system"
 )
 
 // Variables
@@ -33,6 +43,10 @@ var (
 	dispatcher dispatch.Dispatcher
 	serverURL  = flag.String("server", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
 	kubeconfig = flag.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+
+	// The KafkaChannel Controller & Informer (Resynced When The ConfigMap Changes)
+	kafkaChannelController     *kncontroller.Impl
+	kafkaChannelSharedInformer cache.SharedInformer
 )
 
 // The Main Function (Go Command)
@@ -58,7 +72,7 @@ func main() {
 	}
 
 	// Load The Sarama & Eventing-Kafka Configuration From The ConfigMap
-	saramaConfig, _, err := sarama.LoadSettings(ctx)
+	saramaConfig, ekConfig, err := sarama.LoadSettings(ctx)
 	if err != nil {
 		logger.Fatal("Failed To Load Sarama Settings", zap.Error(err))
 	}
@@ -66,6 +80,19 @@ func main() {
 	// Update The Sarama Config - Username/Password Overrides (EnvVars From Secret Take Precedence Over ConfigMap)
 	sarama.UpdateSaramaConfig(saramaConfig, constants.Component, environment.KafkaUsername, environment.KafkaPassword)
 
+	// Update The Sarama Config - Optional TLS Certificates Of The Mounted Kafka Secret (Mutual TLS Authentication)
+	var tlsCertificates sarama.TLSCertificates
+	if len(environment.KafkaSecretPath) > 0 {
+		tlsCertificates, err = sarama.LoadTLSCertificates(environment.KafkaSecretPath)
+		if err != nil {
+			logger.Fatal("Failed To Load The Kafka Secret TLS Certificates", zap.Error(err))
+		}
+		err = sarama.UpdateSaramaTLSConfig(saramaConfig, tlsCertificates)
+		if err != nil {
+			logger.Fatal("Invalid Kafka Secret TLS Certificates", zap.Error(err))
+		}
+	}
+
 	// Initialize Tracing (Watches config-tracing ConfigMap, Assumes Context Came From LoggingContext With Embedded K8S Client Key)
 	err = commonconfig.InitializeTracing(logger.Sugar(), ctx, environment.ServiceName)
 	if err != nil {
@@ -84,17 +111,29 @@ func main() {
 
 	statsReporter := metrics.NewStatsReporter(logger)
 
+	// Check The Connectivity To The Kafka Brokers & The Sessions Of The ConsumerGroups
+	brokers := strings.Split(environment.KafkaBrokers, ",")
+	brokerCheck := commonhealth.NewBrokerCheck(brokers, saramaConfig, environment.KafkaTopic)
+	defer brokerCheck.Close()
+	sessions := commonhealth.NewSessions(commonhealth.DefaultSessionGracePeriod)
+	healthServer.Checker().AddCheck(dispatcherhealth.BrokersCheck, commonhealth.Readiness, brokerCheck.Check)
+	healthServer.Checker().AddCheck(dispatcherhealth.ConsumerGroupsCheck, commonhealth.Readiness, sessions.Check)
+
 	// Create The Dispatcher With Specified Configuration
 	dispatcherConfig := dispatch.DispatcherConfig{
-		Logger:        logger,
-		ClientId:      constants.Component,
-		Brokers:       strings.Split(environment.KafkaBrokers, ","),
-		Topic:         environment.KafkaTopic,
-		Username:      environment.KafkaUsername,
-		Password:      environment.KafkaPassword,
-		ChannelKey:    environment.ChannelKey,
-		StatsReporter: statsReporter,
-		SaramaConfig:  saramaConfig,
+		Logger:          logger,
+		ClientId:        constants.Component,
+		Brokers:         brokers,
+		Topic:           environment.KafkaTopic,
+		Username:        environment.KafkaUsername,
+		Password:        environment.KafkaPassword,
+		ChannelKey:      environment.ChannelKey,
+		StatsReporter:   statsReporter,
+		SaramaConfig:    saramaConfig,
+		DrainTimeout:    time.Duration(ekConfig.Dispatcher.DrainTimeoutSeconds) * time.Second,
+		TLSCertificates: tlsCertificates,
+		Sessions:        sessions,
+		BrokerCheck:     brokerCheck,
 	}
 	dispatcher = dispatch.NewDispatcher(dispatcherConfig)
 
@@ -104,6 +143,19 @@ func main() {
 		logger.Fatal("Failed To Initialize ConfigMap Watcher", zap.Error(err))
 	}
 
+	// Watch The Mounted Kafka Secret For Credential Changes (Rotated Without Restarting The Pod)
+	if len(environment.KafkaSecretPath) > 0 {
+		credentialsWatcher, err := credentials.NewWatcher(logger,
+			filepath.Join(environment.KafkaSecretPath, commonconstants.KafkaSecretUsernameFile),
+			filepath.Join(environment.KafkaSecretPath, commonconstants.KafkaSecretPasswordFile),
+			credentials.DefaultPollInterval,
+			credentialsObserver)
+		if err != nil {
+			logger.Fatal("Failed To Initialize Kafka Secret Watcher", zap.Error(err))
+		}
+		credentialsWatcher.Start(ctx.Done())
+	}
+
 	config, err := clientcmd.BuildConfigFromFlags(*serverURL, *kubeconfig)
 	if err != nil {
 		logger.Fatal("Error building kubeconfig", zap.Error(err))
@@ -116,9 +168,17 @@ func main() {
 	kubeClient := kubernetes.NewForConfigOrDie(config)
 	kafkaInformerFactory := externalversions.NewSharedInformerFactory(kafkaClientSet, kncontroller.DefaultResyncPeriod)
 
+	// Serve The Admin Endpoints Next To The Health Probes (Authorized Against The KafkaChannels/Admin Subresource Of The Namespace)
+	healthServer.Handle(admin.PathPrefix, admin.NewHandler(logger, dispatcher, admin.NewAuthorizer(logger, kubeClient, system.Namespace())))
+
 	// Create KafkaChannel Informer
 	kafkaChannelInformer := kafkaInformerFactory.Messaging().V1beta1().KafkaChannels()
 
+	// Create Subscription Informer (Subscriptions May Be Paused Via Annotation)
+	eventingClientSet := eventingclientset.NewForConfigOrDie(config)
+	eventingInformerFactory := eventinginformers.NewSharedInformerFactory(eventingClientSet, kncontroller.DefaultResyncPeriod)
+	subscriptionInformer := eventingInformerFactory.Messaging().V1().Subscriptions()
+
 	// Construct Array Of Controllers, In Our Case Just The One
 	controllers := [...]*kncontroller.Impl{
 		controller.NewController(
@@ -126,15 +186,24 @@ func main() {
 			environment.ChannelKey,
 			dispatcher,
 			kafkaChannelInformer,
+			subscriptionInformer,
 			kubeClient,
 			kafkaClientSet,
 			ctx.Done(),
 		),
 	}
 
+	// Track The KafkaChannel Controller For ConfigMap Changes
+	kafkaChannelController = controllers[0]
+	kafkaChannelSharedInformer = kafkaChannelInformer.Informer()
+
+	// Check The Sync Of The Informers
+	healthServer.Checker().AddCheck(dispatcherhealth.InformersCheck, commonhealth.Readiness,
+		commonhealth.SyncCheck(kafkaChannelInformer.Informer().HasSynced, subscriptionInformer.Informer().HasSynced))
+
 	// Start The Informers
 	logger.Info("Starting informers.")
-	if err := kncontroller.StartInformers(ctx.Done(), kafkaChannelInformer.Informer()); err != nil {
+	if err := kncontroller.StartInformers(ctx.Done(), kafkaChannelInformer.Informer(), subscriptionInformer.Informer()); err != nil {
 		logger.Error("Failed to start informers", zap.Error(err))
 		return
 	}
@@ -151,7 +220,7 @@ func main() {
 	// Reset The Liveness and Readiness Flags In Preparation For Shutdown
 	healthServer.Shutdown()
 
-	// Shutdown The Dispatcher (Close ConsumerGroups)
+	// Shutdown The Dispatcher (Drain In-Flight Events & Close ConsumerGroups)
 	dispatcher.Shutdown()
 
 	// Stop The Liveness And Readiness Servers
@@ -176,10 +245,30 @@ func configMapObserver(configMap *v1.ConfigMap) {
 		return
 	}
 
-	// Toss the new config map to the dispatcher for inspection and action
-	newDispatcher := dispatcher.ConfigChanged(configMap)
-	if newDispatcher != nil {
-		// The configuration change caused a new dispatcher to be created, so switch to that one
-		dispatcher = newDispatcher
+	// Toss the new config map to the dispatcher for inspection and action (rejected or partially applied changes
+	// leave the ConsumerGroups consuming with their previous configuration, so there is no need to terminate)
+	err := dispatcher.ConfigChanged(configMap)
+	if err != nil {
+		logger.Error("Failed To Apply ConfigMap Changes", zap.Error(err))
+	}
+
+	// Reconcile the KafkaChannel to report (and retry) the subscribers left on a previous configuration
+	if kafkaChannelController != nil {
+		kafkaChannelController.GlobalResync(kafkaChannelSharedInformer)
+	}
+}
+
+// credentialsObserver is the callback function that handles changes to the credentials of the mounted Kafka Secret
+func credentialsObserver(newCredentials credentials.Credentials) {
+
+	// Roll the ConsumerGroups onto the new credentials (those failing to roll keep consuming with the previous ones)
+	err := dispatcher.CredentialsChanged(newCredentials.Username, newCredentials.Password)
+	if err != nil {
+		logger.Error("Failed To Apply Credential Changes", zap.Error(err))
+	}
+
+	// Reconcile the KafkaChannel to report (and retry) the subscribers left on the previous credentials
+	if kafkaChannelController != nil {
+		kafkaChannelController.GlobalResync(kafkaChannelSharedInformer)
 	}
 }
@@ -4,13 +4,17 @@ import (
 	"context"
 	"flag"
 	nethttp "net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	kafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
@@ -20,7 +24,10 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/env"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
+	"knative.dev/eventing-kafka/pkg/common/credentials"
+	"knative.dev/eventing-kafka/pkg/common/ingress"
 	eventingchannel "knative.dev/eventing/pkg/channel"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
 	eventingmetrics "knative.dev/pkg/metrics"
 )
@@ -31,6 +38,7 @@ var (
 	serverURL     = flag.String("server", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
 	kubeconfig    = flag.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	kafkaProducer *producer.Producer
+	producerLock  sync.RWMutex // Serializes The Producer Switches Of The ConfigMap & Credentials Observers With The Messages Holding It
 )
 
 // The Main Function (Go Command)
@@ -64,6 +72,19 @@ func main() {
 	// Update The Sarama Config - Username/Password Overrides (EnvVars From Secret Take Precedence Over ConfigMap)
 	sarama.UpdateSaramaConfig(saramaConfig, constants.Component, environment.KafkaUsername, environment.KafkaPassword)
 
+	// Update The Sarama Config - Optional TLS Certificates Of The Mounted Kafka Secret (Mutual TLS Authentication)
+	var tlsCertificates sarama.TLSCertificates
+	if len(environment.KafkaSecretPath) > 0 {
+		tlsCertificates, err = sarama.LoadTLSCertificates(environment.KafkaSecretPath)
+		if err != nil {
+			logger.Fatal("Failed To Load The Kafka Secret TLS Certificates", zap.Error(err))
+		}
+		err = sarama.UpdateSaramaTLSConfig(saramaConfig, tlsCertificates)
+		if err != nil {
+			logger.Fatal("Invalid Kafka Secret TLS Certificates", zap.Error(err))
+		}
+	}
+
 	// Initialize Tracing (Watches config-tracing ConfigMap, Assumes Context Came From LoggingContext With Embedded K8S Client Key)
 	err = commonconfig.InitializeTracing(logger.Sugar(), ctx, environment.ServiceName)
 	if err != nil {
@@ -97,11 +118,33 @@ func main() {
 	}
 
 	// Initialize The Kafka Producer In Order To Start Processing Status Events
-	kafkaProducer, err = producer.NewProducer(logger, saramaConfig, strings.Split(environment.KafkaBrokers, ","), statsReporter, healthServer)
+	initialProducer, err := producer.NewProducer(logger, saramaConfig, strings.Split(environment.KafkaBrokers, ","), tlsCertificates, statsReporter, healthServer)
 	if err != nil {
 		logger.Fatal("Failed To Initialize Kafka Producer", zap.Error(err))
 	}
-	defer kafkaProducer.Close()
+	producerLock.Lock()
+	kafkaProducer = initialProducer
+	producerLock.Unlock()
+
+	// Close The Current Producer On Exit (The Observers May Have Switched It Meanwhile)
+	defer func() {
+		producerLock.Lock()
+		defer producerLock.Unlock()
+		kafkaProducer.Close()
+	}()
+
+	// Watch The Mounted Kafka Secret For Credential Changes (Rotated Without Restarting The Pod)
+	if len(environment.KafkaSecretPath) > 0 {
+		credentialsWatcher, err := credentials.NewWatcher(logger,
+			filepath.Join(environment.KafkaSecretPath, commonconstants.KafkaSecretUsernameFile),
+			filepath.Join(environment.KafkaSecretPath, commonconstants.KafkaSecretPasswordFile),
+			credentials.DefaultPollInterval,
+			credentialsObserver)
+		if err != nil {
+			logger.Fatal("Failed To Initialize Kafka Secret Watcher", zap.Error(err))
+		}
+		credentialsWatcher.Start(ctx.Done())
+	}
 
 	// Create A New Knative Eventing MessageReceiver (Parses The Channel From The Host Header)
 	messageReceiver, err := eventingchannel.NewMessageReceiver(handleMessage, logger, eventingchannel.ResolveMessageChannelFromHostHeader(eventingchannel.ParseChannel))
@@ -112,8 +155,11 @@ func main() {
 	// Set The Liveness Flag - Readiness Is Set By Individual Components
 	healthServer.SetAlive(true)
 
-	// Start The Message Receiver (Blocking)
-	err = messageReceiver.Start(ctx)
+	// Authenticate The Senders Against The KafkaChannels' Ingress Policies (ServiceAccount Tokens Reviewed By The K8S API)
+	authenticator := ingress.NewAuthenticator(logger, kubeclient.Get(ctx))
+
+	// Start The Message Receiver Behind The Authentication Of The Senders & The Validation Of The Events (Blocking)
+	err = ingress.Start(ctx, ingress.NewAuthHandler(authenticator, getIngressPolicy, ingress.NewHandler(logger, getValidationRules, messageReceiver)))
 	if err != nil {
 		logger.Error("Failed To Start MessageReceiver", zap.Error(err))
 	}
@@ -148,8 +194,15 @@ func handleMessage(ctx context.Context, channelReference eventingchannel.Channel
 		return err
 	}
 
-	// Produce The CloudEvent Binding Message (Send To The Appropriate Kafka Topic)
-	err = kafkaProducer.ProduceKafkaMessage(ctx, channelReference, message, transformers...)
+	// Hold The Current Producer Until The Message Is Produced (A Producer Switched Meanwhile Is Closed Afterwards)
+	producerLock.RLock()
+	currentProducer := kafkaProducer
+	done := currentProducer.Hold()
+	producerLock.RUnlock()
+	defer done()
+
+	// Produce The CloudEvent Binding Message (Send To The Appropriate Kafka Topic, Keyed, Partitioned & Deduplicated As Per The KafkaChannel)
+	err = currentProducer.ProduceKafkaMessage(ctx, channelReference, channel.GetPartitioningSettings(channelReference), channel.GetExactlyOnceSettings(channelReference), message, transformers...)
 	if err != nil {
 		logger.Error("Failed To Produce Kafka Message", zap.Error(err))
 		return err
@@ -159,12 +212,36 @@ func handleMessage(ctx context.Context, channelReference eventingchannel.Channel
 	return nil
 }
 
+// Get The Ingress Validation Rules Of The KafkaChannel Addressed By The Host (Accepting All Events If Unknown)
+func getValidationRules(host string) ingress.Rules {
+	channelReference, err := eventingchannel.ParseChannel(host)
+	if err != nil {
+		return ingress.Rules{} // Rejected By The MessageReceiver
+	}
+	channelReference.Name = kafkautil.TrimKafkaChannelServiceNameSuffix(channelReference.Name)
+	return channel.GetValidationRules(channelReference)
+}
+
+// Get The Ingress Policy Of The KafkaChannel Addressed By The Host (Accepting Any Sender If Not Found)
+func getIngressPolicy(host string) (*kafkav1beta1.IngressPolicy, error) {
+	channelReference, err := eventingchannel.ParseChannel(host)
+	if err != nil {
+		return nil, nil // Rejected By The MessageReceiver
+	}
+	channelReference.Name = kafkautil.TrimKafkaChannelServiceNameSuffix(channelReference.Name)
+	return channel.GetIngressPolicy(channelReference)
+}
+
 // configMapObserver is the callback function that handles changes to our ConfigMap
 func configMapObserver(configMap *v1.ConfigMap) {
 	if configMap == nil {
 		logger.Warn("Nil ConfigMap passed to configMapObserver; ignoring")
 		return
 	}
+
+	producerLock.Lock()
+	defer producerLock.Unlock()
+
 	if kafkaProducer == nil {
 		// This typically happens during startup
 		logger.Debug("Producer is nil during call to configMapObserver; ignoring changes")
@@ -179,3 +256,19 @@ func configMapObserver(configMap *v1.ConfigMap) {
 		kafkaProducer = newProducer
 	}
 }
+
+// credentialsObserver is the callback function that handles changes to the credentials of the mounted Kafka Secret
+func credentialsObserver(newCredentials credentials.Credentials) {
+	producerLock.Lock()
+	defer producerLock.Unlock()
+
+	// Toss the new credentials to the producer, switching to the new producer before closing the current one
+	// so that the messages keep being produced (the current one is closed once the messages holding it are sent)
+	newProducer := kafkaProducer.CredentialsChanged(newCredentials.Username, newCredentials.Password)
+	if newProducer != nil {
+		logger.Info("Producer Credentials Changed; Switching To New Producer")
+		previousProducer := kafkaProducer
+		kafkaProducer = newProducer
+		previousProducer.CloseReplaced()
+	}
+}
@@ -22,20 +22,26 @@ import (
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/eventing/pkg/logconfig"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/signals"
+	"knative.dev/pkg/system"
 	"knative.dev/pkg/webhook"
 	"knative.dev/pkg/webhook/certificates"
+	"knative.dev/pkg/webhook/configmaps"
 	"knative.dev/pkg/webhook/resourcesemantics"
 	"knative.dev/pkg/webhook/resourcesemantics/defaulting"
 	"knative.dev/pkg/webhook/resourcesemantics/validation"
 
 	messagingv1alpha1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1alpha1"
 	messagingv1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
@@ -91,6 +97,34 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 	)
 }
 
+func NewConfigValidationController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	return configmaps.NewAdmissionController(ctx,
+		// Name of the configmap webhook.
+		"config.webhook.kafka.messaging.knative.dev",
+
+		// The path on which to serve the webhook.
+		"/config-validation",
+
+		// The configmaps to validate (only those of the system namespace are read by the channels).
+		configmap.Constructors{
+			// The consolidated KafkaChannel configuration.
+			"config-kafka": func(configMap *corev1.ConfigMap) (*utils.KafkaConfig, error) {
+				if configMap.Namespace != system.Namespace() {
+					return nil, nil
+				}
+				return utils.GetKafkaConfig(configMap.Data)
+			},
+			// The distributed KafkaChannel configuration.
+			commonconfig.SettingsConfigMapName: func(configMap *corev1.ConfigMap) (*commonconfig.EventingKafkaConfig, error) {
+				if configMap.Namespace != system.Namespace() {
+					return nil, nil
+				}
+				return kafkasarama.ValidateSettings(configMap)
+			},
+		},
+	)
+}
+
 func NewConversionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	var (
 		messagingv1alpha1_ = messagingv1alpha1.SchemeGroupVersion.Version
@@ -134,6 +168,6 @@ func main() {
 		NewDefaultingAdmissionController,
 		NewValidationAdmissionController,
 		NewConversionController,
-		// TODO(mattmoor): Support config validation in eventing-kafka.
+		NewConfigValidationController,
 	)
 }
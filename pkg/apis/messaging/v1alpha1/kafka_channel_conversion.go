@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
@@ -87,8 +88,12 @@ func (source *KafkaChannel) ConvertTo(ctx context.Context, obj apis.Convertible)
 
 		sink.ObjectMeta = source.ObjectMeta
 		sink.Spec = v1beta1.KafkaChannelSpec{
-			NumPartitions:     source.Spec.NumPartitions,
-			ReplicationFactor: source.Spec.ReplicationFactor,
+			NumPartitions:      source.Spec.NumPartitions,
+			ReplicationFactor:  source.Spec.ReplicationFactor,
+			Ingress:            convertIngressPolicyTo(source.Spec.Ingress),
+			Partitioning:       convertPartitioningPolicyTo(source.Spec.Partitioning),
+			ExactlyOnceIngress: convertExactlyOnceIngressPolicyTo(source.Spec.ExactlyOnceIngress),
+			EventRules:         convertEventRulesTo(source.Spec.EventRules),
 			ChannelableSpec: eventingduckv1.ChannelableSpec{
 				SubscribableSpec: subscribableSpec,
 				// no delivery in v1alpha1
@@ -171,9 +176,13 @@ func (sink *KafkaChannel) ConvertFrom(ctx context.Context, obj apis.Convertible)
 
 		sink.ObjectMeta = source.ObjectMeta
 		sink.Spec = KafkaChannelSpec{
-			NumPartitions:     source.Spec.NumPartitions,
-			ReplicationFactor: source.Spec.ReplicationFactor,
-			Subscribable:      &subscribableSpec,
+			NumPartitions:      source.Spec.NumPartitions,
+			ReplicationFactor:  source.Spec.ReplicationFactor,
+			Ingress:            convertIngressPolicyFrom(source.Spec.Ingress),
+			Partitioning:       convertPartitioningPolicyFrom(source.Spec.Partitioning),
+			ExactlyOnceIngress: convertExactlyOnceIngressPolicyFrom(source.Spec.ExactlyOnceIngress),
+			EventRules:         convertEventRulesFrom(source.Spec.EventRules),
+			Subscribable:       &subscribableSpec,
 		}
 		sink.Status = KafkaChannelStatus{
 			Status: source.Status.Status,
@@ -190,3 +199,133 @@ func (sink *KafkaChannel) ConvertFrom(ctx context.Context, obj apis.Convertible)
 		return fmt.Errorf("Unknown conversion, got: %T", source)
 	}
 }
+
+// convertIngressPolicyTo converts the v1alpha1 ingress policy into a v1beta1 one.
+func convertIngressPolicyTo(source *IngressPolicy) *v1beta1.IngressPolicy {
+	if source == nil {
+		return nil
+	}
+	sink := &v1beta1.IngressPolicy{
+		Namespaces: append([]string(nil), source.Namespaces...),
+	}
+	if source.JWT != nil {
+		sink.JWT = &v1beta1.JWTIngressPolicy{
+			JWKSURL:   source.JWT.JWKSURL.DeepCopy(),
+			Issuer:    source.JWT.Issuer,
+			Audiences: append([]string(nil), source.JWT.Audiences...),
+		}
+	}
+	if source.ServiceAccount != nil {
+		sink.ServiceAccount = &v1beta1.ServiceAccountIngressPolicy{
+			Audiences: append([]string(nil), source.ServiceAccount.Audiences...),
+		}
+	}
+	return sink
+}
+
+// convertIngressPolicyFrom converts the v1beta1 ingress policy into a v1alpha1 one.
+func convertIngressPolicyFrom(source *v1beta1.IngressPolicy) *IngressPolicy {
+	if source == nil {
+		return nil
+	}
+	sink := &IngressPolicy{
+		Namespaces: append([]string(nil), source.Namespaces...),
+	}
+	if source.JWT != nil {
+		sink.JWT = &JWTIngressPolicy{
+			JWKSURL:   source.JWT.JWKSURL.DeepCopy(),
+			Issuer:    source.JWT.Issuer,
+			Audiences: append([]string(nil), source.JWT.Audiences...),
+		}
+	}
+	if source.ServiceAccount != nil {
+		sink.ServiceAccount = &ServiceAccountIngressPolicy{
+			Audiences: append([]string(nil), source.ServiceAccount.Audiences...),
+		}
+	}
+	return sink
+}
+
+// convertPartitioningPolicyTo converts the v1alpha1 partitioning policy into a v1beta1 one.
+func convertPartitioningPolicyTo(source *PartitioningPolicy) *v1beta1.PartitioningPolicy {
+	if source == nil {
+		return nil
+	}
+	return &v1beta1.PartitioningPolicy{
+		KeyAttribute: source.KeyAttribute,
+		Partitioner:  source.Partitioner,
+	}
+}
+
+// convertPartitioningPolicyFrom converts the v1beta1 partitioning policy into a v1alpha1 one.
+func convertPartitioningPolicyFrom(source *v1beta1.PartitioningPolicy) *PartitioningPolicy {
+	if source == nil {
+		return nil
+	}
+	return &PartitioningPolicy{
+		KeyAttribute: source.KeyAttribute,
+		Partitioner:  source.Partitioner,
+	}
+}
+
+// convertExactlyOnceIngressPolicyTo converts the v1alpha1 exactly once ingress policy into a v1beta1 one.
+func convertExactlyOnceIngressPolicyTo(source *ExactlyOnceIngressPolicy) *v1beta1.ExactlyOnceIngressPolicy {
+	if source == nil {
+		return nil
+	}
+	return &v1beta1.ExactlyOnceIngressPolicy{
+		DeduplicationWindow: source.DeduplicationWindow.DeepCopy(),
+	}
+}
+
+// convertExactlyOnceIngressPolicyFrom converts the v1beta1 exactly once ingress policy into a v1alpha1 one.
+func convertExactlyOnceIngressPolicyFrom(source *v1beta1.ExactlyOnceIngressPolicy) *ExactlyOnceIngressPolicy {
+	if source == nil {
+		return nil
+	}
+	return &ExactlyOnceIngressPolicy{
+		DeduplicationWindow: source.DeduplicationWindow.DeepCopy(),
+	}
+}
+
+// convertEventRulesTo converts the v1alpha1 event rules into v1beta1 ones.
+func convertEventRulesTo(source *EventRules) *v1beta1.EventRules {
+	if source == nil {
+		return nil
+	}
+	sink := &v1beta1.EventRules{
+		RequiredAttributes: append([]string(nil), source.RequiredAttributes...),
+	}
+	if source.MaxEventSize != nil {
+		maxEventSize := source.MaxEventSize.DeepCopy()
+		sink.MaxEventSize = &maxEventSize
+	}
+	if source.DataSchemas != nil {
+		sink.DataSchemas = make(map[string]runtime.RawExtension, len(source.DataSchemas))
+		for eventType, schema := range source.DataSchemas {
+			sink.DataSchemas[eventType] = *schema.DeepCopy()
+		}
+	}
+	return sink
+}
+
+// convertEventRulesFrom converts the v1beta1 event rules into v1alpha1 ones.
+func convertEventRulesFrom(source *v1beta1.EventRules) *EventRules {
+	if source == nil {
+		return nil
+	}
+	sink := &EventRules{
+		RequiredAttributes: append([]string(nil), source.RequiredAttributes...),
+	}
+	if source.MaxEventSize != nil {
+		maxEventSize := source.MaxEventSize.DeepCopy()
+		sink.MaxEventSize = &maxEventSize
+	}
+	if source.DataSchemas != nil {
+		sink.DataSchemas = make(map[string]runtime.RawExtension, len(source.DataSchemas))
+		for eventType, schema := range source.DataSchemas {
+			sink.DataSchemas[eventType] = *schema.DeepCopy()
+		}
+	}
+	return sink
+}
@@ -19,13 +19,16 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	v1 "knative.dev/eventing/pkg/apis/duck/v1"
 	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
@@ -91,6 +94,31 @@ func TestKafkaChannelConversionRoundTripV1alpha1(t *testing.T) {
 			Spec: KafkaChannelSpec{
 				NumPartitions:     1,
 				ReplicationFactor: 2,
+				Ingress: &IngressPolicy{
+					JWT: &JWTIngressPolicy{
+						JWKSURL:   apis.HTTPS("jwks-url"),
+						Issuer:    "issuer",
+						Audiences: []string{"audience"},
+					},
+					ServiceAccount: &ServiceAccountIngressPolicy{
+						Audiences: []string{"sa-audience"},
+					},
+					Namespaces: []string{"ns"},
+				},
+				Partitioning: &PartitioningPolicy{
+					KeyAttribute: "subject",
+					Partitioner:  v1beta1.KafkaChannelPartitionerMurmur2,
+				},
+				ExactlyOnceIngress: &ExactlyOnceIngressPolicy{
+					DeduplicationWindow: &metav1.Duration{Duration: time.Minute},
+				},
+				EventRules: &EventRules{
+					MaxEventSize:       resource.NewQuantity(256*1024, resource.BinarySI),
+					RequiredAttributes: []string{"subject"},
+					DataSchemas: map[string]runtime.RawExtension{
+						"order": {Raw: []byte(`{"type":"object"}`)},
+					},
+				},
 				Subscribable: &eventingduckv1alpha1.Subscribable{
 					Subscribers: []eventingduckv1alpha1.SubscriberSpec{
 						{
@@ -214,6 +242,20 @@ func TestKafkaChannelConversionRoundTripV1beta1(t *testing.T) {
 			Spec: v1beta1.KafkaChannelSpec{
 				NumPartitions:     117,
 				ReplicationFactor: 118,
+				Ingress: &v1beta1.IngressPolicy{
+					JWT: &v1beta1.JWTIngressPolicy{
+						JWKSURL: apis.HTTPS("jwks-url"),
+					},
+					ServiceAccount: &v1beta1.ServiceAccountIngressPolicy{},
+					Namespaces:     []string{"ns-1", "ns-2"},
+				},
+				Partitioning: &v1beta1.PartitioningPolicy{
+					Partitioner: v1beta1.KafkaChannelPartitionerRoundRobin,
+				},
+				ExactlyOnceIngress: &v1beta1.ExactlyOnceIngressPolicy{},
+				EventRules: &v1beta1.EventRules{
+					RequiredAttributes: []string{"tenant"},
+				},
 				ChannelableSpec: v1.ChannelableSpec{
 					SubscribableSpec: v1.SubscribableSpec{
 						Subscribers: []eventingduckv1.SubscriberSpec{
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -59,10 +60,81 @@ type KafkaChannelSpec struct {
 	// ReplicationFactor is the replication factor of a Kafka topic. By default, it is set to 1.
 	ReplicationFactor int16 `json:"replicationFactor"`
 
+	// Ingress restricts the senders of the events to the KafkaChannel. By default, any sender is accepted.
+	// +optional
+	Ingress *IngressPolicy `json:"ingress,omitempty"`
+
+	// Partitioning selects the partitions of the events written to the KafkaChannel. By default, the events are
+	// keyed by their partitionkey extension only, and partitioned by the partitioner of the sarama settings.
+	// +optional
+	Partitioning *PartitioningPolicy `json:"partitioning,omitempty"`
+
+	// ExactlyOnceIngress writes the events sent to the KafkaChannel with an idempotent producer, so that the retries
+	// of the producer do not duplicate them. By default, the events are written with the sarama settings.
+	// +optional
+	ExactlyOnceIngress *ExactlyOnceIngressPolicy `json:"exactlyOnceIngress,omitempty"`
+
+	// EventRules are the rules the events sent to the KafkaChannel must follow, the others being rejected. By
+	// default, any event is accepted.
+	// +optional
+	EventRules *EventRules `json:"eventRules,omitempty"`
+
 	// KafkaChannel conforms to Duck type Subscribable.
 	Subscribable *eventingduck.Subscribable `json:"subscribable,omitempty"`
 }
 
+// IngressPolicy restricts the senders of the events to a KafkaChannel, see the v1beta1 IngressPolicy.
+type IngressPolicy struct {
+	// +optional
+	JWT *JWTIngressPolicy `json:"jwt,omitempty"`
+	// +optional
+	ServiceAccount *ServiceAccountIngressPolicy `json:"serviceAccount,omitempty"`
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// JWTIngressPolicy verifies the bearer tokens as JSON Web Tokens, see the v1beta1 JWTIngressPolicy.
+type JWTIngressPolicy struct {
+	JWKSURL *apis.URL `json:"jwksURL"`
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// ServiceAccountIngressPolicy verifies the bearer tokens with a Kubernetes TokenReview, see the v1beta1
+// ServiceAccountIngressPolicy.
+type ServiceAccountIngressPolicy struct {
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// PartitioningPolicy selects the partitions of the events written to a KafkaChannel, see the v1beta1
+// PartitioningPolicy.
+type PartitioningPolicy struct {
+	// +optional
+	KeyAttribute string `json:"keyAttribute,omitempty"`
+	// +optional
+	Partitioner string `json:"partitioner,omitempty"`
+}
+
+// ExactlyOnceIngressPolicy writes the events sent to a KafkaChannel with an idempotent producer, see the v1beta1
+// ExactlyOnceIngressPolicy.
+type ExactlyOnceIngressPolicy struct {
+	// +optional
+	DeduplicationWindow *metav1.Duration `json:"deduplicationWindow,omitempty"`
+}
+
+// EventRules are the rules the events sent to a KafkaChannel must follow, see the v1beta1 EventRules.
+type EventRules struct {
+	// +optional
+	MaxEventSize *resource.Quantity `json:"maxEventSize,omitempty"`
+	// +optional
+	RequiredAttributes []string `json:"requiredAttributes,omitempty"`
+	// +optional
+	DataSchemas map[string]runtime.RawExtension `json:"dataSchemas,omitempty"`
+}
+
 // KafkaChannelStatus represents the current state of a KafkaChannel.
 type KafkaChannelStatus struct {
 	// inherits duck/v1 Status, which currently provides:
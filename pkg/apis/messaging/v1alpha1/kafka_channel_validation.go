@@ -63,5 +63,9 @@ func (cs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 			}
 		}
 	}
+
+	if cs.Ingress != nil {
+		errs = errs.Also(convertIngressPolicyTo(cs.Ingress).Validate(ctx).ViaField("ingress"))
+	}
 	return errs
 }
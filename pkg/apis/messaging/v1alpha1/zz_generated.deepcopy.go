@@ -21,10 +21,123 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	apis "knative.dev/pkg/apis"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRules) DeepCopyInto(out *EventRules) {
+	*out = *in
+	if in.MaxEventSize != nil {
+		in, out := &in.MaxEventSize, &out.MaxEventSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.RequiredAttributes != nil {
+		in, out := &in.RequiredAttributes, &out.RequiredAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataSchemas != nil {
+		in, out := &in.DataSchemas, &out.DataSchemas
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRules.
+func (in *EventRules) DeepCopy() *EventRules {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExactlyOnceIngressPolicy) DeepCopyInto(out *ExactlyOnceIngressPolicy) {
+	*out = *in
+	if in.DeduplicationWindow != nil {
+		in, out := &in.DeduplicationWindow, &out.DeduplicationWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExactlyOnceIngressPolicy.
+func (in *ExactlyOnceIngressPolicy) DeepCopy() *ExactlyOnceIngressPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExactlyOnceIngressPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressPolicy) DeepCopyInto(out *IngressPolicy) {
+	*out = *in
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTIngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountIngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressPolicy.
+func (in *IngressPolicy) DeepCopy() *IngressPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTIngressPolicy) DeepCopyInto(out *JWTIngressPolicy) {
+	*out = *in
+	if in.JWKSURL != nil {
+		in, out := &in.JWKSURL, &out.JWKSURL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTIngressPolicy.
+func (in *JWTIngressPolicy) DeepCopy() *JWTIngressPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTIngressPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaChannel) DeepCopyInto(out *KafkaChannel) {
 	*out = *in
@@ -89,6 +202,26 @@ func (in *KafkaChannelList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaChannelSpec) DeepCopyInto(out *KafkaChannelSpec) {
 	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Partitioning != nil {
+		in, out := &in.Partitioning, &out.Partitioning
+		*out = new(PartitioningPolicy)
+		**out = **in
+	}
+	if in.ExactlyOnceIngress != nil {
+		in, out := &in.ExactlyOnceIngress, &out.ExactlyOnceIngress
+		*out = new(ExactlyOnceIngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventRules != nil {
+		in, out := &in.EventRules, &out.EventRules
+		*out = new(EventRules)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Subscribable != nil {
 		in, out := &in.Subscribable, &out.Subscribable
 		*out = new(duckv1alpha1.Subscribable)
@@ -125,3 +258,40 @@ func (in *KafkaChannelStatus) DeepCopy() *KafkaChannelStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitioningPolicy) DeepCopyInto(out *PartitioningPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitioningPolicy.
+func (in *PartitioningPolicy) DeepCopy() *PartitioningPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitioningPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountIngressPolicy) DeepCopyInto(out *ServiceAccountIngressPolicy) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountIngressPolicy.
+func (in *ServiceAccountIngressPolicy) DeepCopy() *ServiceAccountIngressPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountIngressPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -26,6 +27,51 @@ import (
 	"knative.dev/pkg/kmeta"
 )
 
+const (
+	// KafkaSubscriptionPausedAnnotation pauses the delivery to the subscriber of the Subscription it
+	// annotates when set to "true". The events are kept in the channel until the Subscription is resumed.
+	KafkaSubscriptionPausedAnnotation = "kafkachannels.messaging.knative.dev/paused"
+
+	// KafkaSubscriptionRateLimitAnnotation caps the delivery to the subscriber of the Subscription it annotates
+	// to the given number of events per second.
+	KafkaSubscriptionRateLimitAnnotation = "kafkachannels.messaging.knative.dev/rate-limit"
+
+	// KafkaSubscriptionMaxInFlightAnnotation caps the number of events being delivered at once to the subscriber
+	// of the Subscription it annotates.
+	KafkaSubscriptionMaxInFlightAnnotation = "kafkachannels.messaging.knative.dev/max-in-flight"
+
+	// KafkaSubscriptionMaxBackoffAnnotation caps the delay between two delivery attempts to the subscriber of the
+	// Subscription it annotates, including the delays asked for with Retry-After. It is a duration such as "30s".
+	KafkaSubscriptionMaxBackoffAnnotation = "kafkachannels.messaging.knative.dev/max-backoff"
+
+	// KafkaSubscriptionRetryableStatusCodesAnnotation is the comma separated list of the status codes retried when
+	// the subscriber of the Subscription it annotates answers with them, such as "429,503".
+	KafkaSubscriptionRetryableStatusCodesAnnotation = "kafkachannels.messaging.knative.dev/retryable-status-codes"
+
+	// KafkaSubscriptionRetryTopicsAnnotation moves the retries of the delivery to the subscriber of the Subscription
+	// it annotates to retry topics when set to "true", so that an event being retried does not hold back the others.
+	KafkaSubscriptionRetryTopicsAnnotation = "kafkachannels.messaging.knative.dev/retry-topics"
+
+	// KafkaSubscriptionDeduplicationWindowAnnotation skips the events delivered again to the subscriber of the
+	// Subscription it annotates within the given duration, such as "10m", recognized by their CloudEvents source
+	// and id within each partition.
+	KafkaSubscriptionDeduplicationWindowAnnotation = "kafkachannels.messaging.knative.dev/deduplication-window"
+)
+
+const (
+	// KafkaChannelPartitionerHash partitions the events by the FNV-1a hash of their key, alike the sarama default.
+	KafkaChannelPartitionerHash = "hash"
+	// KafkaChannelPartitionerMurmur2 partitions the events by the murmur2 hash of their key, alike the Java clients.
+	KafkaChannelPartitionerMurmur2 = "murmur2"
+	// KafkaChannelPartitionerRoundRobin spreads the events evenly across the partitions, ignoring their key.
+	KafkaChannelPartitionerRoundRobin = "roundRobin"
+)
+
+// IsSubscriptionPaused returns true when the annotations of a Subscription pause its delivery.
+func IsSubscriptionPaused(annotations map[string]string) bool {
+	return annotations[KafkaSubscriptionPausedAnnotation] == "true"
+}
+
 // +genclient
 // +genreconciler
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -67,10 +113,107 @@ type KafkaChannelSpec struct {
 	// ReplicationFactor is the replication factor of a Kafka topic. By default, it is set to 1.
 	ReplicationFactor int16 `json:"replicationFactor"`
 
+	// Ingress restricts the senders of the events to the KafkaChannel. By default, any sender is accepted.
+	// +optional
+	Ingress *IngressPolicy `json:"ingress,omitempty"`
+
+	// Partitioning selects the partitions of the events written to the KafkaChannel. By default, the events are
+	// keyed by their partitionkey extension only, and partitioned by the partitioner of the sarama settings.
+	// +optional
+	Partitioning *PartitioningPolicy `json:"partitioning,omitempty"`
+
+	// ExactlyOnceIngress writes the events sent to the KafkaChannel with an idempotent producer, so that the retries
+	// of the producer do not duplicate them. By default, the events are written with the sarama settings.
+	// +optional
+	ExactlyOnceIngress *ExactlyOnceIngressPolicy `json:"exactlyOnceIngress,omitempty"`
+
+	// EventRules are the rules the events sent to the KafkaChannel must follow, the others being rejected. By
+	// default, any event is accepted.
+	// +optional
+	EventRules *EventRules `json:"eventRules,omitempty"`
+
 	// Channel conforms to Duck type Channelable.
 	eventingduck.ChannelableSpec `json:",inline"`
 }
 
+// IngressPolicy restricts the senders of the events to a KafkaChannel to the ones presenting, in the
+// Authorization header, a bearer token accepted by one of its verifiers, or the ServiceAccount token of one of its
+// namespaces.
+type IngressPolicy struct {
+	// JWT accepts the JSON Web Tokens, such as the OIDC ID tokens, signed by the keys of a JSON Web Key Set.
+	// +optional
+	JWT *JWTIngressPolicy `json:"jwt,omitempty"`
+
+	// ServiceAccount accepts the Kubernetes ServiceAccount tokens, verified by a TokenReview.
+	// +optional
+	ServiceAccount *ServiceAccountIngressPolicy `json:"serviceAccount,omitempty"`
+
+	// Namespaces restricts the senders to the ServiceAccounts of these namespaces, identified by the
+	// subject of their token (system:serviceaccount:<namespace>:<name>). By default, any namespace is accepted.
+	// Without JWT nor ServiceAccount, the senders present ServiceAccount tokens valid for the audiences of the
+	// API server.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// JWTIngressPolicy verifies the bearer tokens as JSON Web Tokens.
+type JWTIngressPolicy struct {
+	// JWKSURL is the URL of the JSON Web Key Set holding the public keys of the issuer.
+	JWKSURL *apis.URL `json:"jwksURL"`
+
+	// Issuer is the issuer (iss claim) the tokens must have, unless empty.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// Audiences are the audiences (aud claim) the tokens must have one of, unless empty.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// ServiceAccountIngressPolicy verifies the bearer tokens with a Kubernetes TokenReview.
+type ServiceAccountIngressPolicy struct {
+	// Audiences are the audiences the tokens must be valid for, the ones of the API server if empty.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// PartitioningPolicy selects the partitions of the events written to a KafkaChannel. The events with the same key
+// are written to the same partition, in order.
+type PartitioningPolicy struct {
+	// KeyAttribute names the CloudEvents attribute, or extension, keying the events which have no partitionkey
+	// extension, such as "subject".
+	// +optional
+	KeyAttribute string `json:"keyAttribute,omitempty"`
+
+	// Partitioner is one of the KafkaChannelPartitioner values. The partitioner of the sarama settings is used by
+	// default.
+	// +optional
+	Partitioner string `json:"partitioner,omitempty"`
+}
+
+// ExactlyOnceIngressPolicy writes the events sent to a KafkaChannel with an idempotent producer.
+type ExactlyOnceIngressPolicy struct {
+	// DeduplicationWindow drops the events sent to the KafkaChannel again within this duration, such as "10m",
+	// recognized by their CloudEvents source and id. By default, the events sent again are written again.
+	// +optional
+	DeduplicationWindow *metav1.Duration `json:"deduplicationWindow,omitempty"`
+}
+
+// EventRules are the rules the events sent to a KafkaChannel must follow.
+type EventRules struct {
+	// MaxEventSize caps the size of the data and the attributes of the events, a quantity of bytes such as "256Ki".
+	// +optional
+	MaxEventSize *resource.Quantity `json:"maxEventSize,omitempty"`
+
+	// RequiredAttributes are the CloudEvents attributes, or extensions, the events must have, such as "subject".
+	// +optional
+	RequiredAttributes []string `json:"requiredAttributes,omitempty"`
+
+	// DataSchemas map the CloudEvents types to the JSON Schema the data of the events of that type must match.
+	// +optional
+	DataSchemas map[string]runtime.RawExtension `json:"dataSchemas,omitempty"`
+}
+
 // KafkaChannelStatus represents the current state of a KafkaChannel.
 type KafkaChannelStatus struct {
 	// Channel conforms to Duck type Channelable.
@@ -47,3 +47,15 @@ func TestKafkaChannelGetStatus(t *testing.T) {
 		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", config.GetStatus(), status)
 	}
 }
+
+func TestIsSubscriptionPaused(t *testing.T) {
+	if IsSubscriptionPaused(nil) {
+		t.Error("IsSubscriptionPaused(nil) = true, want false")
+	}
+	if !IsSubscriptionPaused(map[string]string{KafkaSubscriptionPausedAnnotation: "true"}) {
+		t.Error("IsSubscriptionPaused(paused) = false, want true")
+	}
+	if IsSubscriptionPaused(map[string]string{KafkaSubscriptionPausedAnnotation: "false"}) {
+		t.Error("IsSubscriptionPaused(resumed) = true, want false")
+	}
+}
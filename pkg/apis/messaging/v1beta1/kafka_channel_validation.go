@@ -19,11 +19,20 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/pkg/apis"
+
+	"knative.dev/eventing-kafka/pkg/common/jsonschema"
 )
 
+// attributeNameRegexp matches the names of the CloudEvents attributes and extensions.
+var attributeNameRegexp = regexp.MustCompile(`^[a-z0-9]+$`)
+
 func (c *KafkaChannel) Validate(ctx context.Context) *apis.FieldError {
 	errs := c.Spec.Validate(ctx).ViaField("spec")
 
@@ -61,5 +70,111 @@ func (cs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 			errs = errs.Also(fe.ViaField(fmt.Sprintf("subscriber[%d]", i)).ViaField("subscribable"))
 		}
 	}
+
+	if cs.Ingress != nil {
+		errs = errs.Also(cs.Ingress.Validate(ctx).ViaField("ingress"))
+	}
+
+	if cs.Partitioning != nil {
+		errs = errs.Also(cs.Partitioning.Validate(ctx).ViaField("partitioning"))
+	}
+
+	if cs.ExactlyOnceIngress != nil {
+		errs = errs.Also(cs.ExactlyOnceIngress.Validate(ctx).ViaField("exactlyOnceIngress"))
+	}
+
+	if cs.EventRules != nil {
+		errs = errs.Also(cs.EventRules.Validate(ctx).ViaField("eventRules"))
+	}
+	return errs
+}
+
+func (p *IngressPolicy) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if p.JWT == nil && p.ServiceAccount == nil && len(p.Namespaces) == 0 {
+		fe := apis.ErrMissingField("jwt", "serviceAccount", "namespaces")
+		fe.Details = "expected at least one of, got none"
+		errs = errs.Also(fe)
+	}
+
+	if p.JWT != nil {
+		if url := p.JWT.JWKSURL; url == nil {
+			errs = errs.Also(apis.ErrMissingField("jwksURL").ViaField("jwt"))
+		} else if (url.Scheme != "http" && url.Scheme != "https") || url.Host == "" {
+			fe := apis.ErrInvalidValue(url.String(), "jwksURL")
+			fe.Details = "expected an absolute http or https URL"
+			errs = errs.Also(fe.ViaField("jwt"))
+		}
+	}
+
+	for i, namespace := range p.Namespaces {
+		if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+			fe := apis.ErrInvalidArrayValue(namespace, "namespaces", i)
+			fe.Details = strings.Join(msgs, ", ")
+			errs = errs.Also(fe)
+		}
+	}
+	return errs
+}
+
+func (p *PartitioningPolicy) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if p.KeyAttribute != "" && !attributeNameRegexp.MatchString(p.KeyAttribute) {
+		fe := apis.ErrInvalidValue(p.KeyAttribute, "keyAttribute")
+		fe.Details = "expected the name of a CloudEvents attribute or extension (lowercase letters and digits)"
+		errs = errs.Also(fe)
+	}
+
+	switch p.Partitioner {
+	case "", KafkaChannelPartitionerHash, KafkaChannelPartitionerMurmur2, KafkaChannelPartitionerRoundRobin:
+	default:
+		fe := apis.ErrInvalidValue(p.Partitioner, "partitioner")
+		fe.Details = fmt.Sprintf("expected one of '%s', '%s' or '%s'", KafkaChannelPartitionerHash, KafkaChannelPartitionerMurmur2, KafkaChannelPartitionerRoundRobin)
+		errs = errs.Also(fe)
+	}
+	return errs
+}
+
+func (p *ExactlyOnceIngressPolicy) Validate(ctx context.Context) *apis.FieldError {
+	if p.DeduplicationWindow != nil && p.DeduplicationWindow.Duration <= 0 {
+		fe := apis.ErrInvalidValue(p.DeduplicationWindow.Duration.String(), "deduplicationWindow")
+		fe.Details = "expected a positive duration such as '10m'"
+		return fe
+	}
+	return nil
+}
+
+func (r *EventRules) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if r.MaxEventSize != nil && r.MaxEventSize.Sign() <= 0 {
+		fe := apis.ErrInvalidValue(r.MaxEventSize.String(), "maxEventSize")
+		fe.Details = "expected a positive quantity of bytes such as '256Ki'"
+		errs = errs.Also(fe)
+	}
+
+	for i, attribute := range r.RequiredAttributes {
+		if !attributeNameRegexp.MatchString(attribute) {
+			fe := apis.ErrInvalidArrayValue(attribute, "requiredAttributes", i)
+			fe.Details = "expected the name of a CloudEvents attribute or extension (lowercase letters and digits)"
+			errs = errs.Also(fe)
+		}
+	}
+
+	eventTypes := make([]string, 0, len(r.DataSchemas))
+	for eventType := range r.DataSchemas {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	for _, eventType := range eventTypes {
+		schema := r.DataSchemas[eventType]
+		if _, err := jsonschema.Parse(schema.Raw); err != nil {
+			fe := apis.ErrInvalidValue(string(schema.Raw), "")
+			fe.Details = fmt.Sprintf("invalid JSON Schema: %v", err)
+			errs = errs.Also(fe.ViaFieldKey("dataSchemas", eventType))
+		}
+	}
 	return errs
 }
@@ -19,9 +19,12 @@ package v1beta1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/webhook/resourcesemantics"
 
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
@@ -149,6 +152,177 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return fe
 			}(),
 		},
+		"valid partitioning": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Partitioning: &PartitioningPolicy{
+						KeyAttribute: "subject",
+						Partitioner:  KafkaChannelPartitionerMurmur2,
+					},
+				},
+			},
+			want: nil,
+		},
+		"invalid partitioning": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Partitioning: &PartitioningPolicy{
+						KeyAttribute: "Subject",
+						Partitioner:  "sticky",
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("Subject", "spec.partitioning.keyAttribute")
+				fe.Details = "expected the name of a CloudEvents attribute or extension (lowercase letters and digits)"
+				var errs *apis.FieldError
+				errs = errs.Also(fe)
+				fe = apis.ErrInvalidValue("sticky", "spec.partitioning.partitioner")
+				fe.Details = "expected one of 'hash', 'murmur2' or 'roundRobin'"
+				return errs.Also(fe)
+			}(),
+		},
+		"valid exactly once ingress": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					ExactlyOnceIngress: &ExactlyOnceIngressPolicy{
+						DeduplicationWindow: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+			},
+			want: nil,
+		},
+		"invalid exactly once ingress": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					ExactlyOnceIngress: &ExactlyOnceIngressPolicy{
+						DeduplicationWindow: &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("-1m0s", "spec.exactlyOnceIngress.deduplicationWindow")
+				fe.Details = "expected a positive duration such as '10m'"
+				return fe
+			}(),
+		},
+		"valid event rules": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					EventRules: &EventRules{
+						MaxEventSize:       resource.NewQuantity(256*1024, resource.BinarySI),
+						RequiredAttributes: []string{"subject", "tenant"},
+						DataSchemas: map[string]runtime.RawExtension{
+							"com.example.order": {Raw: []byte(`{"type": "object", "required": ["id"]}`)},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		"invalid event rules": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					EventRules: &EventRules{
+						MaxEventSize:       resource.NewQuantity(0, resource.DecimalSI),
+						RequiredAttributes: []string{"subject", "Tenant"},
+						DataSchemas: map[string]runtime.RawExtension{
+							"com.example.order": {Raw: []byte(`{"oneOf": []}`)},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("0", "spec.eventRules.maxEventSize")
+				fe.Details = "expected a positive quantity of bytes such as '256Ki'"
+				var errs *apis.FieldError
+				errs = errs.Also(fe)
+				fe = apis.ErrInvalidArrayValue("Tenant", "spec.eventRules.requiredAttributes", 1)
+				fe.Details = "expected the name of a CloudEvents attribute or extension (lowercase letters and digits)"
+				errs = errs.Also(fe)
+				fe = apis.ErrInvalidValue(`{"oneOf": []}`, "spec.eventRules.dataSchemas.[com.example.order]")
+				fe.Details = "invalid JSON Schema: /oneOf: unsupported keyword"
+				return errs.Also(fe)
+			}(),
+		},
+		"valid ingress policy": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Ingress: &IngressPolicy{
+						JWT: &JWTIngressPolicy{
+							JWKSURL:   apis.HTTPS("issuer.example.com").ResolveReference(&apis.URL{Path: "/keys"}),
+							Issuer:    "https://issuer.example.com",
+							Audiences: []string{"kafkachannel"},
+						},
+						ServiceAccount: &ServiceAccountIngressPolicy{},
+						Namespaces:     []string{"default"},
+					},
+				},
+			},
+			want: nil,
+		},
+		"empty ingress policy": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Ingress:           &IngressPolicy{},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrMissingField("spec.ingress.jwt", "spec.ingress.serviceAccount", "spec.ingress.namespaces")
+				fe.Details = "expected at least one of, got none"
+				return fe
+			}(),
+		},
+		"ingress policy with namespaces only": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Ingress: &IngressPolicy{
+						Namespaces: []string{"default", "Invalid"},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidArrayValue("Invalid", "spec.ingress.namespaces", 1)
+				fe.Details = "a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"
+				return fe
+			}(),
+		},
+		"ingress policy with invalid jwks url": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					Ingress: &IngressPolicy{
+						JWT: &JWTIngressPolicy{
+							JWKSURL: &apis.URL{Path: "/keys"},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("/keys", "spec.ingress.jwt.jwksURL")
+				fe.Details = "expected an absolute http or https URL"
+				return fe
+			}(),
+		},
 	}
 
 	for n, test := range testCases {
@@ -40,9 +40,17 @@ func (source *KafkaSource) ConvertTo(ctx context.Context, obj apis.Convertible)
 
 		sink.ObjectMeta = source.ObjectMeta
 		sink.Spec = v1beta1.KafkaSourceSpec{
-			KafkaAuthSpec: kafkaAuthSpec,
-			Topics:        source.Spec.Topics,
-			ConsumerGroup: source.Spec.ConsumerGroup,
+			KafkaAuthSpec:     kafkaAuthSpec,
+			Topics:            source.Spec.Topics,
+			ConsumerGroup:     source.Spec.ConsumerGroup,
+			CloudEventMapping: source.Spec.CloudEventMapping.DeepCopy(),
+			Batch:             source.Spec.Batch.DeepCopy(),
+		}
+		if source.Spec.Filters != nil {
+			sink.Spec.Filters = make([]v1beta1.KafkaSourceFilter, len(source.Spec.Filters))
+			for i := range source.Spec.Filters {
+				source.Spec.Filters[i].DeepCopyInto(&sink.Spec.Filters[i])
+			}
 		}
 		sink.Status.Status = source.Status.Status
 		source.Status.Status.ConvertTo(ctx, &sink.Status.Status)
@@ -75,14 +83,22 @@ func (sink *KafkaSource) ConvertFrom(ctx context.Context, obj apis.Convertible)
 
 		sink.ObjectMeta = source.ObjectMeta
 		sink.Spec = KafkaSourceSpec{
-			KafkaAuthSpec: kafkaAuthSpec,
-			Topics:        source.Spec.Topics,
-			ConsumerGroup: source.Spec.ConsumerGroup,
-			Sink:          source.Spec.Sink.DeepCopy(),
+			KafkaAuthSpec:     kafkaAuthSpec,
+			Topics:            source.Spec.Topics,
+			ConsumerGroup:     source.Spec.ConsumerGroup,
+			CloudEventMapping: source.Spec.CloudEventMapping.DeepCopy(),
+			Batch:             source.Spec.Batch.DeepCopy(),
+			Sink:              source.Spec.Sink.DeepCopy(),
 		}
 		if reflect.DeepEqual(*sink.Spec.Sink, duckv1.Destination{}) {
 			sink.Spec.Sink = nil
 		}
+		if source.Spec.Filters != nil {
+			sink.Spec.Filters = make([]v1beta1.KafkaSourceFilter, len(source.Spec.Filters))
+			for i := range source.Spec.Filters {
+				source.Spec.Filters[i].DeepCopyInto(&sink.Spec.Filters[i])
+			}
+		}
 		sink.Status.Status = source.Status.Status
 		source.Status.Status.ConvertTo(ctx, &source.Status.Status)
 		// Optionals
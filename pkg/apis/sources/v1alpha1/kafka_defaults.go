@@ -20,6 +20,9 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"knative.dev/pkg/apis"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 )
 
 const (
@@ -28,7 +31,20 @@ const (
 
 // SetDefaults ensures KafkaSource reflects the default values.
 func (k *KafkaSource) SetDefaults(ctx context.Context) {
-	if k != nil && k.Spec.ConsumerGroup == "" {
+	if k == nil {
+		return
+	}
+	if k.Spec.ConsumerGroup == "" && apis.IsInUpdate(ctx) {
+		// Keep the generated consumer group when it is omitted from an update
+		if original, ok := apis.GetBaseline(ctx).(*KafkaSource); ok {
+			k.Spec.ConsumerGroup = original.Spec.ConsumerGroup
+		}
+	}
+	if k.Spec.ConsumerGroup == "" {
 		k.Spec.ConsumerGroup = uuidPrefix + uuid.New().String()
 	}
+	if k.Spec.Batch != nil && k.Spec.Batch.MaxWait == nil {
+		maxWait := v1beta1.DefaultBatchMaxWait
+		k.Spec.Batch.MaxWait = &maxWait
+	}
 }
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	bindingsv1alpha1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -77,6 +78,22 @@ type KafkaSourceSpec struct {
 	// +optional
 	ConsumerGroup string `json:"consumerGroup,omitempty"`
 
+	// CloudEventMapping defines how the CloudEvent attributes of the events
+	// produced from Kafka records that are not CloudEvents are computed.
+	// +optional
+	CloudEventMapping *v1beta1.KafkaCloudEventMappingSpec `json:"ceMapping,omitempty"`
+
+	// Filters is a list of filters the events must all pass to be sent to
+	// the sink. Events not passing the filters are dropped and their offset
+	// is committed.
+	// +optional
+	Filters []v1beta1.KafkaSourceFilter `json:"filters,omitempty"`
+
+	// Batch enables the batch delivery mode, where the records of a
+	// partition are sent to the sink in CloudEvents batches.
+	// +optional
+	Batch *v1beta1.KafkaSourceBatchSpec `json:"batch,omitempty"`
+
 	// Sink is a reference to an object that will resolve to a domain name to use as the sink.
 	// +optional
 	Sink *duckv1.Destination `json:"sink,omitempty"`
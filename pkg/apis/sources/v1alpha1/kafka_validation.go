@@ -18,29 +18,80 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 
+	bindingsv1alpha1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
 )
 
 // Validate ensures KafkaSource is properly configured.
 func (r *KafkaSource) Validate(ctx context.Context) *apis.FieldError {
+	if err := v1beta1.ValidateReceiveAdapterAnnotation(r.GetAnnotations()); err != nil {
+		return err.ViaField("metadata")
+	}
+	if err := v1beta1.ValidatePausedAnnotation(r.GetAnnotations()); err != nil {
+		return err.ViaField("metadata")
+	}
+	if err := r.Spec.CloudEventMapping.Validate(ctx); err != nil {
+		return err.ViaField("ceMapping").ViaField("spec")
+	}
+	for i, f := range r.Spec.Filters {
+		if err := f.Validate(ctx); err != nil {
+			return err.ViaFieldIndex("filters", i).ViaField("spec")
+		}
+	}
+	if err := r.Spec.Batch.Validate(ctx); err != nil {
+		return err.ViaField("batch").ViaField("spec")
+	}
+
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*KafkaSource)
-		if diff, err := kmp.ShortDiff(original.Spec, r.Spec); err != nil {
-			return &apis.FieldError{
-				Message: "Failed to diff KafkaSource",
-				Paths:   []string{"spec"},
-				Details: err.Error(),
-			}
-		} else if diff != "" {
-			return &apis.FieldError{
-				Message: "Immutable fields changed (-old +new)",
-				Paths:   []string{"spec"},
-				Details: diff,
-			}
+		if err := r.checkImmutableFields(original); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkImmutableFields ensures only the mutable fields of the spec changed.
+// See v1beta1 for the list of mutable fields.
+func (r *KafkaSource) checkImmutableFields(original *KafkaSource) *apis.FieldError {
+	if original.Spec.ConsumerGroup != r.Spec.ConsumerGroup && r.GetAnnotations()[v1beta1.KafkaMigrateOffsetsAnnotation] != "true" {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("Immutable field changed, set the %s annotation to \"true\" to migrate the committed offsets", v1beta1.KafkaMigrateOffsetsAnnotation),
+			Paths:   []string{"spec.consumerGroup"},
+			Details: fmt.Sprintf("{%q: %q -> %q}", "consumerGroup", original.Spec.ConsumerGroup, r.Spec.ConsumerGroup),
 		}
 	}
 
+	if diff, err := kmp.ShortDiff(immutableSpec(&original.Spec), immutableSpec(&r.Spec)); err != nil {
+		return &apis.FieldError{
+			Message: "Failed to diff KafkaSource",
+			Paths:   []string{"spec"},
+			Details: err.Error(),
+		}
+	} else if diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec"},
+			Details: diff,
+		}
+	}
 	return nil
 }
+
+// immutableSpec returns a copy of spec without the mutable fields.
+func immutableSpec(spec *KafkaSourceSpec) *KafkaSourceSpec {
+	immutable := spec.DeepCopy()
+	immutable.Topics = nil
+	immutable.ConsumerGroup = ""
+	immutable.Net = bindingsv1alpha1.KafkaNetSpec{}
+	immutable.CloudEventMapping = nil
+	immutable.Filters = nil
+	immutable.Batch = nil
+	immutable.Sink = nil
+	return immutable
+}
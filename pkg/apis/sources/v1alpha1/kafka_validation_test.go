@@ -20,7 +20,10 @@ import (
 	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	bindingsv1alpha1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -46,117 +49,95 @@ var (
 
 func TestKafkaSourceCheckImmutableFields(t *testing.T) {
 	testCases := map[string]struct {
-		orig    *KafkaSourceSpec
-		updated KafkaSourceSpec
-		allowed bool
+		orig        *KafkaSourceSpec
+		updated     func(spec *KafkaSourceSpec)
+		annotations map[string]string
+		allowed     bool
 	}{
 		"nil orig": {
-			updated: fullSpec,
+			updated: func(spec *KafkaSourceSpec) {},
 			allowed: true,
 		},
 		"Topic changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics:             []string{"some-other-topic"},
-				Sink:               fullSpec.Sink,
-				ServiceAccountName: fullSpec.ServiceAccountName,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Topics = []string{"some-other-topic"}
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"Bootstrap servers changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				KafkaAuthSpec: bindingsv1alpha1.KafkaAuthSpec{
-					BootstrapServers: []string{"server1,server2"},
-				},
-				Sink:               fullSpec.Sink,
-				ServiceAccountName: fullSpec.ServiceAccountName,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.BootstrapServers = []string{"server1,server2"}
 			},
 			allowed: false,
 		},
+		"Net changed": {
+			orig: &fullSpec,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Net.SASL.Enable = true
+				spec.Net.SASL.User.SecretKeyRef = &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "some-other-secret"},
+					Key:                  "user",
+				}
+			},
+			allowed: true,
+		},
 		"Sink.APIVersion changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				Sink: &duckv1.Destination{
-					Ref: &duckv1.KReference{
-						APIVersion: "some-other-api-version",
-						Kind:       fullSpec.Sink.Ref.APIVersion,
-						Namespace:  fullSpec.Sink.Ref.Namespace,
-						Name:       fullSpec.Sink.Ref.Name,
-					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Sink.Ref.APIVersion = "some-other-api-version"
 			},
-			allowed: false,
+			allowed: true,
 		},
-		"Sink.Kind changed": {
+		"Sink.Name changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				Sink: &duckv1.Destination{
-					Ref: &duckv1.KReference{
-						APIVersion: fullSpec.Sink.Ref.APIVersion,
-						Kind:       "some-other-kind",
-						Namespace:  fullSpec.Sink.Ref.Namespace,
-						Name:       fullSpec.Sink.Ref.Name,
-					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Sink.Ref.Name = "some-other-name"
 			},
-			allowed: false,
+			allowed: true,
 		},
-		"Sink.Namespace changed": {
+		"Filters changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				Sink: &duckv1.Destination{
-					Ref: &duckv1.KReference{
-						APIVersion: fullSpec.Sink.Ref.APIVersion,
-						Kind:       fullSpec.Sink.Ref.Kind,
-						Namespace:  "some-other-namespace",
-						Name:       fullSpec.Sink.Ref.Name,
-					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Filters = []v1beta1.KafkaSourceFilter{{Exact: map[string]string{"type": "foo"}}}
 			},
-			allowed: false,
+			allowed: true,
 		},
-		"Sink.Name changed": {
+		"ServiceAccountName changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				Sink: &duckv1.Destination{
-					Ref: &duckv1.KReference{
-						APIVersion: fullSpec.Sink.Ref.APIVersion,
-						Kind:       fullSpec.Sink.Ref.Kind,
-						Namespace:  fullSpec.Sink.Ref.Namespace,
-						Name:       "some-other-name",
-					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.ServiceAccountName = "some-other-name"
 			},
 			allowed: false,
 		},
-		"ServiceAccountName changed": {
+		"ConsumerGroup changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				Sink: &duckv1.Destination{
-					Ref: &duckv1.KReference{
-						APIVersion: fullSpec.Sink.Ref.APIVersion,
-						Kind:       fullSpec.Sink.Ref.Kind,
-						Namespace:  fullSpec.Sink.Ref.Namespace,
-						Name:       "some-other-name",
-					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.ConsumerGroup = "some-other-group"
 			},
 			allowed: false,
 		},
+		"ConsumerGroup changed with offsets migration": {
+			orig: &fullSpec,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.ConsumerGroup = "some-other-group"
+			},
+			annotations: map[string]string{v1beta1.KafkaMigrateOffsetsAnnotation: "true"},
+			allowed:     true,
+		},
+		"ConsumerGroup and bootstrap servers changed with offsets migration": {
+			orig: &fullSpec,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.ConsumerGroup = "some-other-group"
+				spec.BootstrapServers = []string{"server1,server2"}
+			},
+			annotations: map[string]string{v1beta1.KafkaMigrateOffsetsAnnotation: "true"},
+			allowed:     false,
+		},
 		"no change": {
 			orig:    &fullSpec,
-			updated: fullSpec,
+			updated: func(spec *KafkaSourceSpec) {},
 			allowed: true,
 		},
 	}
@@ -171,8 +152,12 @@ func TestKafkaSourceCheckImmutableFields(t *testing.T) {
 				ctx = apis.WithinUpdate(ctx, orig)
 			}
 			updated := &KafkaSource{
-				Spec: tc.updated,
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+				Spec: *fullSpec.DeepCopy(),
 			}
+			tc.updated(&updated.Spec)
 
 			err := updated.Validate(ctx)
 			if tc.allowed != (err == nil) {
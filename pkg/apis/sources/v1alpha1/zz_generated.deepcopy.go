@@ -22,6 +22,7 @@ package v1alpha1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	v1 "knative.dev/pkg/apis/duck/v1"
 )
 
@@ -145,6 +146,23 @@ func (in *KafkaSourceSpec) DeepCopyInto(out *KafkaSourceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CloudEventMapping != nil {
+		in, out := &in.CloudEventMapping, &out.CloudEventMapping
+		*out = new(v1beta1.KafkaCloudEventMappingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]v1beta1.KafkaSourceFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Batch != nil {
+		in, out := &in.Batch, &out.Batch
+		*out = new(v1beta1.KafkaSourceBatchSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Sink != nil {
 		in, out := &in.Sink, &out.Sink
 		*out = new(v1.Destination)
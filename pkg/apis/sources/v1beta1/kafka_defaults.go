@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"knative.dev/pkg/apis"
 )
 
 const (
@@ -28,7 +29,20 @@ const (
 
 // SetDefaults ensures KafkaSource reflects the default values.
 func (k *KafkaSource) SetDefaults(ctx context.Context) {
-	if k != nil && k.Spec.ConsumerGroup == "" {
+	if k == nil {
+		return
+	}
+	if k.Spec.ConsumerGroup == "" && apis.IsInUpdate(ctx) {
+		// Keep the generated consumer group when it is omitted from an update
+		if original, ok := apis.GetBaseline(ctx).(*KafkaSource); ok {
+			k.Spec.ConsumerGroup = original.Spec.ConsumerGroup
+		}
+	}
+	if k.Spec.ConsumerGroup == "" {
 		k.Spec.ConsumerGroup = uuidPrefix + uuid.New().String()
 	}
+	if k.Spec.Batch != nil && k.Spec.Batch.MaxWait == nil {
+		maxWait := DefaultBatchMaxWait
+		k.Spec.Batch.MaxWait = &maxWait
+	}
 }
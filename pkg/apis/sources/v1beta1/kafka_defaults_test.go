@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"knative.dev/pkg/apis"
 )
 
 type defaultKafkaTestArgs struct {
@@ -45,6 +46,15 @@ func TestSetDefaults(t *testing.T) {
 			t.Fatalf("Unexpected consumerGroup Set (-want, +got): %s", diff)
 		}
 	}
+	assertBatchMaxWait := func(t *testing.T, ks KafkaSource, expected string) {
+		if ks.Spec.Batch.MaxWait == nil {
+			t.Fatalf("Unexpected nil batch maxWait")
+		}
+		if diff := cmp.Diff(*ks.Spec.Batch.MaxWait, expected); diff != "" {
+			t.Fatalf("Unexpected batch maxWait Set (-want, +got): %s", diff)
+		}
+	}
+	maxWait := "PT5S"
 	testCases := []defaultKafkaTestArgs{
 		{
 			Name:       "nil spec",
@@ -61,6 +71,26 @@ func TestSetDefaults(t *testing.T) {
 			Expected:   "foo",
 			AssertFunc: assertGivenGroup,
 		},
+		{
+			Name: "Default batch maxWait",
+			Initial: KafkaSource{
+				Spec: KafkaSourceSpec{
+					Batch: &KafkaSourceBatchSpec{MaxSize: 10},
+				},
+			},
+			Expected:   DefaultBatchMaxWait,
+			AssertFunc: assertBatchMaxWait,
+		},
+		{
+			Name: "Set batch maxWait",
+			Initial: KafkaSource{
+				Spec: KafkaSourceSpec{
+					Batch: &KafkaSourceBatchSpec{MaxSize: 10, MaxWait: &maxWait},
+				},
+			},
+			Expected:   maxWait,
+			AssertFunc: assertBatchMaxWait,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -72,3 +102,18 @@ func TestSetDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDefaultsKeepsConsumerGroupOnUpdate(t *testing.T) {
+	original := &KafkaSource{
+		Spec: KafkaSourceSpec{
+			ConsumerGroup: uuidPrefix + "original",
+		},
+	}
+	updated := &KafkaSource{}
+
+	updated.SetDefaults(apis.WithinUpdate(context.TODO(), original))
+
+	if diff := cmp.Diff(original.Spec.ConsumerGroup, updated.Spec.ConsumerGroup); diff != "" {
+		t.Fatalf("Unexpected consumerGroup Set (-want, +got): %s", diff)
+	}
+}
@@ -32,6 +32,15 @@ const (
 	// KafkaConditionDeployed has status True when the KafkaSource has had it's receive adapter deployment created.
 	KafkaConditionDeployed apis.ConditionType = "Deployed"
 
+	// KafkaConditionConsuming has status True when the shared receive adapter consumes the topics of the
+	// KafkaSource. It is only set by the shared receive adapter, for the sources it serves, and is propagated
+	// to the KafkaConditionDeployed condition by the KafkaSource reconciler.
+	KafkaConditionConsuming apis.ConditionType = "Consuming"
+
+	// KafkaConditionPaused is True when the consumption of the KafkaSource is
+	// paused. It is not set otherwise.
+	KafkaConditionPaused apis.ConditionType = "Paused"
+
 	// KafkaConditionKeyType is True when the KafkaSource has been configured with valid key type for
 	// the key deserializer.
 	KafkaConditionKeyType apis.ConditionType = "KeyTypeCorrect"
@@ -106,6 +115,52 @@ func (s *KafkaSourceStatus) MarkNotDeployed(reason, messageFormat string, messag
 	KafkaSourceCondSet.Manage(s).MarkFalse(KafkaConditionDeployed, reason, messageFormat, messageA...)
 }
 
+// MarkConsuming sets the condition that the shared receive adapter is
+// consuming the topics of the source.
+func (s *KafkaSourceStatus) MarkConsuming() {
+	KafkaSourceCondSet.Manage(s).MarkTrue(KafkaConditionConsuming)
+}
+
+// MarkNotConsuming sets the condition that the shared receive adapter failed
+// to consume the topics of the source.
+func (s *KafkaSourceStatus) MarkNotConsuming(reason, messageFormat string, messageA ...interface{}) {
+	KafkaSourceCondSet.Manage(s).MarkFalse(KafkaConditionConsuming, reason, messageFormat, messageA...)
+}
+
+// ClearConsuming removes the condition set by the shared receive adapter
+// once it no longer serves the source.
+func (s *KafkaSourceStatus) ClearConsuming() error {
+	return KafkaSourceCondSet.Manage(s).ClearCondition(KafkaConditionConsuming)
+}
+
+// PropagateConsuming sets the deployed condition of a source served by the
+// shared receive adapter from the condition set by the adapter, the source
+// is deploying until the adapter reports it.
+func (s *KafkaSourceStatus) PropagateConsuming(reason, messageFormat string, messageA ...interface{}) {
+	cond := s.GetCondition(KafkaConditionConsuming)
+	switch {
+	case cond == nil:
+		s.MarkDeploying(reason, messageFormat, messageA...)
+	case cond.IsTrue():
+		KafkaSourceCondSet.Manage(s).MarkTrue(KafkaConditionDeployed)
+	case cond.IsFalse():
+		s.MarkNotDeployed(cond.Reason, "%s", cond.Message)
+	default:
+		s.MarkDeploying(cond.Reason, "%s", cond.Message)
+	}
+}
+
+// MarkPaused sets the condition that the consumption of the source is paused.
+func (s *KafkaSourceStatus) MarkPaused() {
+	KafkaSourceCondSet.Manage(s).MarkTrueWithReason(KafkaConditionPaused, "Paused", "The consumption is paused by the %s annotation", KafkaPausedAnnotation)
+}
+
+// MarkNotPaused removes the condition that the consumption of the source is
+// paused.
+func (s *KafkaSourceStatus) MarkNotPaused() {
+	_ = KafkaSourceCondSet.Manage(s).ClearCondition(KafkaConditionPaused)
+}
+
 func (s *KafkaSourceStatus) MarkKeyTypeCorrect() {
 	KafkaSourceCondSet.Manage(s).MarkTrue(KafkaConditionKeyType)
 }
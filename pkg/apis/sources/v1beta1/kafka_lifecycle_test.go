@@ -182,6 +182,112 @@ func TestKafkaSourceStatusGetCondition(t *testing.T) {
 			Type:   KafkaConditionReady,
 			Status: corev1.ConditionTrue,
 		},
+	}, {
+		name: "mark sink and consuming",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("example"))
+			s.MarkConsuming()
+			s.PropagateConsuming("Pending", "%s", "")
+			return s
+		}(),
+		condQuery: KafkaConditionReady,
+		want: &apis.Condition{
+			Type:   KafkaConditionReady,
+			Status: corev1.ConditionTrue,
+		},
+	}, {
+		name: "mark sink and consuming then not consuming",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("example"))
+			s.MarkConsuming()
+			s.PropagateConsuming("Pending", "%s", "")
+			s.MarkNotConsuming("Testing", "hi%s", "")
+			s.PropagateConsuming("Pending", "%s", "")
+			return s
+		}(),
+		condQuery: KafkaConditionReady,
+		want: &apis.Condition{
+			Type:    KafkaConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Testing",
+			Message: "hi",
+		},
+	}, {
+		name: "mark sink and shared receive adapter pending",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("example"))
+			s.PropagateConsuming("Pending", "waiting%s", "")
+			return s
+		}(),
+		condQuery: KafkaConditionReady,
+		want: &apis.Condition{
+			Type:    KafkaConditionReady,
+			Status:  corev1.ConditionUnknown,
+			Reason:  "Pending",
+			Message: "waiting",
+		},
+	}, {
+		name: "mark sink, consuming and not consuming without propagation",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("example"))
+			s.MarkDeployed(availableDeployment)
+			s.MarkNotConsuming("Testing", "hi%s", "")
+			return s
+		}(),
+		condQuery: KafkaConditionReady,
+		want: &apis.Condition{
+			Type:   KafkaConditionReady,
+			Status: corev1.ConditionTrue,
+		},
+	}, {
+		name: "mark sink, deployed and paused",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("example"))
+			s.MarkDeployed(availableDeployment)
+			s.MarkPaused()
+			return s
+		}(),
+		condQuery: KafkaConditionReady,
+		want: &apis.Condition{
+			Type:   KafkaConditionReady,
+			Status: corev1.ConditionTrue,
+		},
+	}, {
+		name: "paused",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkPaused()
+			return s
+		}(),
+		condQuery: KafkaConditionPaused,
+		want: &apis.Condition{
+			Type:    KafkaConditionPaused,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Paused",
+			Message: "The consumption is paused by the kafkasources.sources.knative.dev/paused annotation",
+		},
+	}, {
+		name: "paused then resumed",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkPaused()
+			s.MarkNotPaused()
+			return s
+		}(),
+		condQuery: KafkaConditionPaused,
+		want:      nil,
 	}, {
 		name: "mark sink nil and deployed",
 		s: func() *KafkaSourceStatus {
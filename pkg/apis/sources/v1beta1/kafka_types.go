@@ -77,6 +77,22 @@ type KafkaSourceSpec struct {
 	// +optional
 	ConsumerGroup string `json:"consumerGroup,omitempty"`
 
+	// CloudEventMapping defines how the CloudEvent attributes of the events
+	// produced from Kafka records that are not CloudEvents are computed.
+	// +optional
+	CloudEventMapping *KafkaCloudEventMappingSpec `json:"ceMapping,omitempty"`
+
+	// Filters is a list of filters the events must all pass to be sent to
+	// the sink. Events not passing the filters are dropped and their offset
+	// is committed.
+	// +optional
+	Filters []KafkaSourceFilter `json:"filters,omitempty"`
+
+	// Batch enables the batch delivery mode, where the records of a
+	// partition are sent to the sink in CloudEvents batches.
+	// +optional
+	Batch *KafkaSourceBatchSpec `json:"batch,omitempty"`
+
 	// inherits duck/v1 SourceSpec, which currently provides:
 	// * Sink - a reference to an object that will resolve to a domain name or
 	//   a URI directly to use as the sink.
@@ -85,15 +101,149 @@ type KafkaSourceSpec struct {
 	duckv1.SourceSpec `json:",inline"`
 }
 
+// KafkaCloudEventMappingSpec defines the rules used to compute the CloudEvent
+// attributes of a Kafka record that is not a CloudEvent. Attributes without a
+// rule keep their default value.
+type KafkaCloudEventMappingSpec struct {
+	// Type is the rule for the CloudEvent type attribute.
+	// +optional
+	Type *KafkaAttributeMapping `json:"type,omitempty"`
+
+	// Subject is the rule for the CloudEvent subject attribute.
+	// +optional
+	Subject *KafkaAttributeMapping `json:"subject,omitempty"`
+
+	// ID is the rule for the CloudEvent id attribute.
+	// +optional
+	ID *KafkaAttributeMapping `json:"id,omitempty"`
+
+	// Time is the rule for the CloudEvent time attribute. Values must be
+	// RFC 3339 timestamps.
+	// +optional
+	Time *KafkaAttributeMapping `json:"time,omitempty"`
+}
+
+// KafkaAttributeMapping defines where the value of a CloudEvent attribute
+// is taken from.
+type KafkaAttributeMapping struct {
+	// From is the ordered list of locations to read the value from.
+	// The first location resolving to a non empty value wins.
+	// +optional
+	From []KafkaAttributeSource `json:"from,omitempty"`
+
+	// Default is the value used when none of the locations resolves. When
+	// empty, the attribute keeps the value computed by the source.
+	// +optional
+	Default string `json:"default,omitempty"`
+}
+
+// KafkaAttributeSource is a location in a Kafka record. Exactly one of the
+// fields must be set.
+type KafkaAttributeSource struct {
+	// Header is the name of the Kafka header holding the value.
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// Key reads the value from the record key.
+	// +optional
+	Key bool `json:"key,omitempty"`
+
+	// JSONPath is a JSONPath expression (e.g. "{.metadata.type}") evaluated
+	// against the record value, which must then be a JSON document.
+	// +optional
+	JSONPath string `json:"jsonPath,omitempty"`
+}
+
+// KafkaSourceFilter is a filter applied to the events before they are sent to
+// the sink. Keys are CloudEvent attribute names, including the extensions
+// added by the source: "key" for the record key and "kafkaheader<name>" for
+// the record headers. Exactly one of the fields must be set.
+type KafkaSourceFilter struct {
+	// Exact matches when every attribute is equal to the given value.
+	// +optional
+	Exact map[string]string `json:"exact,omitempty"`
+
+	// Prefix matches when every attribute starts with the given value.
+	// +optional
+	Prefix map[string]string `json:"prefix,omitempty"`
+
+	// Suffix matches when every attribute ends with the given value.
+	// +optional
+	Suffix map[string]string `json:"suffix,omitempty"`
+
+	// All matches when all the nested filters match.
+	// +optional
+	All []KafkaSourceFilter `json:"all,omitempty"`
+
+	// Any matches when at least one of the nested filters matches.
+	// +optional
+	Any []KafkaSourceFilter `json:"any,omitempty"`
+
+	// Not matches when the nested filter does not match.
+	// +optional
+	Not *KafkaSourceFilter `json:"not,omitempty"`
+}
+
+// KafkaSourceBatchSpec defines how records are grouped in batches.
+type KafkaSourceBatchSpec struct {
+	// MaxSize is the maximum number of records in a batch.
+	// +required
+	MaxSize int32 `json:"maxSize"`
+
+	// MaxWait is the maximum time to wait for a batch to fill up before
+	// sending it, expressed as an ISO 8601 duration. Defaults to PT1S.
+	// +optional
+	MaxWait *string `json:"maxWait,omitempty"`
+}
+
 const (
 	// KafkaEventType is the Kafka CloudEvent type.
 	KafkaEventType = "dev.knative.kafka.event"
 
 	KafkaKeyTypeLabel = "kafkasources.sources.knative.dev/key-type"
+
+	// KafkaMigrateOffsetsAnnotation allows changing the consumer group of a
+	// KafkaSource. When set to "true", the committed offsets of the previous
+	// consumer group are copied to the new one before consuming.
+	KafkaMigrateOffsetsAnnotation = "kafkasources.sources.knative.dev/migrate-offsets"
+
+	// KafkaPausedAnnotation pauses the consumption of the KafkaSource when
+	// set to "true". The consumer group membership is kept while paused.
+	KafkaPausedAnnotation = "kafkasources.sources.knative.dev/paused"
+
+	// KafkaReceiveAdapterAnnotation selects the receive adapter running the
+	// KafkaSource consumer group. Defaults to KafkaReceiveAdapterDedicated.
+	KafkaReceiveAdapterAnnotation = "kafkasources.sources.knative.dev/receive-adapter"
+
+	// KafkaReceiveAdapterDedicated runs the consumer group in a receive
+	// adapter deployment owned by the KafkaSource.
+	KafkaReceiveAdapterDedicated = "dedicated"
+
+	// KafkaReceiveAdapterShared runs the consumer group in the shared,
+	// multi-tenant receive adapter.
+	KafkaReceiveAdapterShared = "shared"
+
+	// KafkaBatchContentType is the content type of the requests sent in
+	// batch delivery mode.
+	KafkaBatchContentType = "application/cloudevents-batch+json"
+
+	// DefaultBatchMaxWait is the default maximum time to wait for a batch.
+	DefaultBatchMaxWait = "PT1S"
 )
 
 var KafkaKeyTypeAllowed = []string{"string", "int", "float", "byte-array"}
 
+// IsPaused returns true when the consumption of the KafkaSource is paused.
+func (s *KafkaSource) IsPaused() bool {
+	return s.GetAnnotations()[KafkaPausedAnnotation] == "true"
+}
+
+// IsSharedReceiveAdapter returns true when the consumer group of the
+// KafkaSource runs in the shared receive adapter.
+func (s *KafkaSource) IsSharedReceiveAdapter() bool {
+	return s.GetAnnotations()[KafkaReceiveAdapterAnnotation] == KafkaReceiveAdapterShared
+}
+
 // KafkaEventSource returns the Kafka CloudEvent source.
 func KafkaEventSource(namespace, kafkaSourceName, topic string) string {
 	return fmt.Sprintf("/apis/v1/namespaces/%s/kafkasources/%s#%s", namespace, kafkaSourceName, topic)
@@ -18,29 +18,259 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
 
+	"github.com/rickb777/date/period"
+	"k8s.io/client-go/util/jsonpath"
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmp"
 )
 
 // Validate ensures KafkaSource is properly configured.
 func (r *KafkaSource) Validate(ctx context.Context) *apis.FieldError {
+	if err := ValidateReceiveAdapterAnnotation(r.GetAnnotations()); err != nil {
+		return err.ViaField("metadata")
+	}
+	if err := ValidatePausedAnnotation(r.GetAnnotations()); err != nil {
+		return err.ViaField("metadata")
+	}
+	if err := r.Spec.CloudEventMapping.Validate(ctx); err != nil {
+		return err.ViaField("ceMapping").ViaField("spec")
+	}
+	for i, f := range r.Spec.Filters {
+		if err := f.Validate(ctx); err != nil {
+			return err.ViaFieldIndex("filters", i).ViaField("spec")
+		}
+	}
+	if err := r.Spec.Batch.Validate(ctx); err != nil {
+		return err.ViaField("batch").ViaField("spec")
+	}
+
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*KafkaSource)
-		if diff, err := kmp.ShortDiff(original.Spec, r.Spec); err != nil {
-			return &apis.FieldError{
-				Message: "Failed to diff KafkaSource",
-				Paths:   []string{"spec"},
-				Details: err.Error(),
+		if err := r.checkImmutableFields(original); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateReceiveAdapterAnnotation ensures the receive adapter annotation,
+// when set, selects a known receive adapter.
+func ValidateReceiveAdapterAnnotation(annotations map[string]string) *apis.FieldError {
+	mode, ok := annotations[KafkaReceiveAdapterAnnotation]
+	if !ok || mode == KafkaReceiveAdapterDedicated || mode == KafkaReceiveAdapterShared {
+		return nil
+	}
+	return apis.ErrInvalidValue(mode, KafkaReceiveAdapterAnnotation).ViaField("annotations")
+}
+
+// ValidatePausedAnnotation ensures the paused annotation, when set, is a
+// boolean.
+func ValidatePausedAnnotation(annotations map[string]string) *apis.FieldError {
+	paused, ok := annotations[KafkaPausedAnnotation]
+	if !ok {
+		return nil
+	}
+	if _, err := strconv.ParseBool(paused); err != nil {
+		return apis.ErrInvalidValue(paused, KafkaPausedAnnotation).ViaField("annotations")
+	}
+	return nil
+}
+
+// checkImmutableFields ensures only the mutable fields of the spec changed.
+// The sink, the CloudEvent overrides, the topics, the network and
+// authentication settings, and the event processing options are mutable.
+// The consumer group can be changed only along with the migrate offsets
+// annotation.
+func (r *KafkaSource) checkImmutableFields(original *KafkaSource) *apis.FieldError {
+	if original.Spec.ConsumerGroup != r.Spec.ConsumerGroup && r.GetAnnotations()[KafkaMigrateOffsetsAnnotation] != "true" {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("Immutable field changed, set the %s annotation to \"true\" to migrate the committed offsets", KafkaMigrateOffsetsAnnotation),
+			Paths:   []string{"spec.consumerGroup"},
+			Details: fmt.Sprintf("{%q: %q -> %q}", "consumerGroup", original.Spec.ConsumerGroup, r.Spec.ConsumerGroup),
+		}
+	}
+
+	if diff, err := kmp.ShortDiff(immutableSpec(&original.Spec), immutableSpec(&r.Spec)); err != nil {
+		return &apis.FieldError{
+			Message: "Failed to diff KafkaSource",
+			Paths:   []string{"spec"},
+			Details: err.Error(),
+		}
+	} else if diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec"},
+			Details: diff,
+		}
+	}
+	return nil
+}
+
+// immutableSpec returns a copy of spec without the mutable fields.
+func immutableSpec(spec *KafkaSourceSpec) *KafkaSourceSpec {
+	immutable := spec.DeepCopy()
+	immutable.Topics = nil
+	immutable.ConsumerGroup = ""
+	immutable.Net = bindingsv1beta1.KafkaNetSpec{}
+	immutable.CloudEventMapping = nil
+	immutable.Filters = nil
+	immutable.Batch = nil
+	immutable.SourceSpec = duckv1.SourceSpec{}
+	return immutable
+}
+
+// Validate ensures the CloudEvent mapping rules are well formed.
+func (m *KafkaCloudEventMappingSpec) Validate(ctx context.Context) *apis.FieldError {
+	if m == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	errs = errs.Also(m.Type.Validate(ctx).ViaField("type"))
+	errs = errs.Also(m.Subject.Validate(ctx).ViaField("subject"))
+	errs = errs.Also(m.ID.Validate(ctx).ViaField("id"))
+	if m.Time != nil {
+		errs = errs.Also(m.Time.Validate(ctx).ViaField("time"))
+		if m.Time.Default != "" {
+			if _, err := time.Parse(time.RFC3339, m.Time.Default); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(m.Time.Default, "time.default"))
 			}
-		} else if diff != "" {
+		}
+	}
+	return errs
+}
+
+// Validate ensures the attribute mapping has at least one location or a
+// default, and that every location is well formed.
+func (m *KafkaAttributeMapping) Validate(ctx context.Context) *apis.FieldError {
+	if m == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	if len(m.From) == 0 && m.Default == "" {
+		errs = errs.Also(apis.ErrMissingOneOf("from", "default"))
+	}
+	for i, s := range m.From {
+		errs = errs.Also(s.Validate(ctx).ViaFieldIndex("from", i))
+	}
+	return errs
+}
+
+// Validate ensures exactly one location is set and that JSONPath
+// expressions can be parsed.
+func (s *KafkaAttributeSource) Validate(_ context.Context) *apis.FieldError {
+	set := make([]string, 0, 3)
+	if s.Header != "" {
+		set = append(set, "header")
+	}
+	if s.Key {
+		set = append(set, "key")
+	}
+	if s.JSONPath != "" {
+		set = append(set, "jsonPath")
+	}
+
+	switch len(set) {
+	case 0:
+		return apis.ErrMissingOneOf("header", "key", "jsonPath")
+	case 1:
+	default:
+		return apis.ErrMultipleOneOf(set...)
+	}
+
+	if s.JSONPath != "" {
+		if err := jsonpath.New("").Parse(s.JSONPath); err != nil {
 			return &apis.FieldError{
-				Message: "Immutable fields changed (-old +new)",
-				Paths:   []string{"spec"},
-				Details: diff,
+				Message: "Invalid JSONPath expression",
+				Paths:   []string{"jsonPath"},
+				Details: err.Error(),
 			}
 		}
 	}
-
 	return nil
 }
+
+// Validate ensures exactly one filter dialect is set and that nested filters
+// are valid.
+func (f *KafkaSourceFilter) Validate(ctx context.Context) *apis.FieldError {
+	set := make([]string, 0, 1)
+	if len(f.Exact) > 0 {
+		set = append(set, "exact")
+	}
+	if len(f.Prefix) > 0 {
+		set = append(set, "prefix")
+	}
+	if len(f.Suffix) > 0 {
+		set = append(set, "suffix")
+	}
+	if len(f.All) > 0 {
+		set = append(set, "all")
+	}
+	if len(f.Any) > 0 {
+		set = append(set, "any")
+	}
+	if f.Not != nil {
+		set = append(set, "not")
+	}
+
+	switch len(set) {
+	case 0:
+		return apis.ErrMissingOneOf("exact", "prefix", "suffix", "all", "any", "not")
+	case 1:
+	default:
+		return apis.ErrMultipleOneOf(set...)
+	}
+
+	var errs *apis.FieldError
+	errs = errs.Also(validateFilterAttributes(f.Exact).ViaField("exact"))
+	errs = errs.Also(validateFilterAttributes(f.Prefix).ViaField("prefix"))
+	errs = errs.Also(validateFilterAttributes(f.Suffix).ViaField("suffix"))
+	for i, nested := range f.All {
+		errs = errs.Also(nested.Validate(ctx).ViaFieldIndex("all", i))
+	}
+	for i, nested := range f.Any {
+		errs = errs.Also(nested.Validate(ctx).ViaFieldIndex("any", i))
+	}
+	if f.Not != nil {
+		errs = errs.Also(f.Not.Validate(ctx).ViaField("not"))
+	}
+	return errs
+}
+
+func validateFilterAttributes(attributes map[string]string) *apis.FieldError {
+	var errs *apis.FieldError
+	for name := range attributes {
+		if name == "" {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, apis.CurrentField, "attribute name must not be empty"))
+		}
+	}
+	return errs
+}
+
+// Validate ensures the batch size is positive and the maximum wait is a
+// valid ISO 8601 duration.
+func (b *KafkaSourceBatchSpec) Validate(_ context.Context) *apis.FieldError {
+	if b == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	if b.MaxSize < 1 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(b.MaxSize, 1, math.MaxInt32, "maxSize"))
+	}
+	if b.MaxWait != nil {
+		if p, err := period.Parse(*b.MaxWait); err != nil || p.DurationApprox() <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*b.MaxWait, "maxWait"))
+		}
+	}
+	return errs
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -46,120 +48,97 @@ var (
 
 func TestKafkaSourceCheckImmutableFields(t *testing.T) {
 	testCases := map[string]struct {
-		orig    *KafkaSourceSpec
-		updated KafkaSourceSpec
-		allowed bool
+		orig        *KafkaSourceSpec
+		updated     func(spec *KafkaSourceSpec)
+		annotations map[string]string
+		allowed     bool
 	}{
 		"nil orig": {
-			updated: fullSpec,
+			updated: func(spec *KafkaSourceSpec) {},
 			allowed: true,
 		},
 		"Topic changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics:     []string{"some-other-topic"},
-				SourceSpec: fullSpec.SourceSpec,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Topics = []string{"some-other-topic"}
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"Bootstrap servers changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
-					BootstrapServers: []string{"server1,server2"},
-				},
-				SourceSpec: fullSpec.SourceSpec,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.BootstrapServers = []string{"server1,server2"}
 			},
 			allowed: false,
 		},
+		"Net changed": {
+			orig: &fullSpec,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Net.SASL.Enable = true
+				spec.Net.SASL.User.SecretKeyRef = &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "some-other-secret"},
+					Key:                  "user",
+				}
+			},
+			allowed: true,
+		},
 		"Sink.APIVersion changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				SourceSpec: duckv1.SourceSpec{
-					Sink: duckv1.Destination{
-						Ref: &duckv1.KReference{
-							APIVersion: "some-other-api-version",
-							Kind:       fullSpec.Sink.Ref.APIVersion,
-							Namespace:  fullSpec.Sink.Ref.Namespace,
-							Name:       fullSpec.Sink.Ref.Name,
-						},
-					},
-				},
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Sink.Ref.APIVersion = "some-other-api-version"
 			},
-			allowed: false,
+			allowed: true,
 		},
-		"Sink.Kind changed": {
+		"Sink.Name changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				SourceSpec: duckv1.SourceSpec{
-					Sink: duckv1.Destination{
-						Ref: &duckv1.KReference{
-							APIVersion: fullSpec.Sink.Ref.APIVersion,
-							Kind:       "some-other-kind",
-							Namespace:  fullSpec.Sink.Ref.Namespace,
-							Name:       fullSpec.Sink.Ref.Name,
-						},
-					},
-				},
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Sink.Ref.Name = "some-other-name"
 			},
-			allowed: false,
+			allowed: true,
 		},
-		"Sink.Namespace changed": {
+		"CloudEventOverrides changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				SourceSpec: duckv1.SourceSpec{
-					Sink: duckv1.Destination{
-						Ref: &duckv1.KReference{
-							APIVersion: fullSpec.Sink.Ref.APIVersion,
-							Kind:       fullSpec.Sink.Ref.Kind,
-							Namespace:  "some-other-namespace",
-							Name:       fullSpec.Sink.Ref.Name,
-						},
-					},
-				},
+			updated: func(spec *KafkaSourceSpec) {
+				spec.CloudEventOverrides = &duckv1.CloudEventOverrides{
+					Extensions: map[string]string{"ext": "value"},
+				}
 			},
-			allowed: false,
+			allowed: true,
 		},
-		"Sink.Name changed": {
+		"Filters changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				SourceSpec: duckv1.SourceSpec{
-					Sink: duckv1.Destination{
-						Ref: &duckv1.KReference{
-							APIVersion: fullSpec.Sink.Ref.APIVersion,
-							Kind:       fullSpec.Sink.Ref.Kind,
-							Namespace:  fullSpec.Sink.Ref.Namespace,
-							Name:       "some-other-name",
-						},
-					},
-				},
+			updated: func(spec *KafkaSourceSpec) {
+				spec.Filters = []KafkaSourceFilter{{Exact: map[string]string{"type": "foo"}}}
 			},
-			allowed: false,
+			allowed: true,
 		},
-		"ServiceAccountName changed": {
+		"ConsumerGroup changed": {
 			orig: &fullSpec,
-			updated: KafkaSourceSpec{
-				Topics: fullSpec.Topics,
-				SourceSpec: duckv1.SourceSpec{
-					Sink: duckv1.Destination{
-						Ref: &duckv1.KReference{
-							APIVersion: fullSpec.Sink.Ref.APIVersion,
-							Kind:       fullSpec.Sink.Ref.Kind,
-							Namespace:  fullSpec.Sink.Ref.Namespace,
-							Name:       "some-other-name",
-						},
-					},
-				},
+			updated: func(spec *KafkaSourceSpec) {
+				spec.ConsumerGroup = "some-other-group"
 			},
 			allowed: false,
 		},
+		"ConsumerGroup changed with offsets migration": {
+			orig: &fullSpec,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.ConsumerGroup = "some-other-group"
+			},
+			annotations: map[string]string{KafkaMigrateOffsetsAnnotation: "true"},
+			allowed:     true,
+		},
+		"ConsumerGroup and bootstrap servers changed with offsets migration": {
+			orig: &fullSpec,
+			updated: func(spec *KafkaSourceSpec) {
+				spec.ConsumerGroup = "some-other-group"
+				spec.BootstrapServers = []string{"server1,server2"}
+			},
+			annotations: map[string]string{KafkaMigrateOffsetsAnnotation: "true"},
+			allowed:     false,
+		},
 		"no change": {
 			orig:    &fullSpec,
-			updated: fullSpec,
+			updated: func(spec *KafkaSourceSpec) {},
 			allowed: true,
 		},
 	}
@@ -174,8 +153,12 @@ func TestKafkaSourceCheckImmutableFields(t *testing.T) {
 				ctx = apis.WithinUpdate(ctx, orig)
 			}
 			updated := &KafkaSource{
-				Spec: tc.updated,
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+				Spec: *fullSpec.DeepCopy(),
 			}
+			tc.updated(&updated.Spec)
 
 			err := updated.Validate(ctx)
 			if tc.allowed != (err == nil) {
@@ -184,3 +167,251 @@ func TestKafkaSourceCheckImmutableFields(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaSourceCloudEventMappingValidation(t *testing.T) {
+	testCases := map[string]struct {
+		mapping *KafkaCloudEventMappingSpec
+		allowed bool
+	}{
+		"no mapping": {
+			allowed: true,
+		},
+		"valid mapping": {
+			mapping: &KafkaCloudEventMappingSpec{
+				Type: &KafkaAttributeMapping{
+					From:    []KafkaAttributeSource{{Header: "type"}, {JSONPath: "{.type}"}},
+					Default: "com.example",
+				},
+				Subject: &KafkaAttributeMapping{
+					From: []KafkaAttributeSource{{Key: true}},
+				},
+				Time: &KafkaAttributeMapping{
+					Default: "2020-10-01T12:00:00Z",
+				},
+			},
+			allowed: true,
+		},
+		"no location nor default": {
+			mapping: &KafkaCloudEventMappingSpec{
+				ID: &KafkaAttributeMapping{},
+			},
+			allowed: false,
+		},
+		"empty location": {
+			mapping: &KafkaCloudEventMappingSpec{
+				ID: &KafkaAttributeMapping{
+					From: []KafkaAttributeSource{{}},
+				},
+			},
+			allowed: false,
+		},
+		"multiple locations in one entry": {
+			mapping: &KafkaCloudEventMappingSpec{
+				ID: &KafkaAttributeMapping{
+					From: []KafkaAttributeSource{{Header: "id", Key: true}},
+				},
+			},
+			allowed: false,
+		},
+		"invalid json path": {
+			mapping: &KafkaCloudEventMappingSpec{
+				Type: &KafkaAttributeMapping{
+					From: []KafkaAttributeSource{{JSONPath: "{.type"}},
+				},
+			},
+			allowed: false,
+		},
+		"invalid time default": {
+			mapping: &KafkaCloudEventMappingSpec{
+				Time: &KafkaAttributeMapping{
+					Default: "yesterday",
+				},
+			},
+			allowed: false,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			spec := fullSpec.DeepCopy()
+			spec.CloudEventMapping = tc.mapping
+			source := &KafkaSource{Spec: *spec}
+
+			err := source.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestKafkaSourceFiltersValidation(t *testing.T) {
+	testCases := map[string]struct {
+		filters []KafkaSourceFilter
+		allowed bool
+	}{
+		"no filters": {
+			allowed: true,
+		},
+		"valid filters": {
+			filters: []KafkaSourceFilter{
+				{Exact: map[string]string{"type": "com.example"}},
+				{Any: []KafkaSourceFilter{
+					{Prefix: map[string]string{"kafkaheaderregion": "eu-"}},
+					{Not: &KafkaSourceFilter{Suffix: map[string]string{"key": "-test"}}},
+				}},
+			},
+			allowed: true,
+		},
+		"empty filter": {
+			filters: []KafkaSourceFilter{{}},
+			allowed: false,
+		},
+		"multiple dialects": {
+			filters: []KafkaSourceFilter{{
+				Exact:  map[string]string{"type": "com.example"},
+				Prefix: map[string]string{"type": "com."},
+			}},
+			allowed: false,
+		},
+		"empty attribute name": {
+			filters: []KafkaSourceFilter{{Exact: map[string]string{"": "value"}}},
+			allowed: false,
+		},
+		"invalid nested filter": {
+			filters: []KafkaSourceFilter{{Not: &KafkaSourceFilter{}}},
+			allowed: false,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			spec := fullSpec.DeepCopy()
+			spec.Filters = tc.filters
+			source := &KafkaSource{Spec: *spec}
+
+			err := source.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestKafkaSourceBatchValidation(t *testing.T) {
+	valid := "PT0.5S"
+	invalid := "500ms"
+	zero := "PT0S"
+
+	testCases := map[string]struct {
+		batch   *KafkaSourceBatchSpec
+		allowed bool
+	}{
+		"no batch": {
+			allowed: true,
+		},
+		"valid batch": {
+			batch:   &KafkaSourceBatchSpec{MaxSize: 100, MaxWait: &valid},
+			allowed: true,
+		},
+		"zero max size": {
+			batch:   &KafkaSourceBatchSpec{MaxSize: 0},
+			allowed: false,
+		},
+		"invalid max wait": {
+			batch:   &KafkaSourceBatchSpec{MaxSize: 100, MaxWait: &invalid},
+			allowed: false,
+		},
+		"zero max wait": {
+			batch:   &KafkaSourceBatchSpec{MaxSize: 100, MaxWait: &zero},
+			allowed: false,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			spec := fullSpec.DeepCopy()
+			spec.Batch = tc.batch
+			source := &KafkaSource{Spec: *spec}
+
+			err := source.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestKafkaSourceReceiveAdapterValidation(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		allowed     bool
+	}{
+		"no annotation": {
+			allowed: true,
+		},
+		"dedicated": {
+			annotations: map[string]string{KafkaReceiveAdapterAnnotation: KafkaReceiveAdapterDedicated},
+			allowed:     true,
+		},
+		"shared": {
+			annotations: map[string]string{KafkaReceiveAdapterAnnotation: KafkaReceiveAdapterShared},
+			allowed:     true,
+		},
+		"unknown": {
+			annotations: map[string]string{KafkaReceiveAdapterAnnotation: "pooled"},
+			allowed:     false,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			source := &KafkaSource{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       *fullSpec.DeepCopy(),
+			}
+
+			err := source.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestKafkaSourcePausedValidation(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		allowed     bool
+	}{
+		"no annotation": {
+			allowed: true,
+		},
+		"paused": {
+			annotations: map[string]string{KafkaPausedAnnotation: "true"},
+			allowed:     true,
+		},
+		"resumed": {
+			annotations: map[string]string{KafkaPausedAnnotation: "false"},
+			allowed:     true,
+		},
+		"not a boolean": {
+			annotations: map[string]string{KafkaPausedAnnotation: "yes please"},
+			allowed:     false,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			source := &KafkaSource{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       *fullSpec.DeepCopy(),
+			}
+
+			err := source.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
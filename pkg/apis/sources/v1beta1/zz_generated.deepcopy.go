@@ -24,6 +24,79 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaAttributeMapping) DeepCopyInto(out *KafkaAttributeMapping) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]KafkaAttributeSource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaAttributeMapping.
+func (in *KafkaAttributeMapping) DeepCopy() *KafkaAttributeMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaAttributeMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaAttributeSource) DeepCopyInto(out *KafkaAttributeSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaAttributeSource.
+func (in *KafkaAttributeSource) DeepCopy() *KafkaAttributeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaAttributeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaCloudEventMappingSpec) DeepCopyInto(out *KafkaCloudEventMappingSpec) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(KafkaAttributeMapping)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(KafkaAttributeMapping)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(KafkaAttributeMapping)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = new(KafkaAttributeMapping)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaCloudEventMappingSpec.
+func (in *KafkaCloudEventMappingSpec) DeepCopy() *KafkaCloudEventMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaCloudEventMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaLimitsSpec) DeepCopyInto(out *KafkaLimitsSpec) {
 	*out = *in
@@ -102,6 +175,83 @@ func (in *KafkaSource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSourceBatchSpec) DeepCopyInto(out *KafkaSourceBatchSpec) {
+	*out = *in
+	if in.MaxWait != nil {
+		in, out := &in.MaxWait, &out.MaxWait
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSourceBatchSpec.
+func (in *KafkaSourceBatchSpec) DeepCopy() *KafkaSourceBatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSourceBatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSourceFilter) DeepCopyInto(out *KafkaSourceFilter) {
+	*out = *in
+	if in.Exact != nil {
+		in, out := &in.Exact, &out.Exact
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Suffix != nil {
+		in, out := &in.Suffix, &out.Suffix
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.All != nil {
+		in, out := &in.All, &out.All
+		*out = make([]KafkaSourceFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Any != nil {
+		in, out := &in.Any, &out.Any
+		*out = make([]KafkaSourceFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Not != nil {
+		in, out := &in.Not, &out.Not
+		*out = new(KafkaSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSourceFilter.
+func (in *KafkaSourceFilter) DeepCopy() *KafkaSourceFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSourceFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaSourceList) DeepCopyInto(out *KafkaSourceList) {
 	*out = *in
@@ -144,6 +294,23 @@ func (in *KafkaSourceSpec) DeepCopyInto(out *KafkaSourceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CloudEventMapping != nil {
+		in, out := &in.CloudEventMapping, &out.CloudEventMapping
+		*out = new(KafkaCloudEventMappingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]KafkaSourceFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Batch != nil {
+		in, out := &in.Batch, &out.Batch
+		*out = new(KafkaSourceBatchSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
 	return
 }
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/Shopify/sarama"
 	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
@@ -30,17 +31,53 @@ import (
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
+	"knative.dev/eventing-kafka/pkg/common/admin"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/delivery"
+	"knative.dev/eventing-kafka/pkg/common/health"
+	"knative.dev/eventing-kafka/pkg/common/ingress"
+	"knative.dev/eventing-kafka/pkg/common/partitioning"
+	"knative.dev/eventing-kafka/pkg/common/saramaconfig"
 	eventingchannels "knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
 	"knative.dev/eventing/pkg/kncloudevents"
 )
 
+const (
+	// BrokersCheck, ProducerErrorsCheck, ConsumerGroupsCheck and ListersCheck name the health checks of the
+	// dispatcher.
+	BrokersCheck        = "kafka-brokers"
+	ProducerErrorsCheck = "producer-errors"
+	ConsumerGroupsCheck = "consumer-groups"
+	ListersCheck        = "listers"
+)
+
 type KafkaDispatcher struct {
 	hostToChannelMap atomic.Value
-	// hostToChannelMapLock is used to update hostToChannelMap
+	// hostToChannelMapLock is used to update hostToChannelMap, channelPartitioning, channelValidation and channelIngress
 	hostToChannelMapLock sync.Mutex
+	// channelPartitioning holds the partitioning.Settings of the channels, by channel reference
+	channelPartitioning atomic.Value
+	// channelValidation holds the ingress.Rules of the channels, by host name
+	channelValidation atomic.Value
+	// channelIngress holds the ingress policies of the channels restricting their senders, by host name
+	channelIngress atomic.Value
+	// authenticator verifies the tokens of the senders against the ingress policies
+	authenticator *ingress.Authenticator
+	// adminAuthorizer authorizes the callers of the admin endpoints served along with the health probes
+	adminAuthorizer *admin.Authorizer
+
+	// health runs the health checks served on the healthPort, zero disables serving them
+	health     *health.Checker
+	healthPort int
+	// brokerCheck checks the connectivity to the brokers, producerErrors the outcome of the produced events and
+	// sessions the sessions of the consumer groups
+	brokerCheck    *health.BrokerCheck
+	producerErrors *health.ErrorRate
+	sessions       *health.Sessions
 
 	receiver   *eventingchannels.MessageReceiver
 	dispatcher *eventingchannels.MessageDispatcherImpl
@@ -48,7 +85,24 @@ type KafkaDispatcher struct {
 	kafkaAsyncProducer   sarama.AsyncProducer
 	channelSubscriptions map[eventingchannels.ChannelReference][]types.UID
 	subsConsumerGroups   map[types.UID]sarama.ConsumerGroup
-	subscriptions        map[types.UID]Subscription
+	subsPauseGates       map[types.UID]*consumer.PauseGate
+	subsLimiters         map[types.UID]*delivery.Limiter
+	subsRetryTopics      map[types.UID]*delivery.RetryTopics
+	subsDeduplicators    map[types.UID]*delivery.Deduplicator
+	subsRetryConsumers   map[types.UID]*retryConsumer
+	subsSessions         map[types.UID]*health.Session
+	subsStats            map[types.UID]*consumer.Stats
+	subsHealth           map[types.UID]*delivery.Health
+	// subsAdminPaused holds the subscriptions paused through the admin endpoints, on top of their own pausing
+	subsAdminPaused map[types.UID]bool
+	subscriptions   map[types.UID]Subscription
+	// healthChanged holds the func(eventingchannels.ChannelReference) called when the delivery health of a
+	// subscription to the channel changes
+	healthChanged atomic.Value
+	// retryProducer produces the events to the retry topics, it is created when first needed
+	retryProducer sarama.SyncProducer
+	brokers       []string
+	saramaConfig  *sarama.Config
 	// consumerUpdateLock must be used to update kafkaConsumers
 	consumerUpdateLock   sync.Mutex
 	kafkaConsumerFactory consumer.KafkaConsumerGroupFactory
@@ -60,6 +114,14 @@ type KafkaDispatcher struct {
 type Subscription struct {
 	UID types.UID
 	fanout.Subscription
+	// Paused holds the events in the channel instead of delivering them to the subscriber
+	Paused bool
+	// Limits caps the delivery to the subscriber
+	Limits delivery.Limits
+	// RetryPolicy tunes the retries of the delivery to the subscriber
+	RetryPolicy delivery.RetryPolicy
+	// DeduplicationWindow skips the events delivered again to the subscriber within it, zero disables it
+	DeduplicationWindow time.Duration
 }
 
 func (sub Subscription) String() string {
@@ -81,11 +143,32 @@ func (sub Subscription) String() string {
 	return s.String()
 }
 
+// retryConsumer consumes the retry topics of a subscription up to a tier.
+type retryConsumer struct {
+	consumerGroup sarama.ConsumerGroup
+	tiers         int
+	session       *health.Session
+	stats         *consumer.Stats
+}
+
+// newRetryProducer and newClusterAdmin create the clients of the retry topics, newClient the ones the partitions
+// are scanned back with for deduplication, they are replaced in the tests.
+var (
+	newRetryProducer = sarama.NewSyncProducer
+	newClusterAdmin  = sarama.NewClusterAdmin
+	newClient        = sarama.NewClient
+)
+
 func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs) (*KafkaDispatcher, error) {
 	conf := sarama.NewConfig()
 	conf.Version = sarama.V2_0_0_0
+	if err := args.SaramaSpec.ApplyTo(conf); err != nil {
+		return nil, fmt.Errorf("invalid sarama configuration: %v", err)
+	}
 	conf.ClientID = args.ClientID
-	conf.Consumer.Return.Errors = true // Returns the errors in ConsumerGroup#Errors() https://godoc.org/github.com/Shopify/sarama#ConsumerGroup
+	conf.Consumer.Return.Errors = true    // Returns the errors in ConsumerGroup#Errors() https://godoc.org/github.com/Shopify/sarama#ConsumerGroup
+	conf.Producer.Return.Successes = true // Returns the successes in AsyncProducer#Successes(), for the producer error rate
+	conf.Producer.Partitioner = partitioning.NewPartitionerConstructor(conf.Producer.Partitioner)
 
 	producer, err := sarama.NewAsyncProducer(args.Brokers, conf)
 	if err != nil {
@@ -97,11 +180,31 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs) (*KafkaDispat
 		kafkaConsumerFactory: consumer.NewConsumerGroupFactory(args.Brokers, conf),
 		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
 		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subsPauseGates:       make(map[types.UID]*consumer.PauseGate),
+		subsLimiters:         make(map[types.UID]*delivery.Limiter),
+		subsRetryTopics:      make(map[types.UID]*delivery.RetryTopics),
+		subsDeduplicators:    make(map[types.UID]*delivery.Deduplicator),
+		subsRetryConsumers:   make(map[types.UID]*retryConsumer),
+		subsSessions:         make(map[types.UID]*health.Session),
+		subsStats:            make(map[types.UID]*consumer.Stats),
+		subsHealth:           make(map[types.UID]*delivery.Health),
 		subscriptions:        make(map[types.UID]Subscription),
 		kafkaAsyncProducer:   producer,
+		brokers:              args.Brokers,
+		saramaConfig:         conf,
 		logger:               args.Logger,
 		topicFunc:            args.TopicFunc,
+		authenticator:        ingress.NewAuthenticator(args.Logger.Desugar(), args.KubeClient),
+		adminAuthorizer:      admin.NewAuthorizer(args.Logger.Desugar(), args.KubeClient, args.AdminNamespace),
+		health:               health.NewChecker(health.DefaultInterval, health.DefaultTimeout),
+		healthPort:           args.HealthPort,
+		brokerCheck:          health.NewBrokerCheck(args.Brokers, conf),
+		producerErrors:       health.NewErrorRate(health.DefaultErrorRateWindow, health.DefaultErrorRateThreshold, health.DefaultErrorRateMinCount),
+		sessions:             health.NewSessions(health.DefaultSessionGracePeriod),
 	}
+	dispatcher.health.AddCheck(BrokersCheck, health.Readiness, dispatcher.brokerCheck.Check)
+	dispatcher.health.AddCheck(ProducerErrorsCheck, health.Readiness, dispatcher.producerErrors.Check)
+	dispatcher.health.AddCheck(ConsumerGroupsCheck, health.Readiness, dispatcher.sessions.Check)
 	receiverFunc, err := eventingchannels.NewMessageReceiver(
 		func(ctx context.Context, channel eventingchannels.ChannelReference, message binding.Message, transformers []binding.Transformer, _ nethttp.Header) error {
 			kafkaProducerMessage := sarama.ProducerMessage{
@@ -109,7 +212,7 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs) (*KafkaDispat
 			}
 
 			dispatcher.logger.Debugw("Received a new message from MessageReceiver, dispatching to Kafka", zap.Any("channel", channel))
-			err := protocolkafka.WriteProducerMessage(ctx, message, &kafkaProducerMessage, transformers...)
+			err := dispatcher.getChannelPartitioning(channel).WriteProducerMessage(ctx, message, &kafkaProducerMessage, transformers...)
 			if err != nil {
 				return err
 			}
@@ -127,6 +230,9 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs) (*KafkaDispat
 
 	dispatcher.receiver = receiverFunc
 	dispatcher.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+	dispatcher.channelPartitioning.Store(map[eventingchannels.ChannelReference]partitioning.Settings{})
+	dispatcher.channelValidation.Store(map[string]ingress.Rules{})
+	dispatcher.channelIngress.Store(map[string]*v1beta1.IngressPolicy{})
 	return dispatcher, nil
 }
 
@@ -138,12 +244,33 @@ type KafkaDispatcherArgs struct {
 	Brokers            []string
 	TopicFunc          TopicFunc
 	Logger             *zap.SugaredLogger
+	// SaramaSpec holds the optional sarama client settings.
+	SaramaSpec *saramaconfig.SaramaConfigSpec
+	// KubeClient reviews the ServiceAccount tokens of the senders, the ServiceAccount ingress policies reject all the
+	// senders without it.
+	KubeClient kubernetes.Interface
+	// HealthPort is the port the liveness and readiness probes are served on, along with the admin endpoints, zero
+	// disables them.
+	HealthPort int
+	// AdminNamespace is the namespace the callers of the admin endpoints are authorized in.
+	AdminNamespace string
 }
 
 type consumerMessageHandler struct {
-	logger     *zap.SugaredLogger
-	sub        Subscription
-	dispatcher *eventingchannels.MessageDispatcherImpl
+	logger      *zap.SugaredLogger
+	sub         Subscription
+	dispatcher  *eventingchannels.MessageDispatcherImpl
+	pause       *consumer.PauseGate
+	limiter     *delivery.Limiter
+	retryTopics *delivery.RetryTopics
+	// deduplicator is nil for the retry topics, whose events are not deduplicated
+	deduplicator *delivery.Deduplicator
+	// session tracks the sessions of the consumer group for the health checks
+	session *health.Session
+	// stats tracks the consumption of the consumer group for the admin endpoints
+	stats *consumer.Stats
+	// health tracks the outcome of the deliveries to the subscriber for its status
+	health *delivery.Health
 }
 
 func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMessage) (bool, error) {
@@ -165,24 +292,103 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 		zap.String("subscription", c.sub.String()),
 	)
 
+	// the events retried for other subscriptions are skipped
+	if deliver, err := c.retryTopics.Wait(ctx, consumerMessage); err != nil || !deliver {
+		return err == nil, err
+	}
+
+	// the events already delivered to the subscriber are skipped
+	if c.deduplicator.IsDuplicate(ctx, consumerMessage) {
+		c.logger.Debug("Skipping the message already delivered", zap.String("topic", consumerMessage.Topic), zap.Any("subscription", c.sub.UID))
+		return true, nil
+	}
+
+	release, err := c.limiter.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
 	ctx, span := startTraceFromMessage(c.logger, ctx, message, consumerMessage.Topic)
 	defer span.End()
 
-	err := c.dispatcher.DispatchMessageWithRetries(
+	// the responses are observed to track the health of the delivery
+	attempt := c.health.Attempt(c.sub.DeadLetter)
+	retryConfig := c.retryConfig()
+	if retryConfig != nil {
+		observed := attempt.RetryConfig(*retryConfig)
+		retryConfig = &observed
+	}
+
+	// the retried events go on through the retry topics even when they are disabled in the meantime
+	if _, retried := delivery.RetryAttemptOf(consumerMessage); c.retryTopics != nil && (retried || (c.retryTopics.IsReady() && c.limiter.RetryPolicy().RetryTopics)) {
+		destinations := delivery.Destinations{Subscriber: c.sub.Subscriber, Reply: c.sub.Reply, DeadLetter: c.sub.DeadLetter}
+		err = c.retryTopics.Deliver(ctx, c.dispatcher, message, consumerMessage, destinations, *retryConfig)
+		attempt.Done(err)
+		return err == nil, err
+	}
+
+	err = c.dispatcher.DispatchMessageWithRetries(
 		ctx,
 		message,
 		nil,
 		c.sub.Subscriber,
 		c.sub.Reply,
 		c.sub.DeadLetter,
-		c.sub.RetryConfig,
+		retryConfig,
 	)
+	attempt.Done(err)
+	if err == nil {
+		c.deduplicator.Delivered(ctx, consumerMessage)
+	}
 
 	// NOTE: only return `true` here if DispatchMessage actually delivered the message.
 	return err == nil, err
 }
 
+// retryConfig returns the retry configuration of the subscription following its RetryPolicy, and observing the
+// responses of the subscriber so that the delivery slows down when asked to.
+func (c consumerMessageHandler) retryConfig() *kncloudevents.RetryConfig {
+	if c.limiter == nil {
+		return c.sub.RetryConfig
+	}
+	retryConfig := kncloudevents.NoRetries()
+	if c.sub.RetryConfig != nil {
+		retryConfig = *c.sub.RetryConfig
+	}
+	retryConfig = c.limiter.RetryConfig(retryConfig)
+	return &retryConfig
+}
+
+func (c consumerMessageHandler) GetPauseGate() *consumer.PauseGate {
+	return c.pause
+}
+
+// SetupClaim rebuilds the events delivered from the partition before it was assigned, for deduplication.
+func (c consumerMessageHandler) SetupClaim(ctx context.Context, topic string, partition int32, initialOffset int64) {
+	c.deduplicator.Rebuild(ctx, topic, partition, initialOffset)
+}
+
+// SetupSession records the start of a session of the consumer group.
+func (c consumerMessageHandler) SetupSession() {
+	c.session.Started()
+}
+
+// CleanupSession records the end of a session of the consumer group.
+func (c consumerMessageHandler) CleanupSession() {
+	c.session.Ended()
+}
+
+// GetStats returns the Stats tracking the consumption of the consumer group.
+func (c consumerMessageHandler) GetStats() *consumer.Stats {
+	return c.stats
+}
+
 var _ consumer.KafkaConsumerHandler = (*consumerMessageHandler)(nil)
+var _ consumer.KafkaPausableConsumerHandler = (*consumerMessageHandler)(nil)
+var _ consumer.KafkaClaimSetupConsumerHandler = (*consumerMessageHandler)(nil)
+var _ consumer.KafkaSessionConsumerHandler = (*consumerMessageHandler)(nil)
+var _ consumer.KafkaStatsConsumerHandler = (*consumerMessageHandler)(nil)
 
 type Config struct {
 	// The configuration of each channel in this handler.
@@ -194,6 +400,12 @@ type ChannelConfig struct {
 	Name          string
 	HostName      string
 	Subscriptions []Subscription
+	// Partitioning selects the partition of the events written to the channel
+	Partitioning partitioning.Settings
+	// Validation rejects the events sent to the channel which do not follow its rules
+	Validation ingress.Rules
+	// Ingress restricts the senders of the events to the channel, any sender is accepted when nil
+	Ingress *v1beta1.IngressPolicy
 }
 
 // UpdateKafkaConsumers will be called by new CRD based kafka channel dispatcher controller.
@@ -229,6 +441,24 @@ func (d *KafkaDispatcher) UpdateKafkaConsumers(config *Config) (map[types.UID]er
 				if err := d.subscribe(channelRef, subSpec); err != nil {
 					failedToSubscribe[subSpec.UID] = err
 				}
+			} else if pause, ok := d.subsPauseGates[subSpec.UID]; ok {
+				// pausing, resuming and changing the limits or the retry policy do not restart the consumer group
+				pause.SetPaused(subSpec.Paused || d.subsAdminPaused[subSpec.UID])
+				d.subsLimiters[subSpec.UID].SetLimits(subSpec.Limits)
+				d.subsLimiters[subSpec.UID].SetRetryPolicy(subSpec.RetryPolicy)
+				d.subsDeduplicators[subSpec.UID].SetWindow(subSpec.DeduplicationWindow)
+				// the subscription keeps the settings applied without restarting the consumer group
+				sub := d.subscriptions[subSpec.UID]
+				sub.Paused = subSpec.Paused
+				sub.Limits = subSpec.Limits
+				sub.RetryPolicy = subSpec.RetryPolicy
+				sub.DeduplicationWindow = subSpec.DeduplicationWindow
+				d.subscriptions[subSpec.UID] = sub
+			}
+
+			if err := d.updateRetryTopics(channelRef, subSpec); err != nil {
+				d.logger.Warnw("Could not consume the retry topics", zap.Any("subscription", subSpec.UID), zap.Error(err))
+				failedToSubscribe[subSpec.UID] = err
 			}
 		}
 	}
@@ -257,6 +487,110 @@ func (d *KafkaDispatcher) UpdateKafkaConsumers(config *Config) (map[types.UID]er
 	return failedToSubscribe, nil
 }
 
+// IsPaused returns true when the delivery to the subscriber of the subscription is paused by the subscription
+// itself, rather than through the admin endpoints.
+func (d *KafkaDispatcher) IsPaused(uid types.UID) bool {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	return d.subsPauseGates[uid].IsPaused() && d.subscriptions[uid].Paused
+}
+
+// DeliveryHealth returns the rolling health of the delivery to the subscriber of the subscription.
+func (d *KafkaDispatcher) DeliveryHealth(uid types.UID) delivery.HealthStatus {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	return d.subsHealth[uid].Status()
+}
+
+// OnDeliveryHealthChanged sets the function called with the channel of a subscription whose delivery health changed,
+// after each delivery while unhealthy. It must not block.
+func (d *KafkaDispatcher) OnDeliveryHealthChanged(fn func(channelRef eventingchannels.ChannelReference)) {
+	d.healthChanged.Store(fn)
+}
+
+// deliveryHealthChanged calls the function set by OnDeliveryHealthChanged, if any.
+func (d *KafkaDispatcher) deliveryHealthChanged(channelRef eventingchannels.ChannelReference) {
+	if fn, ok := d.healthChanged.Load().(func(eventingchannels.ChannelReference)); ok && fn != nil {
+		fn(channelRef)
+	}
+}
+
+// SetAdminPaused pauses or resumes the delivery to the subscriber of the subscription through the admin endpoints,
+// it stays paused while the subscription itself is. It returns false when the subscription is unknown.
+func (d *KafkaDispatcher) SetAdminPaused(uid types.UID, paused bool) bool {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	pause, ok := d.subsPauseGates[uid]
+	if !ok {
+		return false
+	}
+	if paused {
+		if d.subsAdminPaused == nil {
+			d.subsAdminPaused = make(map[types.UID]bool)
+		}
+		d.subsAdminPaused[uid] = true
+	} else {
+		delete(d.subsAdminPaused, uid)
+	}
+	pause.SetPaused(d.subscriptions[uid].Paused || paused)
+	return true
+}
+
+// Subscriptions returns the subscriptions of the dispatcher and their consumer groups, for the admin endpoints.
+func (d *KafkaDispatcher) Subscriptions() []admin.Subscription {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	subscriptions := make([]admin.Subscription, 0, len(d.subscriptions))
+	for channelRef, uids := range d.channelSubscriptions {
+		for _, uid := range uids {
+			sub, ok := d.subscriptions[uid]
+			if !ok {
+				continue
+			}
+			topicName := d.topicFunc(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name)
+			subscription := admin.Subscription{
+				UID:         uid,
+				Channel:     channelRef.String(),
+				Paused:      d.subsPauseGates[uid].IsPaused(),
+				AdminPaused: d.subsAdminPaused[uid],
+				ConsumerGroups: []admin.ConsumerGroup{{
+					GroupID: consumerGroupID(channelRef, uid),
+					Topics:  []string{topicName},
+					Stats:   d.subsStats[uid],
+				}},
+			}
+			if sub.Subscriber != nil {
+				subscription.Subscriber = sub.Subscriber.String()
+			}
+			if sub.Reply != nil {
+				subscription.Reply = sub.Reply.String()
+			}
+			if sub.DeadLetter != nil {
+				subscription.DeadLetter = sub.DeadLetter.String()
+			}
+			if retry, ok := d.subsRetryConsumers[uid]; ok {
+				subscription.ConsumerGroups = append(subscription.ConsumerGroups, admin.ConsumerGroup{
+					GroupID: consumerGroupID(channelRef, uid) + ".retry",
+					Topics:  delivery.RetryTopicNames(topicName, retry.tiers),
+					Stats:   retry.stats,
+				})
+			}
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	admin.SortSubscriptions(subscriptions)
+	return subscriptions
+}
+
+// KafkaConfig returns the brokers and the sarama configuration of the dispatcher, for the admin endpoints.
+func (d *KafkaDispatcher) KafkaConfig() ([]string, *sarama.Config) {
+	return d.brokers, d.saramaConfig
+}
+
 // UpdateHostToChannelMap will be called by new CRD based kafka channel dispatcher controller.
 func (d *KafkaDispatcher) UpdateHostToChannelMap(config *Config) error {
 	if config == nil {
@@ -272,6 +606,20 @@ func (d *KafkaDispatcher) UpdateHostToChannelMap(config *Config) error {
 	}
 
 	d.setHostToChannelMap(hcMap)
+
+	partitioningMap := make(map[eventingchannels.ChannelReference]partitioning.Settings, len(config.ChannelConfigs))
+	validationMap := make(map[string]ingress.Rules, len(config.ChannelConfigs))
+	ingressMap := make(map[string]*v1beta1.IngressPolicy, len(config.ChannelConfigs))
+	for _, cConfig := range config.ChannelConfigs {
+		partitioningMap[eventingchannels.ChannelReference{Name: cConfig.Name, Namespace: cConfig.Namespace}] = cConfig.Partitioning
+		validationMap[cConfig.HostName] = cConfig.Validation
+		if cConfig.Ingress != nil {
+			ingressMap[cConfig.HostName] = cConfig.Ingress
+		}
+	}
+	d.channelPartitioning.Store(partitioningMap)
+	d.channelValidation.Store(validationMap)
+	d.channelIngress.Store(ingressMap)
 	return nil
 }
 
@@ -306,16 +654,38 @@ func (d *KafkaDispatcher) Start(ctx context.Context) error {
 		for {
 			select {
 			case e := <-d.kafkaAsyncProducer.Errors():
+				d.producerErrors.Record(e)
 				d.logger.Warn("Got", zap.Error(e))
 			case s := <-d.kafkaAsyncProducer.Successes():
-				d.logger.Info("Sent", zap.Any("success", s))
+				d.producerErrors.Record(nil)
+				d.logger.Debug("Sent", zap.Any("success", s))
 			case <-ctx.Done():
 				return
 			}
 		}
 	}()
 
-	return d.receiver.Start(ctx)
+	d.health.Start(d.logger.Desugar())
+	defer d.health.Stop()
+	defer d.brokerCheck.Close()
+	if d.healthPort != 0 {
+		mux := nethttp.NewServeMux()
+		mux.Handle("/", health.NewHandler(d.health))
+		mux.Handle(admin.PathPrefix, admin.NewHandler(d.logger.Desugar(), d, d.adminAuthorizer))
+		go func() {
+			if err := health.ListenAndServe(ctx, d.healthPort, mux); err != nil {
+				d.logger.Errorw("Unable to serve the health probes", zap.Error(err))
+			}
+		}()
+	}
+
+	validation := ingress.NewHandler(d.logger.Desugar(), d.getChannelValidation, d.receiver)
+	return ingress.Start(ctx, ingress.NewAuthHandler(d.authenticator, d.getChannelIngress, validation))
+}
+
+// HealthChecker returns the checker running the health checks of the dispatcher, more checks can be added to.
+func (d *KafkaDispatcher) HealthChecker() *health.Checker {
+	return d.health
 }
 
 // subscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
@@ -324,15 +694,28 @@ func (d *KafkaDispatcher) subscribe(channelRef eventingchannels.ChannelReference
 	d.logger.Info("Subscribing", zap.Any("channelRef", channelRef), zap.Any("subscription", sub.UID))
 
 	topicName := d.topicFunc(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name)
-	groupID := fmt.Sprintf("kafka.%s.%s.%s", channelRef.Namespace, channelRef.Name, string(sub.UID))
-
-	handler := &consumerMessageHandler{d.logger, sub, d.dispatcher}
+	groupID := consumerGroupID(channelRef, sub.UID)
+
+	pause := consumer.NewPauseGate()
+	pause.SetPaused(sub.Paused || d.subsAdminPaused[sub.UID])
+	limiter := delivery.NewLimiter(sub.Limits)
+	limiter.SetRetryPolicy(sub.RetryPolicy)
+	retryTopics := &delivery.RetryTopics{Logger: d.logger.Desugar(), Topic: topicName, Subscription: sub.UID}
+	deduplicator := delivery.NewDeduplicator(d.logger.Desugar(), sub.UID)
+	deduplicator.SetWindow(sub.DeduplicationWindow)
+	deduplicator.SetNewClient(func() (sarama.Client, error) { return newClient(d.brokers, d.saramaConfig) })
+	session := d.sessions.Track(groupID)
+	stats := consumer.NewStats()
+	health := delivery.NewHealth(delivery.DefaultHealthThresholds)
+	health.SetOnChange(func() { d.deliveryHealthChanged(channelRef) })
+	handler := &consumerMessageHandler{d.logger, sub, d.dispatcher, pause, limiter, retryTopics, deduplicator, session, stats, health}
 
 	consumerGroup, err := d.kafkaConsumerFactory.StartConsumerGroup(groupID, []string{topicName}, d.logger, handler)
 
 	if err != nil {
 		// we can not create a consumer - logging that, with reason
 		d.logger.Infow("Could not create proper consumer", zap.Error(err))
+		session.Close()
 		return err
 	}
 
@@ -347,15 +730,106 @@ func (d *KafkaDispatcher) subscribe(channelRef eventingchannels.ChannelReference
 	d.channelSubscriptions[channelRef] = append(d.channelSubscriptions[channelRef], sub.UID)
 	d.subscriptions[sub.UID] = sub
 	d.subsConsumerGroups[sub.UID] = consumerGroup
+	d.subsPauseGates[sub.UID] = pause
+	d.subsLimiters[sub.UID] = limiter
+	d.subsRetryTopics[sub.UID] = retryTopics
+	d.subsDeduplicators[sub.UID] = deduplicator
+	d.subsSessions[sub.UID] = session
+	d.subsStats[sub.UID] = stats
+	d.subsHealth[sub.UID] = health
+
+	return nil
+}
+
+// updateRetryTopics consumes the retry topics of the subscription when they are enabled, one tier per retry of its
+// delivery. A running retry consumer group keeps draining the retry topics once they are disabled.
+// updateRetryTopics must be called under updateLock.
+func (d *KafkaDispatcher) updateRetryTopics(channelRef eventingchannels.ChannelReference, sub Subscription) error {
+	retryTopics, ok := d.subsRetryTopics[sub.UID]
+	if !ok || !sub.RetryPolicy.RetryTopics || sub.RetryConfig == nil {
+		return nil
+	}
+	tiers := sub.RetryConfig.RetryMax
+	if current, ok := d.subsRetryConsumers[sub.UID]; tiers == 0 || (ok && tiers <= current.tiers) {
+		return nil
+	}
+
+	if d.retryProducer == nil {
+		// a SyncProducer requires the successes to be returned
+		conf := *d.saramaConfig
+		conf.Producer.Return.Successes = true
+		producer, err := newRetryProducer(d.brokers, &conf)
+		if err != nil {
+			return fmt.Errorf("unable to create the retry topics producer: %w", err)
+		}
+		d.retryProducer = producer
+	}
+
+	admin, err := newClusterAdmin(d.brokers, d.saramaConfig)
+	if err != nil {
+		return fmt.Errorf("unable to create the retry topics admin client: %w", err)
+	}
+	defer admin.Close()
+	if err := delivery.EnsureRetryTopics(admin, retryTopics.Topic, tiers); err != nil {
+		return err
+	}
+
+	// the retry consumer group consuming fewer tiers is replaced
+	if err := d.closeRetryConsumer(sub.UID); err != nil {
+		d.logger.Warnw("Error closing the retry consumer group", zap.Error(err))
+	}
+	groupID := consumerGroupID(channelRef, sub.UID) + ".retry"
+	session := d.sessions.Track(groupID)
+	stats := consumer.NewStats()
+	handler := &consumerMessageHandler{d.logger, sub, d.dispatcher, d.subsPauseGates[sub.UID], d.subsLimiters[sub.UID], retryTopics, nil, session, stats, d.subsHealth[sub.UID]}
+	consumerGroup, err := d.kafkaConsumerFactory.StartConsumerGroup(groupID, delivery.RetryTopicNames(retryTopics.Topic, tiers), d.logger, handler)
+	if err != nil {
+		session.Close()
+		return err
+	}
+	go func() {
+		for err := range consumerGroup.Errors() {
+			d.logger.Warnw("Error in retry consumer group", zap.Error(err))
+		}
+	}()
+	d.subsRetryConsumers[sub.UID] = &retryConsumer{consumerGroup: consumerGroup, tiers: tiers, session: session, stats: stats}
 
+	// the events are only produced to the retry topics once they are consumed
+	retryTopics.Producer = d.retryProducer
+	retryTopics.SetReady(true)
 	return nil
 }
 
+// closeRetryConsumer closes the retry consumer group of the subscription, if any.
+// closeRetryConsumer must be called under updateLock.
+func (d *KafkaDispatcher) closeRetryConsumer(uid types.UID) error {
+	current, ok := d.subsRetryConsumers[uid]
+	if !ok {
+		return nil
+	}
+	d.subsRetryTopics[uid].SetReady(false)
+	delete(d.subsRetryConsumers, uid)
+	current.session.Close()
+	return current.consumerGroup.Close()
+}
+
 // unsubscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
 // unsubscribe must be called under updateLock.
 func (d *KafkaDispatcher) unsubscribe(channel eventingchannels.ChannelReference, sub Subscription) error {
 	d.logger.Infow("Unsubscribing from channel", zap.Any("channel", channel), zap.String("subscription", sub.String()))
 	delete(d.subscriptions, sub.UID)
+	delete(d.subsPauseGates, sub.UID)
+	delete(d.subsLimiters, sub.UID)
+	if err := d.closeRetryConsumer(sub.UID); err != nil {
+		d.logger.Warnw("Error closing the retry consumer group", zap.Error(err))
+	}
+	delete(d.subsRetryTopics, sub.UID)
+	delete(d.subsDeduplicators, sub.UID)
+	d.subsSessions[sub.UID].Close()
+	delete(d.subsSessions, sub.UID)
+	delete(d.subsStats, sub.UID)
+	delete(d.subsHealth, sub.UID)
+	delete(d.subsAdminPaused, sub.UID)
 	if subsSlice, ok := d.channelSubscriptions[channel]; ok {
 		var newSlice []types.UID
 		for _, oldSub := range subsSlice {
@@ -372,6 +846,11 @@ func (d *KafkaDispatcher) unsubscribe(channel eventingchannels.ChannelReference,
 	return nil
 }
 
+// consumerGroupID returns the ID of the consumer group of the subscription to the channel.
+func consumerGroupID(channelRef eventingchannels.ChannelReference, uid types.UID) string {
+	return fmt.Sprintf("kafka.%s.%s.%s", channelRef.Namespace, channelRef.Name, string(uid))
+}
+
 func (d *KafkaDispatcher) getHostToChannelMap() map[string]eventingchannels.ChannelReference {
 	return d.hostToChannelMap.Load().(map[string]eventingchannels.ChannelReference)
 }
@@ -380,6 +859,26 @@ func (d *KafkaDispatcher) setHostToChannelMap(hcMap map[string]eventingchannels.
 	d.hostToChannelMap.Store(hcMap)
 }
 
+// getChannelPartitioning returns the partitioning.Settings of the channel, the default ones when unknown.
+func (d *KafkaDispatcher) getChannelPartitioning(channelRef eventingchannels.ChannelReference) partitioning.Settings {
+	partitioningMap, _ := d.channelPartitioning.Load().(map[eventingchannels.ChannelReference]partitioning.Settings)
+	return partitioningMap[channelRef]
+}
+
+// getChannelValidation returns the ingress.Rules of the channel addressed by the host, accepting all the events when
+// unknown.
+func (d *KafkaDispatcher) getChannelValidation(host string) ingress.Rules {
+	validationMap, _ := d.channelValidation.Load().(map[string]ingress.Rules)
+	return validationMap[host]
+}
+
+// getChannelIngress returns the ingress policy of the channel addressed by the host, nil when any sender is accepted
+// or the channel is unknown.
+func (d *KafkaDispatcher) getChannelIngress(host string) (*v1beta1.IngressPolicy, error) {
+	ingressMap, _ := d.channelIngress.Load().(map[string]*v1beta1.IngressPolicy)
+	return ingressMap[host], nil
+}
+
 func (d *KafkaDispatcher) getChannelReferenceFromHost(host string) (eventingchannels.ChannelReference, error) {
 	chMap := d.getHostToChannelMap()
 	cr, ok := chMap[host]
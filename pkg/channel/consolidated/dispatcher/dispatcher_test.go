@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/cloudevents/sdk-go/v2/binding"
@@ -31,10 +33,16 @@ import (
 	"go.uber.org/zap/zaptest"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/delivery"
+	"knative.dev/eventing-kafka/pkg/common/health"
+	"knative.dev/eventing-kafka/pkg/common/ingress"
+	"knative.dev/eventing-kafka/pkg/common/partitioning"
 	eventingchannels "knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/kncloudevents"
 	_ "knative.dev/pkg/system/testing"
 )
 
@@ -324,6 +332,14 @@ func TestDispatcher_UpdateConfig(t *testing.T) {
 				kafkaConsumerFactory: &mockKafkaConsumerFactory{},
 				channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
 				subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+				subsPauseGates:       make(map[types.UID]*consumer.PauseGate),
+				subsLimiters:         make(map[types.UID]*delivery.Limiter),
+				subsRetryTopics:      make(map[types.UID]*delivery.RetryTopics),
+				subsDeduplicators:    make(map[types.UID]*delivery.Deduplicator),
+				subsRetryConsumers:   make(map[types.UID]*retryConsumer),
+				subsSessions:         make(map[types.UID]*health.Session),
+				subsStats:            make(map[types.UID]*consumer.Stats),
+				subsHealth:           make(map[types.UID]*delivery.Health),
 				subscriptions:        make(map[types.UID]Subscription),
 				topicFunc:            utils.TopicName,
 				logger:               zaptest.NewLogger(t).Sugar(),
@@ -376,6 +392,84 @@ func TestDispatcher_UpdateConfig(t *testing.T) {
 	}
 }
 
+func TestDispatcher_ChannelPartitioning(t *testing.T) {
+	d := &KafkaDispatcher{logger: zap.NewNop().Sugar()}
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+
+	settings := partitioning.Settings{KeyAttribute: "subject", Partitioner: "murmur2"}
+	config := &Config{
+		ChannelConfigs: []ChannelConfig{
+			{Namespace: "default", Name: "partitioned", HostName: "partitioned.default", Partitioning: settings},
+			{Namespace: "default", Name: "default", HostName: "default.default"},
+		},
+	}
+	if err := d.UpdateHostToChannelMap(config); err != nil {
+		t.Fatalf("unexpected UpdateHostToChannelMap error: %v", err)
+	}
+
+	if got := d.getChannelPartitioning(eventingchannels.ChannelReference{Namespace: "default", Name: "partitioned"}); got != settings {
+		t.Errorf("unexpected partitioning settings: want %v, got %v", settings, got)
+	}
+	for _, name := range []string{"default", "unknown"} {
+		if got := d.getChannelPartitioning(eventingchannels.ChannelReference{Namespace: "default", Name: name}); got != (partitioning.Settings{}) {
+			t.Errorf("unexpected partitioning settings for channel %s: %v", name, got)
+		}
+	}
+}
+
+func TestDispatcher_ChannelValidation(t *testing.T) {
+	d := &KafkaDispatcher{logger: zap.NewNop().Sugar()}
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+
+	rules := ingress.Rules{MaxEventSize: 1024}
+	config := &Config{
+		ChannelConfigs: []ChannelConfig{
+			{Namespace: "default", Name: "validated", HostName: "validated.default", Validation: rules},
+			{Namespace: "default", Name: "default", HostName: "default.default"},
+		},
+	}
+	if err := d.UpdateHostToChannelMap(config); err != nil {
+		t.Fatalf("unexpected UpdateHostToChannelMap error: %v", err)
+	}
+
+	if got := d.getChannelValidation("validated.default"); got.MaxEventSize != 1024 {
+		t.Errorf("unexpected validation rules: want %v, got %v", rules, got)
+	}
+	for _, host := range []string{"default.default", "unknown.default"} {
+		if got := d.getChannelValidation(host); !got.IsZero() {
+			t.Errorf("unexpected validation rules for host %s: %v", host, got)
+		}
+	}
+}
+
+func TestDispatcher_ChannelIngress(t *testing.T) {
+	d := &KafkaDispatcher{logger: zap.NewNop().Sugar()}
+	d.setHostToChannelMap(map[string]eventingchannels.ChannelReference{})
+
+	policy := &v1beta1.IngressPolicy{
+		ServiceAccount: &v1beta1.ServiceAccountIngressPolicy{},
+		Namespaces:     []string{"default"},
+	}
+	config := &Config{
+		ChannelConfigs: []ChannelConfig{
+			{Namespace: "default", Name: "secured", HostName: "secured.default", Ingress: policy},
+			{Namespace: "default", Name: "default", HostName: "default.default"},
+		},
+	}
+	if err := d.UpdateHostToChannelMap(config); err != nil {
+		t.Fatalf("unexpected UpdateHostToChannelMap error: %v", err)
+	}
+
+	if got, err := d.getChannelIngress("secured.default"); got != policy || err != nil {
+		t.Errorf("unexpected ingress policy: want %v, got %v (%v)", policy, got, err)
+	}
+	for _, host := range []string{"default.default", "unknown.default"} {
+		if got, err := d.getChannelIngress(host); got != nil || err != nil {
+			t.Errorf("unexpected ingress policy for host %s: %v (%v)", host, got, err)
+		}
+	}
+}
+
 func TestSubscribeError(t *testing.T) {
 	cf := &mockKafkaConsumerFactory{createErr: true}
 	d := &KafkaDispatcher{
@@ -420,6 +514,473 @@ func TestUnsubscribeUnknownSub(t *testing.T) {
 	}
 }
 
+func TestDispatcher_PauseSubscription(t *testing.T) {
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subsPauseGates:       make(map[types.UID]*consumer.PauseGate),
+		subsLimiters:         make(map[types.UID]*delivery.Limiter),
+		subsRetryTopics:      make(map[types.UID]*delivery.RetryTopics),
+		subsDeduplicators:    make(map[types.UID]*delivery.Deduplicator),
+		subsRetryConsumers:   make(map[types.UID]*retryConsumer),
+		subsSessions:         make(map[types.UID]*health.Session),
+		subsStats:            make(map[types.UID]*consumer.Stats),
+		subsHealth:           make(map[types.UID]*delivery.Health),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	newConfig := func(paused bool) *Config {
+		return &Config{
+			ChannelConfigs: []ChannelConfig{{
+				Namespace: "default",
+				Name:      "test-channel",
+				HostName:  "a.b.c.d",
+				Subscriptions: []Subscription{{
+					UID:    "subscription-1",
+					Paused: paused,
+				}},
+			}},
+		}
+	}
+
+	if _, err := d.UpdateKafkaConsumers(newConfig(true)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	pause := d.subsPauseGates["subscription-1"]
+	if !pause.IsPaused() {
+		t.Errorf("Expected the subscription to be paused")
+	}
+
+	if _, err := d.UpdateKafkaConsumers(newConfig(false)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	if d.subsPauseGates["subscription-1"] != pause {
+		t.Errorf("Expected the consumer group to keep running")
+	}
+	if pause.IsPaused() {
+		t.Errorf("Expected the subscription to be resumed")
+	}
+
+	if err := d.unsubscribe(eventingchannels.ChannelReference{Namespace: "default", Name: "test-channel"}, d.subscriptions["subscription-1"]); err != nil {
+		t.Fatalf("Unsubscribe error: %v", err)
+	}
+	if _, ok := d.subsPauseGates["subscription-1"]; ok {
+		t.Errorf("Expected the pause gate to be removed")
+	}
+}
+
+func TestDispatcher_AdminPauseSubscription(t *testing.T) {
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subsPauseGates:       make(map[types.UID]*consumer.PauseGate),
+		subsLimiters:         make(map[types.UID]*delivery.Limiter),
+		subsRetryTopics:      make(map[types.UID]*delivery.RetryTopics),
+		subsDeduplicators:    make(map[types.UID]*delivery.Deduplicator),
+		subsRetryConsumers:   make(map[types.UID]*retryConsumer),
+		subsSessions:         make(map[types.UID]*health.Session),
+		subsStats:            make(map[types.UID]*consumer.Stats),
+		subsHealth:           make(map[types.UID]*delivery.Health),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	subscriber, _ := url.Parse("http://subscriber.default.svc.cluster.local")
+	newConfig := func(paused bool) *Config {
+		return &Config{
+			ChannelConfigs: []ChannelConfig{{
+				Namespace: "default",
+				Name:      "test-channel",
+				HostName:  "a.b.c.d",
+				Subscriptions: []Subscription{{
+					UID:          "subscription-1",
+					Subscription: fanout.Subscription{Subscriber: subscriber},
+					Paused:       paused,
+				}},
+			}},
+		}
+	}
+
+	if _, err := d.UpdateKafkaConsumers(newConfig(false)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	subscriptions := d.Subscriptions()
+	if len(subscriptions) != 1 {
+		t.Fatalf("Expected 1 subscription, got %d", len(subscriptions))
+	}
+	if got := subscriptions[0]; got.UID != "subscription-1" || got.Channel != "default/test-channel" ||
+		got.Subscriber != "http://subscriber.default.svc.cluster.local" || got.Paused || got.AdminPaused {
+		t.Errorf("Unexpected subscription %+v", got)
+	}
+	if groups := subscriptions[0].ConsumerGroups; len(groups) != 1 || groups[0].GroupID != "kafka.default.test-channel.subscription-1" ||
+		groups[0].Stats != d.subsStats["subscription-1"] || groups[0].Stats == nil {
+		t.Errorf("Unexpected consumer groups %+v", groups)
+	}
+
+	// The subscription stays paused through the admin endpoints when resumed by its spec
+	pause := d.subsPauseGates["subscription-1"]
+	if !d.SetAdminPaused("subscription-1", true) {
+		t.Fatalf("Expected the subscription to be known")
+	}
+	if !pause.IsPaused() || !d.Subscriptions()[0].AdminPaused {
+		t.Errorf("Expected the subscription to be paused")
+	}
+	if d.IsPaused("subscription-1") {
+		t.Errorf("Expected the subscription not to be reported as paused by its annotation")
+	}
+	if _, err := d.UpdateKafkaConsumers(newConfig(false)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	if !pause.IsPaused() {
+		t.Errorf("Expected the subscription to stay paused")
+	}
+
+	// Resuming it through the admin endpoints keeps the pausing of its spec
+	if _, err := d.UpdateKafkaConsumers(newConfig(true)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	d.SetAdminPaused("subscription-1", false)
+	if !pause.IsPaused() || d.Subscriptions()[0].AdminPaused {
+		t.Errorf("Expected the subscription to be paused by its spec only")
+	}
+	if _, err := d.UpdateKafkaConsumers(newConfig(false)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	if pause.IsPaused() {
+		t.Errorf("Expected the subscription to be resumed")
+	}
+
+	if d.SetAdminPaused("subscription-2", true) {
+		t.Errorf("Expected the subscription to be unknown")
+	}
+
+	d.SetAdminPaused("subscription-1", true)
+	if err := d.unsubscribe(eventingchannels.ChannelReference{Namespace: "default", Name: "test-channel"}, d.subscriptions["subscription-1"]); err != nil {
+		t.Fatalf("Unsubscribe error: %v", err)
+	}
+	if _, ok := d.subsAdminPaused["subscription-1"]; ok {
+		t.Errorf("Expected the admin pausing to be removed")
+	}
+	if _, ok := d.subsStats["subscription-1"]; ok {
+		t.Errorf("Expected the stats to be removed")
+	}
+}
+
+func TestDispatcher_LimitSubscription(t *testing.T) {
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subsPauseGates:       make(map[types.UID]*consumer.PauseGate),
+		subsLimiters:         make(map[types.UID]*delivery.Limiter),
+		subsRetryTopics:      make(map[types.UID]*delivery.RetryTopics),
+		subsDeduplicators:    make(map[types.UID]*delivery.Deduplicator),
+		subsRetryConsumers:   make(map[types.UID]*retryConsumer),
+		subsSessions:         make(map[types.UID]*health.Session),
+		subsStats:            make(map[types.UID]*consumer.Stats),
+		subsHealth:           make(map[types.UID]*delivery.Health),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	newConfig := func(limits delivery.Limits) *Config {
+		return &Config{
+			ChannelConfigs: []ChannelConfig{{
+				Namespace: "default",
+				Name:      "test-channel",
+				HostName:  "a.b.c.d",
+				Subscriptions: []Subscription{{
+					UID:    "subscription-1",
+					Limits: limits,
+				}},
+			}},
+		}
+	}
+
+	if _, err := d.UpdateKafkaConsumers(newConfig(delivery.Limits{RateLimit: 10})); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	limiter := d.subsLimiters["subscription-1"]
+	if diff := cmp.Diff(delivery.Limits{RateLimit: 10}, limiter.Limits()); diff != "" {
+		t.Errorf("unexpected limits (-want, +got) = %v", diff)
+	}
+
+	if _, err := d.UpdateKafkaConsumers(newConfig(delivery.Limits{MaxInFlight: 2})); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	if d.subsLimiters["subscription-1"] != limiter {
+		t.Errorf("Expected the consumer group to keep running")
+	}
+	if diff := cmp.Diff(delivery.Limits{MaxInFlight: 2}, limiter.Limits()); diff != "" {
+		t.Errorf("unexpected limits (-want, +got) = %v", diff)
+	}
+}
+
+type mockClusterAdmin struct {
+	sarama.ClusterAdmin
+	topics map[string]sarama.TopicDetail
+}
+
+func (m *mockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	return m.topics, nil
+}
+
+func (m *mockClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, _ bool) error {
+	m.topics[topic] = *detail
+	return nil
+}
+
+func (m *mockClusterAdmin) Close() error {
+	return nil
+}
+
+type mockSyncProducer struct {
+	sarama.SyncProducer
+}
+
+func TestDispatcher_RetryTopics(t *testing.T) {
+	topic := utils.TopicName(utils.KafkaChannelSeparator, "default", "test-channel")
+	admin := &mockClusterAdmin{topics: map[string]sarama.TopicDetail{topic: {NumPartitions: 1, ReplicationFactor: 1}}}
+	defer func(producer func([]string, *sarama.Config) (sarama.SyncProducer, error), clusterAdmin func([]string, *sarama.Config) (sarama.ClusterAdmin, error)) {
+		newRetryProducer, newClusterAdmin = producer, clusterAdmin
+	}(newRetryProducer, newClusterAdmin)
+	newRetryProducer = func([]string, *sarama.Config) (sarama.SyncProducer, error) {
+		return &mockSyncProducer{}, nil
+	}
+	newClusterAdmin = func([]string, *sarama.Config) (sarama.ClusterAdmin, error) {
+		return admin, nil
+	}
+
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subsPauseGates:       make(map[types.UID]*consumer.PauseGate),
+		subsLimiters:         make(map[types.UID]*delivery.Limiter),
+		subsRetryTopics:      make(map[types.UID]*delivery.RetryTopics),
+		subsDeduplicators:    make(map[types.UID]*delivery.Deduplicator),
+		subsRetryConsumers:   make(map[types.UID]*retryConsumer),
+		subsSessions:         make(map[types.UID]*health.Session),
+		subsStats:            make(map[types.UID]*consumer.Stats),
+		subsHealth:           make(map[types.UID]*delivery.Health),
+		subscriptions:        make(map[types.UID]Subscription),
+		saramaConfig:         sarama.NewConfig(),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	newConfig := func(retries int, retryTopics bool) *Config {
+		return &Config{
+			ChannelConfigs: []ChannelConfig{{
+				Namespace: "default",
+				Name:      "test-channel",
+				HostName:  "a.b.c.d",
+				Subscriptions: []Subscription{{
+					UID:          "subscription-1",
+					Subscription: fanout.Subscription{RetryConfig: &kncloudevents.RetryConfig{RetryMax: retries}},
+					RetryPolicy:  delivery.RetryPolicy{RetryTopics: retryTopics},
+				}},
+			}},
+		}
+	}
+
+	if _, err := d.UpdateKafkaConsumers(newConfig(2, false)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	if _, ok := d.subsRetryConsumers["subscription-1"]; ok {
+		t.Errorf("Expected no retry consumer group without retry topics")
+	}
+
+	if _, err := d.UpdateKafkaConsumers(newConfig(2, true)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	if got := d.subsRetryConsumers["subscription-1"]; got == nil || got.tiers != 2 {
+		t.Errorf("Expected a retry consumer group consuming 2 tiers, got %v", got)
+	}
+	if !d.subsRetryTopics["subscription-1"].IsReady() {
+		t.Errorf("Expected the retry topics to be ready")
+	}
+	for _, name := range delivery.RetryTopicNames(topic, 2) {
+		if _, ok := admin.topics[name]; !ok {
+			t.Errorf("Expected the retry topic %s to be created", name)
+		}
+	}
+
+	// The events retried for other subscriptions are skipped
+	handler := consumerMessageHandler{
+		logger:      d.logger,
+		sub:         d.subscriptions["subscription-1"],
+		retryTopics: d.subsRetryTopics["subscription-1"],
+	}
+	msg := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{
+		{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+		{Key: []byte(delivery.RetrySubscriptionHeader), Value: []byte("subscription-2")},
+	}}
+	if mustMark, err := handler.Handle(context.TODO(), msg); !mustMark || err != nil {
+		t.Errorf("Expected the event to be skipped, got %t, %v", mustMark, err)
+	}
+
+	if _, err := d.UpdateKafkaConsumers(&Config{}); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	if len(d.subsRetryConsumers) != 0 || len(d.subsRetryTopics) != 0 {
+		t.Errorf("Expected the retry consumer group to be closed")
+	}
+}
+
+func TestDispatcher_Deduplication(t *testing.T) {
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[eventingchannels.ChannelReference][]types.UID),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subsPauseGates:       make(map[types.UID]*consumer.PauseGate),
+		subsLimiters:         make(map[types.UID]*delivery.Limiter),
+		subsRetryTopics:      make(map[types.UID]*delivery.RetryTopics),
+		subsDeduplicators:    make(map[types.UID]*delivery.Deduplicator),
+		subsRetryConsumers:   make(map[types.UID]*retryConsumer),
+		subsSessions:         make(map[types.UID]*health.Session),
+		subsStats:            make(map[types.UID]*consumer.Stats),
+		subsHealth:           make(map[types.UID]*delivery.Health),
+		subscriptions:        make(map[types.UID]Subscription),
+		saramaConfig:         sarama.NewConfig(),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	newConfig := func(window time.Duration) *Config {
+		return &Config{
+			ChannelConfigs: []ChannelConfig{{
+				Namespace: "default",
+				Name:      "test-channel",
+				HostName:  "a.b.c.d",
+				Subscriptions: []Subscription{{
+					UID:                 "subscription-1",
+					DeduplicationWindow: window,
+				}},
+			}},
+		}
+	}
+
+	if _, err := d.UpdateKafkaConsumers(newConfig(time.Minute)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	deduplicator := d.subsDeduplicators["subscription-1"]
+	if got := deduplicator.Window(); got != time.Minute {
+		t.Errorf("Expected a deduplication window of 1m, got %v", got)
+	}
+
+	// Changing the window does not restart the consumer group
+	consumerGroup := d.subsConsumerGroups["subscription-1"]
+	if _, err := d.UpdateKafkaConsumers(newConfig(time.Hour)); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	if got := deduplicator.Window(); got != time.Hour {
+		t.Errorf("Expected a deduplication window of 1h, got %v", got)
+	}
+	if d.subsConsumerGroups["subscription-1"] != consumerGroup {
+		t.Errorf("Expected the consumer group to be kept")
+	}
+
+	// The events already delivered are skipped without being dispatched
+	handler := consumerMessageHandler{
+		logger:       d.logger,
+		sub:          d.subscriptions["subscription-1"],
+		deduplicator: deduplicator,
+	}
+	msg := &sarama.ConsumerMessage{Topic: "topic", Headers: []*sarama.RecordHeader{
+		{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+		{Key: []byte("ce_id"), Value: []byte("id")},
+		{Key: []byte("ce_source"), Value: []byte("source")},
+		{Key: []byte("ce_type"), Value: []byte("type")},
+	}}
+	deduplicator.Delivered(context.TODO(), msg)
+	if mustMark, err := handler.Handle(context.TODO(), msg); !mustMark || err != nil {
+		t.Errorf("Expected the event to be skipped, got %t, %v", mustMark, err)
+	}
+
+	if _, err := d.UpdateKafkaConsumers(&Config{}); err != nil {
+		t.Fatalf("Unexpected UpdateKafkaConsumers error: %v", err)
+	}
+	if len(d.subsDeduplicators) != 0 {
+		t.Errorf("Expected the deduplicator to be removed")
+	}
+}
+
+func TestConsumerMessageHandler_RetryConfig(t *testing.T) {
+	handler := consumerMessageHandler{}
+	if handler.retryConfig() != nil {
+		t.Errorf("Expected no retry config without limiter")
+	}
+
+	handler.limiter = delivery.NewLimiter(delivery.Limits{})
+	retryConfig := handler.retryConfig()
+	if retryConfig == nil || retryConfig.CheckRetry == nil {
+		t.Fatalf("Expected a retry config observing the responses")
+	}
+	if retryConfig.RetryMax != 0 {
+		t.Errorf("Expected no retries, got %d", retryConfig.RetryMax)
+	}
+
+	handler.limiter.SetRetryPolicy(delivery.RetryPolicy{MaxBackoff: time.Second, RetryableStatusCodes: []int{http.StatusTooManyRequests}})
+	handler.sub.RetryConfig = &kncloudevents.RetryConfig{
+		RetryMax: 3,
+		Backoff: func(int, *http.Response) time.Duration {
+			return time.Hour
+		},
+	}
+	retryConfig = handler.retryConfig()
+	if retryConfig.RetryMax != 3 {
+		t.Errorf("Expected 3 retries, got %d", retryConfig.RetryMax)
+	}
+	if retry, _ := retryConfig.CheckRetry(context.TODO(), &http.Response{StatusCode: http.StatusTooManyRequests}, nil); !retry {
+		t.Errorf("Expected the retryable status code to be retried")
+	}
+	if retry, _ := retryConfig.CheckRetry(context.TODO(), &http.Response{StatusCode: http.StatusBadRequest}, nil); retry {
+		t.Errorf("Expected the other status codes not to be retried")
+	}
+	if backoff := retryConfig.Backoff(1, nil); backoff > time.Second {
+		t.Errorf("Expected the backoff to be capped to 1s, got %v", backoff)
+	}
+}
+
+func TestConsumerMessageHandler_Health(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	subscriber, _ := url.Parse(server.URL)
+	noRetries := kncloudevents.NoRetries()
+
+	handler := consumerMessageHandler{
+		logger:     zaptest.NewLogger(t).Sugar(),
+		sub:        Subscription{Subscription: fanout.Subscription{Subscriber: subscriber, RetryConfig: &noRetries}},
+		dispatcher: eventingchannels.NewMessageDispatcher(zaptest.NewLogger(t)),
+		health:     delivery.NewHealth(delivery.HealthThresholds{ConsecutiveFailures: 2}),
+	}
+	msg := &sarama.ConsumerMessage{Topic: "topic", Headers: []*sarama.RecordHeader{
+		{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+		{Key: []byte("ce_id"), Value: []byte("id")},
+		{Key: []byte("ce_source"), Value: []byte("source")},
+		{Key: []byte("ce_type"), Value: []byte("type")},
+	}}
+	for i := 0; i < 2; i++ {
+		if _, err := handler.Handle(context.TODO(), msg); err == nil {
+			t.Errorf("Expected the delivery to fail")
+		}
+	}
+
+	status := handler.health.Status()
+	if status.Reason != delivery.DeliveryFailingReason || status.ConsecutiveFailures != 2 || status.LastStatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the delivery to be failing with the status code 503, got %+v", status)
+	}
+}
+
 func TestKafkaDispatcher_Start(t *testing.T) {
 	d := &KafkaDispatcher{}
 
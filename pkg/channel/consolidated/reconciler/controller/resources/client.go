@@ -18,11 +18,16 @@ package resources
 
 import (
 	"github.com/Shopify/sarama"
+
+	"knative.dev/eventing-kafka/pkg/common/saramaconfig"
 )
 
-func MakeClient(clientID string, bootstrapServers []string) (sarama.ClusterAdmin, error) {
+func MakeClient(clientID string, bootstrapServers []string, saramaSpec *saramaconfig.SaramaConfigSpec) (sarama.ClusterAdmin, error) {
 	saramaConf := sarama.NewConfig()
 	saramaConf.Version = sarama.V1_1_0_0
+	if err := saramaSpec.ApplyTo(saramaConf); err != nil {
+		return nil, err
+	}
 	saramaConf.ClientID = clientID
 	return sarama.NewClusterAdmin(bootstrapServers, saramaConf)
 }
@@ -20,8 +20,12 @@ import (
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"knative.dev/pkg/system"
+
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
+	"knative.dev/eventing-kafka/pkg/common/health"
 )
 
 const DispatcherContainerName = "dispatcher"
@@ -74,7 +78,12 @@ func MakeDispatcher(args DispatcherArgs) *v1.Deployment {
 							Ports: []corev1.ContainerPort{{
 								Name:          "metrics",
 								ContainerPort: 9090,
+							}, {
+								Name:          "health",
+								ContainerPort: utils.DispatcherHealthPort,
 							}},
+							LivenessProbe:  makeProbe(health.LivenessPath, 20),
+							ReadinessProbe: makeProbe(health.ReadinessPath, 0),
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									Name:      "config-kafka",
@@ -101,6 +110,20 @@ func MakeDispatcher(args DispatcherArgs) *v1.Deployment {
 	}
 }
 
+// makeProbe returns a probe of the health endpoint of the dispatcher at the path
+func makeProbe(path string, initialDelaySeconds int32) *corev1.Probe {
+	return &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Port: intstr.FromInt(utils.DispatcherHealthPort),
+				Path: path,
+			},
+		},
+		InitialDelaySeconds: initialDelaySeconds,
+		PeriodSeconds:       5,
+	}
+}
+
 func makeEnv(args DispatcherArgs) []corev1.EnvVar {
 	vars := []corev1.EnvVar{{
 		Name:  system.NamespaceEnvKey,
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -29,14 +30,20 @@ import (
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/eventing"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	eventingchannels "knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/client/injection/informers/messaging/v1/subscription"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/tracing"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/dispatcher"
@@ -47,8 +54,16 @@ import (
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
 	kafkachannelreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
 	listers "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/delivery"
+	"knative.dev/eventing-kafka/pkg/common/health"
+	"knative.dev/eventing-kafka/pkg/common/ingress"
+	"knative.dev/eventing-kafka/pkg/common/partitioning"
 )
 
+// deliveryHealthDelay is the delay of the reconciliations reporting the delivery health of the subscribers, so that
+// there is at most one per channel and delay.
+const deliveryHealthDelay = 30 * time.Second
+
 func init() {
 	// Add run types to the default Kubernetes Scheme so Events can be
 	// logged for run types.
@@ -62,6 +77,7 @@ type Reconciler struct {
 	kafkaClientSet       kafkaclientset.Interface
 	kafkachannelLister   listers.KafkaChannelLister
 	kafkachannelInformer cache.SharedIndexInformer
+	subscriptionLister   messaginglisters.SubscriptionLister
 	impl                 *controller.Impl
 }
 
@@ -94,17 +110,24 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	}
 
 	kafkaChannelInformer := kafkachannel.Get(ctx)
+	subscriptionInformer := subscription.Get(ctx)
 	args := &dispatcher.KafkaDispatcherArgs{
 		KnCEConnectionArgs: connectionArgs,
 		ClientID:           "kafka-ch-dispatcher",
 		Brokers:            kafkaConfig.Brokers,
 		TopicFunc:          utils.TopicName,
 		Logger:             logger,
+		SaramaSpec:         kafkaConfig.Sarama,
+		KubeClient:         kubeclient.Get(ctx),
+		HealthPort:         utils.DispatcherHealthPort,
+		AdminNamespace:     system.Namespace(),
 	}
 	kafkaDispatcher, err := dispatcher.NewDispatcher(ctx, args)
 	if err != nil {
 		logger.Fatalw("Unable to create kafka dispatcher", zap.Error(err))
 	}
+	kafkaDispatcher.HealthChecker().AddCheck(dispatcher.ListersCheck, health.Readiness,
+		health.SyncCheck(kafkaChannelInformer.Informer().HasSynced, subscriptionInformer.Informer().HasSynced))
 	logger.Info("Starting the Kafka dispatcher")
 	logger.Infow("Kafka broker configuration", zap.Strings(utils.BrokerConfigMapKey, kafkaConfig.Brokers))
 
@@ -113,6 +136,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		kafkaClientSet:       kafkaclientsetinjection.Get(ctx),
 		kafkachannelLister:   kafkaChannelInformer.Lister(),
 		kafkachannelInformer: kafkaChannelInformer.Informer(),
+		subscriptionLister:   subscriptionInformer.Lister(),
 	}
 	r.impl = kafkachannelreconciler.NewImpl(ctx, r)
 
@@ -125,6 +149,14 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 			Handler:    controller.HandleAll(r.impl.Enqueue),
 		})
 
+	// Watch for subscriptions, to pause and resume them.
+	subscriptionInformer.Informer().AddEventHandler(controller.HandleAll(enqueueSubscriptionChannel(r.impl)))
+
+	// Report the delivery health of the subscribers when it changes, at most once per deliveryHealthDelay.
+	kafkaDispatcher.OnDeliveryHealthChanged(func(channelRef eventingchannels.ChannelReference) {
+		r.impl.EnqueueKeyAfter(types.NamespacedName{Namespace: channelRef.Namespace, Name: channelRef.Name}, deliveryHealthDelay)
+	})
+
 	logger.Info("Starting dispatcher.")
 	go func() {
 		if err := kafkaDispatcher.Start(ctx); err != nil {
@@ -142,6 +174,15 @@ func filterWithAnnotation(namespaced bool) func(obj interface{}) bool {
 	return pkgreconciler.AnnotationFilterFunc(eventing.ScopeAnnotationKey, "cluster", true)
 }
 
+// enqueueSubscriptionChannel enqueues the KafkaChannel a Subscription subscribes to.
+func enqueueSubscriptionChannel(impl *controller.Impl) func(obj interface{}) {
+	return func(obj interface{}) {
+		if sub, ok := obj.(*messagingv1.Subscription); ok && sub.Spec.Channel.Kind == "KafkaChannel" {
+			impl.EnqueueKey(types.NamespacedName{Namespace: sub.Namespace, Name: sub.Spec.Channel.Name})
+		}
+	}
+}
+
 func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel) pkgreconciler.Event {
 	channels, err := r.kafkachannelLister.List(labels.Everything())
 	if err != nil {
@@ -158,7 +199,11 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 			kafkaChannels = append(kafkaChannels, channel)
 		}
 	}
-	config := r.newConfigFromKafkaChannels(kafkaChannels)
+	config, invalidSubscriptions, err := r.newConfigFromKafkaChannels(kafkaChannels)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error listing subscriptions")
+		return err
+	}
 	if err := r.kafkaDispatcher.UpdateHostToChannelMap(config); err != nil {
 		logging.FromContext(ctx).Error("Error updating host to channel map in dispatcher")
 		return err
@@ -169,6 +214,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 		logging.FromContext(ctx).Error("Error updating kafka consumers in dispatcher")
 		return err
 	}
+	// The subscriptions with invalid limits are delivered without limits
+	for _, sub := range kc.Spec.Subscribers {
+		if err, ok := invalidSubscriptions[sub.UID]; ok {
+			if _, failed := failedSubscriptions[sub.UID]; !failed {
+				failedSubscriptions[sub.UID] = err
+			}
+		}
+	}
 	kc.Status.SubscribableStatus = r.createSubscribableStatus(&kc.Spec.SubscribableSpec, failedSubscriptions)
 	if len(failedSubscriptions) > 0 {
 		logging.FromContext(ctx).Error("Some kafka subscriptions failed to subscribe")
@@ -191,6 +244,11 @@ func (r *Reconciler) createSubscribableStatus(subscribable *eventingduckv1.Subsc
 		if err, ok := failedSubscriptions[sub.UID]; ok {
 			status.Ready = corev1.ConditionFalse
 			status.Message = err.Error()
+		} else if r.kafkaDispatcher.IsPaused(sub.UID) {
+			status.Message = "The subscription is paused by the " + v1beta1.KafkaSubscriptionPausedAnnotation + " annotation"
+		} else if health := r.kafkaDispatcher.DeliveryHealth(sub.UID); !health.Healthy() {
+			status.Ready = corev1.ConditionFalse
+			status.Message = health.Message()
 		}
 		subscriberStatus = append(subscriberStatus, status)
 	}
@@ -200,20 +258,45 @@ func (r *Reconciler) createSubscribableStatus(subscribable *eventingduckv1.Subsc
 }
 
 // newConfigFromKafkaChannels creates a new Config from the list of kafka channels.
-func (r *Reconciler) newChannelConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *dispatcher.ChannelConfig {
+// The subscriptions whose annotations are invalid are added to invalidSubscriptions.
+func (r *Reconciler) newChannelConfigFromKafkaChannel(c *v1beta1.KafkaChannel, annotations map[types.UID]map[string]string, invalidSubscriptions map[types.UID]error) *dispatcher.ChannelConfig {
+	// Invalid partitioning policies are rejected by the webhook, the events are partitioned by default otherwise
+	partitioningSettings, _ := partitioning.SettingsFromPolicy(c.Spec.Partitioning)
+	// Invalid event rules are rejected by the webhook as well, all the events are accepted otherwise
+	validationRules, _ := ingress.RulesFromSpec(c.Spec.EventRules)
 	channelConfig := dispatcher.ChannelConfig{
-		Namespace: c.Namespace,
-		Name:      c.Name,
-		HostName:  c.Status.Address.URL.Host,
+		Namespace:    c.Namespace,
+		Name:         c.Name,
+		HostName:     c.Status.Address.URL.Host,
+		Partitioning: partitioningSettings,
+		Validation:   validationRules,
+		Ingress:      c.Spec.Ingress,
 	}
 	if c.Spec.SubscribableSpec.Subscribers != nil {
 		newSubs := make([]dispatcher.Subscription, 0, len(c.Spec.SubscribableSpec.Subscribers))
 		for _, source := range c.Spec.SubscribableSpec.Subscribers {
 			innerSub, _ := fanout.SubscriberSpecToFanoutConfig(source)
 
+			limits, err := delivery.LimitsFromAnnotations(annotations[source.UID])
+			if err != nil {
+				invalidSubscriptions[source.UID] = err
+			}
+			retryPolicy, err := delivery.RetryPolicyFromAnnotations(annotations[source.UID])
+			if err != nil {
+				invalidSubscriptions[source.UID] = err
+			}
+			deduplicationWindow, err := delivery.DeduplicationWindowFromAnnotations(annotations[source.UID])
+			if err != nil {
+				invalidSubscriptions[source.UID] = err
+			}
+
 			newSubs = append(newSubs, dispatcher.Subscription{
-				Subscription: *innerSub,
-				UID:          source.UID,
+				Subscription:        *innerSub,
+				UID:                 source.UID,
+				Paused:              v1beta1.IsSubscriptionPaused(annotations[source.UID]),
+				Limits:              limits,
+				RetryPolicy:         retryPolicy,
+				DeduplicationWindow: deduplicationWindow,
 			})
 		}
 		channelConfig.Subscriptions = newSubs
@@ -223,13 +306,36 @@ func (r *Reconciler) newChannelConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *
 }
 
 // newConfigFromKafkaChannels creates a new Config from the list of kafka channels.
-func (r *Reconciler) newConfigFromKafkaChannels(channels []*v1beta1.KafkaChannel) *dispatcher.Config {
+// It also returns the subscriptions whose annotations are invalid.
+func (r *Reconciler) newConfigFromKafkaChannels(channels []*v1beta1.KafkaChannel) (*dispatcher.Config, map[types.UID]error, error) {
 	cc := make([]dispatcher.ChannelConfig, 0)
+	annotations := make(map[string]map[types.UID]map[string]string)
+	invalidSubscriptions := make(map[types.UID]error)
 	for _, c := range channels {
-		channelConfig := r.newChannelConfigFromKafkaChannel(c)
+		if _, ok := annotations[c.Namespace]; !ok {
+			a, err := r.subscriptionAnnotations(c.Namespace)
+			if err != nil {
+				return nil, nil, err
+			}
+			annotations[c.Namespace] = a
+		}
+		channelConfig := r.newChannelConfigFromKafkaChannel(c, annotations[c.Namespace], invalidSubscriptions)
 		cc = append(cc, *channelConfig)
 	}
 	return &dispatcher.Config{
 		ChannelConfigs: cc,
+	}, invalidSubscriptions, nil
+}
+
+// subscriptionAnnotations returns the annotations of the subscriptions of the namespace, by UID.
+func (r *Reconciler) subscriptionAnnotations(namespace string) (map[types.UID]map[string]string, error) {
+	subs, err := r.subscriptionLister.Subscriptions(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	annotations := make(map[types.UID]map[string]string, len(subs))
+	for _, sub := range subs {
+		annotations[sub.UID] = sub.GetAnnotations()
 	}
+	return annotations, nil
 }
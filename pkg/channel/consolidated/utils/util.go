@@ -25,12 +25,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"knative.dev/pkg/configmap"
+
+	"knative.dev/eventing-kafka/pkg/common/saramaconfig"
 )
 
 const (
 	BrokerConfigMapKey           = "bootstrapServers"
 	MaxIdleConnectionsKey        = "maxIdleConns"
 	MaxIdleConnectionsPerHostKey = "maxIdleConnsPerHost"
+	SaramaConfigKey              = "sarama"
 
 	KafkaChannelSeparator = "."
 
@@ -44,12 +47,17 @@ const (
 
 	DefaultMaxIdleConns        = 1000
 	DefaultMaxIdleConnsPerHost = 100
+
+	// DispatcherHealthPort is the port the dispatcher serves its liveness and readiness probes on
+	DispatcherHealthPort = 8081
 )
 
 type KafkaConfig struct {
 	Brokers             []string
 	MaxIdleConns        int32
 	MaxIdleConnsPerHost int32
+	// Sarama holds the optional sarama client settings, nil when not configured.
+	Sarama *saramaconfig.SaramaConfigSpec
 }
 
 // GetKafkaConfig returns the details of the Kafka cluster.
@@ -63,10 +71,11 @@ func GetKafkaConfig(configMap map[string]string) (*KafkaConfig, error) {
 		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
 	}
 
-	var bootstrapServers string
+	var bootstrapServers, saramaSpec string
 
 	err := configmap.Parse(configMap,
 		configmap.AsString(BrokerConfigMapKey, &bootstrapServers),
+		configmap.AsString(SaramaConfigKey, &saramaSpec),
 		configmap.AsInt32(MaxIdleConnectionsKey, &config.MaxIdleConns),
 		configmap.AsInt32(MaxIdleConnectionsPerHostKey, &config.MaxIdleConnsPerHost),
 	)
@@ -85,6 +94,12 @@ func GetKafkaConfig(configMap map[string]string) (*KafkaConfig, error) {
 	}
 	config.Brokers = bootstrapServersSplitted
 
+	if saramaSpec != "" {
+		if config.Sarama, err = saramaconfig.Parse(saramaSpec); err != nil {
+			return nil, fmt.Errorf("invalid %s value in configuration: %w", SaramaConfigKey, err)
+		}
+	}
+
 	return config, nil
 }
 
@@ -24,6 +24,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	_ "knative.dev/pkg/system/testing"
+
+	"knative.dev/eventing-kafka/pkg/common/saramaconfig"
 )
 
 func TestGenerateTopicNameWithDot(t *testing.T) {
@@ -142,6 +144,21 @@ func TestGetKafkaConfig(t *testing.T) {
 				MaxIdleConnsPerHost: 600,
 			},
 		},
+		{
+			name: "sarama config spec",
+			data: map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "sarama": "apiVersion: v1\nkafkaVersion: 2.3.0\n"},
+			expected: &KafkaConfig{
+				Brokers:             []string{"kafkabroker.kafka:9092"},
+				MaxIdleConns:        1000,
+				MaxIdleConnsPerHost: 100,
+				Sarama:              &saramaconfig.SaramaConfigSpec{APIVersion: "v1", KafkaVersion: "2.3.0"},
+			},
+		},
+		{
+			name:     "invalid sarama config spec",
+			data:     map[string]string{"bootstrapServers": "kafkabroker.kafka:9092", "sarama": "apiVersion: v2\n"},
+			getError: "invalid sarama value in configuration: invalid value: v2: apiVersion",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -29,9 +29,10 @@ type EKReceiverConfig struct {
 	EKKubernetesConfig
 }
 
-// The Dispatcher config has the base Kubernetes fields and some retry settings
+// The Dispatcher config has the base Kubernetes fields and the time allowed for draining in-flight events on shutdown
 type EKDispatcherConfig struct {
 	EKKubernetesConfig
+	DrainTimeoutSeconds int `json:"drainTimeoutSeconds,omitempty"`
 }
 
 // EKKafkaTopicConfig contains some defaults that are only used if not provided by the channel spec
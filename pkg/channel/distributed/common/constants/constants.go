@@ -5,4 +5,10 @@ const (
 
 	// Knative Eventing Namespace
 	KnativeEventingNamespace = "knative-eventing"
+
+	// Kafka Secret Volume (Mounted In The Receiver & Dispatcher To Rotate The Credentials Without Restarting)
+	KafkaSecretVolumeName   = "kafka-secret"
+	KafkaSecretMountPath    = "/etc/kafka-secret"
+	KafkaSecretUsernameFile = "username"
+	KafkaSecretPasswordFile = "password"
 )
@@ -10,9 +10,10 @@ const (
 	HealthPortEnvVarKey     = "HEALTH_PORT"
 
 	// Kafka Authorization
-	KafkaBrokerEnvVarKey   = "KAFKA_BROKERS"
-	KafkaUsernameEnvVarKey = "KAFKA_USERNAME"
-	KafkaPasswordEnvVarKey = "KAFKA_PASSWORD"
+	KafkaBrokerEnvVarKey     = "KAFKA_BROKERS"
+	KafkaUsernameEnvVarKey   = "KAFKA_USERNAME"
+	KafkaPasswordEnvVarKey   = "KAFKA_PASSWORD"
+	KafkaSecretPathEnvVarKey = "KAFKA_SECRET_PATH" // The Directory Of The Mounted Kafka Secret (Watched For Credential Changes)
 
 	// Kafka Configuration
 	KafkaTopicEnvVarKey = "KAFKA_TOPIC"
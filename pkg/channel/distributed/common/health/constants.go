@@ -1,8 +1,11 @@
 package health
 
+import commonhealth "knative.dev/eventing-kafka/pkg/common/health"
+
 // Constants
 const (
 	// Default Health Configuration
-	LivenessPath  = "/healthz" // The Endpoint Of The Liveness Check
-	ReadinessPath = "/healthy" // The Endpoint Of The Readiness Check
+	LivenessPath  = commonhealth.LivenessPath  // The Endpoint Of The Liveness Check
+	ReadinessPath = commonhealth.ReadinessPath // The Endpoint Of The Readiness Check
+	DetailsPath   = commonhealth.DetailsPath   // The Endpoint Of The JSON Health Check Results
 )
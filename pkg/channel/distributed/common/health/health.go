@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"go.uber.org/zap"
+	commonhealth "knative.dev/eventing-kafka/pkg/common/health"
 )
 
 // Interface For Providing Overrides For Liveness And Readiness Information
@@ -18,9 +19,11 @@ type Status interface {
 
 // Structure Containing Basic Liveness Information For Health Server
 type Server struct {
-	server   *http.Server // The Golang HTTP Server Instance
+	server   *http.Server   // The Golang HTTP Server Instance
+	serveMux *http.ServeMux // The Handlers Of The HTTP Server (Additional Ones May Be Registered)
 	status   Status
-	HttpPort string // The HTTP Port The Dispatcher Server Listens On
+	checker  *commonhealth.Checker // Runs The Health Checks Aggregated With The Status
+	HttpPort string                // The HTTP Port The Dispatcher Server Listens On
 
 	// Synchronization Mutexes
 	liveMutex sync.Mutex // Synchronizes access to the liveness flag
@@ -34,6 +37,7 @@ func NewHealthServer(httpPort string, healthStatus Status) *Server {
 	health := &Server{
 		HttpPort: httpPort,
 		status:   healthStatus,
+		checker:  commonhealth.NewChecker(commonhealth.DefaultInterval, commonhealth.DefaultTimeout),
 	}
 
 	// Initialize The HTTP Server
@@ -50,6 +54,11 @@ func (hs *Server) SetAlive(isAlive bool) {
 	hs.liveMutex.Unlock()
 }
 
+// Access Function For The Health Checker (Its Checks Are Run Once The Server Is Started)
+func (hs *Server) Checker() *commonhealth.Checker {
+	return hs.checker
+}
+
 // Set All Liveness And Readiness Flags To False
 func (hs *Server) Shutdown() {
 	hs.SetAlive(false)
@@ -61,12 +70,19 @@ func (hs *Server) initializeServer(httpPort string) {
 	serveMux := http.NewServeMux()
 	serveMux.HandleFunc(LivenessPath, hs.HandleLiveness)
 	serveMux.HandleFunc(ReadinessPath, hs.HandleReadiness)
+	serveMux.HandleFunc(DetailsPath, hs.HandleDetails)
 
 	// Create The Server For Configured HTTP Port
 	server := &http.Server{Addr: ":" + httpPort, Handler: serveMux}
 
 	// Set The Initialized HTTP Server
 	hs.server = server
+	hs.serveMux = serveMux
+}
+
+// Register An Additional Handler For The Specified Pattern (Such As The Admin Endpoints)
+func (hs *Server) Handle(pattern string, handler http.Handler) {
+	hs.serveMux.Handle(pattern, handler)
 }
 
 // Start The HTTP Server (Blocking Call)
@@ -81,6 +97,9 @@ func (hs *Server) Start(logger *zap.Logger) {
 	// (If "0" is passed in then the Listen call will assign one arbitrarily)
 	hs.HttpPort = strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
 
+	// Start Running The Health Checks
+	hs.checker.Start(logger)
+
 	go func() {
 		logger.Info("Starting Server HTTP Server on port " + hs.HttpPort)
 
@@ -94,6 +113,7 @@ func (hs *Server) Start(logger *zap.Logger) {
 // Stop The HTTP Server Listening For Requests
 func (hs *Server) Stop(logger *zap.Logger) {
 	logger.Info("Stopping Server HTTP Server")
+	hs.checker.Stop()
 	err := hs.server.Shutdown(context.TODO())
 	if err != nil {
 		logger.Error("Server Failed To Shutdown HTTP Server", zap.Error(err))
@@ -113,7 +133,7 @@ func (hs *Server) HandleLiveness(responseWriter http.ResponseWriter, request *ht
 		responseWriter.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
-	if hs.status.Alive() {
+	if hs.status.Alive() && hs.checker.Alive() {
 		responseWriter.WriteHeader(http.StatusOK)
 	} else {
 		responseWriter.WriteHeader(http.StatusInternalServerError)
@@ -126,9 +146,21 @@ func (hs *Server) HandleReadiness(responseWriter http.ResponseWriter, request *h
 		responseWriter.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
-	if hs.status.Ready() {
+	if hs.status.Ready() && hs.checker.Ready() {
 		responseWriter.WriteHeader(http.StatusOK)
 	} else {
 		responseWriter.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+// HTTP Request Handler For The Health Check Results (/health) - Aggregated With The Liveness And Readiness Flags
+func (hs *Server) HandleDetails(responseWriter http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		responseWriter.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	report := hs.checker.Report()
+	report.Alive = report.Alive && hs.status.Alive()
+	report.Ready = report.Ready && hs.status.Ready()
+	commonhealth.WriteReport(responseWriter, report)
+}
@@ -1,6 +1,9 @@
 package health
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +13,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	commonhealth "knative.dev/eventing-kafka/pkg/common/health"
 	logtesting "knative.dev/pkg/logging/testing"
 )
 
@@ -18,6 +22,7 @@ const (
 	testHttpHost  = "localhost"
 	livenessPath  = "/healthz"
 	readinessPath = "/healthy"
+	detailsPath   = "/health"
 )
 
 // Test Struct That Implements The HealthInterface Functions
@@ -57,6 +62,24 @@ func TestNewHealthServer(t *testing.T) {
 	assert.Equal(t, false, health.alive)
 }
 
+// Test The Handle() Functionality
+func TestHandle(t *testing.T) {
+
+	// Create A Health Server With An Additional Handler
+	health := getTestHealthServer()
+	health.Handle("/admin/", http.HandlerFunc(func(responseWriter http.ResponseWriter, _ *http.Request) {
+		responseWriter.WriteHeader(http.StatusTeapot)
+	}))
+
+	// Verify The Additional Handler Is Served Along With The Probes
+	recorder := httptest.NewRecorder()
+	health.server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/admin/config", nil))
+	assert.Equal(t, http.StatusTeapot, recorder.Code)
+	recorder = httptest.NewRecorder()
+	health.server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, readinessPath, nil))
+	assert.NotEqual(t, http.StatusTeapot, recorder.Code)
+}
+
 // Test Flag Set And Reset Functions
 func TestFlagWrites(t *testing.T) {
 
@@ -116,6 +139,50 @@ func TestHealthHandler(t *testing.T) {
 	getEventToHandler(t, health.HandleLiveness, livenessPath, http.StatusInternalServerError)
 }
 
+// Test The Health Checks Aggregated With The Liveness And Readiness Flags
+func TestHealthChecks(t *testing.T) {
+
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Create A New Health Server With A Failing Readiness Check & A Passing Liveness Check
+	health := getTestHealthServer()
+	health.SetAlive(true)
+	health.Checker().AddCheck("brokers", commonhealth.Readiness, func(context.Context) error { return errors.New("unreachable") })
+	health.Checker().AddCheck("alive", commonhealth.Liveness, func(context.Context) error { return nil })
+
+	// Verify That The Pending Checks Fail Both Probes
+	getEventToHandler(t, health.HandleLiveness, livenessPath, http.StatusInternalServerError)
+	getEventToHandler(t, health.HandleReadiness, readinessPath, http.StatusInternalServerError)
+
+	// Verify That Once Checked Only The Readiness Fails
+	health.Checker().Start(logger)
+	defer health.Checker().Stop()
+	assert.Eventually(t, func() bool { return health.Checker().Alive() }, time.Second, 10*time.Millisecond)
+	getEventToHandler(t, health.HandleLiveness, livenessPath, http.StatusOK)
+	getEventToHandler(t, health.HandleReadiness, readinessPath, http.StatusInternalServerError)
+
+	// Verify The JSON Details Of The Checks
+	responseRecorder := httptest.NewRecorder()
+	health.HandleDetails(responseRecorder, createNewRequest(t, http.MethodGet, detailsPath, nil))
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	var report commonhealth.Report
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &report))
+	assert.True(t, report.Alive)
+	assert.False(t, report.Ready)
+	assert.Len(t, report.Checks, 2)
+	assert.Equal(t, "brokers", report.Checks[1].Name)
+	assert.Equal(t, "unreachable", report.Checks[1].Error)
+
+	// Verify That The Liveness Flag Is Aggregated In The Details
+	health.SetAlive(false)
+	responseRecorder = httptest.NewRecorder()
+	health.HandleDetails(responseRecorder, createNewRequest(t, http.MethodGet, detailsPath, nil))
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &report))
+	assert.False(t, report.Alive)
+
+	performUnsupportedMethodRequestTest(t, http.MethodPost, detailsPath, health.HandleDetails)
+}
+
 // Test The Health Server Via Live HTTP Calls
 func TestHealthServer(t *testing.T) {
 
@@ -7,11 +7,13 @@ import (
 
 	"github.com/Shopify/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/eventhubcache"
 )
 
 // Sarama ClusterAdmin Wrapping Interface To Facilitate Other Implementations (e.g. Azure EventHubs)
+// (CreateTopic Returns Nil Or An Error Which Is, Or Wraps, A *sarama.TopicError - ErrNoError On Success)
 type AdminClientInterface interface {
-	CreateTopic(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
+	CreateTopic(context.Context, string, *sarama.TopicDetail) error
 	DeleteTopic(context.Context, string) *sarama.TopicError
 	Close() error
 	GetKafkaSecretName(topicName string) string
@@ -50,12 +52,15 @@ const (
 //
 // * If no authorization is required (local dev instance) then specify username and password as the empty string ""
 //
+// The EventHub AdminClient provisions new Azure Namespaces, when all of them are full, via the NamespaceProvisioner
+// carried by the context (see eventhubcache.WithNamespaceProvisioner), if any.
+//
 func CreateAdminClient(ctx context.Context, saramaConfig *sarama.Config, clientId string, adminClientType AdminClientType) (AdminClientInterface, error) {
 	switch adminClientType {
 	case Kafka:
 		return NewKafkaAdminClientWrapper(ctx, saramaConfig, clientId, constants.KnativeEventingNamespace)
 	case EventHub:
-		return NewEventHubAdminClientWrapper(ctx, constants.KnativeEventingNamespace)
+		return NewEventHubAdminClientWrapper(ctx, constants.KnativeEventingNamespace, eventhubcache.GetNamespaceProvisioner(ctx))
 	case Custom:
 		return NewCustomAdminClientWrapper(ctx, constants.KnativeEventingNamespace)
 	case Unknown:
@@ -71,8 +76,8 @@ var NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.
 }
 
 // New EventHub AdminClient Wrapper To Facilitate Unit Testing
-var NewEventHubAdminClientWrapper = func(ctx context.Context, namespace string) (AdminClientInterface, error) {
-	return NewEventHubAdminClient(ctx, namespace)
+var NewEventHubAdminClientWrapper = func(ctx context.Context, namespace string, provisioner eventhubcache.NamespaceProvisioner) (AdminClientInterface, error) {
+	return NewEventHubAdminClient(ctx, namespace, provisioner)
 }
 
 // New Custom AdminClient Wrapper To Facilitate Unit Testing
@@ -25,6 +25,9 @@ import (
 // custom logic for the Creation / Deletion of Kafka topics, we are including
 // this option.  It is a basic REST pass-through to well-defined endpoints on
 // a sidecar container running in the eventing-kafka Controller Deployment.
+// Beyond the Creation / Deletion required by the AdminClientInterface, the
+// versioned sidecar protocol also supports describing, listing and updating
+// topics as well as checking the health of the sidecar.
 //
 // See the .../common/kafka/README.md for full details.
 //
@@ -89,7 +92,7 @@ func NewCustomAdminClient(ctx context.Context, namespace string) (AdminClientInt
 }
 
 // Custom REST Pass-Through Function For Creating Topics
-func (c *CustomAdminClient) CreateTopic(_ context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+func (c *CustomAdminClient) CreateTopic(_ context.Context, topicName string, topicDetail *sarama.TopicDetail) error {
 
 	// Create An Updated Logger With TopicName
 	logger := c.logger.With(zap.String("TopicName", topicName))
@@ -115,14 +118,13 @@ func (c *CustomAdminClient) CreateTopic(_ context.Context, topicName string, top
 	url := c.sidecarTopicsUrl("")
 
 	// Create The HTTP POST Request
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(requestBody))
+	request, err := newSidecarRequest(http.MethodPost, url, requestBody)
 	if err != nil {
 		logger.Error("Failed To Create New HTTP POST Request", zap.String("URL", url), zap.Error(err))
 		return adminutil.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to create new http request for creation of topic '%s'", topicName))
 	}
 
 	// Populate Required Headers
-	request.Header.Set("Content-Type", "application/json")
 	request.Header.Set(custom.TopicNameHeader, topicName)
 
 	// Make The HTTP Request
@@ -152,11 +154,11 @@ func (c *CustomAdminClient) DeleteTopic(_ context.Context, topicName string) *sa
 	// Create Topics URL For Sidecar Endpoint (TopicName In DELETE URL!)
 	url := c.sidecarTopicsUrl(topicName)
 
-	// Create The HTTP POST Request
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	// Create The HTTP DELETE Request
+	request, err := newSidecarRequest(http.MethodDelete, url, nil)
 	if err != nil {
-		logger.Error("Failed To Create New HTTP POST Request", zap.String("URL", url), zap.Error(err))
-		return adminutil.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to create new http request for creation of topic '%s'", topicName))
+		logger.Error("Failed To Create New HTTP DELETE Request", zap.String("URL", url), zap.Error(err))
+		return adminutil.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to create new http request for deletion of topic '%s'", topicName))
 	}
 
 	// Make The HTTP Request
@@ -171,6 +173,127 @@ func (c *CustomAdminClient) DeleteTopic(_ context.Context, topicName string) *sa
 	return c.mapHttpResponse("delete", response)
 }
 
+// Custom REST Pass-Through Function For Describing A Topic
+func (c *CustomAdminClient) DescribeTopic(_ context.Context, topicName string) (*sarama.TopicDetail, *sarama.TopicError) {
+
+	// Create An Updated Logger With TopicName
+	logger := c.logger.With(zap.String("TopicName", topicName))
+
+	// Validate The Topic
+	if len(topicName) <= 0 {
+		logger.Warn("Received Empty/Nil Topic Configuration")
+		return nil, adminutil.NewTopicError(sarama.ErrInvalidRequest, "received empty/nil topic name")
+	}
+
+	// Make The HTTP GET Request (TopicName In GET URL!)
+	statusCode, responseBody, topicError := c.doSidecarRequest(logger, "describe", http.MethodGet, c.sidecarTopicsUrl(topicName), nil)
+	if topicError != nil {
+		return nil, topicError
+	}
+
+	// Map Any Failure Into A Sarama TopicError
+	topicError = c.mapHttpStatus("describe", statusCode, responseBody)
+	if topicError.Err != sarama.ErrNoError {
+		return nil, topicError
+	}
+
+	// Parse The Response Body Into A Custom TopicDetail & Convert To A Sarama TopicDetail
+	customTopicDetail := &custom.TopicDetail{}
+	err := json.Unmarshal(responseBody, customTopicDetail)
+	if err != nil {
+		logger.Error("Failed To Unmarshal Describe Topic Response Body", zap.ByteString("Body", responseBody), zap.Error(err))
+		return nil, adminutil.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to unmarshal response body for description of topic '%s'", topicName))
+	}
+	return customTopicDetail.ToSaramaTopicDetail(), nil
+}
+
+// Custom REST Pass-Through Function For Listing The Topics (Sarama TopicDetails By TopicName)
+func (c *CustomAdminClient) ListTopics(_ context.Context) (map[string]sarama.TopicDetail, *sarama.TopicError) {
+
+	// Make The HTTP GET Request (No TopicName In GET URL!)
+	statusCode, responseBody, topicError := c.doSidecarRequest(c.logger, "list", http.MethodGet, c.sidecarTopicsUrl(""), nil)
+	if topicError != nil {
+		return nil, topicError
+	}
+
+	// Map Any Failure Into A Sarama TopicError
+	topicError = c.mapHttpStatus("list", statusCode, responseBody)
+	if topicError.Err != sarama.ErrNoError {
+		return nil, topicError
+	}
+
+	// Parse The Response Body Into Custom TopicDetails & Convert To Sarama TopicDetails
+	customTopicDetails := make(map[string]custom.TopicDetail)
+	err := json.Unmarshal(responseBody, &customTopicDetails)
+	if err != nil {
+		c.logger.Error("Failed To Unmarshal List Topics Response Body", zap.ByteString("Body", responseBody), zap.Error(err))
+		return nil, adminutil.NewTopicError(sarama.ErrUnknown, "failed to unmarshal response body for listing of topics")
+	}
+	topicDetails := make(map[string]sarama.TopicDetail, len(customTopicDetails))
+	for topicName, customTopicDetail := range customTopicDetails {
+		topicDetails[topicName] = *customTopicDetail.ToSaramaTopicDetail()
+	}
+	return topicDetails, nil
+}
+
+// Custom REST Pass-Through Function For Updating The Partitions And / Or Config Entries Of A Topic
+func (c *CustomAdminClient) UpdateTopic(_ context.Context, topicName string, topicUpdate *custom.TopicUpdate) *sarama.TopicError {
+
+	// Create An Updated Logger With TopicName
+	logger := c.logger.With(zap.String("TopicName", topicName))
+
+	// Validate Topic
+	if len(topicName) <= 0 || topicUpdate == nil {
+		logger.Warn("Received Empty/Nil Topic Configuration", zap.Any("TopicUpdate", topicUpdate))
+		return adminutil.NewTopicError(sarama.ErrInvalidRequest, "received empty/nil topic name and / or update")
+	}
+
+	// Create The Request Body From The Custom TopicUpdate
+	requestBody, err := json.Marshal(topicUpdate)
+	if err != nil {
+		logger.Error("Failed To Marshall Update Topic Request Body", zap.Any("TopicUpdate", topicUpdate), zap.Error(err))
+		return adminutil.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("failed to marshal request body for update of topic '%s'", topicName))
+	}
+
+	// Make The HTTP PATCH Request (TopicName In PATCH URL!)
+	statusCode, responseBody, topicError := c.doSidecarRequest(logger, "update", http.MethodPatch, c.sidecarTopicsUrl(topicName), requestBody)
+	if topicError != nil {
+		return topicError
+	}
+
+	// Map The HTTP Response Into A Sarama TopicError & Return
+	return c.mapHttpStatus("update", statusCode, responseBody)
+}
+
+// Custom REST Pass-Through Function For Checking The Health Of The Sidecar
+func (c *CustomAdminClient) Health(_ context.Context) (*custom.Health, error) {
+
+	// Create The HTTP GET Request
+	url := "http://" + custom.SidecarHost + ":" + custom.SidecarPort + custom.HealthPath
+	request, err := newSidecarRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new http request for sidecar health: %w", err)
+	}
+
+	// Make The HTTP Request
+	response, err := c.httpClient.Do(request)
+	defer c.safeCloseHTTPResponseBody(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make http request for sidecar health: %w", err)
+	}
+
+	// Parse The Health From The Response Body (Also Present For Unhealthy 503 Responses)
+	health := &custom.Health{}
+	err = json.NewDecoder(response.Body).Decode(health)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sidecar health response with status code '%d': %w", response.StatusCode, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return health, fmt.Errorf("sidecar is unhealthy with status code '%d' and status '%s'", response.StatusCode, health.Status)
+	}
+	return health, nil
+}
+
 // Custom REST Pass-Through Function For Closing The Admin Client
 func (c *CustomAdminClient) Close() error {
 	return nil // Nothing to "close" in the Custom implementation (just a REST client) so this is just a compatibility no-op.
@@ -191,6 +314,45 @@ func (c *CustomAdminClient) safeCloseHTTPResponseBody(response *http.Response) {
 	}
 }
 
+// Create A New HTTP Request To The Sidecar With The Protocol Version Header & Optional JSON Body
+func newSidecarRequest(method string, url string, body []byte) (*http.Request, error) {
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set(custom.ProtocolVersionHeader, custom.ProtocolVersion)
+	return request, nil
+}
+
+// Make The HTTP Request To The Sidecar & Return The StatusCode And Body Of The Response (TopicError If No Response)
+func (c *CustomAdminClient) doSidecarRequest(logger *zap.Logger, operation string, method string, url string, body []byte) (int, []byte, *sarama.TopicError) {
+
+	// Create The HTTP Request
+	request, err := newSidecarRequest(method, url, body)
+	if err != nil {
+		logger.Error("Failed To Create New HTTP Request", zap.String("Method", method), zap.String("URL", url), zap.Error(err))
+		return 0, nil, adminutil.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to create new http request for topic '%s' operation", operation))
+	}
+
+	// Make The HTTP Request
+	response, err := c.httpClient.Do(request)
+	defer c.safeCloseHTTPResponseBody(response)
+	if err != nil {
+		logger.Error("HTTP Request To Sidecar Failed", zap.String("Method", method), zap.Error(err))
+		return 0, nil, adminutil.NewTopicError(sarama.ErrNetworkException, fmt.Sprintf("failed to make http request for topic '%s' operation", operation))
+	}
+
+	// Read The Response Body
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		logger.Warn("Failed To Parse Response Body", zap.Error(err))
+	}
+	return response.StatusCode, responseBody, nil
+}
+
 // Get The Expected Topics URL For The Custom Sidecar Implementation
 func (c *CustomAdminClient) sidecarTopicsUrl(topicName string) string {
 	topicsUrl := "http://" + custom.SidecarHost + ":" + custom.SidecarPort + custom.TopicsPath
@@ -214,27 +376,14 @@ func (c *CustomAdminClient) mapHttpResponse(operation string, response *http.Res
 	// Verify There Is A Response
 	if response != nil {
 
-		// Get The Response's Status Code
-		statusCode := response.StatusCode
-
-		// Read The Response Body & Convert To String
+		// Read The Response Body
 		responseBodyBytes, err := ioutil.ReadAll(response.Body)
 		if err != nil {
 			c.logger.Warn("Failed To Parse Response Body", zap.Error(err))
 		}
-		responseBodyString := string(responseBodyBytes)
-
-		// Separate Success & Error Response Codes
-		switch {
-		case statusCode >= 200 && statusCode <= 299:
-			return adminutil.NewTopicError(sarama.ErrNoError, fmt.Sprintf("custom sidecar topic '%s' operation succeeded with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		case statusCode == 404 && operation == "delete": // 404 Not Found Indicates Topic Does Not Exist In Delete Operation
-			return adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		case statusCode == 409 && operation == "create": // 409 Conflict Indicates Topic Already Exists In Create Operation
-			return adminutil.NewTopicError(sarama.ErrTopicAlreadyExists, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		default:
-			return adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("custom sidecar topic '%s' operation failed with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		}
+
+		// Map The Response's Status Code & Body
+		return c.mapHttpStatus(operation, response.StatusCode, responseBodyBytes)
 
 	} else {
 
@@ -242,3 +391,22 @@ func (c *CustomAdminClient) mapHttpResponse(operation string, response *http.Res
 		return adminutil.NewTopicError(sarama.ErrUnknown, "received nil http response")
 	}
 }
+
+// Utility Function For Mapping The Response Code & Body To Sarama TopicError Struct (See mapHttpResponse)
+func (c *CustomAdminClient) mapHttpStatus(operation string, statusCode int, responseBodyBytes []byte) *sarama.TopicError {
+
+	// Convert The Response Body To String
+	responseBodyString := string(responseBodyBytes)
+
+	// Separate Success & Error Response Codes
+	switch {
+	case statusCode >= 200 && statusCode <= 299:
+		return adminutil.NewTopicError(sarama.ErrNoError, fmt.Sprintf("custom sidecar topic '%s' operation succeeded with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	case statusCode == 404 && (operation == "delete" || operation == "describe" || operation == "update"): // 404 Not Found Indicates Topic Does Not Exist In Single Topic Operations
+		return adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	case statusCode == 409 && operation == "create": // 409 Conflict Indicates Topic Already Exists In Create Operation
+		return adminutil.NewTopicError(sarama.ErrTopicAlreadyExists, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	default:
+		return adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("custom sidecar topic '%s' operation failed with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 
 	"k8s.io/client-go/kubernetes/fake"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom"
@@ -131,7 +132,7 @@ func TestCustomAdminClientCreateTopic(t *testing.T) {
 	assert.NotNil(t, adminClient)
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
 
 	// Verify The Results
 	assert.NotNil(t, resultTopicError)
@@ -181,6 +182,96 @@ func TestCustomAdminClientDeleteTopic(t *testing.T) {
 	}
 }
 
+// Test The Custom AdminClient DescribeTopic(), ListTopics() & UpdateTopic() Functionality
+func TestCustomAdminClientDescribeListUpdateTopic(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopicName"
+	retentionMillis := "86400000"
+	updatedRetentionMillis := "172800000"
+
+	// Create & Start The Reference Sidecar HTTP Server With A Test Topic & Defer Close
+	backend := custom.NewMemoryBackend()
+	err := backend.CreateTopic(context.TODO(), topicName, custom.NewTopicDetail(2, 1, nil, map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis}))
+	assert.Nil(t, err)
+	referenceSidecarServer := newReferenceSidecarServer(t, backend)
+	referenceSidecarServer.Start()
+	defer referenceSidecarServer.Close()
+
+	// Create A New Custom AdminClient To Test
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.TODO(), logger)
+	adminClient := &CustomAdminClient{logger: logger.Desugar(), httpClient: &http.Client{Timeout: custom.SidecarTimeout}}
+
+	// Verify The Topic Is Described & Listed
+	topicDetail, topicError := adminClient.DescribeTopic(ctx, topicName)
+	assert.Nil(t, topicError)
+	assert.NotNil(t, topicDetail)
+	assert.Equal(t, int32(2), topicDetail.NumPartitions)
+	assert.Equal(t, retentionMillis, *topicDetail.ConfigEntries[constants.TopicDetailConfigRetentionMs])
+	topicDetails, topicError := adminClient.ListTopics(ctx)
+	assert.Nil(t, topicError)
+	assert.Len(t, topicDetails, 1)
+	assert.Equal(t, *topicDetail, topicDetails[topicName])
+
+	// Update The Topic & Verify The Updated Description
+	topicError = adminClient.UpdateTopic(ctx, topicName, &custom.TopicUpdate{
+		NumPartitions: 4,
+		ConfigEntries: map[string]*string{constants.TopicDetailConfigRetentionMs: &updatedRetentionMillis},
+	})
+	assert.NotNil(t, topicError)
+	assert.Equal(t, sarama.ErrNoError, topicError.Err)
+	topicDetail, topicError = adminClient.DescribeTopic(ctx, topicName)
+	assert.Nil(t, topicError)
+	assert.Equal(t, int32(4), topicDetail.NumPartitions)
+	assert.Equal(t, updatedRetentionMillis, *topicDetail.ConfigEntries[constants.TopicDetailConfigRetentionMs])
+
+	// Verify Decreasing The Partitions Is Rejected
+	topicError = adminClient.UpdateTopic(ctx, topicName, &custom.TopicUpdate{NumPartitions: 1})
+	assert.NotNil(t, topicError)
+	assert.Equal(t, sarama.ErrInvalidRequest, topicError.Err)
+
+	// Verify The Unknown Topic Is Not Found
+	topicDetail, topicError = adminClient.DescribeTopic(ctx, "UnknownTopicName")
+	assert.Nil(t, topicDetail)
+	assert.NotNil(t, topicError)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, topicError.Err)
+	topicError = adminClient.UpdateTopic(ctx, "UnknownTopicName", &custom.TopicUpdate{NumPartitions: 4})
+	assert.NotNil(t, topicError)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, topicError.Err)
+
+	// Verify The Invalid Requests Are Rejected Without Calling The Sidecar
+	topicDetail, topicError = adminClient.DescribeTopic(ctx, "")
+	assert.Nil(t, topicDetail)
+	assert.Equal(t, sarama.ErrInvalidRequest, topicError.Err)
+	topicError = adminClient.UpdateTopic(ctx, topicName, nil)
+	assert.Equal(t, sarama.ErrInvalidRequest, topicError.Err)
+}
+
+// Test The Custom AdminClient Health() Functionality
+func TestCustomAdminClientHealth(t *testing.T) {
+
+	// Create A New Custom AdminClient To Test
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.TODO(), logger)
+	adminClient := &CustomAdminClient{logger: logger.Desugar(), httpClient: &http.Client{Timeout: custom.SidecarTimeout}}
+
+	// Verify The Error Without A Sidecar
+	health, err := adminClient.Health(ctx)
+	assert.NotNil(t, err)
+	assert.Nil(t, health)
+
+	// Create & Start The Reference Sidecar HTTP Server & Defer Close
+	referenceSidecarServer := newReferenceSidecarServer(t, custom.NewMemoryBackend())
+	referenceSidecarServer.Start()
+	defer referenceSidecarServer.Close()
+
+	// Verify The Health Of The Sidecar
+	health, err = adminClient.Health(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, &custom.Health{Status: custom.HealthStatusOK, ProtocolVersion: custom.ProtocolVersion}, health)
+}
+
 // Test The Custom AdminClient Close() Functionality
 func TestCustomAdminClientClose(t *testing.T) {
 
@@ -277,11 +368,22 @@ func (s *MockSidecarServer) ServeHTTP(responseWriter http.ResponseWriter, reques
 	responseWriter.WriteHeader(s.statusCode)
 }
 
+// Create A Test HTTP Server Running The Reference Sidecar Implementation On The Expected Sidecar Host:Port
+func newReferenceSidecarServer(t *testing.T, backend custom.TopicBackend) *httptest.Server {
+	listener, err := net.Listen("tcp", custom.SidecarHost+":"+custom.SidecarPort)
+	assert.Nil(t, err)
+	assert.NotNil(t, listener)
+	server := httptest.NewUnstartedServer(custom.NewServer(zap.NewNop(), backend))
+	server.Listener = listener
+	return server
+}
+
 // Utility Function For Verifying The Inbound HTTP Request (What Is Sent To The Sidecar)
 func verifySidecarRequest(t *testing.T, request *http.Request, body []byte, topicName string, saramaTopicDetail *sarama.TopicDetail) {
 
 	// Verify Common Request Data
 	assert.Equal(t, custom.SidecarHost+":"+custom.SidecarPort, request.Host)
+	assert.Equal(t, custom.ProtocolVersion, request.Header.Get(custom.ProtocolVersionHeader))
 
 	// Verify Method Specific Request Data
 	switch request.Method {
@@ -30,9 +30,31 @@ var _ AdminClientInterface = &EventHubAdminClient{}
 
 // EventHub AdminClient Definition
 type EventHubAdminClient struct {
-	logger    *zap.Logger
-	namespace string
-	cache     eventhubcache.CacheInterface
+	logger      *zap.Logger
+	namespace   string
+	cache       eventhubcache.CacheInterface
+	provisioner eventhubcache.NamespaceProvisioner
+}
+
+// The Error Returned When No EventHub Can Be Created Because The Azure EventHub Namespaces Are Full, Wrapping The
+// Kafka TopicError It Maps To (ErrInvalidTxnState)
+type EventHubCapacityLimitError struct {
+	TopicError *sarama.TopicError
+}
+
+// Create A New EventHubCapacityLimitError With The Specified Message
+func NewEventHubCapacityLimitError(message string) *EventHubCapacityLimitError {
+	return &EventHubCapacityLimitError{TopicError: adminutil.NewTopicError(sarama.ErrInvalidTxnState, message)}
+}
+
+// The Message Of The Wrapped Kafka TopicError
+func (e *EventHubCapacityLimitError) Error() string {
+	return e.TopicError.Error()
+}
+
+// Unwrap The Kafka TopicError (For errors.As)
+func (e *EventHubCapacityLimitError) Unwrap() error {
+	return e.TopicError
 }
 
 // EventHub ErrorCode RegExp - For Extracting Azure ErrorCodes From Error Messages
@@ -43,8 +65,9 @@ var NewCacheWrapper = func(ctx context.Context, k8sNamespace string) eventhubcac
 	return eventhubcache.NewCache(ctx, k8sNamespace)
 }
 
-// Create A New Azure EventHub AdminClient Based On Kafka Secrets In The Specified K8S Namespace
-func NewEventHubAdminClient(ctx context.Context, namespace string) (AdminClientInterface, error) {
+// Create A New Azure EventHub AdminClient Based On Kafka Secrets In The Specified K8S Namespace, Provisioning New
+// Azure EventHub Namespaces Via The Optional NamespaceProvisioner When All Of Them Are Full
+func NewEventHubAdminClient(ctx context.Context, namespace string, provisioner eventhubcache.NamespaceProvisioner) (AdminClientInterface, error) {
 
 	// Get The Logger From The Context
 	logger := logging.FromContext(ctx).Desugar()
@@ -62,14 +85,15 @@ func NewEventHubAdminClient(ctx context.Context, namespace string) (AdminClientI
 	// Create And Return A New EventHub AdminClient With Namespace Cache
 	logger.Debug("Successfully Created New Azure EventHub AdminClient")
 	return &EventHubAdminClient{
-		logger:    logger,
-		namespace: namespace,
-		cache:     cache,
+		logger:      logger,
+		namespace:   namespace,
+		cache:       cache,
+		provisioner: provisioner,
 	}, nil
 }
 
 // Kafka AdminClient CreateTopics Implementation Using Azure EventHub API
-func (c *EventHubAdminClient) CreateTopic(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+func (c *EventHubAdminClient) CreateTopic(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) error {
 
 	// Extract The Kafka TopicSpecification Configuration
 	topicNumPartitions := topicDetail.NumPartitions
@@ -91,8 +115,18 @@ func (c *EventHubAdminClient) CreateTopic(ctx context.Context, topicName string,
 		if eventHubNamespace == nil {
 
 			// No EventHub Namespaces Found In Cache - Return Error
-			c.logger.Warn("Found No EventHub Namespace In Cache - Skipping Topic Creation", zap.String("Topic", topicName))
-			return adminutil.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("no azure eventhub namespaces in cache - unable to create EventHub '%s'", topicName))
+			capacity := c.cache.GetCapacity()
+			if len(capacity) == 0 {
+				c.logger.Warn("Found No EventHub Namespace In Cache - Skipping Topic Creation", zap.String("Topic", topicName))
+				return adminutil.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("no azure eventhub namespaces in cache - unable to create EventHub '%s'", topicName))
+			}
+
+			// All EventHub Namespaces Are Full - Attempt To Provision A New One Or Return Error
+			eventHubNamespace = c.provisionNamespace(ctx, len(capacity))
+			if eventHubNamespace == nil {
+				c.logger.Warn("All EventHub Namespaces Are Full - Skipping Topic Creation", zap.String("Topic", topicName), zap.Any("Capacity", capacity))
+				return NewEventHubCapacityLimitError(fmt.Sprintf("all %d azure eventhub namespaces reached their eventhub limit - unable to create EventHub '%s'", len(capacity), topicName))
+			}
 		}
 	}
 
@@ -113,8 +147,10 @@ func (c *EventHubAdminClient) CreateTopic(ctx context.Context, topicName string,
 		if errorCode == constants.EventHubErrorCodeConflict {
 			return adminutil.NewTopicError(sarama.ErrTopicAlreadyExists, "mapped from EventHubErrorCodeConflict")
 		} else if errorCode == constants.EventHubErrorCodeCapacityLimit {
-			c.logger.Warn("Failed To Create EventHub - Reached Capacity Limit", zap.Error(err))
-			return adminutil.NewTopicError(sarama.ErrInvalidTxnState, "mapped from EventHubErrorCodeCapacityLimit")
+			// Skip The Namespace Until One Of Its EventHubs Is Deleted (Its Count Or Limit May Be Off)
+			c.logger.Warn("Failed To Create EventHub - Reached Capacity Limit", zap.String("Namespace", eventHubNamespace.Name), zap.Error(err))
+			c.cache.MarkNamespaceFull(eventHubNamespace)
+			return NewEventHubCapacityLimitError("mapped from EventHubErrorCodeCapacityLimit")
 		} else if errorCode == constants.EventHubErrorCodeUnknown {
 			c.logger.Error("Failed To Create EventHub - Missing Error Code", zap.Error(err))
 			return adminutil.NewUnknownTopicError("mapped from EventHubErrorCodeUnknown")
@@ -191,6 +227,41 @@ func (c *EventHubAdminClient) Close() error {
 	return nil // Nothing to "close" in the HubManager (just a REST client) so this is just a compatibility no-op.
 }
 
+// Provision A New Azure EventHub Namespace Via The NamespaceProvisioner, If Any, & Add It To The Cache
+func (c *EventHubAdminClient) provisionNamespace(ctx context.Context, namespaceCount int) *eventhubcache.Namespace {
+
+	// Nothing To Do Without NamespaceProvisioner Or Once At The Azure Limit
+	if c.provisioner == nil {
+		return nil
+	}
+	if namespaceCount >= constants.MaxEventHubNamespaces {
+		c.logger.Warn("Reached The Maximum Number Of EventHub Namespaces - Skipping Namespace Provisioning", zap.Int("Namespaces", namespaceCount))
+		return nil
+	}
+
+	// Provision The New Namespace & Its Kafka Secret
+	kafkaSecret, err := c.provisioner.Provision(ctx, c.namespace)
+	if err != nil {
+		c.logger.Error("Failed To Provision A New EventHub Namespace", zap.Error(err))
+		return nil
+	}
+
+	// Add The New Namespace To The Cache
+	namespace, err := c.cache.AddNamespace(ctx, kafkaSecret)
+	if err != nil {
+		c.logger.Error("Failed To Add The Provisioned EventHub Namespace To The Cache", zap.String("Secret", kafkaSecret.Name), zap.Error(err))
+		return nil
+	}
+	if namespace.IsFull() {
+		c.logger.Warn("Provisioned EventHub Namespace Is Already Full", zap.String("Namespace", namespace.Name))
+		return nil
+	}
+
+	// Return The New Namespace
+	c.logger.Info("Successfully Provisioned A New EventHub Namespace", zap.String("Namespace", namespace.Name), zap.String("Secret", kafkaSecret.Name))
+	return namespace
+}
+
 // Utility Function For Converting Millis To Days (Rounded Up To Larger Day Value)
 func convertMillisToDays(millis int64) int32 {
 	return int32(math.Ceil(float64(millis) / float64(constants.MillisPerDay)))
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"testing"
@@ -10,6 +11,8 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/eventhubcache"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 	logtesting "knative.dev/pkg/logging/testing"
@@ -36,11 +39,13 @@ func TestNewEventHubAdminClientSuccess(t *testing.T) {
 	defer func() { NewCacheWrapper = newCacheWrapperPlaceholder }()
 
 	// Perform The Test
-	adminClient, err := NewEventHubAdminClient(ctx, namespace)
+	provisioner := &MockNamespaceProvisioner{}
+	adminClient, err := NewEventHubAdminClient(ctx, namespace, provisioner)
 
 	// Verify The Results
 	assert.Nil(t, err)
 	assert.NotNil(t, adminClient)
+	assert.Equal(t, provisioner, adminClient.(*EventHubAdminClient).provisioner)
 	mockCache.AssertExpectations(t)
 }
 
@@ -65,7 +70,7 @@ func TestNewEventHubAdminClientError(t *testing.T) {
 	defer func() { NewCacheWrapper = newCacheWrapperPlaceholder }()
 
 	// Perform The Test
-	adminClient, err := NewEventHubAdminClient(ctx, namespace)
+	adminClient, err := NewEventHubAdminClient(ctx, namespace, nil)
 
 	// Verify The Results
 	assert.NotNil(t, err)
@@ -110,7 +115,7 @@ func TestEventHubAdminClientCreateTopicSuccess(t *testing.T) {
 	adminClient := &EventHubAdminClient{logger: logger, cache: mockCache}
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
 
 	// Verify The Results
 	assert.NotNil(t, resultTopicError)
@@ -142,7 +147,7 @@ func TestEventHubAdminClientCreateTopicInvalidConfig(t *testing.T) {
 	adminClient := &EventHubAdminClient{logger: logger}
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
 
 	// Verify The Results
 	assert.NotNil(t, resultTopicError)
@@ -165,6 +170,7 @@ func TestEventHubAdminClientCreateTopicNoNamespace(t *testing.T) {
 	mockCache := &MockCache{}
 	mockCache.On("GetNamespace", topicName).Return(nil)
 	mockCache.On("GetLeastPopulatedNamespace").Return(nil)
+	mockCache.On("GetCapacity").Return(nil)
 
 	// Create The Sarama TopicDetail For The Topic/EventHub To Be Created
 	topicDetail := &sarama.TopicDetail{
@@ -179,7 +185,7 @@ func TestEventHubAdminClientCreateTopicNoNamespace(t *testing.T) {
 	adminClient := &EventHubAdminClient{logger: logger, cache: mockCache}
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
 
 	// Verify The Results
 	assert.NotNil(t, resultTopicError)
@@ -220,7 +226,7 @@ func TestEventHubAdminClientCreateTopicNoHubManager(t *testing.T) {
 	adminClient := &EventHubAdminClient{logger: logger, cache: mockCache}
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
 
 	// Verify The Results
 	assert.NotNil(t, resultTopicError)
@@ -266,7 +272,7 @@ func TestEventHubAdminClientCreateTopicAlreadyExists(t *testing.T) {
 	adminClient := &EventHubAdminClient{logger: logger, cache: mockCache}
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
 
 	// Verify The Results
 	assert.NotNil(t, resultTopicError)
@@ -295,10 +301,11 @@ func TestEventHubAdminClientCreateTopicCapacityLimit(t *testing.T) {
 	// Create A Namespace With The Mock HubManager
 	namespace := &eventhubcache.Namespace{HubManager: mockHubManager}
 
-	// Create A Mock EventHub Cache
+	// Create A Mock EventHub Cache Expecting The Namespace To Be Marked Full
 	mockCache := &MockCache{}
 	mockCache.On("GetNamespace", topicName).Return(nil)
 	mockCache.On("GetLeastPopulatedNamespace").Return(namespace)
+	mockCache.On("MarkNamespaceFull", namespace).Return()
 
 	// Create The Sarama TopicDetail For The Topic/EventHub To Be Created
 	topicDetail := &sarama.TopicDetail{
@@ -313,9 +320,12 @@ func TestEventHubAdminClientCreateTopicCapacityLimit(t *testing.T) {
 	adminClient := &EventHubAdminClient{logger: logger, cache: mockCache}
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	err := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, err)
 
 	// Verify The Results
+	var capacityLimitError *EventHubCapacityLimitError
+	assert.True(t, errors.As(err, &capacityLimitError))
 	assert.NotNil(t, resultTopicError)
 	assert.Equal(t, sarama.ErrInvalidTxnState, resultTopicError.Err)
 	assert.Equal(t, "mapped from EventHubErrorCodeCapacityLimit", *resultTopicError.ErrMsg)
@@ -323,6 +333,99 @@ func TestEventHubAdminClientCreateTopicCapacityLimit(t *testing.T) {
 	mockCache.AssertExpectations(t)
 }
 
+// Test The EventHub AdminClient CreateTopic() Functionality - Full Namespaces Path
+func TestEventHubAdminClientCreateTopicFullNamespaces(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	topicRetentionMillisString := strconv.FormatInt(int64(3*constants.MillisPerDay), 10)
+	capacity := []eventhubcache.NamespaceCapacity{
+		{Name: "TestNamespace1", Tier: constants.EventHubTierStandard, Count: 10, Limit: 10, Full: true},
+		{Name: "TestNamespace2", Tier: constants.EventHubTierBasic, Count: 10, Limit: 10, Full: true},
+	}
+
+	// Create A Mock EventHub Cache With Full Namespaces
+	mockCache := &MockCache{}
+	mockCache.On("GetNamespace", topicName).Return(nil)
+	mockCache.On("GetLeastPopulatedNamespace").Return(nil)
+	mockCache.On("GetCapacity").Return(capacity)
+
+	// Create The Sarama TopicDetail For The Topic/EventHub To Be Created
+	topicDetail := &sarama.TopicDetail{
+		NumPartitions: 4,
+		ConfigEntries: map[string]*string{constants.TopicDetailConfigRetentionMs: &topicRetentionMillisString},
+	}
+
+	// Create A New EventHub AdminClient With Mock Cache & Without NamespaceProvisioner To Test
+	adminClient := &EventHubAdminClient{logger: logtesting.TestLogger(t).Desugar(), cache: mockCache}
+
+	// Perform The Test
+	err := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, err)
+
+	// Verify The Results
+	var capacityLimitError *EventHubCapacityLimitError
+	assert.True(t, errors.As(err, &capacityLimitError))
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrInvalidTxnState, resultTopicError.Err)
+	assert.Equal(t, "all 2 azure eventhub namespaces reached their eventhub limit - unable to create EventHub 'TestTopicName'", *resultTopicError.ErrMsg)
+	mockCache.AssertExpectations(t)
+
+	// Verify The NamespaceProvisioner Errors Are Returned As Capacity Limit Errors
+	adminClient.provisioner = &MockNamespaceProvisioner{err: fmt.Errorf("expected test error")}
+	err = adminClient.CreateTopic(ctx, topicName, topicDetail)
+	assert.True(t, errors.As(err, &capacityLimitError))
+	assert.Equal(t, 1, adminClient.provisioner.(*MockNamespaceProvisioner).calls)
+}
+
+// Test The EventHub AdminClient CreateTopic() Functionality - Provisioned Namespace Path
+func TestEventHubAdminClientCreateTopicProvisionedNamespace(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	k8sNamespace := "TestK8SNamespace"
+	topicName := "TestTopicName"
+	topicRetentionMillisString := strconv.FormatInt(int64(3*constants.MillisPerDay), 10)
+	kafkaSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "TestKafkaSecret", Namespace: k8sNamespace}}
+
+	// Create A Mock HubManager
+	mockHubManager := &MockHubManager{}
+	mockHubManager.On("Put", ctx, topicName, mock.Anything).Return(nil, nil)
+
+	// Create A Namespace With The Mock HubManager
+	namespace := &eventhubcache.Namespace{Name: "TestNamespace2", HubManager: mockHubManager, Limit: 10}
+
+	// Create A Mock EventHub Cache With A Full Namespace
+	mockCache := &MockCache{}
+	mockCache.On("GetNamespace", topicName).Return(nil)
+	mockCache.On("GetLeastPopulatedNamespace").Return(nil)
+	mockCache.On("GetCapacity").Return([]eventhubcache.NamespaceCapacity{{Name: "TestNamespace1", Count: 10, Limit: 10, Full: true}})
+	mockCache.On("AddNamespace", ctx, kafkaSecret).Return(namespace, nil)
+	mockCache.On("AddEventHub", ctx, topicName, namespace).Return()
+
+	// Create The Sarama TopicDetail For The Topic/EventHub To Be Created
+	topicDetail := &sarama.TopicDetail{
+		NumPartitions: 4,
+		ConfigEntries: map[string]*string{constants.TopicDetailConfigRetentionMs: &topicRetentionMillisString},
+	}
+
+	// Create A New EventHub AdminClient With Mock Cache & NamespaceProvisioner To Test
+	provisioner := &MockNamespaceProvisioner{secret: kafkaSecret}
+	adminClient := &EventHubAdminClient{logger: logtesting.TestLogger(t).Desugar(), namespace: k8sNamespace, cache: mockCache, provisioner: provisioner}
+
+	// Perform The Test
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	assert.Equal(t, 1, provisioner.calls)
+	assert.Equal(t, k8sNamespace, provisioner.k8sNamespace)
+	mockHubManager.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
 // Test The EventHub AdminClient CreateTopic() Functionality - Error Path
 func TestEventHubAdminClientCreateTopicError(t *testing.T) {
 
@@ -360,7 +463,7 @@ func TestEventHubAdminClientCreateTopicError(t *testing.T) {
 	adminClient := &EventHubAdminClient{logger: logger, cache: mockCache}
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
 
 	// Verify The Results
 	assert.NotNil(t, resultTopicError)
@@ -568,6 +671,10 @@ func (m *MockCache) RemoveEventHub(ctx context.Context, eventhub string) {
 	m.Called(ctx, eventhub)
 }
 
+func (m *MockCache) MarkNamespaceFull(namespace *eventhubcache.Namespace) {
+	m.Called(namespace)
+}
+
 func (m *MockCache) GetNamespace(eventhub string) *eventhubcache.Namespace {
 	args := m.Called(eventhub)
 	response := args.Get(0)
@@ -578,6 +685,26 @@ func (m *MockCache) GetNamespace(eventhub string) *eventhubcache.Namespace {
 	}
 }
 
+func (m *MockCache) AddNamespace(ctx context.Context, kafkaSecret *corev1.Secret) (*eventhubcache.Namespace, error) {
+	args := m.Called(ctx, kafkaSecret)
+	response := args.Get(0)
+	if response == nil {
+		return nil, args.Error(1)
+	} else {
+		return response.(*eventhubcache.Namespace), args.Error(1)
+	}
+}
+
+func (m *MockCache) GetCapacity() []eventhubcache.NamespaceCapacity {
+	args := m.Called()
+	response := args.Get(0)
+	if response == nil {
+		return nil
+	} else {
+		return response.([]eventhubcache.NamespaceCapacity)
+	}
+}
+
 func (m *MockCache) GetLeastPopulatedNamespace() *eventhubcache.Namespace {
 	args := m.Called()
 	response := args.Get(0)
@@ -588,6 +715,27 @@ func (m *MockCache) GetLeastPopulatedNamespace() *eventhubcache.Namespace {
 	}
 }
 
+//
+// Mock EventHub NamespaceProvisioner
+//
+
+// Ensure The Mock NamespaceProvisioner Implements The Interface
+var _ eventhubcache.NamespaceProvisioner = &MockNamespaceProvisioner{}
+
+// The Mock EventHub NamespaceProvisioner Returning The Kafka Secret Or Error
+type MockNamespaceProvisioner struct {
+	secret       *corev1.Secret
+	err          error
+	calls        int
+	k8sNamespace string
+}
+
+func (m *MockNamespaceProvisioner) Provision(_ context.Context, k8sNamespace string) (*corev1.Secret, error) {
+	m.calls++
+	m.k8sNamespace = k8sNamespace
+	return m.secret, m.err
+}
+
 //
 // Mock EventHub HubManager
 //
@@ -73,6 +73,13 @@ func NewKafkaAdminClient(ctx context.Context, saramaConfig *sarama.Config, clien
 	// Update The Sarama ClusterAdmin Configuration With Our Values
 	kafkasarama.UpdateSaramaConfig(saramaConfig, clientId, username, password)
 
+	// Configure The Optional TLS Certificates (Mutual TLS Authentication)
+	err = kafkasarama.UpdateSaramaTLSConfig(saramaConfig, kafkasarama.TLSCertificatesFromSecret(&kafkaSecret))
+	if err != nil {
+		logger.Error("Failed To Configure The Kafka Secret TLS Certificates", zap.Error(err))
+		return nil, err
+	}
+
 	// Create A New Sarama ClusterAdmin
 	clusterAdmin, err := NewClusterAdminWrapper(brokers, saramaConfig)
 	if err != nil {
@@ -100,13 +107,14 @@ var NewClusterAdminWrapper = func(brokers []string, config *sarama.Config) (sara
 }
 
 // Sarama Pass-Through Function For Creating Topics
-func (k KafkaAdminClient) CreateTopic(_ context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+func (k KafkaAdminClient) CreateTopic(_ context.Context, topicName string, topicDetail *sarama.TopicDetail) error {
 	if k.clusterAdmin == nil {
 		k.logger.Error("Unable To Create Topic Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
 		return adminutil.NewUnknownTopicError("unable to create topic due to invalid ClusterAdmin - check Kafka authorization secrets")
-	} else {
-		err := k.clusterAdmin.CreateTopic(topicName, topicDetail, false)
+	} else if err := k.clusterAdmin.CreateTopic(topicName, topicDetail, false); err != nil {
 		return adminutil.PromoteErrorToTopicError(err)
+	} else {
+		return nil // Not A Nil *sarama.TopicError Which Would Be A Non-Nil error
 	}
 }
 
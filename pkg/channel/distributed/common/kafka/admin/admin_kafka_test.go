@@ -140,7 +140,7 @@ func TestKafkaAdminClientCreateTopic(t *testing.T) {
 	}
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
 
 	// Verify The Results
 	assert.NotNil(t, resultTopicError)
@@ -176,7 +176,7 @@ func TestKafkaAdminClientCreateTopicInvalidAdminClient(t *testing.T) {
 	adminClient := &KafkaAdminClient{logger: logger}
 
 	// Perform The Test
-	resultTopicError := adminClient.CreateTopic(ctx, topicName, topicDetail)
+	resultTopicError := asTopicError(t, adminClient.CreateTopic(ctx, topicName, topicDetail))
 
 	// Verify The Results
 	assert.NotNil(t, resultTopicError)
@@ -2,11 +2,13 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/eventhubcache"
 	commontesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/testing"
 )
 
@@ -46,16 +48,18 @@ func TestCreateAdminClientKafka(t *testing.T) {
 func TestCreateAdminClientEventHub(t *testing.T) {
 
 	// Test Data
-	ctx := context.TODO()
+	provisioner := &MockNamespaceProvisioner{}
+	ctx := eventhubcache.WithNamespaceProvisioner(context.TODO(), provisioner)
 	clientId := "TestClientId"
 	adminClientType := EventHub
 	mockAdminClient = &MockAdminClient{}
 
 	// Replace the NewEventHubAdminClientWrapper To Provide Mock AdminClient & Defer Reset
 	NewEventHubAdminClientWrapperRef := NewEventHubAdminClientWrapper
-	NewEventHubAdminClientWrapper = func(ctxArg context.Context, namespaceArg string) (AdminClientInterface, error) {
+	NewEventHubAdminClientWrapper = func(ctxArg context.Context, namespaceArg string, provisionerArg eventhubcache.NamespaceProvisioner) (AdminClientInterface, error) {
 		assert.Equal(t, ctx, ctxArg)
 		assert.Equal(t, constants.KnativeEventingNamespace, namespaceArg)
+		assert.Equal(t, provisioner, provisionerArg)
 		assert.Equal(t, adminClientType, adminClientType)
 		return mockAdminClient, nil
 	}
@@ -121,6 +125,15 @@ func TestCreateAdminClientUnknown(t *testing.T) {
 
 var _ AdminClientInterface = &MockAdminClient{}
 
+// Utility Function For Getting The Sarama TopicError Returned By CreateTopic() (Nil If None)
+func asTopicError(t *testing.T, err error) *sarama.TopicError {
+	var topicError *sarama.TopicError
+	if err != nil {
+		assert.True(t, errors.As(err, &topicError))
+	}
+	return topicError
+}
+
 type MockAdminClient struct {
 	kafkaSecret string
 }
@@ -129,7 +142,7 @@ func (c MockAdminClient) GetKafkaSecretName(string) string {
 	return c.kafkaSecret
 }
 
-func (c MockAdminClient) CreateTopic(context.Context, string, *sarama.TopicDetail) *sarama.TopicError {
+func (c MockAdminClient) CreateTopic(context.Context, string, *sarama.TopicDetail) error {
 	return nil
 }
 
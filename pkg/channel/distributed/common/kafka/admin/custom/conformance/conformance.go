@@ -0,0 +1,213 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom"
+)
+
+//
+// Custom Sidecar Conformance Test Harness
+//
+// This validates that a running custom sidecar adheres to the sidecar protocol expected by the eventing-kafka
+// "custom" AdminClient.  Sidecar implementers can call it from their own tests, against their sidecar started
+// with a test (e.g. local or in-memory) Kafka, for example...
+//
+//   func TestConformance(t *testing.T) {
+//       server := httptest.NewServer(mySidecarHandler)
+//       defer server.Close()
+//       conformance.Run(t, server.URL)
+//   }
+//
+// The harness creates, updates and deletes a uniquely named Topic (see TopicName) with a single partition, so the
+// sidecar's backend must allow it.
+//
+
+// The Prefix Of The Name Of The Topic Used By The Conformance Tests
+const TopicNamePrefix = "eventing-kafka-conformance-"
+
+// The Timeout Of The Requests To The Sidecar
+var RequestTimeout = custom.SidecarTimeout
+
+// Get A Unique Topic Name For The Conformance Tests
+func TopicName() string {
+	return TopicNamePrefix + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+// Run The Conformance Tests Against The Sidecar Serving The Specified Base URL (e.g. http://localhost:8888)
+func Run(t *testing.T, sidecarURL string) {
+
+	// The Test Topic
+	topicName := TopicName()
+	retentionMillis := "86400000"
+	topicDetail := &custom.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: 1,
+		ConfigEntries:     map[string]*string{"retention.ms": &retentionMillis},
+	}
+
+	// The Client Sending The Requests
+	client := &sidecarClient{baseURL: sidecarURL, httpClient: &http.Client{Timeout: RequestTimeout}}
+
+	// The Conformance Tests, In Order (Each Relying Upon The Previous Ones)
+	t.Run("health", func(t *testing.T) {
+		statusCode, body := client.do(t, http.MethodGet, custom.HealthPath, nil, nil)
+		expectStatusCode(t, http.StatusOK, statusCode, body)
+		health := &custom.Health{}
+		if err := json.Unmarshal(body, health); err != nil {
+			t.Fatalf("expected a JSON Health body, got '%s': %v", body, err)
+		}
+		if health.Status != custom.HealthStatusOK || health.ProtocolVersion != custom.ProtocolVersion {
+			t.Errorf("expected the status '%s' and the protocol version '%s', got %+v", custom.HealthStatusOK, custom.ProtocolVersion, health)
+		}
+	})
+
+	t.Run("create", func(t *testing.T) {
+		statusCode, body := client.do(t, http.MethodPost, custom.TopicsPath, map[string]string{custom.TopicNameHeader: topicName}, topicDetail)
+		expectSuccess(t, statusCode, body)
+	})
+
+	t.Run("create existing", func(t *testing.T) {
+		statusCode, body := client.do(t, http.MethodPost, custom.TopicsPath, map[string]string{custom.TopicNameHeader: topicName}, topicDetail)
+		expectStatusCode(t, http.StatusConflict, statusCode, body)
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		actual := client.describe(t, topicName)
+		if actual.NumPartitions != topicDetail.NumPartitions {
+			t.Errorf("expected %d partitions, got %d", topicDetail.NumPartitions, actual.NumPartitions)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		statusCode, body := client.do(t, http.MethodGet, custom.TopicsPath, nil, nil)
+		expectStatusCode(t, http.StatusOK, statusCode, body)
+		topics := make(map[string]custom.TopicDetail)
+		if err := json.Unmarshal(body, &topics); err != nil {
+			t.Fatalf("expected a JSON map of the TopicDetails by name, got '%s': %v", body, err)
+		}
+		if _, ok := topics[topicName]; !ok {
+			t.Errorf("expected the topic '%s' to be listed", topicName)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		retentionMillis := "172800000"
+		update := &custom.TopicUpdate{NumPartitions: 2, ConfigEntries: map[string]*string{"retention.ms": &retentionMillis}}
+		statusCode, body := client.do(t, http.MethodPatch, custom.TopicsPath+"/"+topicName, nil, update)
+		expectSuccess(t, statusCode, body)
+		actual := client.describe(t, topicName)
+		if actual.NumPartitions != 2 {
+			t.Errorf("expected 2 partitions after the update, got %d", actual.NumPartitions)
+		}
+		if value := actual.ConfigEntries["retention.ms"]; value == nil || *value != retentionMillis {
+			t.Errorf("expected the updated retention.ms config entry '%s', got %v", retentionMillis, value)
+		}
+	})
+
+	t.Run("decrease partitions", func(t *testing.T) {
+		statusCode, body := client.do(t, http.MethodPatch, custom.TopicsPath+"/"+topicName, nil, &custom.TopicUpdate{NumPartitions: 1})
+		expectStatusCode(t, http.StatusBadRequest, statusCode, body)
+	})
+
+	t.Run("unsupported protocol version", func(t *testing.T) {
+		statusCode, body := client.do(t, http.MethodGet, custom.TopicsPath+"/"+topicName, map[string]string{custom.ProtocolVersionHeader: "v0"}, nil)
+		expectStatusCode(t, http.StatusBadRequest, statusCode, body)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		statusCode, body := client.do(t, http.MethodDelete, custom.TopicsPath+"/"+topicName, nil, nil)
+		expectSuccess(t, statusCode, body)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		statusCode, body := client.do(t, http.MethodGet, custom.TopicsPath+"/"+topicName, nil, nil)
+		expectStatusCode(t, http.StatusNotFound, statusCode, body)
+		statusCode, body = client.do(t, http.MethodPatch, custom.TopicsPath+"/"+topicName, nil, &custom.TopicUpdate{NumPartitions: 2})
+		expectStatusCode(t, http.StatusNotFound, statusCode, body)
+		statusCode, body = client.do(t, http.MethodDelete, custom.TopicsPath+"/"+topicName, nil, nil)
+		expectStatusCode(t, http.StatusNotFound, statusCode, body)
+	})
+}
+
+// The Client Sending The Protocol Requests To The Sidecar
+type sidecarClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Send The Request With The Headers & JSON Body (Unless nil), Returning The StatusCode & Body Of The Response
+func (c *sidecarClient) do(t *testing.T, method string, path string, headers map[string]string, body interface{}) (int, []byte) {
+	t.Helper()
+	var requestBody []byte
+	if body != nil {
+		var err error
+		if requestBody, err = json.Marshal(body); err != nil {
+			t.Fatalf("failed to marshal the request body: %v", err)
+		}
+	}
+	request, err := http.NewRequest(method, c.baseURL+path, bytes.NewReader(requestBody))
+	if err != nil {
+		t.Fatalf("failed to create the %s %s request: %v", method, path, err)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set(custom.ProtocolVersionHeader, custom.ProtocolVersion)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		t.Fatalf("failed to send the %s %s request: %v", method, path, err)
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("failed to read the %s %s response: %v", method, path, err)
+	}
+	if version := response.Header.Get(custom.ProtocolVersionHeader); version != custom.ProtocolVersion {
+		t.Errorf("expected the %s %s response to have the %s header '%s', got '%s'", method, path, custom.ProtocolVersionHeader, custom.ProtocolVersion, version)
+	}
+	return response.StatusCode, responseBody
+}
+
+// Describe The Topic, Which Must Exist
+func (c *sidecarClient) describe(t *testing.T, topicName string) *custom.TopicDetail {
+	t.Helper()
+	statusCode, body := c.do(t, http.MethodGet, custom.TopicsPath+"/"+topicName, nil, nil)
+	expectStatusCode(t, http.StatusOK, statusCode, body)
+	topicDetail := &custom.TopicDetail{}
+	if err := json.Unmarshal(body, topicDetail); err != nil {
+		t.Fatalf("expected a JSON TopicDetail body, got '%s': %v", body, err)
+	}
+	return topicDetail
+}
+
+// Expect A 2XX StatusCode
+func expectSuccess(t *testing.T, statusCode int, body []byte) {
+	t.Helper()
+	if statusCode < 200 || statusCode > 299 {
+		t.Fatal(unexpectedStatusCode("2XX", statusCode, body))
+	}
+}
+
+// Expect The Specified StatusCode
+func expectStatusCode(t *testing.T, expected int, statusCode int, body []byte) {
+	t.Helper()
+	if statusCode != expected {
+		t.Fatal(unexpectedStatusCode(strconv.Itoa(expected), statusCode, body))
+	}
+}
+
+// Describe The Unexpected StatusCode
+func unexpectedStatusCode(expected string, statusCode int, body []byte) string {
+	return fmt.Sprintf("expected the status code %s, got %d with the body '%s'", expected, statusCode, body)
+}
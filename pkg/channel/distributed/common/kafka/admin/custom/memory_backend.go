@@ -0,0 +1,139 @@
+package custom
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+//
+// In-Memory TopicBackend
+//
+// This TopicBackend keeps the Topics in memory without creating anything in Kafka.  It documents the expected
+// behavior of the Topic operations (as validated by the conformance package), and can be used to stub the sidecar
+// in tests.
+//
+
+// Verify The MemoryBackend Implements The TopicBackend Interface
+var _ TopicBackend = &MemoryBackend{}
+
+// In-Memory TopicBackend Struct
+type MemoryBackend struct {
+	mutex  sync.Mutex
+	topics map[string]TopicDetail
+}
+
+// In-Memory TopicBackend Constructor
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{topics: make(map[string]TopicDetail)}
+}
+
+// Create The Topic Unless It Already Exists
+func (b *MemoryBackend) CreateTopic(_ context.Context, topicName string, topicDetail *TopicDetail) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.topics[topicName]; ok {
+		return fmt.Errorf("%w: '%s'", ErrTopicAlreadyExists, topicName)
+	}
+	if topicDetail.NumPartitions <= 0 {
+		return fmt.Errorf("%w: '%s' must have at least one partition", ErrInvalidTopic, topicName)
+	}
+	b.topics[topicName] = copyTopicDetail(*topicDetail)
+	return nil
+}
+
+// Delete The Existing Topic
+func (b *MemoryBackend) DeleteTopic(_ context.Context, topicName string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.topics[topicName]; !ok {
+		return fmt.Errorf("%w: '%s'", ErrTopicNotFound, topicName)
+	}
+	delete(b.topics, topicName)
+	return nil
+}
+
+// Describe The Existing Topic
+func (b *MemoryBackend) DescribeTopic(_ context.Context, topicName string) (*TopicDetail, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	topicDetail, ok := b.topics[topicName]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrTopicNotFound, topicName)
+	}
+	topicDetail = copyTopicDetail(topicDetail)
+	return &topicDetail, nil
+}
+
+// List The Topics By Name
+func (b *MemoryBackend) ListTopics(_ context.Context) (map[string]TopicDetail, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	topics := make(map[string]TopicDetail, len(b.topics))
+	for topicName, topicDetail := range b.topics {
+		topics[topicName] = copyTopicDetail(topicDetail)
+	}
+	return topics, nil
+}
+
+// Update The Partitions And / Or The Config Entries Of The Existing Topic
+func (b *MemoryBackend) UpdateTopic(_ context.Context, topicName string, topicUpdate *TopicUpdate) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	topicDetail, ok := b.topics[topicName]
+	if !ok {
+		return fmt.Errorf("%w: '%s'", ErrTopicNotFound, topicName)
+	}
+	if topicUpdate.NumPartitions != 0 && topicUpdate.NumPartitions < topicDetail.NumPartitions {
+		return fmt.Errorf("%w: the partitions of '%s' cannot be decreased from %d to %d", ErrInvalidTopic, topicName, topicDetail.NumPartitions, topicUpdate.NumPartitions)
+	}
+	topicDetail = copyTopicDetail(topicDetail)
+	if topicUpdate.NumPartitions != 0 {
+		topicDetail.NumPartitions = topicUpdate.NumPartitions
+	}
+	for key, value := range topicUpdate.ConfigEntries {
+		if topicDetail.ConfigEntries == nil {
+			topicDetail.ConfigEntries = make(map[string]*string)
+		}
+		if value == nil {
+			delete(topicDetail.ConfigEntries, key)
+		} else {
+			topicDetail.ConfigEntries[key] = copyString(value)
+		}
+	}
+	b.topics[topicName] = topicDetail
+	return nil
+}
+
+// The In-Memory TopicBackend Is Always Healthy
+func (b *MemoryBackend) Healthy(_ context.Context) error {
+	return nil
+}
+
+// Utility Function For Deep Copying A TopicDetail
+func copyTopicDetail(topicDetail TopicDetail) TopicDetail {
+	if topicDetail.ReplicaAssignment != nil {
+		replicaAssignment := make(map[int32][]int32, len(topicDetail.ReplicaAssignment))
+		for partition, replicas := range topicDetail.ReplicaAssignment {
+			replicaAssignment[partition] = append([]int32(nil), replicas...)
+		}
+		topicDetail.ReplicaAssignment = replicaAssignment
+	}
+	if topicDetail.ConfigEntries != nil {
+		configEntries := make(map[string]*string, len(topicDetail.ConfigEntries))
+		for key, value := range topicDetail.ConfigEntries {
+			configEntries[key] = copyString(value)
+		}
+		topicDetail.ConfigEntries = configEntries
+	}
+	return topicDetail
+}
+
+// Utility Function For Copying A String Pointer
+func copyString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	valueCopy := *value
+	return &valueCopy
+}
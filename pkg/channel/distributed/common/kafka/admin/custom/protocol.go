@@ -0,0 +1,22 @@
+package custom
+
+//
+//  These Golang Types complete the TopicDetail for the other requests and
+//  responses of the "custom" AdminClient sidecar protocol (see the README
+//  of the common/kafka package for the full protocol).
+//
+
+// The Status Of A Healthy Sidecar
+const HealthStatusOK = "ok"
+
+// Custom TopicUpdate Struct - The Body Of The PATCH Request, Only The Specified Fields Are Updated
+type TopicUpdate struct {
+	NumPartitions int32              `json:"numPartitions,omitempty"` // The new number of partitions, which can only be increased
+	ConfigEntries map[string]*string `json:"configEntries,omitempty"` // The config entries to set, those with a nil value are removed
+}
+
+// Custom Health Struct - The Body Of The Health Response
+type Health struct {
+	Status          string `json:"status"`
+	ProtocolVersion string `json:"protocolVersion"`
+}
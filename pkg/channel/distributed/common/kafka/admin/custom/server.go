@@ -0,0 +1,180 @@
+package custom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+//
+// Custom Sidecar Reference Implementation
+//
+// This is a server-side implementation of the sidecar protocol which third-party implementers can use to build their
+// sidecar by only providing a TopicBackend performing the actual Topic operations.  It handles the routing, the
+// protocol version, the (un)marshalling of the JSON bodies and the mapping of the TopicBackend errors to the expected
+// HTTP StatusCodes.  For example...
+//
+//   http.ListenAndServe(":"+custom.SidecarPort, custom.NewServer(logger, myTopicBackend))
+//
+
+// Errors To Be Returned (Or Wrapped) By The TopicBackend & Mapped To The Expected HTTP StatusCodes
+var (
+	ErrTopicAlreadyExists = errors.New("topic already exists") // 409 Conflict
+	ErrTopicNotFound      = errors.New("topic not found")      // 404 Not Found
+	ErrInvalidTopic       = errors.New("invalid topic")        // 400 Bad Request
+)
+
+// The Topic Operations Of A Custom Sidecar
+type TopicBackend interface {
+	CreateTopic(ctx context.Context, topicName string, topicDetail *TopicDetail) error
+	DeleteTopic(ctx context.Context, topicName string) error
+	DescribeTopic(ctx context.Context, topicName string) (*TopicDetail, error)
+	ListTopics(ctx context.Context) (map[string]TopicDetail, error)
+	UpdateTopic(ctx context.Context, topicName string, topicUpdate *TopicUpdate) error
+	Healthy(ctx context.Context) error
+}
+
+// Custom Sidecar Server Struct (An http.Handler)
+type Server struct {
+	logger  *zap.Logger
+	backend TopicBackend
+}
+
+// Custom Sidecar Server Constructor
+func NewServer(logger *zap.Logger, backend TopicBackend) *Server {
+	return &Server{logger: logger, backend: backend}
+}
+
+// The HTTP Handler Interface Implementation
+func (s *Server) ServeHTTP(responseWriter http.ResponseWriter, request *http.Request) {
+
+	// Always Identify The Protocol Version & Reject The Unsupported Ones (No Version Is The Original v1 Protocol)
+	responseWriter.Header().Set(ProtocolVersionHeader, ProtocolVersion)
+	if version := request.Header.Get(ProtocolVersionHeader); len(version) > 0 && version != ProtocolVersion {
+		http.Error(responseWriter, fmt.Sprintf("unsupported protocol version '%s'", version), http.StatusBadRequest)
+		return
+	}
+
+	// Route The Request
+	path := request.URL.Path
+	switch {
+	case path == HealthPath:
+		s.serveHealth(responseWriter, request)
+	case path == TopicsPath:
+		s.serveTopics(responseWriter, request)
+	case strings.HasPrefix(path, TopicsPath+"/") && !strings.Contains(path[len(TopicsPath)+1:], "/"):
+		s.serveTopic(responseWriter, request, path[len(TopicsPath)+1:])
+	default:
+		http.NotFound(responseWriter, request)
+	}
+}
+
+// Serve The Health Requests
+func (s *Server) serveHealth(responseWriter http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		s.methodNotAllowed(responseWriter, http.MethodGet)
+		return
+	}
+	health := &Health{Status: HealthStatusOK, ProtocolVersion: ProtocolVersion}
+	statusCode := http.StatusOK
+	if err := s.backend.Healthy(request.Context()); err != nil {
+		health.Status = err.Error()
+		statusCode = http.StatusServiceUnavailable
+	}
+	s.writeJSON(responseWriter, statusCode, health)
+}
+
+// Serve The Requests Of The Topics Path (Create & List)
+func (s *Server) serveTopics(responseWriter http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodPost:
+		topicName := request.Header.Get(TopicNameHeader)
+		if len(topicName) == 0 {
+			http.Error(responseWriter, fmt.Sprintf("missing '%s' header", TopicNameHeader), http.StatusBadRequest)
+			return
+		}
+		topicDetail := &TopicDetail{}
+		if err := json.NewDecoder(request.Body).Decode(topicDetail); err != nil {
+			http.Error(responseWriter, fmt.Sprintf("invalid topic detail: %v", err), http.StatusBadRequest)
+			return
+		}
+		s.writeResult(responseWriter, http.StatusCreated, s.backend.CreateTopic(request.Context(), topicName, topicDetail))
+	case http.MethodGet:
+		topics, err := s.backend.ListTopics(request.Context())
+		if err != nil {
+			s.writeError(responseWriter, err)
+			return
+		}
+		s.writeJSON(responseWriter, http.StatusOK, topics)
+	default:
+		s.methodNotAllowed(responseWriter, http.MethodGet, http.MethodPost)
+	}
+}
+
+// Serve The Requests Of A Single Topic (Describe, Update & Delete)
+func (s *Server) serveTopic(responseWriter http.ResponseWriter, request *http.Request, topicName string) {
+	switch request.Method {
+	case http.MethodGet:
+		topicDetail, err := s.backend.DescribeTopic(request.Context(), topicName)
+		if err != nil {
+			s.writeError(responseWriter, err)
+			return
+		}
+		s.writeJSON(responseWriter, http.StatusOK, topicDetail)
+	case http.MethodPatch:
+		topicUpdate := &TopicUpdate{}
+		if err := json.NewDecoder(request.Body).Decode(topicUpdate); err != nil {
+			http.Error(responseWriter, fmt.Sprintf("invalid topic update: %v", err), http.StatusBadRequest)
+			return
+		}
+		s.writeResult(responseWriter, http.StatusOK, s.backend.UpdateTopic(request.Context(), topicName, topicUpdate))
+	case http.MethodDelete:
+		s.writeResult(responseWriter, http.StatusOK, s.backend.DeleteTopic(request.Context(), topicName))
+	default:
+		s.methodNotAllowed(responseWriter, http.MethodGet, http.MethodPatch, http.MethodDelete)
+	}
+}
+
+// Write The StatusCode Of A Successful Operation Or The Error
+func (s *Server) writeResult(responseWriter http.ResponseWriter, statusCode int, err error) {
+	if err != nil {
+		s.writeError(responseWriter, err)
+		return
+	}
+	responseWriter.WriteHeader(statusCode)
+}
+
+// Write The Error With The StatusCode It Maps To
+func (s *Server) writeError(responseWriter http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrTopicAlreadyExists):
+		http.Error(responseWriter, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrTopicNotFound):
+		http.Error(responseWriter, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidTopic):
+		http.Error(responseWriter, err.Error(), http.StatusBadRequest)
+	default:
+		s.logger.Error("Failed To Perform Topic Operation", zap.Error(err))
+		http.Error(responseWriter, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Write The JSON Body With The StatusCode
+func (s *Server) writeJSON(responseWriter http.ResponseWriter, statusCode int, body interface{}) {
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(statusCode)
+	if err := json.NewEncoder(responseWriter).Encode(body); err != nil {
+		s.logger.Error("Failed To Write Response Body", zap.Error(err))
+	}
+}
+
+// Reject The Request Whose Method Is Not One Of The Allowed Methods
+func (s *Server) methodNotAllowed(responseWriter http.ResponseWriter, allowed ...string) {
+	responseWriter.Header().Set("Allow", strings.Join(allowed, ", "))
+	http.Error(responseWriter, "method not allowed", http.StatusMethodNotAllowed)
+}
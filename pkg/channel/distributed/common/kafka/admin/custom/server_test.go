@@ -0,0 +1,92 @@
+package custom_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/custom/conformance"
+)
+
+// Test The Reference Server With The In-Memory TopicBackend Against The Conformance Tests
+func TestServerConformance(t *testing.T) {
+	server := httptest.NewServer(custom.NewServer(zap.NewNop(), custom.NewMemoryBackend()))
+	defer server.Close()
+	conformance.Run(t, server.URL)
+}
+
+// Test The Reference Server's Routing & Error Mapping
+func TestServerErrors(t *testing.T) {
+
+	// Create A Reference Server With A Failing TopicBackend
+	backendErr := errors.New("test backend error")
+	server := httptest.NewServer(custom.NewServer(zap.NewNop(), &failingBackend{err: backendErr}))
+	defer server.Close()
+
+	// Define The TestCases
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		statusCode int
+		allow      string
+	}{
+		{name: "Unhealthy", method: http.MethodGet, path: custom.HealthPath, statusCode: http.StatusServiceUnavailable},
+		{name: "Backend Failure", method: http.MethodGet, path: custom.TopicsPath, statusCode: http.StatusInternalServerError},
+		{name: "Missing Topic Name", method: http.MethodPost, path: custom.TopicsPath, statusCode: http.StatusBadRequest},
+		{name: "Unknown Path", method: http.MethodGet, path: custom.TopicsPath + "/topic/partitions", statusCode: http.StatusNotFound},
+		{name: "Topics Method Not Allowed", method: http.MethodPut, path: custom.TopicsPath, statusCode: http.StatusMethodNotAllowed, allow: "GET, POST"},
+		{name: "Topic Method Not Allowed", method: http.MethodPost, path: custom.TopicsPath + "/topic", statusCode: http.StatusMethodNotAllowed, allow: "GET, PATCH, DELETE"},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request, err := http.NewRequest(testCase.method, server.URL+testCase.path, nil)
+			assert.Nil(t, err)
+			response, err := http.DefaultClient.Do(request)
+			assert.Nil(t, err)
+			defer response.Body.Close()
+			assert.Equal(t, testCase.statusCode, response.StatusCode)
+			assert.Equal(t, custom.ProtocolVersion, response.Header.Get(custom.ProtocolVersionHeader))
+			assert.Equal(t, testCase.allow, response.Header.Get("Allow"))
+		})
+	}
+}
+
+// Test The Legacy Requests Without The Protocol Version Header Are Supported
+func TestServerWithoutProtocolVersion(t *testing.T) {
+	server := httptest.NewServer(custom.NewServer(zap.NewNop(), custom.NewMemoryBackend()))
+	defer server.Close()
+	request, err := http.NewRequest(http.MethodDelete, server.URL+custom.TopicsPath+"/topic", nil)
+	assert.Nil(t, err)
+	response, err := http.DefaultClient.Do(request)
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+// TopicBackend Failing All Operations
+type failingBackend struct {
+	err error
+}
+
+func (b *failingBackend) CreateTopic(context.Context, string, *custom.TopicDetail) error {
+	return b.err
+}
+func (b *failingBackend) DeleteTopic(context.Context, string) error { return b.err }
+func (b *failingBackend) DescribeTopic(context.Context, string) (*custom.TopicDetail, error) {
+	return nil, b.err
+}
+func (b *failingBackend) ListTopics(context.Context) (map[string]custom.TopicDetail, error) {
+	return nil, b.err
+}
+func (b *failingBackend) UpdateTopic(context.Context, string, *custom.TopicUpdate) error {
+	return b.err
+}
+func (b *failingBackend) Healthy(context.Context) error { return b.err }
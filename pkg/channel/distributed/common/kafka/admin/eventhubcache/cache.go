@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +27,18 @@ type CacheInterface interface {
 	RemoveEventHub(ctx context.Context, eventhub string)
 	GetNamespace(eventhub string) *Namespace
 	GetLeastPopulatedNamespace() *Namespace
+	AddNamespace(ctx context.Context, kafkaSecret *corev1.Secret) (*Namespace, error)
+	MarkNamespaceFull(namespace *Namespace)
+	GetCapacity() []NamespaceCapacity
+}
+
+// The Capacity Of An Azure EventHub Namespace
+type NamespaceCapacity struct {
+	Name  string
+	Tier  string
+	Count int  // The Number Of EventHubs In The Namespace
+	Limit int  // The Maximum Number Of EventHubs In The Namespace
+	Full  bool // Whether The Namespace Reached Its Limit
 }
 
 // Verify The Cache Struct Implements The Interface
@@ -71,38 +84,9 @@ func (c *Cache) Update(ctx context.Context) error {
 
 	// Loop Over The Secrets Populating The Cache
 	for _, kafkaSecret := range kafkaSecrets.Items {
-
-		// Validate Secret Data
-		if !c.validateKafkaSecret(&kafkaSecret) {
-			err = errors.New("invalid Kafka Secret found")
-			c.logger.Error("Found Invalid Kafka Secret", zap.Any("Kafka Secret", kafkaSecret), zap.Error(err))
-			return err
-		}
-
-		// Create A New Namespace From The Secret
-		namespace, err := NewNamespaceFromKafkaSecret(c.logger, &kafkaSecret)
-		if err != nil {
-			c.logger.Error("Failed To Get Namespace From Kafka Secret", zap.Any("Kafka Secret", kafkaSecret), zap.Error(err))
+		if _, err := c.AddNamespace(ctx, &kafkaSecret); err != nil {
 			return err
 		}
-
-		// Add The Namespace To The Namespace Map
-		c.namespaceMap[namespace.Name] = namespace
-
-		// List The EventHubs For The Namespace
-		eventHubs, err := namespace.HubManager.List(ctx)
-		if err != nil {
-			c.logger.Error("Failed To List EventHubs In Namespace", zap.String("Namespace", namespace.Name), zap.Error(err))
-			return err
-		}
-
-		// Loop Over The EventHubs For The Namespace
-		for _, eventHub := range eventHubs {
-
-			// Add The EventHub To The Namespace & Increment The Namespace EventHub Count
-			c.eventhubMap[eventHub.Name] = namespace
-			namespace.Count = namespace.Count + 1
-		}
 	}
 
 	// Log Some Basic Cache Information
@@ -115,11 +99,52 @@ func (c *Cache) Update(ctx context.Context) error {
 	return nil
 }
 
+// Add The Azure EventHub Namespace Of The Specified Kafka Secret, And Its EventHubs, To The Cache
+func (c *Cache) AddNamespace(ctx context.Context, kafkaSecret *corev1.Secret) (*Namespace, error) {
+
+	// Validate Secret Data
+	if !c.validateKafkaSecret(kafkaSecret) {
+		err := errors.New("invalid Kafka Secret found")
+		c.logger.Error("Found Invalid Kafka Secret", zap.Any("Kafka Secret", kafkaSecret), zap.Error(err))
+		return nil, err
+	}
+
+	// Create A New Namespace From The Secret
+	namespace, err := NewNamespaceFromKafkaSecret(c.logger, kafkaSecret)
+	if err != nil {
+		c.logger.Error("Failed To Get Namespace From Kafka Secret", zap.Any("Kafka Secret", kafkaSecret), zap.Error(err))
+		return nil, err
+	}
+
+	// Add The Namespace To The Namespace Map
+	c.namespaceMap[namespace.Name] = namespace
+
+	// List The EventHubs For The Namespace
+	eventHubs, err := namespace.HubManager.List(ctx)
+	if err != nil {
+		c.logger.Error("Failed To List EventHubs In Namespace", zap.String("Namespace", namespace.Name), zap.Error(err))
+		return nil, err
+	}
+
+	// Loop Over The EventHubs For The Namespace
+	for _, eventHub := range eventHubs {
+
+		// Add The EventHub To The Namespace & Increment The Namespace EventHub Count
+		c.eventhubMap[eventHub.Name] = namespace
+		namespace.Count = namespace.Count + 1
+	}
+
+	// Report The Namespace Capacity & Return The Namespace
+	c.recordCapacity(namespace)
+	return namespace, nil
+}
+
 // Add The Specified EventHub / Namespace To The Cache
 func (c *Cache) AddEventHub(ctx context.Context, eventhub string, namespace *Namespace) {
 	if namespace != nil {
 		namespace.Count = namespace.Count + 1
 		c.eventhubMap[eventhub] = namespace
+		c.recordCapacity(namespace)
 	}
 }
 
@@ -128,16 +153,44 @@ func (c *Cache) RemoveEventHub(ctx context.Context, eventhub string) {
 	namespace := c.GetNamespace(eventhub)
 	if namespace != nil && namespace.Count > 0 {
 		namespace.Count = namespace.Count - 1
+		namespace.Full = false
+		c.recordCapacity(namespace)
 	}
 	delete(c.eventhubMap, eventhub)
 }
 
+// Mark The Specified Namespace As Full (Azure Refused A New EventHub) Until One Of Its EventHubs Is Removed
+func (c *Cache) MarkNamespaceFull(namespace *Namespace) {
+	if namespace != nil {
+		namespace.Full = true
+		c.recordCapacity(namespace)
+	}
+}
+
 // Get The Namespace Associated With The Specified EventHub (Topic) Name
 func (c *Cache) GetNamespace(eventhub string) *Namespace {
 	return c.eventhubMap[eventhub]
 }
 
-// Get The Namespace With The Least Number Of EventHubs
+// Get The Capacity Of The Namespaces In The Cache, Sorted By Name
+func (c *Cache) GetCapacity() []NamespaceCapacity {
+	capacity := make([]NamespaceCapacity, 0, len(c.namespaceMap))
+	for _, namespace := range c.namespaceMap {
+		if namespace != nil {
+			capacity = append(capacity, NamespaceCapacity{
+				Name:  namespace.Name,
+				Tier:  namespace.Tier,
+				Count: namespace.Count,
+				Limit: namespace.Limit,
+				Full:  namespace.IsFull(),
+			})
+		}
+	}
+	sort.Slice(capacity, func(i, j int) bool { return capacity[i].Name < capacity[j].Name })
+	return capacity
+}
+
+// Get The Namespace With The Least Number Of EventHubs (Skipping Those Which Reached The Limit Of Their Tier)
 func (c *Cache) GetLeastPopulatedNamespace() *Namespace {
 
 	// Track The Least Populated Namespace
@@ -146,8 +199,8 @@ func (c *Cache) GetLeastPopulatedNamespace() *Namespace {
 	// Loop Over The Namespaces In The Map
 	for _, namespace := range c.namespaceMap {
 
-		// Skip Any Invalid Data (Precautionary - Shouldn't Happen)
-		if namespace == nil {
+		// Skip Any Invalid Data (Precautionary - Shouldn't Happen) & Any Full Namespace
+		if namespace == nil || namespace.IsFull() {
 			continue
 		}
 
@@ -174,6 +227,8 @@ func (c *Cache) GetLeastPopulatedNamespace() *Namespace {
 			zap.String("Namespace", leastPopulatedNamespace.Name),
 			zap.Int("Count", leastPopulatedNamespace.Count),
 		)
+	} else if len(c.namespaceMap) > 0 {
+		c.logger.Warn("All Azure EventHub Namespaces In Cache Are Full!", zap.Any("Namespaces", c.getNamespaceNames()))
 	} else {
 		c.logger.Warn("No Azure EventHub Namespaces In Cache!")
 	}
@@ -182,6 +237,13 @@ func (c *Cache) GetLeastPopulatedNamespace() *Namespace {
 	return leastPopulatedNamespace
 }
 
+// Utility Function For Reporting The Capacity Of The Specified Namespace Via Metrics
+func (c *Cache) recordCapacity(namespace *Namespace) {
+	if err := recordNamespaceCapacity(namespace); err != nil {
+		c.logger.Warn("Failed To Record Azure EventHub Namespace Capacity", zap.String("Namespace", namespace.Name), zap.Error(err))
+	}
+}
+
 // Utility Function For Validating Kafka Secret
 func (c *Cache) validateKafkaSecret(secret *corev1.Secret) bool {
 
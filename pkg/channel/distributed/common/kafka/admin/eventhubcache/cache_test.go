@@ -218,6 +218,29 @@ func TestRemoveEventHub(t *testing.T) {
 	assert.Equal(t, 0, namespace2.Count)
 }
 
+// Test The Cache's MarkNamespaceFull() Functionality
+func TestMarkNamespaceFull(t *testing.T) {
+
+	// Create A Namespace Below Its Limit & A Cache To Test
+	namespace := &Namespace{Name: "TestNamespaceName", Count: 2, Limit: 10}
+	cache := &Cache{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		namespaceMap: map[string]*Namespace{namespace.Name: namespace},
+		eventhubMap:  map[string]*Namespace{"TestEventHub1": namespace, "TestEventHub2": namespace},
+	}
+
+	// Verify The Namespace Marked Full Is Skipped
+	cache.MarkNamespaceFull(namespace)
+	assert.True(t, namespace.IsFull())
+	assert.Nil(t, cache.GetLeastPopulatedNamespace())
+	assert.True(t, cache.GetCapacity()[0].Full)
+
+	// Verify The Namespace Is Used Again Once One Of Its EventHubs Is Removed
+	cache.RemoveEventHub(context.TODO(), "TestEventHub1")
+	assert.False(t, namespace.IsFull())
+	assert.Equal(t, namespace, cache.GetLeastPopulatedNamespace())
+}
+
 // Test The Cache's GetNamespace() Functionality
 func TestGetNamespace(t *testing.T) {
 
@@ -316,6 +339,84 @@ func TestGetLeastPopulatedNamespace(t *testing.T) {
 	assert.Equal(t, namespaceCount3, namespace.Count)
 }
 
+// Test The Cache's GetLeastPopulatedNamespace() Functionality With Full Namespaces
+func TestGetLeastPopulatedNamespaceFull(t *testing.T) {
+
+	// Create A Test Logger
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Create The Cache's Namespace Map With Namespaces Without Limit, Full & Partially Populated
+	namespaceMap := map[string]*Namespace{
+		"TestNamespaceName1": {Name: "TestNamespaceName1", Count: 0, Limit: 0},
+		"TestNamespaceName2": {Name: "TestNamespaceName2", Count: 10, Limit: 10},
+		"TestNamespaceName3": {Name: "TestNamespaceName3", Count: 9, Limit: 10},
+	}
+
+	// Create A Cache To Test
+	cache := &Cache{
+		logger:       logger,
+		namespaceMap: namespaceMap,
+		eventhubMap:  make(map[string]*Namespace),
+	}
+
+	// Namespaces Without Limit Are Never Full
+	assert.Equal(t, "TestNamespaceName1", cache.GetLeastPopulatedNamespace().Name)
+
+	// Skip The Full Namespaces
+	namespaceMap["TestNamespaceName1"].Limit = 10
+	namespaceMap["TestNamespaceName1"].Count = 10
+	assert.Equal(t, "TestNamespaceName3", cache.GetLeastPopulatedNamespace().Name)
+
+	// Until All The Namespaces Are Full
+	cache.AddEventHub(context.TODO(), "TestEventHub", namespaceMap["TestNamespaceName3"])
+	assert.Nil(t, cache.GetLeastPopulatedNamespace())
+	assert.Equal(t, []NamespaceCapacity{
+		{Name: "TestNamespaceName1", Count: 10, Limit: 10, Full: true},
+		{Name: "TestNamespaceName2", Count: 10, Limit: 10, Full: true},
+		{Name: "TestNamespaceName3", Count: 10, Limit: 10, Full: true},
+	}, cache.GetCapacity())
+}
+
+// Test The Cache's AddNamespace() Functionality
+func TestAddNamespace(t *testing.T) {
+
+	// Test Data
+	kafkaSecret := createKafkaSecret("TestKafkaSecretName", "TestK8SNamespace", "TestBrokers", "TestUsername", "TestPassword", "TestNamespace")
+	kafkaSecret.Data[constants.KafkaSecretKeyTier] = []byte(constants.EventHubTierPremium)
+
+	// Replace The NewHubManagerFromConnectionString Wrapper To Provide Mock Implementation & Defer Reset
+	mockHubManager := &MockHubManager{ListHubEntities: []*eventhub.HubEntity{createEventHubEntity("TestHubEntityName")}}
+	newHubManagerFromConnectionStringWrapperPlaceholder := NewHubManagerFromConnectionStringWrapper
+	NewHubManagerFromConnectionStringWrapper = func(connectionString string) (managerInterface HubManagerInterface, e error) {
+		return mockHubManager, nil
+	}
+	defer func() { NewHubManagerFromConnectionStringWrapper = newHubManagerFromConnectionStringWrapperPlaceholder }()
+
+	// Create A Cache To Test
+	cache := &Cache{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		namespaceMap: make(map[string]*Namespace),
+		eventhubMap:  make(map[string]*Namespace),
+	}
+
+	// Perform The Test
+	namespace, err := cache.AddNamespace(context.TODO(), kafkaSecret)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, "TestNamespace", namespace.Name)
+	assert.Equal(t, namespace, cache.GetNamespace("TestHubEntityName"))
+	assert.Equal(t, []NamespaceCapacity{
+		{Name: "TestNamespace", Tier: constants.EventHubTierPremium, Count: 1, Limit: 100},
+	}, cache.GetCapacity())
+
+	// Invalid Kafka Secrets Are Rejected
+	delete(kafkaSecret.Data, constants.KafkaSecretKeyNamespace)
+	namespace, err = cache.AddNamespace(context.TODO(), kafkaSecret)
+	assert.NotNil(t, err)
+	assert.Nil(t, namespace)
+}
+
 //
 // Utilities
 //
@@ -1,6 +1,8 @@
 package eventhubcache
 
 import (
+	"strings"
+
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
@@ -14,6 +16,9 @@ type Namespace struct {
 	Secret     string
 	HubManager HubManagerInterface
 	Count      int
+	Tier       string // The Azure EventHub Namespace Tier (basic, standard, premium or dedicated)
+	Limit      int    // The Maximum Number Of EventHubs In The Namespace For Its Tier
+	Full       bool   // Whether Azure Refused A New EventHub In The Namespace (Regardless Of Its Count & Limit)
 }
 
 // Namespace Complete Argument Constructor
@@ -34,6 +39,8 @@ func NewNamespace(logger *zap.Logger, name string, username string, password str
 		Secret:     secret,
 		HubManager: hubManager,
 		Count:      count,
+		Tier:       constants.EventHubTierDefault,
+		Limit:      constants.EventHubTierLimits[constants.EventHubTierDefault],
 	}, nil
 }
 
@@ -45,8 +52,33 @@ func NewNamespaceFromKafkaSecret(logger *zap.Logger, kafkaSecret *corev1.Secret)
 	username := string(data[constants.KafkaSecretKeyUsername])
 	password := string(data[constants.KafkaSecretKeyPassword])
 	namespace := string(data[constants.KafkaSecretKeyNamespace])
+	tier := strings.ToLower(string(data[constants.KafkaSecretKeyTier]))
 	secret := kafkaSecret.Name
 
 	// Create A New Namespace From The Secret
-	return NewNamespace(logger, namespace, username, password, secret, 0)
+	eventHubNamespace, err := NewNamespace(logger, namespace, username, password, secret, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply The Namespace's Tier Limits (Defaulting To The Standard Tier)
+	if len(tier) > 0 {
+		if limit, ok := constants.EventHubTierLimits[tier]; ok {
+			eventHubNamespace.Tier = tier
+			eventHubNamespace.Limit = limit
+		} else {
+			logger.Warn("Unknown Azure EventHub Namespace Tier - Defaulting To Standard",
+				zap.String("Namespace", namespace),
+				zap.String("Tier", tier))
+		}
+	}
+
+	// Return The Namespace
+	return eventHubNamespace, nil
+}
+
+// Determine Whether The Namespace Has Reached The EventHub Limit Of Its Tier (Unknown Limits Are Only Full Once
+// Azure Refused A New EventHub)
+func (n *Namespace) IsFull() bool {
+	return n.Full || (n.Limit > 0 && n.Count >= n.Limit)
 }
@@ -46,6 +46,8 @@ func TestNewNamespace(t *testing.T) {
 	assert.Equal(t, secret, namespace.Secret)
 	assert.Equal(t, count, namespace.Count)
 	assert.Equal(t, mockHubManager, namespace.HubManager)
+	assert.Equal(t, constants.EventHubTierStandard, namespace.Tier)
+	assert.Equal(t, 10, namespace.Limit)
 }
 
 // Test The NewNamespace() Constructor With HubManager Error Handling
@@ -120,4 +122,56 @@ func TestNewNamespaceFromKafkaSecret(t *testing.T) {
 	assert.Equal(t, secret, namespace.Secret)
 	assert.Equal(t, count, namespace.Count)
 	assert.Equal(t, mockHubManager, namespace.HubManager)
+	assert.Equal(t, constants.EventHubTierStandard, namespace.Tier)
+	assert.Equal(t, 10, namespace.Limit)
+}
+
+// Test The NewNamespaceFromKafkaSecret() Constructor With The Namespace Tier
+func TestNewNamespaceFromKafkaSecretTier(t *testing.T) {
+
+	// Replace The NewHubManagerFromConnectionString Wrapper To Provide Mock Implementation & Defer Reset
+	newHubManagerFromConnectionStringWrapperPlaceholder := NewHubManagerFromConnectionStringWrapper
+	NewHubManagerFromConnectionStringWrapper = func(connectionString string) (managerInterface HubManagerInterface, e error) {
+		return &MockHubManager{}, nil
+	}
+	defer func() { NewHubManagerFromConnectionStringWrapper = newHubManagerFromConnectionStringWrapperPlaceholder }()
+
+	// Create A Test Logger
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Define The Tier TestCases
+	testCases := map[string]struct {
+		tier      string
+		wantTier  string
+		wantLimit int
+	}{
+		"basic":     {tier: "basic", wantTier: constants.EventHubTierBasic, wantLimit: 10},
+		"premium":   {tier: "Premium", wantTier: constants.EventHubTierPremium, wantLimit: 100},
+		"dedicated": {tier: "dedicated", wantTier: constants.EventHubTierDedicated, wantLimit: 1000},
+		"unknown":   {tier: "unknown", wantTier: constants.EventHubTierStandard, wantLimit: 10},
+	}
+
+	// Run The TestCases
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			kafkaSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "TestSecret"},
+				Data: map[string][]byte{
+					constants.KafkaSecretKeyNamespace: []byte("TestNamespace"),
+					constants.KafkaSecretKeyTier:      []byte(tc.tier),
+				},
+			}
+			namespace, err := NewNamespaceFromKafkaSecret(logger, kafkaSecret)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantTier, namespace.Tier)
+			assert.Equal(t, tc.wantLimit, namespace.Limit)
+			assert.False(t, namespace.IsFull())
+			namespace.Count = tc.wantLimit
+			assert.True(t, namespace.IsFull())
+		})
+	}
+
+	// Namespaces Without Limit Are Never Full, Unless Azure Refused A New EventHub
+	assert.False(t, (&Namespace{Count: 1000}).IsFull())
+	assert.True(t, (&Namespace{Count: 1000, Full: true}).IsFull())
 }
@@ -0,0 +1,38 @@
+package eventhubcache
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+//
+// Azure EventHub Namespace Provisioner
+//
+// Azure limits the number of EventHubs per Namespace based on its tier, so once all the known Namespaces are full
+// no more EventHubs can be created.  A NamespaceProvisioner can be plugged into the EventHub AdminClient in order to
+// create a new Azure EventHub Namespace in that case.  The creation of the Namespace itself (resource group, tier,
+// authorization rules, etc.) is left to the implementation, which must also create the corresponding Kafka Secret
+// (with the constants.KafkaSecretLabel label, the "namespace" and optionally the "tier" keys) in the K8S Namespace
+// so that the new Namespace is known after a restart.  It is injected into the EventHub AdminClient via the context
+// passed to the controller (see WithNamespaceProvisioner).
+//
+type NamespaceProvisioner interface {
+
+	// Provision A New Azure EventHub Namespace & Return Its Kafka Secret, Created In The Specified K8S Namespace
+	Provision(ctx context.Context, k8sNamespace string) (*corev1.Secret, error)
+}
+
+// The Context Key Of The NamespaceProvisioner
+type namespaceProvisionerKey struct{}
+
+// Return A Copy Of The Context Carrying The Specified NamespaceProvisioner
+func WithNamespaceProvisioner(ctx context.Context, provisioner NamespaceProvisioner) context.Context {
+	return context.WithValue(ctx, namespaceProvisionerKey{}, provisioner)
+}
+
+// Get The NamespaceProvisioner Carried By The Context (Nil If None)
+func GetNamespaceProvisioner(ctx context.Context) NamespaceProvisioner {
+	provisioner, _ := ctx.Value(namespaceProvisionerKey{}).(NamespaceProvisioner)
+	return provisioner
+}
@@ -0,0 +1,28 @@
+package eventhubcache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Test The NamespaceProvisioner Context Functionality
+func TestNamespaceProvisionerContext(t *testing.T) {
+
+	// Verify No NamespaceProvisioner Is Carried By Default
+	assert.Nil(t, GetNamespaceProvisioner(context.TODO()))
+
+	// Verify The NamespaceProvisioner Carried By The Context Is Returned
+	provisioner := &testNamespaceProvisioner{}
+	ctx := WithNamespaceProvisioner(context.TODO(), provisioner)
+	assert.Equal(t, provisioner, GetNamespaceProvisioner(ctx))
+}
+
+// Test NamespaceProvisioner Provisioning Nothing
+type testNamespaceProvisioner struct{}
+
+func (p *testNamespaceProvisioner) Provision(_ context.Context, _ string) (*corev1.Secret, error) {
+	return nil, nil
+}
@@ -0,0 +1,69 @@
+package eventhubcache
+
+import (
+	"context"
+	"log"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+
+	// LabelEventHubNamespace is the label for the name of the Azure EventHub Namespace.
+	LabelEventHubNamespace = "eventhub_namespace"
+
+	// LabelEventHubTier is the label for the tier of the Azure EventHub Namespace.
+	LabelEventHubTier = "eventhub_tier"
+)
+
+var (
+	// Gauge For The Number Of EventHubs In An Azure EventHub Namespace
+	eventHubCount = stats.Int64(
+		"eventhub_namespace_eventhub_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Number Of EventHubs In The Azure EventHub Namespace",
+		stats.UnitDimensionless,
+	)
+
+	// Gauge For The Maximum Number Of EventHubs In An Azure EventHub Namespace
+	eventHubLimit = stats.Int64(
+		"eventhub_namespace_eventhub_limit", // The METRICS_DOMAIN will be prepended to the name.
+		"Maximum Number Of EventHubs In The Azure EventHub Namespace For Its Tier",
+		stats.UnitDimensionless,
+	)
+
+	// The Tag Keys Of The Azure EventHub Namespace Measurements
+	eventHubNamespaceKey = tag.MustNewKey(LabelEventHubNamespace)
+	eventHubTierKey      = tag.MustNewKey(LabelEventHubTier)
+)
+
+// Register the OpenCensus View Structures
+func init() {
+	for _, measure := range []*stats.Int64Measure{eventHubCount, eventHubLimit} {
+		err := view.Register(&view.View{
+			Description: measure.Description(),
+			Measure:     measure,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{eventHubNamespaceKey, eventHubTierKey},
+		})
+		if err != nil {
+			log.Printf("failed to register opencensus views, %v", err)
+		}
+	}
+}
+
+// Record The Number Of EventHubs & The Limit Of The Specified Namespace
+func recordNamespaceCapacity(namespace *Namespace) error {
+	ctx, err := tag.New(context.Background(),
+		tag.Insert(eventHubNamespaceKey, namespace.Name),
+		tag.Insert(eventHubTierKey, namespace.Tier),
+	)
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, eventHubCount.M(int64(namespace.Count)))
+	metrics.Record(ctx, eventHubLimit.M(int64(namespace.Limit)))
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 )
 
 // Utility Function For Getting All (Limit 100) The Kafka Secrets In A K8S Namespace
@@ -36,8 +37,14 @@ func ValidateKafkaSecret(logger *zap.Logger, secret *corev1.Secret) bool {
 		// Validate Kafka Secret Data (Allowing for Kafka not having Authentication enabled)
 		if len(brokers) > 0 && len(username) >= 0 && len(password) >= 0 {
 
-			// Mark Kafka Secret As Valid
-			valid = true
+			// Validate The Optional TLS Certificates (Mutual TLS Authentication)
+			if err := kafkasarama.TLSCertificatesFromSecret(secret).Validate(); err != nil {
+				logger.Error("Kafka Secret Contains Invalid TLS Certificates", zap.String("Name", secret.Name), zap.Error(err))
+			} else {
+
+				// Mark Kafka Secret As Valid
+				valid = true
+			}
 
 		} else {
 
@@ -78,6 +78,22 @@ func TestValidateKafkaSecret(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Invalid Kafka Secret (TLS Client Certificate Without Key)",
+			data: map[string][]byte{
+				constants.KafkaSecretKeyBrokers: []byte(brokers),
+				constants.KafkaSecretKeyTLSCert: []byte("TestClientCert"),
+			},
+			want: false,
+		},
+		{
+			name: "Invalid Kafka Secret (TLS CA Certificate)",
+			data: map[string][]byte{
+				constants.KafkaSecretKeyBrokers: []byte(brokers),
+				constants.KafkaSecretKeyCACert:  []byte("TestCACert"),
+			},
+			want: false,
+		},
 		{
 			name: "Invalid Kafka Secret (No Brokers)",
 			data: map[string][]byte{
@@ -17,6 +17,10 @@ const (
 	KafkaSecretKeyNamespace = "namespace"
 	KafkaSecretKeyUsername  = "username"
 	KafkaSecretKeyPassword  = "password"
+	KafkaSecretKeyTLSCert   = "tls.crt" // Optional Client Certificate (Mutual TLS)
+	KafkaSecretKeyTLSKey    = "tls.key" // Optional Client Key (Mutual TLS)
+	KafkaSecretKeyCACert    = "ca.crt"  // Optional CA Certificate
+	KafkaSecretKeyTier      = "tier"    // Optional Azure EventHub Namespace Tier
 
 	// Kafka Admin/Consumer/Producer Config Values
 	ConfigNetSaslVersion = sarama.SASLHandshakeV1 // Latest version, seems to work with EventHubs as well.
@@ -33,6 +37,13 @@ const (
 	// EventHub Constraints
 	MaxEventHubNamespaces = 100
 
+	// EventHub Namespace Tiers
+	EventHubTierBasic     = "basic"
+	EventHubTierStandard  = "standard"
+	EventHubTierPremium   = "premium"
+	EventHubTierDedicated = "dedicated"
+	EventHubTierDefault   = EventHubTierStandard
+
 	// KafkaChannel Constants
 	KafkaChannelServiceNameSuffix = "kn-channel" // Specific Value For Use With Knative e2e Tests!
 )
@@ -49,4 +60,12 @@ var (
 	// Azure EventHubs if this is set any higher than V1_0_0_0.
 	//
 	ConfigKafkaVersionDefault = sarama.V1_0_0_0
+
+	// The Maximum Number Of EventHubs Per Azure EventHub Namespace For Each Tier
+	EventHubTierLimits = map[string]int{
+		EventHubTierBasic:     10,
+		EventHubTierStandard:  10,
+		EventHubTierPremium:   100,
+		EventHubTierDedicated: 1000,
+	}
 )
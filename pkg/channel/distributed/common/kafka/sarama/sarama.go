@@ -13,12 +13,15 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/testing"
+	"knative.dev/eventing-kafka/pkg/common/saramaconfig"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/logging"
 	"knative.dev/pkg/system"
 )
 
@@ -238,6 +241,18 @@ func MergeSaramaSettings(config *sarama.Config, configMap *corev1.ConfigMap) (*s
 	// Merge The ConfigMap Settings Into The Provided Config
 	saramaSettingsYamlString := configMap.Data[testing.SaramaSettingsConfigKey]
 
+	// Apply A Typed SaramaConfigSpec As Is (The Raw Sarama.Config YAML Handled Below Is Deprecated)
+	if saramaconfig.IsSpec(saramaSettingsYamlString) {
+		spec, err := saramaconfig.Parse(saramaSettingsYamlString)
+		if err != nil {
+			return nil, fmt.Errorf("ConfigMap's sarama value is not a valid SaramaConfigSpec: %v", err)
+		}
+		if err = spec.ApplyTo(config); err != nil {
+			return nil, err
+		}
+		return config, nil
+	}
+
 	// Extract (Remove) The KafkaVersion From The Sarama Config YAML
 	saramaSettingsYamlString, kafkaVersion, err := extractKafkaVersion(saramaSettingsYamlString)
 	if err != nil {
@@ -293,8 +308,47 @@ func LoadSettings(ctx context.Context) (*sarama.Config, *commonconfig.EventingKa
 		return nil, nil, fmt.Errorf("ConfigMap's eventing-kafka value could not be converted to an EventingKafkaConfig struct: %s : %v", err, eventingKafkaConfigString)
 	}
 
+	// Suggest The Equivalent SaramaConfigSpec When The Raw Sarama.Config YAML Is Used
+	saramaSettingsYamlString := configMap.Data[commonconfig.SaramaSettingsConfigKey]
+	if !saramaconfig.IsSpec(saramaSettingsYamlString) {
+		if spec, err := MigrateSaramaSettings(saramaSettingsYamlString); err == nil {
+			logging.FromContext(ctx).Warnw("ConfigMap's sarama value uses the deprecated raw Sarama.Config YAML, please replace it with the equivalent SaramaConfigSpec", zap.String("spec", spec))
+		}
+	}
+
 	// Merge The Sarama Settings In The ConfigMap Into A New Base Sarama Config
 	saramaConfig, err := MergeSaramaSettings(nil, configMap)
 
 	return saramaConfig, eventingKafkaConfig, err
 }
+
+// Migrate The Specified Raw Sarama.Config YAML String To The Equivalent Typed SaramaConfigSpec YAML String
+// Settings Which Cannot Be Represented In The SaramaConfigSpec Are Dropped (See saramaconfig.FromSaramaConfig())
+func MigrateSaramaSettings(saramaSettingsYamlString string) (string, error) {
+
+	// Parse The Raw Sarama.Config YAML The Same Way As The Components Do
+	configMap := &corev1.ConfigMap{Data: map[string]string{commonconfig.SaramaSettingsConfigKey: saramaSettingsYamlString}}
+	config, err := MergeSaramaSettings(nil, configMap)
+	if err != nil {
+		return "", err
+	}
+	spec := saramaconfig.FromSaramaConfig(config)
+
+	// The RootPEMs Cannot Be Recovered From The Parsed CertPool So Copy Them From The YAML
+	type tlsConfigShell struct {
+		Net struct {
+			TLS struct {
+				Config struct {
+					RootPEMs []string
+				}
+			}
+		}
+	}
+	shell := &tlsConfigShell{}
+	if err = yaml.Unmarshal([]byte(saramaSettingsYamlString), shell); err != nil {
+		return "", err
+	}
+	spec.Net.TLS.RootPEMs = shell.Net.TLS.Config.RootPEMs
+
+	return spec.YAML()
+}
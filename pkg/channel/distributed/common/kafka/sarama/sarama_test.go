@@ -269,6 +269,42 @@ func TestMergeSaramaSettings(t *testing.T) {
 	config, err = MergeSaramaSettings(config, configMap)
 	assert.Nil(t, err)
 	assert.True(t, config.Net.TLS.Config.InsecureSkipVerify)
+
+	// Verify that a typed SaramaConfigSpec is applied
+	configMap = commontesting.GetTestSaramaConfigMap("apiVersion: v1\nkafkaVersion: 2.3.0\nproducer:\n  timeout: 30s\n", commontesting.TestEKConfig)
+	config, err = MergeSaramaSettings(nil, configMap)
+	assert.Nil(t, err)
+	assert.Equal(t, sarama.V2_3_0_0, config.Version)
+	assert.Equal(t, 30*time.Second, config.Producer.Timeout)
+	assert.True(t, config.Producer.Return.Successes)
+
+	// Verify error when an invalid SaramaConfigSpec is provided
+	configMap = commontesting.GetTestSaramaConfigMap("apiVersion: v1\nproducer:\n  compression: brotli\n", commontesting.TestEKConfig)
+	config, err = MergeSaramaSettings(nil, configMap)
+	assert.NotNil(t, err)
+	assert.Nil(t, config)
+}
+
+// Test The MigrateSaramaSettings() Functionality
+func TestMigrateSaramaSettings(t *testing.T) {
+
+	// Migrate The Raw Sarama.Config YAML
+	specYaml, err := MigrateSaramaSettings(EKDefaultSaramaConfigWithRootCert)
+	assert.Nil(t, err)
+	assert.Contains(t, specYaml, "apiVersion: v1")
+	assert.Contains(t, specYaml, "retention: 168h0m0s")
+
+	// Verify The Migrated SaramaConfigSpec Results In The Same Sarama.Config
+	legacyConfig, err := MergeSaramaSettings(nil, commontesting.GetTestSaramaConfigMap(EKDefaultSaramaConfigWithRootCert, commontesting.TestEKConfig))
+	assert.Nil(t, err)
+	migratedConfig, err := MergeSaramaSettings(nil, commontesting.GetTestSaramaConfigMap(specYaml, commontesting.TestEKConfig))
+	assert.Nil(t, err)
+	assert.True(t, ConfigEqual(legacyConfig, migratedConfig))
+	assert.Equal(t, legacyConfig.Net.TLS.Config.RootCAs.Subjects(), migratedConfig.Net.TLS.Config.RootCAs.Subjects())
+
+	// Verify The Error Of An Invalid Raw Sarama.Config YAML
+	_, err = MigrateSaramaSettings("Version: INVALID")
+	assert.NotNil(t, err)
 }
 
 // Verify that comparisons of sarama config structs function as expected
@@ -0,0 +1,131 @@
+package sarama
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Shopify/sarama"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+)
+
+// The (PEM Encoded) Client Certificate, Client Key & CA Certificate Of The Kafka Secret For Mutual TLS Authentication
+type TLSCertificates struct {
+	ClientCert string
+	ClientKey  string
+	CACert     string
+}
+
+// Utility Function For Extracting The Optional TLS Certificates From The Data Of A Kafka Secret
+func TLSCertificatesFromSecret(secret *corev1.Secret) TLSCertificates {
+	return TLSCertificates{
+		ClientCert: string(secret.Data[constants.KafkaSecretKeyTLSCert]),
+		ClientKey:  string(secret.Data[constants.KafkaSecretKeyTLSKey]),
+		CACert:     string(secret.Data[constants.KafkaSecretKeyCACert]),
+	}
+}
+
+// Utility Function For Loading The Optional TLS Certificates From The Files Of A Mounted Kafka Secret (Missing Files Are Empty)
+func LoadTLSCertificates(secretPath string) (TLSCertificates, error) {
+	var certificates TLSCertificates
+	for key, value := range map[string]*string{
+		constants.KafkaSecretKeyTLSCert: &certificates.ClientCert,
+		constants.KafkaSecretKeyTLSKey:  &certificates.ClientKey,
+		constants.KafkaSecretKeyCACert:  &certificates.CACert,
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(secretPath, key))
+		if err != nil && !os.IsNotExist(err) {
+			return TLSCertificates{}, err
+		}
+		*value = string(data)
+	}
+	return certificates, nil
+}
+
+// Returns True If None Of The TLS Certificates Are Specified
+func (c TLSCertificates) IsEmpty() bool {
+	return len(c.ClientCert) == 0 && len(c.ClientKey) == 0 && len(c.CACert) == 0
+}
+
+// Validate The TLS Certificates (The Client Certificate & Key Being Optional But Only Together)
+func (c TLSCertificates) Validate() error {
+	_, _, err := c.parse()
+	return err
+}
+
+// Parse The TLS Certificates Into The Client Certificates And CA CertPool (nil When Not Specified)
+func (c TLSCertificates) parse() ([]tls.Certificate, *x509.CertPool, error) {
+
+	// Parse The Client Certificate / Key Pair
+	var clientCerts []tls.Certificate
+	if len(c.ClientCert) > 0 || len(c.ClientKey) > 0 {
+		if len(c.ClientCert) == 0 || len(c.ClientKey) == 0 {
+			return nil, nil, errors.New("the TLS client certificate and key must be specified together")
+		}
+		clientCert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid TLS client certificate / key: %w", err)
+		}
+		clientCerts = []tls.Certificate{clientCert}
+	}
+
+	// Parse The CA Certificate
+	var caCertPool *x509.CertPool
+	if len(c.CACert) > 0 {
+		caCertPool = x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, nil, errors.New("invalid TLS CA certificate: no PEM encoded certificate found")
+		}
+	}
+
+	return clientCerts, caCertPool, nil
+}
+
+// Utility Function For Configuring The TLS Certificates Of The Kafka Secret (Mutual TLS Authentication)
+//
+// This is the distributed channel's equivalent of the KafkaSource's client certificate handling, except that
+// the hostname of the brokers is still verified (unless disabled with InsecureSkipVerify in the ConfigMap).
+// The client certificate authenticates the client with the brokers, and the CA certificate is trusted in
+// addition to any RootPEMs of the ConfigMap.  Specifying any certificate enables TLS.  The config is left
+// untouched if no certificate is specified, or if they are invalid.
+func UpdateSaramaTLSConfig(config *sarama.Config, certificates TLSCertificates) error {
+
+	// Nothing To Configure Without Certificates
+	if certificates.IsEmpty() {
+		return nil
+	}
+
+	// Parse The Certificates
+	clientCerts, caCertPool, err := certificates.parse()
+	if err != nil {
+		return err
+	}
+
+	// Start From A Copy Of The Current TLS Config (Which May Hold The ConfigMap's RootPEMs & InsecureSkipVerify)
+	tlsConfig := config.Net.TLS.Config.Clone()
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+
+	// Add The Client Certificate & The CA Certificate
+	if clientCerts != nil {
+		tlsConfig.Certificates = clientCerts
+	}
+	if caCertPool != nil {
+		if tlsConfig.RootCAs == nil {
+			tlsConfig.RootCAs = caCertPool
+		} else {
+			tlsConfig.RootCAs.AppendCertsFromPEM([]byte(certificates.CACert))
+		}
+	}
+
+	// Enable TLS With The Updated Config
+	config.Net.TLS.Enable = true
+	config.Net.TLS.Config = tlsConfig
+	return nil
+}
@@ -0,0 +1,129 @@
+package sarama
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+)
+
+// Create A Self Signed (PEM Encoded) Certificate & Key For Testing
+func newTestCertificate(t *testing.T, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+// Test The UpdateSaramaTLSConfig() Functionality
+func TestUpdateSaramaTLSConfig(t *testing.T) {
+
+	// Test Data
+	clientCert, clientKey := newTestCertificate(t, "client")
+	caCert, _ := newTestCertificate(t, "ca")
+	rootCert, _ := newTestCertificate(t, "root")
+
+	// No Certificates Leave The Config Untouched
+	config := sarama.NewConfig()
+	assert.Nil(t, UpdateSaramaTLSConfig(config, TLSCertificates{}))
+	assert.False(t, config.Net.TLS.Enable)
+	assert.Nil(t, config.Net.TLS.Config)
+
+	// The Client Certificate & CA Certificate Enable TLS
+	config = sarama.NewConfig()
+	assert.Nil(t, UpdateSaramaTLSConfig(config, TLSCertificates{ClientCert: clientCert, ClientKey: clientKey, CACert: caCert}))
+	assert.True(t, config.Net.TLS.Enable)
+	require.NotNil(t, config.Net.TLS.Config)
+	assert.Len(t, config.Net.TLS.Config.Certificates, 1)
+	assert.Len(t, config.Net.TLS.Config.RootCAs.Subjects(), 1)
+	assert.False(t, config.Net.TLS.Config.InsecureSkipVerify)
+	assert.Nil(t, config.Validate())
+
+	// The CA Certificate Is Added To The ConfigMap's RootPEMs, Keeping Its Other Settings
+	rootCAs := x509.NewCertPool()
+	require.True(t, rootCAs.AppendCertsFromPEM([]byte(rootCert)))
+	config = sarama.NewConfig()
+	config.Net.TLS.Config = &tls.Config{RootCAs: rootCAs, InsecureSkipVerify: true}
+	assert.Nil(t, UpdateSaramaTLSConfig(config, TLSCertificates{CACert: caCert}))
+	assert.True(t, config.Net.TLS.Enable)
+	assert.Empty(t, config.Net.TLS.Config.Certificates)
+	assert.Len(t, config.Net.TLS.Config.RootCAs.Subjects(), 2)
+	assert.True(t, config.Net.TLS.Config.InsecureSkipVerify)
+
+	// Configs With The Same Certificates Are Equal
+	config1 := sarama.NewConfig()
+	config2 := sarama.NewConfig()
+	certificates := TLSCertificates{ClientCert: clientCert, ClientKey: clientKey, CACert: caCert}
+	assert.Nil(t, UpdateSaramaTLSConfig(config1, certificates))
+	assert.Nil(t, UpdateSaramaTLSConfig(config2, certificates))
+	assert.True(t, ConfigEqual(config1, config2))
+
+	// Invalid Certificates Leave The Config Untouched
+	config = sarama.NewConfig()
+	assert.NotNil(t, UpdateSaramaTLSConfig(config, TLSCertificates{ClientCert: clientCert}))
+	assert.NotNil(t, UpdateSaramaTLSConfig(config, TLSCertificates{ClientCert: clientCert, ClientKey: "invalid"}))
+	assert.NotNil(t, UpdateSaramaTLSConfig(config, TLSCertificates{CACert: "invalid"}))
+	assert.False(t, config.Net.TLS.Enable)
+	assert.Nil(t, config.Net.TLS.Config)
+}
+
+// Test The TLSCertificatesFromSecret() & LoadTLSCertificates() Functionality
+func TestLoadTLSCertificates(t *testing.T) {
+
+	// Test Data
+	clientCert, clientKey := newTestCertificate(t, "client")
+	caCert, _ := newTestCertificate(t, "ca")
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			constants.KafkaSecretKeyBrokers: []byte("TestBrokers"),
+			constants.KafkaSecretKeyTLSCert: []byte(clientCert),
+			constants.KafkaSecretKeyTLSKey:  []byte(clientKey),
+		},
+	}
+
+	// Extract The Certificates From The Secret Data
+	certificates := TLSCertificatesFromSecret(secret)
+	assert.Equal(t, TLSCertificates{ClientCert: clientCert, ClientKey: clientKey}, certificates)
+	assert.False(t, certificates.IsEmpty())
+	assert.Nil(t, certificates.Validate())
+	assert.True(t, TLSCertificatesFromSecret(&corev1.Secret{}).IsEmpty())
+
+	// Load The Certificates From A Mounted Secret (Missing Files Are Empty)
+	secretPath, err := ioutil.TempDir("", "kafka-secret")
+	require.Nil(t, err)
+	defer os.RemoveAll(secretPath)
+	certificates, err = LoadTLSCertificates(secretPath)
+	assert.Nil(t, err)
+	assert.True(t, certificates.IsEmpty())
+	require.Nil(t, ioutil.WriteFile(filepath.Join(secretPath, constants.KafkaSecretKeyCACert), []byte(caCert), 0600))
+	certificates, err = LoadTLSCertificates(secretPath)
+	assert.Nil(t, err)
+	assert.Equal(t, TLSCertificates{CACert: caCert}, certificates)
+}
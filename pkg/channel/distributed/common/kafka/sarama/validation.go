@@ -0,0 +1,142 @@
+package sarama
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	"knative.dev/eventing-kafka/pkg/common/saramaconfig"
+	"knative.dev/pkg/apis"
+)
+
+// Placeholder SASL Credentials (The Actual Ones Are Provided By The Kafka Secret At Runtime)
+const validationSaslPlaceholder = "validation"
+
+//
+// Validate The Sarama & EventingKafka Settings Of The Specified ConfigMap
+//
+// This performs the same extraction & unmarshalling as MergeSaramaSettings() and LoadSettings(), except
+// that unknown fields are rejected, followed by the semantic checks of sarama's Config.Validate().  It is
+// intended to be registered with the webhook so that invalid edits are rejected with precise field errors,
+// rather than being discovered at runtime by each component.  The returned EventingKafkaConfig has NOT been
+// verified against the requirements of any particular component.
+//
+func ValidateSettings(configMap *corev1.ConfigMap) (*commonconfig.EventingKafkaConfig, error) {
+
+	// Validate The ConfigMap Data
+	if configMap.Data == nil {
+		return nil, apis.ErrMissingField("data")
+	}
+
+	// Validate Both Sections Of The ConfigMap, Reporting All Errors
+	var errs *apis.FieldError
+	errs = errs.Also(validateSaramaSettings(configMap.Data[commonconfig.SaramaSettingsConfigKey]).ViaKey(commonconfig.SaramaSettingsConfigKey))
+	eventingKafkaConfig, err := validateEventingKafkaSettings(configMap.Data[commonconfig.EventingKafkaSettingsConfigKey])
+	errs = errs.Also(err.ViaKey(commonconfig.EventingKafkaSettingsConfigKey))
+	errs = errs.ViaField("data")
+
+	// Return The EventingKafkaConfig Unless Invalid (Avoiding A Non-Nil error Wrapping A Nil *FieldError)
+	if errs != nil {
+		return nil, errs
+	}
+	return eventingKafkaConfig, nil
+}
+
+// Validate The Sarama Settings YAML String
+func validateSaramaSettings(saramaSettingsYamlString string) *apis.FieldError {
+
+	// Start With The Same Defaults As MergeSaramaSettings()
+	config := sarama.NewConfig()
+	config.Version = constants.ConfigKafkaVersionDefault
+	UpdateSaramaConfig(config, config.ClientID, "", "")
+
+	// Parse & Apply Either The Typed SaramaConfigSpec Or The Raw Sarama.Config YAML
+	var fieldErr *apis.FieldError
+	if saramaconfig.IsSpec(saramaSettingsYamlString) {
+		fieldErr = validateSaramaConfigSpec(saramaSettingsYamlString, config)
+	} else {
+		fieldErr = validateRawSaramaConfig(saramaSettingsYamlString, config)
+	}
+	if fieldErr != nil {
+		return fieldErr
+	}
+
+	// Validate The Semantics Of The Sarama.Config (With Placeholders For The SASL Credentials From The Kafka Secret)
+	if config.Net.SASL.Enable && len(config.Net.SASL.User) == 0 {
+		config.Net.SASL.User = validationSaslPlaceholder
+		config.Net.SASL.Password = validationSaslPlaceholder
+	}
+	if err := config.Validate(); err != nil {
+		return &apis.FieldError{Message: "invalid sarama configuration", Paths: []string{apis.CurrentField}, Details: err.Error()}
+	}
+
+	// Return Success
+	return nil
+}
+
+// Validate The Typed SaramaConfigSpec YAML String & Apply It To The Specified Sarama.Config
+func validateSaramaConfigSpec(saramaSettingsYamlString string, config *sarama.Config) *apis.FieldError {
+	spec, err := saramaconfig.Parse(saramaSettingsYamlString)
+	if err != nil {
+		if fieldErr, ok := err.(*apis.FieldError); ok {
+			return fieldErr
+		}
+		return &apis.FieldError{Message: "invalid sarama settings", Paths: []string{apis.CurrentField}, Details: err.Error()}
+	}
+	_ = spec.ApplyTo(config) // Already Validated By Parse()
+	return nil
+}
+
+// Validate The Raw Sarama.Config YAML String & Unmarshal It Into The Specified Sarama.Config
+func validateRawSaramaConfig(saramaSettingsYamlString string, config *sarama.Config) *apis.FieldError {
+
+	// Extract (Remove) The KafkaVersion From The Sarama Config YAML
+	saramaSettingsYamlString, kafkaVersion, err := extractKafkaVersion(saramaSettingsYamlString)
+	if err != nil {
+		return apis.ErrInvalidValue(err.Error(), "Version")
+	}
+	config.Version = kafkaVersion
+
+	// Extract (Remove) Any TLS.Config RootCAs
+	saramaSettingsYamlString, _, err = extractRootCerts(saramaSettingsYamlString)
+	if err != nil {
+		return apis.ErrInvalidValue(err.Error(), "Net.TLS.Config.RootPEMs")
+	}
+
+	// Strictly Unmarshal The Sarama Config YAML Into The Sarama.Config
+	if err = unmarshalStrict(saramaSettingsYamlString, config); err != nil {
+		return &apis.FieldError{Message: "invalid sarama settings", Paths: []string{apis.CurrentField}, Details: err.Error()}
+	}
+	return nil
+}
+
+// Validate The EventingKafka Settings YAML String
+func validateEventingKafkaSettings(eventingKafkaSettingsYamlString string) (*commonconfig.EventingKafkaConfig, *apis.FieldError) {
+	eventingKafkaConfig := &commonconfig.EventingKafkaConfig{}
+	if err := unmarshalStrict(eventingKafkaSettingsYamlString, eventingKafkaConfig); err != nil {
+		return nil, &apis.FieldError{Message: "invalid eventing-kafka settings", Paths: []string{apis.CurrentField}, Details: err.Error()}
+	}
+	return eventingKafkaConfig, nil
+}
+
+// Unmarshal A YAML String Into The Specified Object, Rejecting Unknown Fields (Typos Are Otherwise Silently Ignored)
+func unmarshalStrict(yamlString string, obj interface{}) error {
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(bytes.TrimSpace(jsonBytes), []byte("null")) {
+		return nil // Empty Settings
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(obj); err != nil {
+		return fmt.Errorf("failed to unmarshal the settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package sarama
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+)
+
+// Test The ValidateSettings() Functionality
+func TestValidateSettings(t *testing.T) {
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name          string
+		data          map[string]string
+		expectedPaths []string
+	}
+
+	// Define The TestCases
+	testCases := []testCase{
+		{
+			name: "Valid Settings",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey:        "Version: 2.0.0\n" + EKDefaultSaramaConfig,
+				commonconfig.EventingKafkaSettingsConfigKey: EKDefaultConfigYaml,
+			},
+		},
+		{
+			name: "Valid Settings With RootCert & SASL Without Credentials",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey:        strings.Replace(EKDefaultSaramaConfigWithRootCert, "  SASL:\n", "  SASL:\n    Enable: true\n", 1),
+				commonconfig.EventingKafkaSettingsConfigKey: EKDefaultConfigYaml,
+			},
+		},
+		{
+			name: "Empty Settings",
+			data: map[string]string{},
+		},
+		{
+			name:          "Missing Data",
+			expectedPaths: []string{"data"},
+		},
+		{
+			name: "Invalid Kafka Version",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey: "Version: 0.0.0.0.1\n" + EKDefaultSaramaConfig,
+			},
+			expectedPaths: []string{"data[sarama].Version"},
+		},
+		{
+			name: "Invalid RootPEMs",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey: strings.Replace(EKDefaultSaramaConfigWithRootCert, "MIIGBDCC", "INVALID", 1),
+			},
+			expectedPaths: []string{"data[sarama].Net.TLS.Config.RootPEMs"},
+		},
+		{
+			name: "Unknown Sarama Field",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey: strings.Replace(EKDefaultSaramaConfig, "Consumer:", "Consumr:", 1),
+			},
+			expectedPaths: []string{"data[sarama]"},
+		},
+		{
+			name: "Invalid Sarama Configuration",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey: EKDefaultSaramaConfig + "Producer:\n  Idempotent: true\n",
+			},
+			expectedPaths: []string{"data[sarama]"},
+		},
+		{
+			name: "Valid SaramaConfigSpec",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey: "apiVersion: v1\nnet:\n  maxOpenRequests: 1\nproducer:\n  idempotent: true\n  requiredAcks: all\n",
+			},
+		},
+		{
+			name: "Invalid SaramaConfigSpec Field",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey: "apiVersion: v1\nproducer:\n  compression: brotli\n",
+			},
+			expectedPaths: []string{"data[sarama].producer.compression"},
+		},
+		{
+			name: "Unknown SaramaConfigSpec Field",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey: "apiVersion: v1\nproducer:\n  compresion: gzip\n",
+			},
+			expectedPaths: []string{"data[sarama]", "unknown field"},
+		},
+		{
+			name: "Invalid SaramaConfigSpec Configuration",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey: "apiVersion: v1\nproducer:\n  idempotent: true\n",
+			},
+			expectedPaths: []string{"data[sarama]", "invalid sarama configuration"},
+		},
+		{
+			name: "Invalid Sarama & EventingKafka Settings",
+			data: map[string]string{
+				commonconfig.SaramaSettingsConfigKey:        "Net: [",
+				commonconfig.EventingKafkaSettingsConfigKey: strings.Replace(EKDefaultConfigYaml, "cpuLimit", "cpuLimt", 1),
+			},
+			expectedPaths: []string{"data[eventing-kafka]", "data[sarama]"},
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Perform The Test
+			eventingKafkaConfig, err := ValidateSettings(&corev1.ConfigMap{Data: testCase.data})
+
+			// Verify The Results
+			if len(testCase.expectedPaths) == 0 {
+				assert.Nil(t, err)
+				assert.NotNil(t, eventingKafkaConfig)
+			} else {
+				assert.Nil(t, eventingKafkaConfig)
+				assert.NotNil(t, err)
+				for _, path := range testCase.expectedPaths {
+					assert.Contains(t, err.Error(), path)
+				}
+			}
+		})
+	}
+}
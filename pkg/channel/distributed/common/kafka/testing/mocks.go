@@ -33,8 +33,15 @@ func NewMockConsumerGroup(t *testing.T) *MockConsumerGroup {
 }
 
 func (m *MockConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
-	<-m.consumeChan                      // Block To Simulate Real Execution
-	return sarama.ErrClosedConsumerGroup // Return ConsumerGroup Closed "Error" For Clean Shutdown
+	if err := handler.Setup(&MockConsumerGroupSession{ctx: ctx}); err != nil { // Set Up The Session To Simulate Joining The Group
+		return err
+	}
+	select {
+	case <-m.consumeChan: // Block To Simulate Real Execution
+		return sarama.ErrClosedConsumerGroup // Return ConsumerGroup Closed "Error" For Clean Shutdown
+	case <-ctx.Done(): // End The Session When The Context Is Canceled
+		return nil
+	}
 }
 
 func (m *MockConsumerGroup) Errors() <-chan error {
@@ -47,3 +54,43 @@ func (m *MockConsumerGroup) Close() error {
 	m.Closed = true
 	return nil
 }
+
+//
+// Mock ConsumerGroupSession Implementation
+//
+
+// Verify The Mock ConsumerGroupSession Implements The Interface
+var _ sarama.ConsumerGroupSession = &MockConsumerGroupSession{}
+
+// Define The Mock ConsumerGroupSession (Without Any Claims)
+type MockConsumerGroupSession struct {
+	ctx context.Context
+}
+
+func (m *MockConsumerGroupSession) Claims() map[string][]int32 {
+	return map[string][]int32{}
+}
+
+func (m *MockConsumerGroupSession) MemberID() string {
+	return ""
+}
+
+func (m *MockConsumerGroupSession) GenerationID() int32 {
+	return 0
+}
+
+func (m *MockConsumerGroupSession) MarkOffset(_ string, _ int32, _ int64, _ string) {
+}
+
+func (m *MockConsumerGroupSession) ResetOffset(_ string, _ int32, _ int64, _ string) {
+}
+
+func (m *MockConsumerGroupSession) MarkMessage(_ *sarama.ConsumerMessage, _ string) {
+}
+
+func (m *MockConsumerGroupSession) Context() context.Context {
+	return m.ctx
+}
+
+func (m *MockConsumerGroupSession) Commit() {
+}
@@ -47,6 +47,8 @@ func VerifyConfiguration(configuration *config.EventingKafkaConfig) error {
 		return ControllerConfigurationError("Dispatcher.MemoryRequest must be nonzero")
 	case configuration.Dispatcher.Replicas < 1:
 		return ControllerConfigurationError("Dispatcher.Replicas must be > 0")
+	case configuration.Dispatcher.DrainTimeoutSeconds < 0:
+		return ControllerConfigurationError("Dispatcher.DrainTimeoutSeconds must be >= 0")
 	case configuration.Receiver.CpuLimit == resource.Quantity{}:
 		return ControllerConfigurationError("Receiver.CpuLimit must be nonzero")
 	case configuration.Receiver.CpuRequest == resource.Quantity{}:
@@ -21,6 +21,7 @@ const (
 	dispatcherCpuRequest    = "100m"
 	dispatcherMemoryLimit   = "50Mi"
 	dispatcherCpuLimit      = "300m"
+	dispatcherDrainTimeout  = 45
 
 	channelReplicas      = 1
 	channelMemoryRequest = "10Mi"
@@ -43,6 +44,7 @@ type TestCase struct {
 	dispatcherMemoryLimit              resource.Quantity
 	dispatcherMemoryRequest            resource.Quantity
 	dispatcherReplicas                 int
+	dispatcherDrainTimeoutSeconds      int
 	channelCpuLimit                    resource.Quantity
 	channelCpuRequest                  resource.Quantity
 	channelMemoryLimit                 resource.Quantity
@@ -65,6 +67,7 @@ func getValidTestCase(name string) TestCase {
 		dispatcherMemoryLimit:              resource.MustParse(dispatcherMemoryLimit),
 		dispatcherMemoryRequest:            resource.MustParse(dispatcherMemoryRequest),
 		dispatcherReplicas:                 dispatcherReplicas,
+		dispatcherDrainTimeoutSeconds:      dispatcherDrainTimeout,
 		channelCpuLimit:                    resource.MustParse(channelCpuLimit),
 		channelCpuRequest:                  resource.MustParse(channelCpuRquest),
 		channelMemoryLimit:                 resource.MustParse(channelMemoryLimit),
@@ -123,6 +126,11 @@ func TestVerifyConfiguration(t *testing.T) {
 	testCase.expectedError = ControllerConfigurationError("Dispatcher.Replicas must be > 0")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Invalid Config - Dispatcher.DrainTimeoutSeconds")
+	testCase.dispatcherDrainTimeoutSeconds = -1
+	testCase.expectedError = ControllerConfigurationError("Dispatcher.DrainTimeoutSeconds must be >= 0")
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Invalid Config - Receiver.CpuLimit")
 	testCase.channelCpuLimit = resource.Quantity{}
 	testCase.expectedError = ControllerConfigurationError("Receiver.CpuLimit must be nonzero")
@@ -166,6 +174,7 @@ func TestVerifyConfiguration(t *testing.T) {
 		testConfig.Dispatcher.MemoryLimit = testCase.dispatcherMemoryLimit
 		testConfig.Dispatcher.MemoryRequest = testCase.dispatcherMemoryRequest
 		testConfig.Dispatcher.Replicas = testCase.dispatcherReplicas
+		testConfig.Dispatcher.DrainTimeoutSeconds = testCase.dispatcherDrainTimeoutSeconds
 		testConfig.Receiver.CpuLimit = testCase.channelCpuLimit
 		testConfig.Receiver.CpuRequest = testCase.channelCpuRequest
 		testConfig.Receiver.MemoryLimit = testCase.channelMemoryLimit
@@ -187,6 +196,7 @@ func TestVerifyConfiguration(t *testing.T) {
 			assert.Equal(t, testCase.dispatcherMemoryLimit, testConfig.Dispatcher.MemoryLimit)
 			assert.Equal(t, testCase.dispatcherMemoryRequest, testConfig.Dispatcher.MemoryRequest)
 			assert.Equal(t, testCase.dispatcherReplicas, testConfig.Dispatcher.Replicas)
+			assert.Equal(t, testCase.dispatcherDrainTimeoutSeconds, testConfig.Dispatcher.DrainTimeoutSeconds)
 			assert.Equal(t, testCase.channelCpuLimit, testConfig.Receiver.CpuLimit)
 			assert.Equal(t, testCase.channelCpuRequest, testConfig.Receiver.CpuRequest)
 			assert.Equal(t, testCase.channelMemoryLimit, testConfig.Receiver.MemoryLimit)
@@ -42,6 +42,10 @@ const (
 	// Reconciliation Error Messages
 	ReconciliationFailedError = "reconciliation failed"
 
+	// KafkaChannel TopicReady Condition Reasons
+	TopicFailedReason               = "TopicFailed"
+	EventHubCapacityExhaustedReason = "EventHubCapacityExhausted" // All The Azure EventHub Namespaces Are Full
+
 	// Eventing-Kafka Finalizers Prefix
 	EventingKafkaFinalizerPrefix = "eventing-kafka/"
 
@@ -73,4 +77,7 @@ const (
 	DispatcherLivenessPeriod  = 5
 	DispatcherReadinessDelay  = 10
 	DispatcherReadinessPeriod = 5
+
+	// Dispatcher Termination Grace Period Beyond The Configured DrainTimeout (Allowing Aborted Dispatches To End)
+	DispatcherTerminationGracePeriodMargin = 10
 )
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
@@ -219,6 +220,9 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 		return nil, err
 	}
 
+	// Get The Kafka Secret (Already Verified By The Env Vars) Mounted For Rotating The Credentials Without Restarting
+	kafkaSecret := r.adminClient.GetKafkaSecretName(util.TopicName(channel))
+
 	// Create The Dispatcher's Deployment
 	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -253,6 +257,9 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: r.environment.ServiceAccount,
+					Volumes: []corev1.Volume{
+						util.NewKafkaSecretVolume(kafkaSecret),
+					},
 					Containers: []corev1.Container{
 						{
 							Name: deploymentName,
@@ -279,6 +286,9 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 							Image:           r.environment.DispatcherImage,
 							Env:             envVars,
 							ImagePullPolicy: corev1.PullIfNotPresent,
+							VolumeMounts: []corev1.VolumeMount{
+								util.NewKafkaSecretVolumeMount(),
+							},
 							Resources: corev1.ResourceRequirements{
 								Limits: corev1.ResourceList{
 									corev1.ResourceMemory: r.config.Dispatcher.MemoryLimit,
@@ -296,6 +306,12 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 		},
 	}
 
+	// Allow The Dispatcher To Drain In-Flight Events Within Its Configured DrainTimeout On Termination
+	if r.config.Dispatcher.DrainTimeoutSeconds > 0 {
+		terminationGracePeriodSeconds := int64(r.config.Dispatcher.DrainTimeoutSeconds + constants.DispatcherTerminationGracePeriodMargin)
+		deployment.Spec.Template.Spec.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
+	}
+
 	// Return The Dispatcher's Deployment
 	return deployment, nil
 }
@@ -371,6 +387,7 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{Name: kafkaSecret},
 					Key:                  constants.KafkaSecretDataKeyUsername,
+					Optional:             pointer.BoolPtr(true), // Not Required Without SASL Authentication (e.g. Mutual TLS)
 				},
 			},
 		})
@@ -382,9 +399,16 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{Name: kafkaSecret},
 					Key:                  constants.KafkaSecretDataKeyPassword,
+					Optional:             pointer.BoolPtr(true), // Not Required Without SASL Authentication (e.g. Mutual TLS)
 				},
 			},
 		})
+
+		// Append The Path Of The Mounted Kafka Secret (Watched For Credential Changes) As Env Var
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  commonenv.KafkaSecretPathEnvVarKey,
+			Value: commonconstants.KafkaSecretMountPath,
+		})
 	}
 
 	// Return The Dispatcher Deployment EnvVars Array
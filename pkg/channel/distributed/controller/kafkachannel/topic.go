@@ -2,6 +2,7 @@ package kafkachannel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -9,6 +10,7 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
@@ -36,7 +38,11 @@ func (r *Reconciler) reconcileTopic(ctx context.Context, channel *kafkav1beta1.K
 	if err != nil {
 		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.KafkaTopicReconciliationFailed.String(), "Failed To Reconcile Kafka Topic For Channel: %v", err)
 		logger.Error("Failed To Reconcile Topic", zap.Error(err))
-		channel.Status.MarkTopicFailed("TopicFailed", fmt.Sprintf("Channel Kafka Topic Failed: %s", err))
+		if r.isCapacityLimitError(err) {
+			channel.Status.MarkTopicFailed(constants.EventHubCapacityExhaustedReason, fmt.Sprintf("Azure EventHub Namespaces Are Full: %s", err))
+		} else {
+			channel.Status.MarkTopicFailed(constants.TopicFailedReason, fmt.Sprintf("Channel Kafka Topic Failed: %s", err))
+		}
 	} else {
 		logger.Info("Successfully Reconciled Topic")
 		channel.Status.MarkTopicTrue()
@@ -63,8 +69,9 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 
 	// Attempt To Create The Topic & Process TopicError Results (Including Success ;)
 	err := r.adminClient.CreateTopic(ctx, topicName, topicDetail)
-	if err != nil {
-		switch err.Err {
+	var topicError *sarama.TopicError
+	if errors.As(err, &topicError) {
+		switch topicError.Err {
 		case sarama.ErrNoError:
 			logger.Info("Successfully Created New Kafka Topic (ErrNoError)")
 			return nil
@@ -72,15 +79,24 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 			logger.Info("Kafka Topic Already Exists - No Creation Required")
 			return nil
 		default:
-			logger.Error("Failed To Create Topic", zap.Any("TopicError", err))
+			logger.Error("Failed To Create Topic", zap.Any("TopicError", topicError))
 			return err
 		}
+	} else if err != nil {
+		logger.Error("Failed To Create Topic", zap.Error(err))
+		return err
 	} else {
 		logger.Info("Successfully Created New Kafka Topic (Nil TopicError)")
 		return nil
 	}
 }
 
+// Determine Whether The Error Is Due To The Azure EventHub Namespaces Being Full
+func (r *Reconciler) isCapacityLimitError(err error) bool {
+	var capacityLimitError *kafkaadmin.EventHubCapacityLimitError
+	return errors.As(err, &capacityLimitError)
+}
+
 // Delete The Specified Kafka Topic
 func (r *Reconciler) deleteTopic(ctx context.Context, topicName string) error {
 
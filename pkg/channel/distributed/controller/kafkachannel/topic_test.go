@@ -10,6 +10,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
 	"knative.dev/pkg/controller"
@@ -22,7 +23,9 @@ type TopicTestCase struct {
 	Channel         *kafkav1beta1.KafkaChannel
 	WantTopicDetail *sarama.TopicDetail
 	MockErrorCode   sarama.KError
+	MockError       error // Returned By CreateTopic() Instead Of The TopicError With The MockErrorCode
 	WantError       string
+	WantReason      string
 	WantCreate      bool
 	WantDelete      bool
 }
@@ -112,6 +115,29 @@ func TestReconcileTopic(t *testing.T) {
 			},
 			MockErrorCode: sarama.ErrBrokerNotAvailable,
 			WantError:     sarama.ErrBrokerNotAvailable.Error() + " - " + controllertesting.ErrorString,
+			WantReason:    constants.TopicFailedReason,
+		},
+		{
+			Name: "Error Creating EventHub In Full Namespaces",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{constants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+			},
+			MockError:     kafkaadmin.NewEventHubCapacityLimitError(controllertesting.ErrorString),
+			WantError:     sarama.ErrInvalidTxnState.Error() + " - " + controllertesting.ErrorString,
+			WantReason:    constants.EventHubCapacityExhaustedReason,
 		},
 		{
 			Name: "Delete Existing Topic",
@@ -193,6 +219,12 @@ func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 			if !mockAdminClient.CreateTopicsCalled() {
 				t.Errorf("expected CreateTopics() called to be %t", tc.WantCreate)
 			}
+			if tc.WantReason != "" {
+				condition := tc.Channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady)
+				if condition == nil || condition.Reason != tc.WantReason {
+					t.Errorf("expected the TopicReady reason %s, got %+v", tc.WantReason, condition)
+				}
+			}
 		}
 
 		// Perform The Test (Delete) - Called By Knative FinalizeKind() Directly
@@ -221,7 +253,7 @@ func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase) *controlle
 	return &controllertesting.MockAdminClient{
 
 		// Mock CreateTopic Behavior - Validate Parameters & Return MockError
-		MockCreateTopicFunc: func(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+		MockCreateTopicFunc: func(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) error {
 			if !tc.WantCreate {
 				t.Error("Unexpected CreateTopics() Call")
 			}
@@ -234,6 +266,9 @@ func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase) *controlle
 			if diff := cmp.Diff(tc.WantTopicDetail, topicDetail); diff != "" {
 				t.Errorf("expected TopicDetail: %+v", diff)
 			}
+			if tc.MockError != nil {
+				return tc.MockError
+			}
 			errMsg := controllertesting.SuccessString
 			if tc.MockErrorCode != sarama.ErrNoError {
 				errMsg = controllertesting.ErrorString
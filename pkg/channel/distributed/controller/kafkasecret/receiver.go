@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/health"
@@ -268,6 +269,9 @@ func (r *Reconciler) newChannelDeployment(secret *corev1.Secret) (*appsv1.Deploy
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: r.environment.ServiceAccount,
+					Volumes: []corev1.Volume{
+						util.NewKafkaSecretVolume(secret.Name),
+					},
 					Containers: []corev1.Container{
 						{
 							Name: deploymentName,
@@ -300,6 +304,9 @@ func (r *Reconciler) newChannelDeployment(secret *corev1.Secret) (*appsv1.Deploy
 							},
 							Env:             channelEnvVars,
 							ImagePullPolicy: corev1.PullIfNotPresent,
+							VolumeMounts: []corev1.VolumeMount{
+								util.NewKafkaSecretVolumeMount(),
+							},
 							Resources: corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
 									corev1.ResourceCPU:    r.config.Receiver.CpuRequest,
@@ -370,6 +377,7 @@ func (r *Reconciler) receiverDeploymentEnvVars(secret *corev1.Secret) ([]corev1.
 			SecretKeyRef: &corev1.SecretKeySelector{
 				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
 				Key:                  constants.KafkaSecretDataKeyUsername,
+				Optional:             pointer.BoolPtr(true), // Not Required Without SASL Authentication (e.g. Mutual TLS)
 			},
 		},
 	})
@@ -381,10 +389,17 @@ func (r *Reconciler) receiverDeploymentEnvVars(secret *corev1.Secret) ([]corev1.
 			SecretKeyRef: &corev1.SecretKeySelector{
 				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
 				Key:                  constants.KafkaSecretDataKeyPassword,
+				Optional:             pointer.BoolPtr(true), // Not Required Without SASL Authentication (e.g. Mutual TLS)
 			},
 		},
 	})
 
+	// Append The Path Of The Mounted Kafka Secret (Watched For Credential Changes) As Env Var
+	envVars = append(envVars, corev1.EnvVar{
+		Name:  commonenv.KafkaSecretPathEnvVarKey,
+		Value: commonconstants.KafkaSecretMountPath,
+	})
+
 	// Return The Receiver Deployment EnvVars Array
 	return envVars, nil
 }
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
@@ -506,6 +507,16 @@ func NewKafkaChannelReceiverDeployment() *appsv1.Deployment {
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: ServiceAccount,
+					Volumes: []corev1.Volume{
+						{
+							Name: commonconstants.KafkaSecretVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: KafkaSecretName,
+								},
+							},
+						},
+					},
 					Containers: []corev1.Container{
 						{
 							Name: ReceiverDeploymentName,
@@ -576,6 +587,7 @@ func NewKafkaChannelReceiverDeployment() *appsv1.Deployment {
 										SecretKeyRef: &corev1.SecretKeySelector{
 											LocalObjectReference: corev1.LocalObjectReference{Name: KafkaSecretName},
 											Key:                  constants.KafkaSecretDataKeyUsername,
+											Optional:             pointer.BoolPtr(true),
 										},
 									},
 								},
@@ -585,11 +597,23 @@ func NewKafkaChannelReceiverDeployment() *appsv1.Deployment {
 										SecretKeyRef: &corev1.SecretKeySelector{
 											LocalObjectReference: corev1.LocalObjectReference{Name: KafkaSecretName},
 											Key:                  constants.KafkaSecretDataKeyPassword,
+											Optional:             pointer.BoolPtr(true),
 										},
 									},
 								},
+								{
+									Name:  commonenv.KafkaSecretPathEnvVarKey,
+									Value: commonconstants.KafkaSecretMountPath,
+								},
 							},
 							ImagePullPolicy: corev1.PullIfNotPresent,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      commonconstants.KafkaSecretVolumeName,
+									MountPath: commonconstants.KafkaSecretMountPath,
+									ReadOnly:  true,
+								},
+							},
 							Resources: corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
 									corev1.ResourceCPU:    resource.MustParse(ReceiverCpuRequest),
@@ -693,6 +717,16 @@ func NewKafkaChannelDispatcherDeployment() *appsv1.Deployment {
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: ServiceAccount,
+					Volumes: []corev1.Volume{
+						{
+							Name: commonconstants.KafkaSecretVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: KafkaSecretName,
+								},
+							},
+						},
+					},
 					Containers: []corev1.Container{
 						{
 							Name:  dispatcherName,
@@ -765,6 +799,7 @@ func NewKafkaChannelDispatcherDeployment() *appsv1.Deployment {
 										SecretKeyRef: &corev1.SecretKeySelector{
 											LocalObjectReference: corev1.LocalObjectReference{Name: KafkaSecretName},
 											Key:                  constants.KafkaSecretDataKeyUsername,
+											Optional:             pointer.BoolPtr(true),
 										},
 									},
 								},
@@ -774,11 +809,23 @@ func NewKafkaChannelDispatcherDeployment() *appsv1.Deployment {
 										SecretKeyRef: &corev1.SecretKeySelector{
 											LocalObjectReference: corev1.LocalObjectReference{Name: KafkaSecretName},
 											Key:                  constants.KafkaSecretDataKeyPassword,
+											Optional:             pointer.BoolPtr(true),
 										},
 									},
 								},
+								{
+									Name:  commonenv.KafkaSecretPathEnvVarKey,
+									Value: commonconstants.KafkaSecretMountPath,
+								},
 							},
 							ImagePullPolicy: corev1.PullIfNotPresent,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      commonconstants.KafkaSecretVolumeName,
+									MountPath: commonconstants.KafkaSecretMountPath,
+									ReadOnly:  true,
+								},
+							},
 							Resources: corev1.ResourceRequirements{
 								Limits: corev1.ResourceList{
 									corev1.ResourceMemory: resource.MustParse(DispatcherMemoryLimit),
@@ -19,12 +19,12 @@ type MockAdminClient struct {
 	closeCalled         bool
 	createTopicsCalled  bool
 	deleteTopicsCalled  bool
-	MockCreateTopicFunc func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
+	MockCreateTopicFunc func(context.Context, string, *sarama.TopicDetail) error
 	MockDeleteTopicFunc func(context.Context, string) *sarama.TopicError
 }
 
 // Mock Kafka AdminClient CreateTopic() Function - Calls Custom CreateTopic() If Specified, Otherwise Returns Success
-func (m *MockAdminClient) CreateTopic(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+func (m *MockAdminClient) CreateTopic(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) error {
 	m.createTopicsCalled = true
 	if m.MockCreateTopicFunc != nil {
 		return m.MockCreateTopicFunc(ctx, topicName, topicDetail)
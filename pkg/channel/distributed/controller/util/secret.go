@@ -5,6 +5,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 )
 
@@ -33,3 +34,25 @@ func NewSecretOwnerReference(secret *corev1.Secret) metav1.OwnerReference {
 		Controller:         &controller,
 	}
 }
+
+// Create A New Volume Of The Specified K8S Kafka Secret (Updated By The Kubelet When The Secret Changes)
+// All Keys Are Mounted As Files, So That The Optional Ones (Credentials, TLS Certificates) Need Not Be Present
+func NewKafkaSecretVolume(secretName string) corev1.Volume {
+	return corev1.Volume{
+		Name: commonconstants.KafkaSecretVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	}
+}
+
+// Create A New (Read Only) VolumeMount Of The Kafka Secret Volume
+func NewKafkaSecretVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      commonconstants.KafkaSecretVolumeName,
+		MountPath: commonconstants.KafkaSecretMountPath,
+		ReadOnly:  true,
+	}
+}
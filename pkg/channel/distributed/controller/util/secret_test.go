@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -48,3 +49,20 @@ func TestNewSecretOwnerReference(t *testing.T) {
 	assert.True(t, *controllerRef.BlockOwnerDeletion)
 	assert.True(t, *controllerRef.Controller)
 }
+
+// Test The NewKafkaSecretVolume() & NewKafkaSecretVolumeMount() Functionality
+func TestNewKafkaSecretVolume(t *testing.T) {
+
+	// Perform The Test
+	volume := NewKafkaSecretVolume("TestSecretName")
+	volumeMount := NewKafkaSecretVolumeMount()
+
+	// Verify The Results
+	assert.Equal(t, commonconstants.KafkaSecretVolumeName, volume.Name)
+	assert.NotNil(t, volume.Secret)
+	assert.Equal(t, "TestSecretName", volume.Secret.SecretName)
+	assert.Nil(t, volume.Secret.Items) // All Keys Mounted
+	assert.Equal(t, volume.Name, volumeMount.Name)
+	assert.Equal(t, commonconstants.KafkaSecretMountPath, volumeMount.MountPath)
+	assert.True(t, volumeMount.ReadOnly)
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -20,7 +23,11 @@ import (
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned/scheme"
 	informers "knative.dev/eventing-kafka/pkg/client/informers/externalversions/messaging/v1beta1"
 	listers "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/delivery"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	messaginginformers "knative.dev/eventing/pkg/client/informers/externalversions/messaging/v1"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 )
@@ -33,6 +40,9 @@ const (
 	channelReconciled         = "ChannelReconciled"
 	channelReconcileFailed    = "ChannelReconcileFailed"
 	channelUpdateStatusFailed = "ChannelUpdateStatusFailed"
+
+	// The Delay Of The Reconciliations Reporting The Delivery Health Of The Subscribers (At Most One Per Delay, Sparing The API Server)
+	deliveryHealthDelay = 30 * time.Second
 )
 
 // Reconciler reconciles KafkaChannels.
@@ -42,6 +52,7 @@ type Reconciler struct {
 	dispatcher           dispatcher.Dispatcher
 	kafkachannelInformer cache.SharedIndexInformer
 	kafkachannelLister   listers.KafkaChannelLister
+	subscriptionLister   messaginglisters.SubscriptionLister
 	impl                 *controller.Impl
 	recorder             record.EventRecorder
 	kafkaClientSet       versioned.Interface
@@ -56,6 +67,7 @@ func NewController(
 	channelKey string,
 	dispatcher dispatcher.Dispatcher,
 	kafkachannelInformer informers.KafkaChannelInformer,
+	subscriptionInformer messaginginformers.SubscriptionInformer,
 	kubeClient kubernetes.Interface,
 	kafkaClientSet versioned.Interface,
 	stopChannel <-chan struct{},
@@ -67,6 +79,7 @@ func NewController(
 		dispatcher:           dispatcher,
 		kafkachannelInformer: kafkachannelInformer.Informer(),
 		kafkachannelLister:   kafkachannelInformer.Lister(),
+		subscriptionLister:   subscriptionInformer.Lister(),
 		kafkaClientSet:       kafkaClientSet,
 	}
 	reconciler.impl = controller.NewImpl(reconciler, reconciler.logger.Sugar(), ReconcilerName)
@@ -75,6 +88,21 @@ func NewController(
 
 	// Watch for kafka channels.
 	kafkachannelInformer.Informer().AddEventHandler(controller.HandleAll(reconciler.impl.Enqueue))
+
+	// Report The Delivery Health Of The Subscribers When It Changes (Rate-Limited By The Delay Of The Enqueued Key)
+	if namespace, name, err := cache.SplitMetaNamespaceKey(channelKey); err == nil {
+		channelName := types.NamespacedName{Namespace: namespace, Name: name}
+		dispatcher.OnDeliveryHealthChanged(func() {
+			reconciler.impl.EnqueueKeyAfter(channelName, deliveryHealthDelay)
+		})
+	}
+
+	// Watch for subscriptions, to pause and resume them.
+	subscriptionInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		if subscription, ok := obj.(*messagingv1.Subscription); ok && subscription.Spec.Channel.Kind == "KafkaChannel" {
+			reconciler.impl.EnqueueKey(types.NamespacedName{Namespace: subscription.Namespace, Name: subscription.Spec.Channel.Name})
+		}
+	}))
 	logger.Debug("Creating event broadcaster")
 	eventBroadcaster := record.NewBroadcaster()
 	watches := []watch.Interface{
@@ -163,11 +191,59 @@ func (r Reconciler) reconcile(channel *kafkav1beta1.KafkaChannel) error {
 		subscribers = make([]eventingduck.SubscriberSpec, 0)
 	}
 
+	// Get The Annotations Of The Subscriptions (Paused, Limits, RetryPolicy & Deduplication Window)
+	annotations, err := r.subscriptionAnnotations(channel.Namespace)
+	if err != nil {
+		r.logger.Error("Failed To List Subscriptions", zap.Error(err))
+		return err
+	}
+
+	// Pause, Limit, Configure The Retries & Deduplicate The Delivery Of The Subscribers As Annotated (Before Any New ConsumerGroup Starts - Invalid Annotations Are Ignored)
+	pausedSubscriptions := make(map[types.UID]bool)
+	subscriptionLimits := make(map[types.UID]delivery.Limits)
+	retryPolicies := make(map[types.UID]delivery.RetryPolicy)
+	deduplicationWindows := make(map[types.UID]time.Duration)
+	invalidSubscriptions := make(map[types.UID]error)
+	for _, subscriber := range subscribers {
+		pausedSubscriptions[subscriber.UID] = kafkav1beta1.IsSubscriptionPaused(annotations[subscriber.UID])
+		if limits, err := delivery.LimitsFromAnnotations(annotations[subscriber.UID]); err != nil {
+			invalidSubscriptions[subscriber.UID] = err
+		} else {
+			subscriptionLimits[subscriber.UID] = limits
+		}
+		if retryPolicy, err := delivery.RetryPolicyFromAnnotations(annotations[subscriber.UID]); err != nil {
+			invalidSubscriptions[subscriber.UID] = err
+		} else {
+			retryPolicies[subscriber.UID] = retryPolicy
+		}
+		if window, err := delivery.DeduplicationWindowFromAnnotations(annotations[subscriber.UID]); err != nil {
+			invalidSubscriptions[subscriber.UID] = err
+		} else {
+			deduplicationWindows[subscriber.UID] = window
+		}
+	}
+	r.dispatcher.PauseSubscriptions(pausedSubscriptions)
+	r.dispatcher.LimitSubscriptions(subscriptionLimits)
+	r.dispatcher.SetRetryPolicies(retryPolicies)
+	r.dispatcher.SetDeduplicationWindows(deduplicationWindows)
+
 	// Update The ConsumerGroups To Align With Current KafkaChannel Subscribers
 	failedSubscriptions := r.dispatcher.UpdateSubscriptions(subscribers)
 
-	// Update The KafkaChannel Subscribable Status Based On ConsumerGroup Creation Status
-	channel.Status.SubscribableStatus = r.createSubscribableStatus(channel.Spec.Subscribers, failedSubscriptions)
+	// Report The Subscribers With Invalid Annotations As Failed
+	for _, subscriber := range subscribers {
+		if err, ok := invalidSubscriptions[subscriber.UID]; ok {
+			if failedSubscriptions == nil {
+				failedSubscriptions = make(map[eventingduck.SubscriberSpec]error)
+			}
+			if _, failed := failedSubscriptions[subscriber]; !failed {
+				failedSubscriptions[subscriber] = err
+			}
+		}
+	}
+
+	// Update The KafkaChannel Subscribable Status Based On ConsumerGroup Creation Status & The Delivery Health
+	channel.Status.SubscribableStatus = r.createSubscribableStatus(channel.Spec.Subscribers, failedSubscriptions, pausedSubscriptions, r.dispatcher.DeliveryHealth())
 
 	// Log Failed Subscriptions & Return Error
 	if len(failedSubscriptions) > 0 {
@@ -179,8 +255,21 @@ func (r Reconciler) reconcile(channel *kafkav1beta1.KafkaChannel) error {
 	return nil
 }
 
+// Get The Annotations Of The Subscriptions In The Specified Namespace By UID
+func (r Reconciler) subscriptionAnnotations(namespace string) (map[types.UID]map[string]string, error) {
+	subscriptions, err := r.subscriptionLister.Subscriptions(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	annotations := make(map[types.UID]map[string]string, len(subscriptions))
+	for _, subscription := range subscriptions {
+		annotations[subscription.UID] = subscription.GetAnnotations()
+	}
+	return annotations, nil
+}
+
 // Create The SubscribableStatus Block Based On The Updated Subscriptions
-func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.SubscriberSpec, failedSubscriptions map[eventingduck.SubscriberSpec]error) eventingduck.SubscribableStatus {
+func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.SubscriberSpec, failedSubscriptions map[eventingduck.SubscriberSpec]error, pausedSubscriptions map[types.UID]bool, deliveryHealth map[types.UID]delivery.HealthStatus) eventingduck.SubscribableStatus {
 
 	subscriberStatus := make([]eventingduck.SubscriberStatus, 0)
 
@@ -193,6 +282,11 @@ func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.Subscri
 		if err, ok := failedSubscriptions[subscriber]; ok {
 			status.Ready = corev1.ConditionFalse
 			status.Message = err.Error()
+		} else if pausedSubscriptions[subscriber.UID] {
+			status.Message = "The subscription is paused by the " + kafkav1beta1.KafkaSubscriptionPausedAnnotation + " annotation"
+		} else if health := deliveryHealth[subscriber.UID]; !health.Healthy() {
+			status.Ready = corev1.ConditionFalse
+			status.Message = health.Message()
 		}
 		subscriberStatus = append(subscriberStatus, status)
 	}
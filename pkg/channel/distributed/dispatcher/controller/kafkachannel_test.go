@@ -4,9 +4,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
@@ -17,7 +19,11 @@ import (
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	fakeclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
 	"knative.dev/eventing-kafka/pkg/client/informers/externalversions"
+	"knative.dev/eventing-kafka/pkg/common/admin"
+	"knative.dev/eventing-kafka/pkg/common/delivery"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	fakeeventingclientset "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions"
 	"knative.dev/pkg/controller"
 	kncontroller "knative.dev/pkg/controller"
 	logtesting "knative.dev/pkg/logging/testing"
@@ -46,10 +52,12 @@ func TestNewController(t *testing.T) {
 	fakeK8sClientSet := fake.NewSimpleClientset()
 	kafkaInformerFactory := externalversions.NewSharedInformerFactory(fakeKafkaChannelClientSet, kncontroller.DefaultResyncPeriod)
 	kafkaChannelInformer := kafkaInformerFactory.Messaging().V1beta1().KafkaChannels()
+	eventingInformerFactory := eventinginformers.NewSharedInformerFactory(fakeeventingclientset.NewSimpleClientset(), kncontroller.DefaultResyncPeriod)
+	subscriptionInformer := eventingInformerFactory.Messaging().V1().Subscriptions()
 	stopChan := make(chan struct{})
 
 	// Perform The Test
-	c := NewController(logger, channelKey, mockDispatcher, kafkaChannelInformer, fakeK8sClientSet, fakeKafkaChannelClientSet, stopChan)
+	c := NewController(logger, channelKey, mockDispatcher, kafkaChannelInformer, subscriptionInformer, fakeK8sClientSet, fakeKafkaChannelClientSet, stopChan)
 
 	// Verify Results
 	assert.NotNil(t, c)
@@ -139,6 +147,137 @@ func TestAllCases(t *testing.T) {
 				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
 			},
 		},
+		{
+			Name: "channel ready, paused subscriber",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+				reconciletesting.NewPausedSubscription("1", "sub", testNS, kcName),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriberPaused("1"),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
+			},
+		},
+		{
+			Name: "channel ready, subscriber with failing deliveries",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber("unhealthy", "http://foobar"),
+					reconciletesting.WithSubscriberReady("unhealthy")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithSubscriber("unhealthy", "http://foobar"),
+					reconciletesting.WithSubscriberFailed("unhealthy", "DeliveryFailing: the last 10 deliveries to the subscriber failed, the last one with the status code 503, no delivery succeeded yet"),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
+			},
+		},
+		{
+			Name: "channel ready, subscriber with invalid limits",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+				reconciletesting.NewAnnotatedSubscription("1", "sub", testNS, kcName, map[string]string{
+					v1beta1.KafkaSubscriptionRateLimitAnnotation: "fast",
+				}),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriberFailed("1", `invalid kafkachannels.messaging.knative.dev/rate-limit annotation "fast": expected a positive number of events per second`),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: some kafka subscribers failed to subscribe"),
+			},
+		},
+		{
+			Name: "channel ready, subscriber with invalid retry policy",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+				reconciletesting.NewAnnotatedSubscription("1", "sub", testNS, kcName, map[string]string{
+					v1beta1.KafkaSubscriptionRetryableStatusCodesAnnotation: "429,busy",
+				}),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriberFailed("1", `invalid kafkachannels.messaging.knative.dev/retryable-status-codes annotation "429,busy": expected a comma separated list of HTTP status codes`),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: some kafka subscribers failed to subscribe"),
+			},
+		},
+		{
+			Name: "channel ready, subscriber with invalid deduplication window",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+				reconciletesting.NewAnnotatedSubscription("1", "sub", testNS, kcName, map[string]string{
+					v1beta1.KafkaSubscriptionDeduplicationWindowAnnotation: "-1m",
+				}),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriberFailed("1", `invalid kafkachannels.messaging.knative.dev/deduplication-window annotation "-1m": expected a positive duration`),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: some kafka subscribers failed to subscribe"),
+			},
+		},
 	}
 
 	table.Test(t, reconciletesting.MakeFactory(func(listers *reconciletesting.Listers, kafkaClient versioned.Interface, eventRecorder record.EventRecorder) controller.Reconciler {
@@ -147,6 +286,7 @@ func TestAllCases(t *testing.T) {
 			channelKey:           kcKey,
 			kafkachannelInformer: nil,
 			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			subscriptionLister:   listers.GetSubscriptionLister(),
 			dispatcher:           NewMockDispatcher(t),
 			recorder:             eventRecorder,
 			kafkaClientSet:       kafkaClient,
@@ -181,6 +321,44 @@ func (m MockDispatcher) UpdateSubscriptions(_ []eventingduck.SubscriberSpec) map
 	return nil
 }
 
-func (m MockDispatcher) ConfigChanged(*corev1.ConfigMap) dispatcher.Dispatcher {
+func (m MockDispatcher) PauseSubscriptions(_ map[types.UID]bool) {
+}
+
+func (m MockDispatcher) LimitSubscriptions(_ map[types.UID]delivery.Limits) {
+}
+
+func (m MockDispatcher) SetRetryPolicies(_ map[types.UID]delivery.RetryPolicy) {
+}
+
+func (m MockDispatcher) SetDeduplicationWindows(_ map[types.UID]time.Duration) {
+}
+
+// Mock The Delivery To The "unhealthy" Subscriber Failing
+func (m MockDispatcher) DeliveryHealth() map[types.UID]delivery.HealthStatus {
+	return map[types.UID]delivery.HealthStatus{
+		"unhealthy": {ConsecutiveFailures: 10, LastStatusCode: 503, Reason: delivery.DeliveryFailingReason},
+	}
+}
+
+func (m MockDispatcher) OnDeliveryHealthChanged(_ func()) {
+}
+
+func (m MockDispatcher) SetAdminPaused(_ types.UID, _ bool) bool {
+	return false
+}
+
+func (m MockDispatcher) Subscriptions() []admin.Subscription {
+	return nil
+}
+
+func (m MockDispatcher) KafkaConfig() ([]string, *sarama.Config) {
+	return nil, nil
+}
+
+func (m MockDispatcher) ConfigChanged(*corev1.ConfigMap) error {
+	return nil
+}
+
+func (m MockDispatcher) CredentialsChanged(_ string, _ string) error {
 	return nil
 }
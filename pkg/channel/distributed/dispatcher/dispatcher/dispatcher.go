@@ -3,15 +3,23 @@ package dispatcher
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer"
 	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/common/admin"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/delivery"
+	commonhealth "knative.dev/eventing-kafka/pkg/common/health"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
 )
@@ -28,34 +36,119 @@ type DispatcherConfig struct {
 	StatsReporter   metrics.StatsReporter
 	SaramaConfig    *sarama.Config
 	SubscriberSpecs []eventingduck.SubscriberSpec
+	DrainTimeout    time.Duration               // Optional (Defaults To DefaultDrainTimeout)
+	TLSCertificates kafkasarama.TLSCertificates // Optional (Mutual TLS Authentication, Already Applied To The SaramaConfig)
+	Sessions        *commonhealth.Sessions      // Optional (Tracks The Sessions Of The ConsumerGroups For The Health Checks)
+	BrokerCheck     *commonhealth.BrokerCheck   // Optional (Updated With The SaramaConfig The ConsumerGroups Are Rolled Onto)
 }
 
+// The Default Time Allowed For In-Flight Dispatches To Complete When Closing ConsumerGroups (Within The Default Pod Termination Grace Period)
+const DefaultDrainTimeout = 20 * time.Second
+
+// The Time Allowed For ConsumerGroup Sessions To End Once The In-Flight Dispatches Exceeding The DrainTimeout Have Been Aborted
+const drainAbortTimeout = 5 * time.Second
+
+// The Time Allowed For A ConsumerGroup Rolled Onto A New SaramaConfig To Join The Group Before Being Rolled Back
+const rollJoinTimeout = 30 * time.Second
+
+// The Impact Of A SaramaConfig Change On The Running Dispatcher
+type configImpact int
+
+const (
+	configImpactNone     configImpact = iota // No Changes
+	configImpactProducer                     // Producer Changes Only (Applying To The Producers Created Later)
+	configImpactConsumer                     // Consumer Changes (The ConsumerGroups Are Rolled Onto The New SaramaConfig)
+)
+
 // Knative Eventing SubscriberSpec Wrapper Enhanced With Sarama ConsumerGroup
 type SubscriberWrapper struct {
 	eventingduck.SubscriberSpec
 	GroupId       string
 	ConsumerGroup sarama.ConsumerGroup
 	StopChan      chan struct{}
+	PauseGate     *commonconsumer.PauseGate
+	Limiter       *delivery.Limiter
+	RetryTopics   *delivery.RetryTopics
+	Deduplicator  *delivery.Deduplicator
+	Health        *delivery.Health // The Rolling Health Of The Delivery To The Subscriber (Reported In Its Status)
+
+	// The ConsumerGroup Of The Retry Topics (Started Once Retry Topics Are Enabled)
+	RetryConsumerGroup sarama.ConsumerGroup
+	RetryStopChan      chan struct{}
+	RetryTiers         int
+
+	// The Running Consumption Of The Topic & Retry Topics (Drained Before Closing The ConsumerGroups)
+	consumption      *consumption
+	retryConsumption *consumption
+
+	// The SaramaConfig (& Its Generation) The ConsumerGroups Were Created With
+	saramaConfig     *sarama.Config
+	configGeneration int
+
+	// Whether The ConsumerGroups Are Being Rolled (Without The consumerUpdateLock) & Whether The Subscriber Was Removed
+	// Meanwhile (Its ConsumerGroups Then Being Closed Once Rolled)
+	rolling bool
+	removed bool
 }
 
 // SubscriberWrapper Constructor
 func NewSubscriberWrapper(subscriberSpec eventingduck.SubscriberSpec, groupId string, consumerGroup sarama.ConsumerGroup) *SubscriberWrapper {
-	return &SubscriberWrapper{subscriberSpec, groupId, consumerGroup, make(chan struct{})}
+	return &SubscriberWrapper{
+		SubscriberSpec: subscriberSpec,
+		GroupId:        groupId,
+		ConsumerGroup:  consumerGroup,
+		StopChan:       make(chan struct{}),
+		PauseGate:      commonconsumer.NewPauseGate(),
+		Limiter:        delivery.NewLimiter(delivery.Limits{}),
+		Health:         delivery.NewHealth(delivery.DefaultHealthThresholds),
+	}
 }
 
 //  Dispatcher Interface
 type Dispatcher interface {
-	ConfigChanged(*v1.ConfigMap) Dispatcher
+	admin.Dispatcher
+	ConfigChanged(*v1.ConfigMap) error
+	CredentialsChanged(username string, password string) error
 	Shutdown()
 	UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec) map[eventingduck.SubscriberSpec]error
+	PauseSubscriptions(paused map[types.UID]bool)
+	LimitSubscriptions(limits map[types.UID]delivery.Limits)
+	SetRetryPolicies(policies map[types.UID]delivery.RetryPolicy)
+	SetDeduplicationWindows(windows map[types.UID]time.Duration)
+	DeliveryHealth() map[types.UID]delivery.HealthStatus
+	OnDeliveryHealthChanged(fn func())
 }
 
 // Define A DispatcherImpl Struct With Configuration & ConsumerGroup State
 type DispatcherImpl struct {
 	DispatcherConfig
-	subscribers        map[types.UID]*SubscriberWrapper
-	consumerUpdateLock sync.Mutex
-	messageDispatcher  channel.MessageDispatcher
+	subscribers         map[types.UID]*SubscriberWrapper
+	pausedSubscriptions map[types.UID]bool
+	subscriptionLimits  map[types.UID]delivery.Limits
+	retryPolicies       map[types.UID]delivery.RetryPolicy
+	dedupWindows        map[types.UID]time.Duration // The Deduplication Windows Of The Subscriptions
+	adminPaused         map[types.UID]bool          // The Subscriptions Paused Through The Admin Endpoints
+	retryProducer       sarama.SyncProducer
+	retiredProducers    []sarama.SyncProducer // Retry Topics Producers Replaced By New Credentials (Closed Once Unused)
+	configGeneration    int                   // Incremented By Each SaramaConfig Change Impacting The ConsumerGroups
+	rolling             bool                  // Whether ConsumerGroups Are Being Rolled Onto The Current SaramaConfig
+	consumerUpdateLock  sync.Mutex
+	messageDispatcher   channel.MessageDispatcher
+	healthChanged       atomic.Value // The func() Called When The Delivery Health Of A Subscriber Changes (Without The consumerUpdateLock)
+}
+
+// Wrapper Functions To Facilitate Testing With Mock Sarama Retry Topic Clients
+var newRetryProducerWrapper = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+	syncProducer, _, err := producer.CreateSyncProducer(brokers, config)
+	return syncProducer, err
+}
+var newClusterAdminWrapper = func(brokers []string, config *sarama.Config) (sarama.ClusterAdmin, error) {
+	return sarama.NewClusterAdmin(brokers, config)
+}
+
+// Wrapper Function To Facilitate Testing With A Mock Sarama Client (Scanning Back The Partitions For Deduplication)
+var newClientWrapper = func(brokers []string, config *sarama.Config) (sarama.Client, error) {
+	return sarama.NewClient(brokers, config)
 }
 
 // Verify The DispatcherImpl Implements The Dispatcher Interface
@@ -78,10 +171,25 @@ func NewDispatcher(dispatcherConfig DispatcherConfig) Dispatcher {
 // Shutdown The Dispatcher
 func (d *DispatcherImpl) Shutdown() {
 
-	// Close ConsumerGroups Of All Subscriptions
+	// Synchronize With The Subscription Updates (Whose Rolling May Release The Lock Meanwhile)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	// Drain & Close ConsumerGroups Of All Subscriptions
+	subscribers := make([]*SubscriberWrapper, 0, len(d.subscribers))
 	for _, subscriber := range d.subscribers {
-		d.closeConsumerGroup(subscriber)
+		subscribers = append(subscribers, subscriber)
+	}
+	d.closeConsumerGroups(subscribers...)
+
+	// Close The Retry Topics Producer
+	if d.retryProducer != nil {
+		if err := d.retryProducer.Close(); err != nil {
+			d.Logger.Error("Failed To Close Retry Topics Producer", zap.Error(err))
+		}
+		d.retryProducer = nil
 	}
+	d.closeRetiredProducers(true)
 }
 
 // Update The Dispatcher's Subscriptions To Align With New State
@@ -122,8 +230,17 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 
 			} else {
 
-				// Create A New SubscriberWrapper With The ConsumerGroup
+				// Create A New SubscriberWrapper With The ConsumerGroup (Paused If Requested)
 				subscriber := NewSubscriberWrapper(subscriberSpec, groupId, consumerGroup)
+				subscriber.saramaConfig = d.SaramaConfig
+				subscriber.configGeneration = d.configGeneration
+				subscriber.PauseGate.SetPaused(d.pausedSubscriptions[subscriberSpec.UID] || d.adminPaused[subscriberSpec.UID])
+				subscriber.Limiter.SetLimits(d.subscriptionLimits[subscriberSpec.UID])
+				subscriber.Limiter.SetRetryPolicy(d.retryPolicies[subscriberSpec.UID])
+				subscriber.RetryTopics = &delivery.RetryTopics{Logger: logger, Topic: d.Topic, Subscription: subscriberSpec.UID}
+				subscriber.Deduplicator = delivery.NewDeduplicator(logger, subscriberSpec.UID)
+				subscriber.Deduplicator.SetWindow(d.dedupWindows[subscriberSpec.UID])
+				subscriber.Health.SetOnChange(d.deliveryHealthChanged)
 
 				// Should start observing metrics from Sarama Config.MetricsRegistry from CreateConsumerGroup() above ; )
 
@@ -137,8 +254,17 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 
 		} else {
 
-			// Otherwise Just Add To List Of Active Subscribers
+			// Otherwise Just Add To List Of Active Subscribers (Keeping It If Removed While Its ConsumerGroups Are Rolled)
 			activeSubscriptions[subscriberSpec.UID] = true
+			d.subscribers[subscriberSpec.UID].removed = false
+		}
+
+		// Consume The Subscriber's Retry Topics If Enabled (Once Rolled If Its ConsumerGroups Are Being Rolled)
+		if subscriber, ok := d.subscribers[subscriberSpec.UID]; ok && !subscriber.rolling {
+			if err := d.updateRetryTopics(subscriber, subscriberSpec); err != nil {
+				d.Logger.Error("Failed To Consume Retry Topics", zap.String("GroupId", subscriber.GroupId), zap.Error(err))
+				failedSubscriptions[subscriberSpec] = err
+			}
 		}
 	}
 
@@ -146,19 +272,207 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 	// if necessary without going through the inactive subscribers again.
 	d.SubscriberSpecs = []eventingduck.SubscriberSpec{}
 
-	// Close ConsumerGroups For Removed Subscriptions (In Map But No Longer Active)
+	// Drain & Close ConsumerGroups For Removed Subscriptions (In Map But No Longer Active, Once Rolled If Being Rolled)
+	var removedSubscribers []*SubscriberWrapper
 	for _, subscriber := range d.subscribers {
 		if !activeSubscriptions[subscriber.UID] {
-			d.closeConsumerGroup(subscriber)
+			subscriber.removed = true
+			if !subscriber.rolling {
+				removedSubscribers = append(removedSubscribers, subscriber)
+			}
 		} else {
 			d.SubscriberSpecs = append(d.SubscriberSpecs, subscriber.SubscriberSpec)
 		}
 	}
+	d.closeConsumerGroups(removedSubscribers...)
+
+	// Forget The Admin Pausing Of The Removed Subscriptions
+	for uid := range d.adminPaused {
+		if _, ok := d.subscribers[uid]; !ok {
+			delete(d.adminPaused, uid)
+		}
+	}
+
+	// Retry Rolling The ConsumerGroups Still On A Previous SaramaConfig (Reporting The Subscriber Halting The Rolling)
+	if subscriber, err := d.rollOutdatedConsumerGroups(); err != nil {
+		for _, subscriberSpec := range subscriberSpecs {
+			if subscriberSpec.UID == subscriber.UID {
+				failedSubscriptions[subscriberSpec] = err
+			}
+		}
+	}
+
+	// Close The Retry Topics Producers Retired By A Credentials Change Once The Subscribers Were Rolled Off Them
+	d.closeRetiredProducers(false)
 
 	// Return Any Failed Subscriber Errors
 	return failedSubscriptions
 }
 
+// Pause The Specified Subscriptions & Resume All Others (The ConsumerGroups Keep Running To Retain Their Membership)
+func (d *DispatcherImpl) PauseSubscriptions(paused map[types.UID]bool) {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	// Track The Paused Subscriptions For Subscribers Created Later
+	d.pausedSubscriptions = paused
+
+	// Pause / Resume The Existing Subscribers (Unless Paused Through The Admin Endpoints)
+	for uid, subscriber := range d.subscribers {
+		subscriber.PauseGate.SetPaused(paused[uid] || d.adminPaused[uid])
+	}
+}
+
+// Pause Or Resume The Specified Subscription Through The Admin Endpoints (On Top Of PauseSubscriptions), Returning False If Unknown
+func (d *DispatcherImpl) SetAdminPaused(uid types.UID, paused bool) bool {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	// Only Known Subscriptions Can Be Paused (Until They Are Removed Or The Dispatcher Restarts)
+	subscriber, ok := d.subscribers[uid]
+	if !ok {
+		return false
+	}
+	if d.adminPaused == nil {
+		d.adminPaused = make(map[types.UID]bool)
+	}
+	if paused {
+		d.adminPaused[uid] = true
+	} else {
+		delete(d.adminPaused, uid)
+	}
+	subscriber.PauseGate.SetPaused(d.pausedSubscriptions[uid] || paused)
+	return true
+}
+
+// Get The State Of The Subscriptions & Their ConsumerGroups For The Admin Endpoints
+func (d *DispatcherImpl) Subscriptions() []admin.Subscription {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	subscriptions := make([]admin.Subscription, 0, len(d.subscribers))
+	for uid, subscriber := range d.subscribers {
+		subscription := admin.Subscription{
+			UID:         uid,
+			Channel:     d.ChannelKey,
+			Subscriber:  subscriber.SubscriberURI.String(),
+			Reply:       subscriber.ReplyURI.String(),
+			Paused:      subscriber.PauseGate.IsPaused(),
+			AdminPaused: d.adminPaused[uid],
+		}
+		if subscriber.Delivery != nil && subscriber.Delivery.DeadLetterSink != nil {
+			subscription.DeadLetter = subscriber.Delivery.DeadLetterSink.URI.String()
+		}
+		if subscriber.consumption != nil {
+			subscription.ConsumerGroups = append(subscription.ConsumerGroups, admin.ConsumerGroup{
+				GroupID: subscriber.GroupId,
+				Topics:  []string{d.Topic},
+				Stats:   subscriber.consumption.stats,
+			})
+		}
+		if subscriber.retryConsumption != nil {
+			subscription.ConsumerGroups = append(subscription.ConsumerGroups, admin.ConsumerGroup{
+				GroupID: subscriber.GroupId + ".retry",
+				Topics:  delivery.RetryTopicNames(d.Topic, subscriber.RetryTiers),
+				Stats:   subscriber.retryConsumption.stats,
+			})
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	admin.SortSubscriptions(subscriptions)
+	return subscriptions
+}
+
+// Get The Rolling Health Of The Delivery To The Subscribers
+func (d *DispatcherImpl) DeliveryHealth() map[types.UID]delivery.HealthStatus {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	deliveryHealth := make(map[types.UID]delivery.HealthStatus, len(d.subscribers))
+	for uid, subscriber := range d.subscribers {
+		deliveryHealth[uid] = subscriber.Health.Status()
+	}
+	return deliveryHealth
+}
+
+// Register The Function Called When The Delivery Health Of A Subscriber Changes (After Each Delivery While Unhealthy - It Must Not Block)
+func (d *DispatcherImpl) OnDeliveryHealthChanged(fn func()) {
+	d.healthChanged.Store(fn)
+}
+
+// Notify The Registered Function (If Any) Of A Delivery Health Change
+func (d *DispatcherImpl) deliveryHealthChanged() {
+	if fn, ok := d.healthChanged.Load().(func()); ok && fn != nil {
+		fn()
+	}
+}
+
+// Get The Brokers & The SaramaConfig The ConsumerGroups Are Created With For The Admin Endpoints
+func (d *DispatcherImpl) KafkaConfig() ([]string, *sarama.Config) {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	return d.Brokers, d.SaramaConfig
+}
+
+// Limit The Delivery To The Specified Subscriptions (Unlimited For All Others)
+func (d *DispatcherImpl) LimitSubscriptions(limits map[types.UID]delivery.Limits) {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	// Track The Limits For Subscribers Created Later
+	d.subscriptionLimits = limits
+
+	// Update The Limits Of The Existing Subscribers
+	for uid, subscriber := range d.subscribers {
+		subscriber.Limiter.SetLimits(limits[uid])
+	}
+}
+
+// Set The RetryPolicy Of The Specified Subscriptions (Default RetryPolicy For All Others)
+func (d *DispatcherImpl) SetRetryPolicies(policies map[types.UID]delivery.RetryPolicy) {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	// Track The RetryPolicies For Subscribers Created Later
+	d.retryPolicies = policies
+
+	// Update The RetryPolicy Of The Existing Subscribers (Applies To The Next Messages)
+	for uid, subscriber := range d.subscribers {
+		subscriber.Limiter.SetRetryPolicy(policies[uid])
+	}
+}
+
+// Set The Deduplication Window Of The Specified Subscriptions (Deduplication Disabled For All Others)
+func (d *DispatcherImpl) SetDeduplicationWindows(windows map[types.UID]time.Duration) {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	// Track The Deduplication Windows For Subscribers Created Later
+	d.dedupWindows = windows
+
+	// Update The Deduplication Window Of The Existing Subscribers (Forgetting The Delivered Events When It Changes)
+	for uid, subscriber := range d.subscribers {
+		subscriber.Deduplicator.SetWindow(windows[uid])
+	}
+}
+
 // Start Consuming Messages With The Specified Subscriber's ConsumerGroup
 func (d *DispatcherImpl) startConsuming(subscriber *SubscriberWrapper) {
 
@@ -168,50 +482,285 @@ func (d *DispatcherImpl) startConsuming(subscriber *SubscriberWrapper) {
 		// Setup The ConsumerGroup Level Logger
 		logger := d.Logger.With(zap.String("GroupId", subscriber.GroupId))
 
-		// Asynchronously Process ConsumerGroup's Error Channel
-		go func() {
-			logger.Info("ConsumerGroup Error Processing Initiated")
-			for err := range subscriber.ConsumerGroup.Errors() { // Closing ConsumerGroup Will Break Out Of This
-				logger.Error("ConsumerGroup Error", zap.Error(err))
-			}
-			logger.Info("ConsumerGroup Error Processing Terminated")
-		}()
-
-		// Create A New ConsumerGroupHandler To Consume Messages With
-		handler := NewHandler(logger, &subscriber.SubscriberSpec)
-
-		// Consume Messages Asynchronously
-		go func() {
-
-			// Infinite Loop To Support Server-Side ConsumerGroup Re-Balance Which Ends Consume() Execution
-			ctx := context.Background()
-			for {
-				select {
-
-				// Non-Blocking Stop Channel Check
-				case <-subscriber.StopChan:
-					logger.Info("ConsumerGroup Closed - Ceasing Consumption")
-					return
-
-				// Start ConsumerGroup Consumption
-				default:
-					logger.Info("ConsumerGroup Message Consumption Initiated")
-					err := subscriber.ConsumerGroup.Consume(ctx, []string{d.Topic}, handler)
-					if err != nil {
-						if err == sarama.ErrClosedConsumerGroup {
-							logger.Info("ConsumerGroup Closed Error - Ceasing Consumption") // Should be caught above but here as added precaution.
-							break
-						} else {
-							logger.Error("ConsumerGroup Failed To Consume Messages", zap.Error(err))
-						}
+		// Create A New ConsumerGroupHandler To Consume Messages With (Dispatching Until The Consumption Is Aborted)
+		subscriber.consumption = newConsumption()
+		subscriber.consumption.session = d.Sessions.Track(subscriber.GroupId)
+		handler := NewHandler(subscriber.consumption.dispatchCtx, logger, &subscriber.SubscriberSpec, subscriber.PauseGate, subscriber.Limiter, subscriber.RetryTopics, subscriber.Deduplicator)
+		handler.Stats = subscriber.consumption.stats
+		handler.Health = subscriber.Health
+
+		// Scan Back The Partitions Assigned To The ConsumerGroup With Its SaramaConfig (When Deduplicating)
+		saramaConfig := subscriber.saramaConfig
+		subscriber.Deduplicator.SetNewClient(func() (sarama.Client, error) { return newClientWrapper(d.Brokers, saramaConfig) })
+
+		// Consume The Channel's Topic
+		subscriber.consumption.consume(logger, subscriber.ConsumerGroup, subscriber.StopChan, []string{d.Topic}, handler)
+	}
+}
+
+// Consume The Subscriber's Retry Topics Up To The Number Of Retries Of Its Delivery (When Retry Topics Are Enabled)
+func (d *DispatcherImpl) updateRetryTopics(subscriber *SubscriberWrapper, subscriberSpec eventingduck.SubscriberSpec) error {
+
+	// Nothing To Do Unless Enabled With More Tiers Than Currently Consumed (A Running Retry ConsumerGroup Keeps Draining The Retry Topics)
+	tiers := retryTiers(subscriberSpec)
+	if !subscriber.Limiter.RetryPolicy().RetryTopics || tiers <= subscriber.RetryTiers {
+		return nil
+	}
+
+	// Lazily Create The Producer Shared By All Subscribers (SyncProducers Require Successes To Be Returned)
+	if d.retryProducer == nil {
+		config := *d.SaramaConfig
+		config.Producer.Return.Successes = true
+		retryProducer, err := newRetryProducerWrapper(d.Brokers, &config)
+		if err != nil {
+			return fmt.Errorf("failed to create the retry topics producer: %w", err)
+		}
+		d.retryProducer = retryProducer
+	}
+
+	// Create The Missing Retry Topics Alike The Channel's Topic
+	clusterAdmin, err := newClusterAdminWrapper(d.Brokers, d.SaramaConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create the cluster admin: %w", err)
+	}
+	defer func() { _ = clusterAdmin.Close() }()
+	if err := delivery.EnsureRetryTopics(clusterAdmin, d.Topic, tiers); err != nil {
+		return err
+	}
+
+	// Replace Any Retry ConsumerGroup Consuming Fewer Tiers
+	d.closeRetryConsumerGroup(subscriber)
+	groupId := subscriber.GroupId + ".retry"
+	logger := d.Logger.With(zap.String("GroupId", groupId))
+	saramaConfig := subscriber.saramaConfig
+	if saramaConfig == nil {
+		saramaConfig = d.SaramaConfig
+	}
+	retryConsumerGroup, _, err := consumer.CreateConsumerGroup(d.Brokers, saramaConfig, groupId)
+	if err != nil {
+		return fmt.Errorf("failed to create the retry topics consumer group: %w", err)
+	}
+	subscriber.RetryConsumerGroup = retryConsumerGroup
+	subscriber.RetryStopChan = make(chan struct{})
+	subscriber.RetryTiers = tiers
+
+	// Start Consuming The Retry Topics & Only Then Produce To Them
+	subscriber.RetryTopics.Producer = d.retryProducer
+	subscriber.retryConsumption = newConsumption()
+	subscriber.retryConsumption.session = d.Sessions.Track(groupId)
+	handler := NewHandler(subscriber.retryConsumption.dispatchCtx, logger, &subscriber.SubscriberSpec, subscriber.PauseGate, subscriber.Limiter, subscriber.RetryTopics, nil)
+	handler.Stats = subscriber.retryConsumption.stats
+	handler.Health = subscriber.Health
+	subscriber.retryConsumption.consume(logger, retryConsumerGroup, subscriber.RetryStopChan, delivery.RetryTopicNames(d.Topic, tiers), handler)
+	subscriber.RetryTopics.SetReady(true)
+	logger.Info("Consuming Retry Topics", zap.Int("Tiers", tiers))
+	return nil
+}
+
+// Get The Number Of Retry Tiers Of A Subscriber (One Per Retry Of Its Delivery)
+func retryTiers(subscriberSpec eventingduck.SubscriberSpec) int {
+	if subscriberSpec.Delivery == nil || subscriberSpec.Delivery.Retry == nil {
+		return 0
+	}
+	return int(*subscriberSpec.Delivery.Retry)
+}
+
+// The Running Consumption Of Topics By A ConsumerGroup
+type consumption struct {
+	ctx         context.Context // The Context Of The ConsumerGroup Sessions (Canceled To Stop Fetching Messages)
+	cancel      context.CancelFunc
+	dispatchCtx context.Context // The Context Of The Handler's Dispatches (Canceled To Abort In-Flight Dispatches)
+	abort       context.CancelFunc
+	joined      chan struct{} // Closed Once The First ConsumerGroup Session Has Been Set Up
+	joinOnce    sync.Once
+	done        chan struct{}         // Closed Once The Last ConsumerGroup Session Has Ended
+	session     *commonhealth.Session // Optional (Tracks The ConsumerGroup Sessions For The Health Checks)
+	stats       *commonconsumer.Stats // Tracks The Claims, Offsets & Dispatches Of The ConsumerGroup For The Admin Endpoints
+}
+
+// Consumption Constructor
+func newConsumption() *consumption {
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatchCtx, abort := context.WithCancel(context.Background())
+	return &consumption{
+		ctx:         ctx,
+		cancel:      cancel,
+		dispatchCtx: dispatchCtx,
+		abort:       abort,
+		joined:      make(chan struct{}),
+		done:        make(chan struct{}),
+		stats:       commonconsumer.NewStats(),
+	}
+}
+
+// A ConsumerGroupHandler Signaling Its Consumption Once The ConsumerGroup Has Joined The Group (& Tracking Its Sessions)
+type joinSignalingHandler struct {
+	sarama.ConsumerGroupHandler
+	consumption *consumption
+}
+
+// ConsumerGroupHandler Lifecycle Method (Runs Once The ConsumerGroup Has Joined The Group & Been Assigned Its Claims)
+func (h joinSignalingHandler) Setup(session sarama.ConsumerGroupSession) error {
+	h.consumption.joinOnce.Do(func() { close(h.consumption.joined) })
+	h.consumption.session.Started()
+	h.consumption.stats.SessionStarted(session.Claims())
+	return h.ConsumerGroupHandler.Setup(session)
+}
+
+// ConsumerGroupHandler Lifecycle Method (Runs Once The Session's ConsumeClaims Have Returned, Such As On Re-Balance)
+func (h joinSignalingHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	h.consumption.session.Ended()
+	h.consumption.stats.SessionEnded()
+	return h.ConsumerGroupHandler.Cleanup(session)
+}
+
+// Consume The Specified Topics With A ConsumerGroup Until Its StopChan Is Closed Or The Consumption Is Stopped
+func (c *consumption) consume(logger *zap.Logger, consumerGroup sarama.ConsumerGroup, stopChan chan struct{}, topics []string, handler sarama.ConsumerGroupHandler) {
+
+	// Asynchronously Process ConsumerGroup's Error Channel
+	go func() {
+		logger.Info("ConsumerGroup Error Processing Initiated")
+		for err := range consumerGroup.Errors() { // Closing ConsumerGroup Will Break Out Of This
+			logger.Error("ConsumerGroup Error", zap.Error(err))
+		}
+		logger.Info("ConsumerGroup Error Processing Terminated")
+	}()
+
+	// Consume Messages Asynchronously
+	go func() {
+
+		// Signal The End Of The Last Session (Whose Marked Offsets Have Then Been Committed) & Stop Tracking Them
+		defer close(c.done)
+		defer c.session.Close()
+
+		// Infinite Loop To Support Server-Side ConsumerGroup Re-Balance Which Ends Consume() Execution
+		for {
+			select {
+
+			// Non-Blocking Stop Channel Check
+			case <-stopChan:
+				logger.Info("ConsumerGroup Closed - Ceasing Consumption")
+				return
+
+			// Non-Blocking Stopped Consumption Check
+			case <-c.ctx.Done():
+				logger.Info("ConsumerGroup Drained - Ceasing Consumption")
+				return
+
+			// Start ConsumerGroup Consumption (Returning Only Once The Session's ConsumeClaims Returned & Its Offsets Were Committed)
+			default:
+				logger.Info("ConsumerGroup Message Consumption Initiated")
+				err := consumerGroup.Consume(c.ctx, topics, joinSignalingHandler{ConsumerGroupHandler: handler, consumption: c})
+				if err != nil {
+					if err == sarama.ErrClosedConsumerGroup {
+						logger.Info("ConsumerGroup Closed Error - Ceasing Consumption") // Should be caught above but here as added precaution.
+						break
+					} else {
+						logger.Error("ConsumerGroup Failed To Consume Messages", zap.Error(err))
 					}
 				}
 			}
-		}()
+		}
+	}()
+}
+
+// Stop Fetching Messages & End The Current Session Once Its In-Flight Dispatches Complete (Nil-Safe)
+func (c *consumption) stop() {
+	if c != nil {
+		c.cancel()
+	}
+}
+
+// Wait Up To The Specified Timeout For The ConsumerGroup To Join The Group, Returning Whether It Joined
+func (c *consumption) waitJoined(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-c.joined:
+		return true
+	case <-c.done:
+		return false
+	case <-timer.C:
+		return false
+	}
+}
+
+// Wait For The Consumption To End Or The Specified Context To Be Done, Returning Whether It Ended (Nil-Safe)
+func (c *consumption) wait(ctx context.Context) bool {
+	if c == nil {
+		return true
+	}
+	select {
+	case <-c.done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Drain The Specified Consumptions, Allowing Their In-Flight Dispatches To Complete Within The DrainTimeout.
+// Dispatches Still In-Flight After The DrainTimeout Are Aborted & Their Messages Left Unmarked, So That They
+// Are Consumed Again (Instead Of Being Lost) By Whichever ConsumerGroup Member Claims Their Partitions Next.
+func (d *DispatcherImpl) drain(consumptions ...*consumption) {
+
+	// Stop Fetching Messages With All Of The Consumptions At Once
+	for _, c := range consumptions {
+		c.stop()
+	}
+
+	// Wait For The Sessions To End (Committing The Offsets Of The Messages Marked So Far)
+	drainTimeout := d.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = DefaultDrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	var undrained []*consumption
+	for _, c := range consumptions {
+		if !c.wait(ctx) {
+			undrained = append(undrained, c)
+		}
+	}
+	if len(undrained) == 0 {
+		return
+	}
+
+	// Abort The Dispatches Still In-Flight & Wait (Briefly) For The Sessions To End
+	d.Logger.Warn("DrainTimeout Exceeded - Aborting In-Flight Dispatches", zap.Duration("DrainTimeout", drainTimeout), zap.Int("Count", len(undrained)))
+	abortCtx, abortCancel := context.WithTimeout(context.Background(), drainAbortTimeout)
+	defer abortCancel()
+	for _, c := range undrained {
+		c.abort()
+	}
+	for _, c := range undrained {
+		if !c.wait(abortCtx) {
+			d.Logger.Error("ConsumerGroup Session Failed To End After Aborting In-Flight Dispatches")
+		}
+	}
+}
+
+// Drain & Close The ConsumerGroups Associated With The Specified Subscribers (Sharing A Single DrainTimeout)
+func (d *DispatcherImpl) closeConsumerGroups(subscribers ...*SubscriberWrapper) {
+
+	// Nothing To Drain If No Subscribers Were Specified
+	if len(subscribers) == 0 {
+		return
+	}
+
+	// Drain The Topic & Retry Topics Consumptions Of All The Subscribers Together
+	consumptions := make([]*consumption, 0, 2*len(subscribers))
+	for _, subscriber := range subscribers {
+		consumptions = append(consumptions, subscriber.consumption, subscriber.retryConsumption)
+	}
+	d.drain(consumptions...)
+
+	// Close The Drained ConsumerGroups
+	for _, subscriber := range subscribers {
+		d.closeConsumerGroup(subscriber)
 	}
 }
 
-// Close The ConsumerGroup Associated With A Single Subscriber
+// Close The ConsumerGroup Associated With A Single Subscriber (Which Should Already Have Been Drained)
 func (d *DispatcherImpl) closeConsumerGroup(subscriber *SubscriberWrapper) {
 
 	// Get The ConsumerGroup Associated with The Specified Subscriber
@@ -220,11 +769,18 @@ func (d *DispatcherImpl) closeConsumerGroup(subscriber *SubscriberWrapper) {
 	// Create Logger With GroupId & Subscriber URI
 	logger := d.Logger.With(zap.String("GroupId", subscriber.GroupId), zap.String("URI", subscriber.SubscriberURI.String()))
 
+	// Close The Retry Topics ConsumerGroup First (Logging Any Failure)
+	d.closeRetryConsumerGroup(subscriber)
+
 	// If The ConsumerGroup Is Valid
 	if consumerGroup != nil {
 
-		// Mark The Subscriber's ConsumerGroup As Stopped
-		close(subscriber.StopChan)
+		// Mark The Subscriber's ConsumerGroup As Stopped (Unless A Previous Close Failed)
+		select {
+		case <-subscriber.StopChan:
+		default:
+			close(subscriber.StopChan)
+		}
 
 		// Close The ConsumerGroup
 		err := consumerGroup.Close()
@@ -243,47 +799,297 @@ func (d *DispatcherImpl) closeConsumerGroup(subscriber *SubscriberWrapper) {
 	}
 }
 
+// Drain & Close The Retry Topics ConsumerGroup Associated With A Single Subscriber (If Any)
+func (d *DispatcherImpl) closeRetryConsumerGroup(subscriber *SubscriberWrapper) {
+	if subscriber.RetryConsumerGroup == nil {
+		return
+	}
+	subscriber.RetryTopics.SetReady(false)
+	d.drain(subscriber.retryConsumption)
+	close(subscriber.RetryStopChan)
+	if err := subscriber.RetryConsumerGroup.Close(); err != nil {
+		d.Logger.Error("Failed To Close Retry Topics ConsumerGroup", zap.String("GroupId", subscriber.GroupId), zap.Error(err))
+	}
+	subscriber.RetryConsumerGroup = nil
+	subscriber.retryConsumption = nil
+	subscriber.RetryTiers = 0
+}
+
 // ConfigChanged is called by the configMapObserver handler function in main() so that
-// settings specific to the dispatcher may be extracted and the ConsumerGroups restarted if necessary.
+// settings specific to the dispatcher may be extracted and applied to the running ConsumerGroups.
 // The new configmap could technically have changes to the eventing-kafka section as well as the sarama
 // section, but none of those matter to a currently-running Dispatcher, so those are ignored here
 // (which avoids the necessity of calling env.GetEnvironment and env.VerifyOverrides).  If those settings
 // are needed in the future, the environment will also need to be re-parsed here.
-// If there aren't any consumer-specific differences between the current config and the new one,
-// then just log that and move on; do not restart the ConsumerGroups unnecessarily.
-func (d *DispatcherImpl) ConfigChanged(configMap *v1.ConfigMap) Dispatcher {
+// Invalid settings are rejected (returning an error) and the current ones are kept.  Changes impacting
+// the consumers roll the ConsumerGroups onto the new settings one at a time, halting on the first one
+// failing, which is kept on the previous settings and retried (and reported in the subscriber status)
+// by the next UpdateSubscriptions().  Other changes do not restart the ConsumerGroups unnecessarily.
+func (d *DispatcherImpl) ConfigChanged(configMap *v1.ConfigMap) error {
 
 	// Create A New Sarama Config
 	d.Logger.Debug("New ConfigMap Received", zap.String("configMap.Name", configMap.ObjectMeta.Name))
 
 	newConfig, err := kafkasarama.MergeSaramaSettings(nil, configMap)
 	if err != nil {
-		d.Logger.Error("Unable to merge sarama settings", zap.Error(err))
-		return nil
+		return fmt.Errorf("unable to merge sarama settings, keeping the current ones: %w", err)
 	}
 
-	// Validate Configuration (Should Always Be Present)
-	if d.SaramaConfig != nil {
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
 
-		// Some of the current config settings may not be overridden by the configmap (username, password, etc.)
+	// Some of the current config settings may not be overridden by the configmap (username, password, etc.)
+	if d.SaramaConfig != nil {
 		kafkasarama.UpdateSaramaConfig(newConfig, d.SaramaConfig.ClientID, d.SaramaConfig.Net.SASL.User, d.SaramaConfig.Net.SASL.Password)
+	}
+
+	// Nor may the TLS certificates of the kafka secret (validated on startup)
+	if err := kafkasarama.UpdateSaramaTLSConfig(newConfig, d.TLSCertificates); err != nil {
+		return fmt.Errorf("unable to apply the kafka secret TLS certificates, keeping the current settings: %w", err)
+	}
+
+	// Reject Invalid Settings Before Applying Them To Any ConsumerGroup
+	if err := newConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid sarama settings, keeping the current ones: %w", err)
+	}
+
+	// Apply The New Configuration According To The Impact Of Its Changes
+	switch configChangeImpact(d.SaramaConfig, newConfig) {
+	case configImpactNone:
+		d.Logger.Info("No Changes Detected In New Configuration - Ignoring")
+		return nil
+	case configImpactProducer:
+		d.Logger.Info("Producer Changes Detected In New Configuration - Applying To The Retry Topics Producer Created Later")
+		d.SaramaConfig = newConfig
+		return nil
+	}
+
+	// Roll The ConsumerGroups Onto The New Configuration One At A Time
+	d.Logger.Info("Consumer Changes Detected In New Configuration - Rolling ConsumerGroups")
+	if err := d.rollAllConsumerGroups(newConfig); err != nil {
+		return fmt.Errorf("halted rolling the consumer groups onto the new sarama settings: %w", err)
+	}
+	return nil
+}
+
+// CredentialsChanged is called by the credentials watcher in main() when the SASL username / password of the
+// mounted Kafka Secret change, so that they are applied without restarting the pod.  Alike ConfigChanged, the
+// ConsumerGroups are rolled onto the new credentials one at a time, halting on the first one failing (which is
+// kept on the previous credentials and retried by the next UpdateSubscriptions()).  The Retry Topics Producer is
+// recreated with the new credentials, the previous one being closed once no longer used by any subscriber.
+func (d *DispatcherImpl) CredentialsChanged(username string, password string) error {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
 
-		// Ignore the "Producer" section as changes to that do not require recreating the Dispatcher
-		if kafkasarama.ConfigEqual(newConfig, d.SaramaConfig, newConfig.Producer) {
-			d.Logger.Info("No Consumer Changes Detected In New Configuration - Ignoring")
-			return nil
+	// Nothing To Roll Without A Current Configuration (Or If The Credentials Are Unchanged)
+	d.Username = username
+	d.Password = password
+	if d.SaramaConfig == nil {
+		d.Logger.Warn("Dispatcher Has No Config - Ignoring Credentials Change")
+		return nil
+	}
+	if d.SaramaConfig.Net.SASL.User == username && d.SaramaConfig.Net.SASL.Password == password {
+		d.Logger.Info("No Changes Detected In New Credentials - Ignoring")
+		return nil
+	}
+
+	// Copy The Current Configuration With The New Credentials
+	newConfig := *d.SaramaConfig
+	newConfig.Net.SASL.User = username
+	newConfig.Net.SASL.Password = password
+
+	// Retire The Retry Topics Producer (Lazily Recreated With The New Credentials By updateRetryTopics)
+	if d.retryProducer != nil {
+		d.retiredProducers = append(d.retiredProducers, d.retryProducer)
+		d.retryProducer = nil
+	}
+
+	// Roll The ConsumerGroups Onto The New Credentials One At A Time
+	d.Logger.Info("Credentials Changed - Rolling ConsumerGroups", zap.String("Username", username))
+	err := d.rollAllConsumerGroups(&newConfig)
+	d.closeRetiredProducers(false)
+	if err != nil {
+		return fmt.Errorf("halted rolling the consumer groups onto the new credentials: %w", err)
+	}
+	return nil
+}
+
+// Roll The ConsumerGroups Of All The Subscribers Onto The Specified SaramaConfig One At A Time (Halting On The First Failure)
+func (d *DispatcherImpl) rollAllConsumerGroups(newConfig *sarama.Config) error {
+	d.SaramaConfig = newConfig
+	d.BrokerCheck.Update(d.Brokers, newConfig)
+	d.configGeneration++
+	_, err := d.rollOutdatedConsumerGroups()
+	return err
+}
+
+// Roll The ConsumerGroups Left On A Previous SaramaConfig Onto The Current One, One Subscriber At A Time, Halting On The
+// First Failure (Returning The Subscriber Which Failed).  Each Subscriber Can Take Up To The DrainTimeout Plus The
+// rollJoinTimeout, So The consumerUpdateLock Held By The Caller Is Released While Waiting, Leaving The Other Dispatcher
+// Operations Unblocked.  A Rolling Already In Progress Rolls The ConsumerGroups Onto The Current SaramaConfig Anyway,
+// So Nothing Is Done Meanwhile.
+func (d *DispatcherImpl) rollOutdatedConsumerGroups() (*SubscriberWrapper, error) {
+	if d.rolling {
+		return nil, nil
+	}
+	d.rolling = true
+	defer func() { d.rolling = false }()
+	for {
+		subscriber := d.nextOutdatedSubscriber()
+		if subscriber == nil {
+			return nil, nil
+		}
+		if err := d.rollConsumerGroups(subscriber); err != nil {
+			return subscriber, err
 		}
 	}
+}
 
-	// Create A New Dispatcher With The New Configuration (Reusing All Other Existing Config)
-	d.Logger.Info("Consumer Changes Detected In New Configuration - Recreating Dispatcher")
-	d.Shutdown()
-	d.DispatcherConfig.SaramaConfig = newConfig
-	newDispatcher := NewDispatcher(d.DispatcherConfig)
-	failedSubscriptions := newDispatcher.UpdateSubscriptions(d.SubscriberSpecs)
-	if len(failedSubscriptions) > 0 {
-		d.Logger.Fatal("Failed To Subscribe Kafka Subscriptions For New Dispatcher", zap.Int("Count", len(failedSubscriptions)))
+// Get The First Subscriber (Ordered By UID) Whose ConsumerGroups Are On A Previous SaramaConfig, If Any
+func (d *DispatcherImpl) nextOutdatedSubscriber() *SubscriberWrapper {
+	uids := make([]string, 0, len(d.subscribers))
+	for uid, subscriber := range d.subscribers {
+		if !subscriber.removed && subscriber.configGeneration != d.configGeneration {
+			uids = append(uids, string(uid))
+		}
+	}
+	if len(uids) == 0 {
 		return nil
 	}
-	return newDispatcher
+	sort.Strings(uids)
+	return d.subscribers[types.UID(uids[0])]
+}
+
+// Run The Specified Function Without The consumerUpdateLock Held By The Caller (Such As To Wait On The Kafka Brokers
+// Or The ConsumerGroups), So That The Other Dispatcher Operations Are Not Blocked Meanwhile
+func (d *DispatcherImpl) unlocked(fn func()) {
+	d.consumerUpdateLock.Unlock()
+	defer d.consumerUpdateLock.Lock()
+	fn()
+}
+
+// Close The Retired Retry Topics Producers No Longer Producing For Any Subscriber (Or All Of Them When Forced)
+func (d *DispatcherImpl) closeRetiredProducers(force bool) {
+	var inUse []sarama.SyncProducer
+	for _, retiredProducer := range d.retiredProducers {
+		used := false
+		for _, subscriber := range d.subscribers {
+			if subscriber.RetryTopics.IsReady() && subscriber.RetryTopics.Producer == retiredProducer {
+				used = true
+				break
+			}
+		}
+		if used && !force {
+			inUse = append(inUse, retiredProducer)
+		} else if err := retiredProducer.Close(); err != nil {
+			d.Logger.Error("Failed To Close Retired Retry Topics Producer", zap.Error(err))
+		}
+	}
+	d.retiredProducers = inUse
+}
+
+// Determine The Impact Of The Changes Between Two SaramaConfigs
+func configChangeImpact(currentConfig *sarama.Config, newConfig *sarama.Config) configImpact {
+	switch {
+	case currentConfig == nil:
+		return configImpactConsumer
+	case kafkasarama.ConfigEqual(newConfig, currentConfig):
+		return configImpactNone
+	case kafkasarama.ConfigEqual(newConfig, currentConfig, newConfig.Producer):
+		return configImpactProducer // Ignore the "Producer" section as changes to that do not impact the ConsumerGroups
+	default:
+		return configImpactConsumer
+	}
+}
+
+// Roll A Subscriber's ConsumerGroups Onto The Dispatcher's Current SaramaConfig.  The New ConsumerGroup Is Created
+// (Connecting To The Kafka Brokers) Before The Current One Is Drained & Replaced, And Is Rolled Back To The Previous
+// SaramaConfig Unless It Joins The Group Within The rollJoinTimeout.  (The Retry Topics ConsumerGroup Is Recreated
+// With The Current SaramaConfig By updateRetryTopics.)  Must Be Called With The consumerUpdateLock, Which Is Released
+// While Waiting (The Subscriber Being Marked As Rolling Meanwhile).
+func (d *DispatcherImpl) rollConsumerGroups(subscriber *SubscriberWrapper) (err error) {
+
+	// Create A Logger With The GroupId
+	logger := d.Logger.With(zap.String("GroupId", subscriber.GroupId))
+
+	// Close The ConsumerGroups Once Rolled If The Subscriber Was Removed Meanwhile
+	subscriber.rolling = true
+	defer func() {
+		subscriber.rolling = false
+		if subscriber.removed && d.subscribers[subscriber.UID] == subscriber {
+			logger.Info("Closing ConsumerGroup Of Subscriber Removed While Rolling")
+			d.closeConsumerGroups(subscriber)
+			err = nil
+		}
+	}()
+
+	// Create The New ConsumerGroup While The Current One Keeps Consuming
+	brokers, saramaConfig, configGeneration := d.Brokers, d.SaramaConfig, d.configGeneration
+	var consumerGroup sarama.ConsumerGroup
+	d.unlocked(func() {
+		consumerGroup, _, err = consumer.CreateConsumerGroup(brokers, saramaConfig, subscriber.GroupId)
+	})
+	if err != nil {
+		logger.Error("Failed To Create ConsumerGroup With New Configuration - Keeping The Previous One", zap.Error(err))
+		return fmt.Errorf("failed to apply the new kafka configuration, still consuming with the previous one: %w", err)
+	}
+
+	// Replace The Current ConsumerGroups & Verify The New One Joins The Group
+	previousConfig := subscriber.saramaConfig
+	d.replaceConsumerGroup(subscriber, consumerGroup, saramaConfig)
+	joined := false
+	newConsumption := subscriber.consumption
+	d.unlocked(func() { joined = newConsumption.waitJoined(rollJoinTimeout) })
+	if joined {
+		logger.Info("Successfully Rolled ConsumerGroup Onto New Configuration")
+		subscriber.configGeneration = configGeneration
+		return d.updateRetryTopics(subscriber, subscriber.SubscriberSpec)
+	}
+
+	// Otherwise Roll Back To The Previous SaramaConfig
+	err = fmt.Errorf("the consumer group failed to join within %v with the new kafka configuration", rollJoinTimeout)
+	logger.Error("Rolling Back ConsumerGroup To Previous Configuration", zap.Error(err))
+	var previousConsumerGroup sarama.ConsumerGroup
+	var rollbackErr error
+	d.unlocked(func() {
+		previousConsumerGroup, _, rollbackErr = consumer.CreateConsumerGroup(brokers, previousConfig, subscriber.GroupId)
+	})
+	if rollbackErr != nil {
+		d.closeConsumerGroups(subscriber) // Recreated By The Next UpdateSubscriptions()
+		return fmt.Errorf("%v, and failed to roll back to the previous one: %w", err, rollbackErr)
+	}
+	d.replaceConsumerGroup(subscriber, previousConsumerGroup, previousConfig)
+	if retryErr := d.updateRetryTopics(subscriber, subscriber.SubscriberSpec); retryErr != nil {
+		logger.Error("Failed To Consume Retry Topics With Previous Configuration", zap.Error(retryErr))
+	}
+	return err
+}
+
+// Drain (Without The consumerUpdateLock) & Close The Subscriber's ConsumerGroups & Start Consuming With The Specified
+// One Instead
+func (d *DispatcherImpl) replaceConsumerGroup(subscriber *SubscriberWrapper, consumerGroup sarama.ConsumerGroup, saramaConfig *sarama.Config) {
+	if subscriber.RetryConsumerGroup != nil {
+		subscriber.RetryTopics.SetReady(false)
+	}
+	consumptions := []*consumption{subscriber.consumption, subscriber.retryConsumption}
+	d.unlocked(func() { d.drain(consumptions...) })
+	d.closeRetryConsumerGroup(subscriber) // Already Drained
+	if subscriber.ConsumerGroup != nil {
+		// Mark The Subscriber's ConsumerGroup As Stopped (Unless A Previous Close Failed)
+		select {
+		case <-subscriber.StopChan:
+		default:
+			close(subscriber.StopChan)
+		}
+		if err := subscriber.ConsumerGroup.Close(); err != nil {
+			d.Logger.Error("Failed To Close Replaced ConsumerGroup", zap.String("GroupId", subscriber.GroupId), zap.Error(err))
+		}
+	}
+	subscriber.ConsumerGroup = consumerGroup
+	subscriber.StopChan = make(chan struct{})
+	subscriber.saramaConfig = saramaConfig
+	d.startConsuming(subscriber)
 }
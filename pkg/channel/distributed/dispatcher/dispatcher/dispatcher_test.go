@@ -1,22 +1,28 @@
 package dispatcher
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/Shopify/sarama"
 	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	"knative.dev/eventing-kafka/pkg/common/delivery"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
+	"knative.dev/pkg/apis"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
 
@@ -156,6 +162,254 @@ func TestShutdown(t *testing.T) {
 	assert.Len(t, dispatcher.subscribers, 0)
 }
 
+// Test The Dispatcher's Shutdown() Functionality Drains The Running Consumptions
+func TestShutdownDrainsConsumption(t *testing.T) {
+
+	// Create A Subscriber Consuming With A Mock ConsumerGroup
+	consumerGroup := kafkatesting.NewMockConsumerGroup(t)
+	subscriber := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid123}, "kafka.123", consumerGroup)
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger: logtesting.TestLogger(t).Desugar(),
+			Topic:  "TestTopic",
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{uid123: subscriber},
+	}
+	dispatcher.startConsuming(subscriber)
+	consumption := subscriber.consumption
+	assert.NotNil(t, consumption)
+
+	// Perform The Test
+	dispatcher.Shutdown()
+
+	// Verify The Consumption Ended Without Aborting Its Dispatches Before The ConsumerGroup Was Closed
+	select {
+	case <-consumption.done:
+	default:
+		t.Fatal("Consumption not drained")
+	}
+	assert.NotNil(t, consumption.ctx.Err())
+	assert.Nil(t, consumption.dispatchCtx.Err())
+	assert.True(t, consumerGroup.Closed)
+	assert.Len(t, dispatcher.subscribers, 0)
+}
+
+// Test The Dispatcher's drain() Functionality
+func TestDrain(t *testing.T) {
+
+	// Create A Dispatcher With A Short DrainTimeout
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:       logtesting.TestLogger(t).Desugar(),
+			DrainTimeout: 50 * time.Millisecond,
+		},
+	}
+
+	// Create A Consumption Whose Session Ends Once Stopped
+	drained := newConsumption()
+	go func() {
+		<-drained.ctx.Done()
+		close(drained.done)
+	}()
+
+	// Create A Consumption Whose In-Flight Dispatch Only Ends Once Aborted
+	aborted := newConsumption()
+	go func() {
+		<-aborted.dispatchCtx.Done()
+		close(aborted.done)
+	}()
+
+	// Perform The Test (Including A Consumption That Was Never Started)
+	start := time.Now()
+	dispatcher.drain(drained, aborted, nil)
+
+	// Verify Only The In-Flight Dispatch Exceeding The DrainTimeout Was Aborted
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	assert.Nil(t, drained.dispatchCtx.Err())
+	assert.NotNil(t, aborted.dispatchCtx.Err())
+	assert.True(t, drained.wait(context.Background()))
+	assert.True(t, aborted.wait(context.Background()))
+}
+
+// Test The Dispatcher's PauseSubscriptions() Functionality
+func TestPauseSubscriptions(t *testing.T) {
+
+	// Create Test Subscribers
+	subscriber1 := eventingduck.SubscriberSpec{UID: id123}
+	subscriber2 := eventingduck.SubscriberSpec{UID: id456}
+	consumerGroup1 := kafkatesting.NewMockConsumerGroup(t)
+	consumerGroup2 := kafkatesting.NewMockConsumerGroup(t)
+
+	// Create The Dispatcher To Test With Existing Subscribers
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger: logtesting.TestLogger(t).Desugar(),
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{
+			subscriber1.UID: NewSubscriberWrapper(subscriber1, "kafka.1", consumerGroup1),
+			subscriber2.UID: NewSubscriberWrapper(subscriber2, "kafka.2", consumerGroup2),
+		},
+	}
+
+	// Pause The First Subscriber
+	dispatcher.PauseSubscriptions(map[types.UID]bool{subscriber1.UID: true})
+	assert.True(t, dispatcher.subscribers[subscriber1.UID].PauseGate.IsPaused())
+	assert.False(t, dispatcher.subscribers[subscriber2.UID].PauseGate.IsPaused())
+
+	// Resume The First Subscriber
+	dispatcher.PauseSubscriptions(map[types.UID]bool{})
+	assert.False(t, dispatcher.subscribers[subscriber1.UID].PauseGate.IsPaused())
+	assert.False(t, consumerGroup1.Closed)
+}
+
+// Test The Dispatcher's SetAdminPaused() & Subscriptions() Functionality
+func TestSetAdminPaused(t *testing.T) {
+
+	// Create Test Subscribers
+	subscriber1 := eventingduck.SubscriberSpec{UID: id123, SubscriberURI: &apis.URL{Scheme: "http", Host: "subscriber1"}}
+	subscriber2 := eventingduck.SubscriberSpec{UID: id456}
+
+	// Create The Dispatcher To Test With Existing Subscribers
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:     logtesting.TestLogger(t).Desugar(),
+			ChannelKey: "ns/channel",
+			Topic:      "topic",
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{
+			subscriber1.UID: NewSubscriberWrapper(subscriber1, "kafka.1", kafkatesting.NewMockConsumerGroup(t)),
+			subscriber2.UID: NewSubscriberWrapper(subscriber2, "kafka.2", kafkatesting.NewMockConsumerGroup(t)),
+		},
+	}
+
+	// Pause The First Subscriber Through The Admin Endpoints
+	assert.True(t, dispatcher.SetAdminPaused(subscriber1.UID, true))
+	assert.True(t, dispatcher.subscribers[subscriber1.UID].PauseGate.IsPaused())
+	assert.False(t, dispatcher.subscribers[subscriber2.UID].PauseGate.IsPaused())
+	assert.False(t, dispatcher.SetAdminPaused("unknown", true))
+
+	// Verify The Subscriptions
+	subscriptions := dispatcher.Subscriptions()
+	assert.Len(t, subscriptions, 2)
+	assert.Equal(t, subscriber1.UID, subscriptions[0].UID)
+	assert.Equal(t, "ns/channel", subscriptions[0].Channel)
+	assert.Equal(t, "http://subscriber1", subscriptions[0].Subscriber)
+	assert.True(t, subscriptions[0].Paused)
+	assert.True(t, subscriptions[0].AdminPaused)
+	assert.Equal(t, subscriber2.UID, subscriptions[1].UID)
+	assert.False(t, subscriptions[1].Paused)
+
+	// The First Subscriber Stays Paused When Its Subscription Is Resumed
+	dispatcher.PauseSubscriptions(map[types.UID]bool{})
+	assert.True(t, dispatcher.subscribers[subscriber1.UID].PauseGate.IsPaused())
+
+	// Resuming It Through The Admin Endpoints Keeps The Pausing Of Its Subscription
+	dispatcher.PauseSubscriptions(map[types.UID]bool{subscriber1.UID: true})
+	assert.True(t, dispatcher.SetAdminPaused(subscriber1.UID, false))
+	assert.True(t, dispatcher.subscribers[subscriber1.UID].PauseGate.IsPaused())
+	dispatcher.PauseSubscriptions(map[types.UID]bool{})
+	assert.False(t, dispatcher.subscribers[subscriber1.UID].PauseGate.IsPaused())
+	assert.False(t, dispatcher.Subscriptions()[0].AdminPaused)
+}
+
+// Test The Dispatcher's LimitSubscriptions() Functionality
+func TestLimitSubscriptions(t *testing.T) {
+
+	// Create Test Subscribers
+	subscriber1 := eventingduck.SubscriberSpec{UID: id123}
+	subscriber2 := eventingduck.SubscriberSpec{UID: id456}
+	consumerGroup1 := kafkatesting.NewMockConsumerGroup(t)
+	consumerGroup2 := kafkatesting.NewMockConsumerGroup(t)
+
+	// Create The Dispatcher To Test With Existing Subscribers
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger: logtesting.TestLogger(t).Desugar(),
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{
+			subscriber1.UID: NewSubscriberWrapper(subscriber1, "kafka.1", consumerGroup1),
+			subscriber2.UID: NewSubscriberWrapper(subscriber2, "kafka.2", consumerGroup2),
+		},
+	}
+
+	// Limit The First Subscriber
+	limits := delivery.Limits{RateLimit: 10, MaxInFlight: 2}
+	dispatcher.LimitSubscriptions(map[types.UID]delivery.Limits{subscriber1.UID: limits})
+	assert.Equal(t, limits, dispatcher.subscribers[subscriber1.UID].Limiter.Limits())
+	assert.Equal(t, delivery.Limits{}, dispatcher.subscribers[subscriber2.UID].Limiter.Limits())
+
+	// Remove The Limits
+	dispatcher.LimitSubscriptions(map[types.UID]delivery.Limits{})
+	assert.Equal(t, delivery.Limits{}, dispatcher.subscribers[subscriber1.UID].Limiter.Limits())
+	assert.False(t, consumerGroup1.Closed)
+}
+
+// Test The Dispatcher's SetRetryPolicies() Functionality
+func TestSetRetryPolicies(t *testing.T) {
+
+	// Create Test Subscribers
+	subscriber1 := eventingduck.SubscriberSpec{UID: id123}
+	subscriber2 := eventingduck.SubscriberSpec{UID: id456}
+	consumerGroup1 := kafkatesting.NewMockConsumerGroup(t)
+	consumerGroup2 := kafkatesting.NewMockConsumerGroup(t)
+
+	// Create The Dispatcher To Test With Existing Subscribers
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger: logtesting.TestLogger(t).Desugar(),
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{
+			subscriber1.UID: NewSubscriberWrapper(subscriber1, "kafka.1", consumerGroup1),
+			subscriber2.UID: NewSubscriberWrapper(subscriber2, "kafka.2", consumerGroup2),
+		},
+	}
+
+	// Set The RetryPolicy Of The First Subscriber
+	policy := delivery.RetryPolicy{MaxBackoff: time.Minute, RetryableStatusCodes: []int{429, 503}}
+	dispatcher.SetRetryPolicies(map[types.UID]delivery.RetryPolicy{subscriber1.UID: policy})
+	assert.Equal(t, policy, dispatcher.subscribers[subscriber1.UID].Limiter.RetryPolicy())
+	assert.Equal(t, delivery.RetryPolicy{}, dispatcher.subscribers[subscriber2.UID].Limiter.RetryPolicy())
+
+	// Remove The RetryPolicy
+	dispatcher.SetRetryPolicies(map[types.UID]delivery.RetryPolicy{})
+	assert.Equal(t, delivery.RetryPolicy{}, dispatcher.subscribers[subscriber1.UID].Limiter.RetryPolicy())
+	assert.False(t, consumerGroup1.Closed)
+}
+
+// Test The Dispatcher's SetDeduplicationWindows() Functionality
+func TestSetDeduplicationWindows(t *testing.T) {
+
+	// Create Test Subscribers
+	subscriber1 := eventingduck.SubscriberSpec{UID: id123}
+	subscriber2 := eventingduck.SubscriberSpec{UID: id456}
+	consumerGroup1 := kafkatesting.NewMockConsumerGroup(t)
+	consumerGroup2 := kafkatesting.NewMockConsumerGroup(t)
+
+	// Create The Dispatcher To Test With Existing Subscribers
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger: logtesting.TestLogger(t).Desugar(),
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{
+			subscriber1.UID: NewSubscriberWrapper(subscriber1, "kafka.1", consumerGroup1),
+			subscriber2.UID: NewSubscriberWrapper(subscriber2, "kafka.2", consumerGroup2),
+		},
+	}
+	for _, subscriber := range dispatcher.subscribers {
+		subscriber.Deduplicator = delivery.NewDeduplicator(dispatcher.Logger, subscriber.UID)
+	}
+
+	// Set The Deduplication Window Of The First Subscriber
+	dispatcher.SetDeduplicationWindows(map[types.UID]time.Duration{subscriber1.UID: time.Minute})
+	assert.Equal(t, time.Minute, dispatcher.subscribers[subscriber1.UID].Deduplicator.Window())
+	assert.Equal(t, time.Duration(0), dispatcher.subscribers[subscriber2.UID].Deduplicator.Window())
+
+	// Disable The Deduplication
+	dispatcher.SetDeduplicationWindows(map[types.UID]time.Duration{})
+	assert.Equal(t, time.Duration(0), dispatcher.subscribers[subscriber1.UID].Deduplicator.Window())
+	assert.False(t, consumerGroup1.Closed)
+}
+
 func getSaramaConfigFromYaml(t *testing.T, saramaYaml string) *sarama.Config {
 	var config *sarama.Config
 	jsonSettings, err := yaml.YAMLToJSON([]byte(saramaYaml))
@@ -164,6 +418,14 @@ func getSaramaConfigFromYaml(t *testing.T, saramaYaml string) *sarama.Config {
 	return config
 }
 
+// Utility Function For Getting A Valid SaramaConfig Merged From The Base ConfigMap
+func getValidSaramaConfig(t *testing.T) *sarama.Config {
+	config, err := kafkasarama.MergeSaramaSettings(nil, getBaseConfigMap())
+	assert.Nil(t, err)
+	kafkasarama.UpdateSaramaConfig(config, "TestClientId", "", "")
+	return config
+}
+
 // Test The UpdateSubscriptions() Functionality
 func TestUpdateSubscriptions(t *testing.T) {
 
@@ -320,6 +582,102 @@ func TestUpdateSubscriptions(t *testing.T) {
 	}
 }
 
+// Test The Dispatcher's Retry Topics Functionality
+func TestUpdateRetryTopics(t *testing.T) {
+
+	// Mock The Retry Topics Clients & ConsumerGroups (Restored After The Test)
+	clusterAdmin := &mockClusterAdmin{topics: map[string]sarama.TopicDetail{"topic": {NumPartitions: 2, ReplicationFactor: 1}}}
+	retryProducer := &mockSyncProducer{}
+	consumerGroups := make(map[string]*kafkatesting.MockConsumerGroup)
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	newRetryProducerWrapperPlaceholder := newRetryProducerWrapper
+	newClusterAdminWrapperPlaceholder := newClusterAdminWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(_ []string, groupId string, _ *sarama.Config) (sarama.ConsumerGroup, error) {
+		consumerGroups[groupId] = kafkatesting.NewMockConsumerGroup(t)
+		return consumerGroups[groupId], nil
+	}
+	newRetryProducerWrapper = func(_ []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		assert.True(t, config.Producer.Return.Successes)
+		return retryProducer, nil
+	}
+	newClusterAdminWrapper = func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		return clusterAdmin, nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+		newRetryProducerWrapper = newRetryProducerWrapperPlaceholder
+		newClusterAdminWrapper = newClusterAdminWrapperPlaceholder
+	}()
+
+	// Create The Dispatcher To Test With Retry Topics Enabled
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:       logtesting.TestLogger(t).Desugar(),
+		Topic:        "topic",
+		SaramaConfig: getSaramaConfigFromYaml(t, TestConfigBase),
+	}).(*DispatcherImpl)
+	dispatcher.SetRetryPolicies(map[types.UID]delivery.RetryPolicy{uid123: {RetryTopics: true}})
+	subscriberWithRetries := func(retries int32) eventingduck.SubscriberSpec {
+		return eventingduck.SubscriberSpec{UID: uid123, Delivery: &eventingduck.DeliverySpec{Retry: &retries}}
+	}
+
+	// The Retry Topics Are Created & Consumed
+	assert.Empty(t, dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{subscriberWithRetries(2)}))
+	subscriber := dispatcher.subscribers[uid123]
+	assert.True(t, subscriber.RetryTopics.IsReady())
+	assert.Equal(t, retryProducer, subscriber.RetryTopics.Producer)
+	assert.Equal(t, 2, subscriber.RetryTiers)
+	assert.Contains(t, clusterAdmin.topics, "topic.retry.2")
+	retryConsumerGroup := consumerGroups["kafka.123.retry"]
+	assert.Equal(t, retryConsumerGroup, subscriber.RetryConsumerGroup)
+
+	// More Retries Replace The Retry ConsumerGroup
+	assert.Empty(t, dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{subscriberWithRetries(3)}))
+	assert.True(t, retryConsumerGroup.Closed)
+	assert.Equal(t, 3, subscriber.RetryTiers)
+	assert.Contains(t, clusterAdmin.topics, "topic.retry.3")
+
+	// Disabling The Retry Topics Keeps Draining Them
+	dispatcher.SetRetryPolicies(map[types.UID]delivery.RetryPolicy{})
+	assert.Empty(t, dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{subscriberWithRetries(3)}))
+	assert.False(t, consumerGroups["kafka.123.retry"].Closed)
+
+	// Shutdown Closes The Retry ConsumerGroup & Producer
+	dispatcher.Shutdown()
+	assert.True(t, consumerGroups["kafka.123.retry"].Closed)
+	assert.False(t, subscriber.RetryTopics.IsReady())
+	assert.True(t, retryProducer.closed)
+}
+
+// Mock ClusterAdmin Listing & Creating Topics In Memory
+type mockClusterAdmin struct {
+	sarama.ClusterAdmin
+	topics map[string]sarama.TopicDetail
+}
+
+func (m *mockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	return m.topics, nil
+}
+
+func (m *mockClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, _ bool) error {
+	m.topics[topic] = *detail
+	return nil
+}
+
+func (m *mockClusterAdmin) Close() error {
+	return nil
+}
+
+// Mock SyncProducer Tracking Its Closure
+type mockSyncProducer struct {
+	sarama.SyncProducer
+	closed bool
+}
+
+func (m *mockSyncProducer) Close() error {
+	m.closed = true
+	return nil
+}
+
 // Utility Function For Creating A SubscriberWrapper With Specified UID & Mock ConsumerGroup
 func createSubscriberWrapper(t *testing.T, uid types.UID) *SubscriberWrapper {
 	return NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid}, fmt.Sprintf("kafka.%s", string(uid)), kafkatesting.NewMockConsumerGroup(t))
@@ -348,52 +706,364 @@ func TestConfigChanged(t *testing.T) {
 	// Setup Environment
 	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, constants.KnativeEventingNamespace))
 
-	// Create Mocks
-	var dispatcher Dispatcher
-	dispatcher = &DispatcherImpl{
-		DispatcherConfig:  DispatcherConfig{Logger: logger},
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Create A Dispatcher With A Consuming Subscriber
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:       logger,
+			SaramaConfig: getValidSaramaConfig(t),
+		},
 		subscribers:       make(map[types.UID]*SubscriberWrapper),
 		messageDispatcher: channel.NewMessageDispatcher(logger),
 	}
+	assert.Empty(t, dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{{UID: uid123}}))
+	defer dispatcher.Shutdown()
 
 	// Apply a change to the Consumer config
-	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigConsumerChange, true)
+	runConfigChangedTest(t, dispatcher, TestConfigConsumerChange, true)
 
 	// Apply an additional setting to the Consumer config
-	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigConsumerAdd, true)
+	runConfigChangedTest(t, dispatcher, TestConfigConsumerAdd, true)
 
 	// Change one of the metadata settings
-	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigMetadataChange, true)
+	runConfigChangedTest(t, dispatcher, TestConfigMetadataChange, true)
 
 	// Change one of the admin settings
-	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigAdminChange, true)
+	runConfigChangedTest(t, dispatcher, TestConfigAdminChange, true)
 
-	// Verify that Producer changes do not cause Reconfigure to be called
-	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigProducerChange, false)
-	assert.NotNil(t, dispatcher)
+	// Verify that Producer changes do not cause the ConsumerGroups to be rolled
+	runConfigChangedTest(t, dispatcher, TestConfigProducerChange, false)
+
+	// Verify that invalid settings are rejected
+	saramaConfig := dispatcher.SaramaConfig
+	configMap := getBaseConfigMap()
+	configMap.Data[commonconfig.SaramaSettingsConfigKey] = "Consumer: [invalid"
+	assert.NotNil(t, dispatcher.ConfigChanged(configMap))
+	assert.Equal(t, saramaConfig, dispatcher.SaramaConfig)
 }
 
-func runConfigChangedTest(t *testing.T, originalDispatcher Dispatcher, base *corev1.ConfigMap, changed string, expectedNewDispatcher bool) Dispatcher {
+func runConfigChangedTest(t *testing.T, dispatcher *DispatcherImpl, changed string, expectRolled bool) {
+
 	// Change the Consumer settings to the base config
-	newDispatcher := originalDispatcher.ConfigChanged(base)
-	if newDispatcher != nil {
-		// Simulate what happens in main() when the dispatcher changes
-		originalDispatcher = newDispatcher
-	}
+	assert.Nil(t, dispatcher.ConfigChanged(getBaseConfigMap()))
+	subscriber := dispatcher.subscribers[uid123]
+	consumerGroup := subscriber.ConsumerGroup.(*kafkatesting.MockConsumerGroup)
+	configGeneration := dispatcher.configGeneration
 
 	// Alter the configmap to use the changed settings
-	newConfig := base
+	newConfig := getBaseConfigMap()
 	newConfig.Data[commonconfig.SaramaSettingsConfigKey] = changed
 
 	// Inform the Dispatcher that the config has changed to the new settings
-	newDispatcher = originalDispatcher.ConfigChanged(newConfig)
+	assert.Nil(t, dispatcher.ConfigChanged(newConfig))
+
+	// Verify that the ConsumerGroup was rolled onto the new settings or not, as expected
+	assert.Equal(t, expectRolled, consumerGroup.Closed)
+	assert.Equal(t, expectRolled, subscriber.ConsumerGroup != consumerGroup)
+	assert.Equal(t, expectRolled, dispatcher.configGeneration != configGeneration)
+	assert.Equal(t, dispatcher.configGeneration, subscriber.configGeneration)
+	assert.Same(t, dispatcher.subscribers[uid123], subscriber)
+}
+
+// Test The Dispatcher's ConfigChanged Functionality When A ConsumerGroup Fails To Roll
+func TestConfigChangedRollFailure(t *testing.T) {
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Setup Environment
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, constants.KnativeEventingNamespace))
+
+	// Replace The NewConsumerGroupWrapper With A Mock Failing On Demand & Restore After Test
+	var consumerGroupErr error
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		if consumerGroupErr != nil {
+			return nil, consumerGroupErr
+		}
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Create A Dispatcher With Two Consuming Subscribers
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:       logger,
+			SaramaConfig: getValidSaramaConfig(t),
+		},
+		subscribers:       make(map[types.UID]*SubscriberWrapper),
+		messageDispatcher: channel.NewMessageDispatcher(logger),
+	}
+	subscriberSpecs := []eventingduck.SubscriberSpec{{UID: uid123}, {UID: uid456}}
+	assert.Empty(t, dispatcher.UpdateSubscriptions(subscriberSpecs))
+	defer dispatcher.Shutdown()
+	consumerGroup1 := dispatcher.subscribers[uid123].ConsumerGroup.(*kafkatesting.MockConsumerGroup)
+	consumerGroup2 := dispatcher.subscribers[uid456].ConsumerGroup.(*kafkatesting.MockConsumerGroup)
+	saramaConfig := dispatcher.SaramaConfig
+
+	// Perform The Test With ConsumerGroups Failing To Be Created
+	consumerGroupErr = fmt.Errorf("test-error")
+	configMap := getBaseConfigMap()
+	configMap.Data[commonconfig.SaramaSettingsConfigKey] = TestConfigConsumerChange
+	assert.NotNil(t, dispatcher.ConfigChanged(configMap))
+
+	// Verify The Rolling Halted With Both ConsumerGroups Still Consuming With The Previous Settings
+	assert.False(t, consumerGroup1.Closed)
+	assert.False(t, consumerGroup2.Closed)
+	assert.Equal(t, consumerGroup1, dispatcher.subscribers[uid123].ConsumerGroup)
+	assert.Equal(t, consumerGroup2, dispatcher.subscribers[uid456].ConsumerGroup)
+	assert.Equal(t, saramaConfig, dispatcher.subscribers[uid123].saramaConfig)
+	assert.NotEqual(t, saramaConfig, dispatcher.SaramaConfig)
+
+	// Verify The Failed Subscriber Is Reported (The Rolling Halting Again)
+	failedSubscriptions := dispatcher.UpdateSubscriptions(subscriberSpecs)
+	assert.Len(t, failedSubscriptions, 1)
+	assert.NotNil(t, failedSubscriptions[subscriberSpecs[0]])
+
+	// Verify The Rolling Completes Once The ConsumerGroups Can Be Created
+	consumerGroupErr = nil
+	assert.Empty(t, dispatcher.UpdateSubscriptions(subscriberSpecs))
+	assert.True(t, consumerGroup1.Closed)
+	assert.True(t, consumerGroup2.Closed)
+	assert.Equal(t, dispatcher.SaramaConfig, dispatcher.subscribers[uid123].saramaConfig)
+	assert.Equal(t, dispatcher.SaramaConfig, dispatcher.subscribers[uid456].saramaConfig)
+}
+
+// Test The Dispatcher's CredentialsChanged Functionality
+func TestCredentialsChanged(t *testing.T) {
+
+	// Mock The Retry Topics Clients & ConsumerGroups (Restored After The Test)
+	clusterAdmin := &mockClusterAdmin{topics: map[string]sarama.TopicDetail{"topic": {NumPartitions: 2, ReplicationFactor: 1}}}
+	var retryProducers []*mockSyncProducer
+	var consumerGroupConfigs []*sarama.Config
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	newRetryProducerWrapperPlaceholder := newRetryProducerWrapper
+	newClusterAdminWrapperPlaceholder := newClusterAdminWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(_ []string, _ string, config *sarama.Config) (sarama.ConsumerGroup, error) {
+		consumerGroupConfigs = append(consumerGroupConfigs, config)
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	newRetryProducerWrapper = func(_ []string, config *sarama.Config) (sarama.SyncProducer, error) {
+		retryProducer := &mockSyncProducer{}
+		retryProducers = append(retryProducers, retryProducer)
+		return retryProducer, nil
+	}
+	newClusterAdminWrapper = func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		return clusterAdmin, nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+		newRetryProducerWrapper = newRetryProducerWrapperPlaceholder
+		newClusterAdminWrapper = newClusterAdminWrapperPlaceholder
+	}()
+
+	// Create A Dispatcher With A Subscriber Consuming Its Retry Topics
+	saramaConfig := getSaramaConfigFromYaml(t, TestConfigBase)
+	kafkasarama.UpdateSaramaConfig(saramaConfig, "clientId", "user", "password")
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:       zap.NewNop(), // The Error Processing Of The Closed ConsumerGroups May Outlive The Test
+		Topic:        "topic",
+		SaramaConfig: saramaConfig,
+	}).(*DispatcherImpl)
+	defer dispatcher.Shutdown()
+	retries := int32(1)
+	dispatcher.SetRetryPolicies(map[types.UID]delivery.RetryPolicy{uid123: {RetryTopics: true}})
+	assert.Empty(t, dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{{UID: uid123, Delivery: &eventingduck.DeliverySpec{Retry: &retries}}}))
+	subscriber := dispatcher.subscribers[uid123]
+	consumerGroup := subscriber.ConsumerGroup.(*kafkatesting.MockConsumerGroup)
+	assert.Len(t, retryProducers, 1)
+
+	// Unchanged Credentials Do Not Roll The ConsumerGroups
+	assert.Nil(t, dispatcher.CredentialsChanged("user", "password"))
+	assert.False(t, consumerGroup.Closed)
+	assert.Same(t, saramaConfig, dispatcher.SaramaConfig)
+
+	// New Credentials Roll The ConsumerGroups & Replace The Retry Topics Producer
+	assert.Nil(t, dispatcher.CredentialsChanged("rotated-user", "rotated-password"))
+	assert.True(t, consumerGroup.Closed)
+	assert.Same(t, dispatcher.subscribers[uid123], subscriber)
+	assert.Equal(t, "rotated-user", dispatcher.SaramaConfig.Net.SASL.User)
+	assert.Equal(t, "rotated-password", dispatcher.SaramaConfig.Net.SASL.Password)
+	assert.Equal(t, "user", saramaConfig.Net.SASL.User)
+	assert.Equal(t, dispatcher.SaramaConfig, subscriber.saramaConfig)
+	for _, config := range consumerGroupConfigs[2:] {
+		assert.Equal(t, "rotated-user", config.Net.SASL.User)
+	}
+	assert.Len(t, retryProducers, 2)
+	assert.True(t, retryProducers[0].closed)
+	assert.False(t, retryProducers[1].closed)
+	assert.Equal(t, retryProducers[1], subscriber.RetryTopics.Producer)
+	assert.True(t, subscriber.RetryTopics.IsReady())
+	assert.Empty(t, dispatcher.retiredProducers)
+
+	// The New Credentials Are Kept By Subsequent ConfigMap Changes
+	assert.Nil(t, dispatcher.ConfigChanged(getBaseConfigMap()))
+	assert.Equal(t, "rotated-user", dispatcher.SaramaConfig.Net.SASL.User)
+	assert.Equal(t, "rotated-password", dispatcher.SaramaConfig.Net.SASL.Password)
+}
 
-	// Verify that a new dispatcher was created or not, as expected
-	assert.Equal(t, expectedNewDispatcher, newDispatcher != nil)
+// Test The Dispatcher's CredentialsChanged Functionality When A ConsumerGroup Fails To Roll
+func TestCredentialsChangedRollFailure(t *testing.T) {
+
+	// Replace The NewConsumerGroupWrapper With A Mock Failing On Demand & Restore After Test
+	var consumerGroupErr error
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(_ []string, _ string, _ *sarama.Config) (sarama.ConsumerGroup, error) {
+		if consumerGroupErr != nil {
+			return nil, consumerGroupErr
+		}
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Create A Dispatcher With A Consuming Subscriber
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:       zap.NewNop(),
+		SaramaConfig: getValidSaramaConfig(t),
+	}).(*DispatcherImpl)
+	subscriberSpecs := []eventingduck.SubscriberSpec{{UID: uid123}}
+	assert.Empty(t, dispatcher.UpdateSubscriptions(subscriberSpecs))
+	defer dispatcher.Shutdown()
+	consumerGroup := dispatcher.subscribers[uid123].ConsumerGroup.(*kafkatesting.MockConsumerGroup)
+	previousConfig := dispatcher.SaramaConfig
+
+	// Perform The Test With ConsumerGroups Failing To Be Created
+	consumerGroupErr = fmt.Errorf("test-error")
+	assert.NotNil(t, dispatcher.CredentialsChanged("rotated-user", "rotated-password"))
+
+	// Verify The ConsumerGroup Keeps Consuming With The Previous Credentials
+	assert.False(t, consumerGroup.Closed)
+	assert.Equal(t, previousConfig, dispatcher.subscribers[uid123].saramaConfig)
+	assert.Equal(t, "rotated-user", dispatcher.SaramaConfig.Net.SASL.User)
+
+	// Verify The Rolling Completes Once The ConsumerGroups Can Be Created
+	consumerGroupErr = nil
+	assert.Empty(t, dispatcher.UpdateSubscriptions(subscriberSpecs))
+	assert.True(t, consumerGroup.Closed)
+	assert.Equal(t, dispatcher.SaramaConfig, dispatcher.subscribers[uid123].saramaConfig)
+}
+
+// Test That The Dispatcher's Subscriptions Can Be Updated While A ConsumerGroup Waits To Join During A Roll
+func TestConfigChangedRollUnlocked(t *testing.T) {
+
+	// Setup Environment
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, constants.KnativeEventingNamespace))
+
+	// Replace The NewConsumerGroupWrapper With A Mock Joining On Demand & Restore After Test
+	var joiningConsumerGroup *joinBlockingConsumerGroup
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(_ []string, _ string, _ *sarama.Config) (sarama.ConsumerGroup, error) {
+		if joiningConsumerGroup != nil {
+			return joiningConsumerGroup, nil
+		}
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Create A Dispatcher With A Consuming Subscriber
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:       logtesting.TestLogger(t).Desugar(),
+		SaramaConfig: getValidSaramaConfig(t),
+	}).(*DispatcherImpl)
+	subscriberSpecs := []eventingduck.SubscriberSpec{{UID: uid123}}
+	assert.Empty(t, dispatcher.UpdateSubscriptions(subscriberSpecs))
+	defer dispatcher.Shutdown()
+	assert.Nil(t, dispatcher.ConfigChanged(getBaseConfigMap()))
+	consumerGroup := dispatcher.subscribers[uid123].ConsumerGroup.(*kafkatesting.MockConsumerGroup)
+
+	// Change The Consumer Config With The New ConsumerGroup Waiting To Join The Group
+	joiningConsumerGroup = &joinBlockingConsumerGroup{
+		MockConsumerGroup: kafkatesting.NewMockConsumerGroup(t),
+		consuming:         make(chan struct{}),
+		join:              make(chan struct{}),
+	}
+	configMap := getBaseConfigMap()
+	configMap.Data[commonconfig.SaramaSettingsConfigKey] = TestConfigConsumerChange
+	configChangedErr := make(chan error)
+	go func() { configChangedErr <- dispatcher.ConfigChanged(configMap) }()
+	waitFor(t, joiningConsumerGroup.consuming)
+	assert.True(t, consumerGroup.Closed)
+
+	// Verify The Subscriptions Can Be Updated (& Removed) Meanwhile
+	updated := make(chan struct{})
+	go func() {
+		assert.Empty(t, dispatcher.UpdateSubscriptions(subscriberSpecs))
+		assert.Empty(t, dispatcher.UpdateSubscriptions(nil))
+		close(updated)
+	}()
+	waitFor(t, updated)
+	assert.False(t, joiningConsumerGroup.Closed)
+
+	// Verify The Removed Subscriber's ConsumerGroup Is Closed Once Rolled
+	close(joiningConsumerGroup.join)
+	assert.Nil(t, <-configChangedErr)
+	assert.True(t, joiningConsumerGroup.Closed)
+	assert.Empty(t, dispatcher.subscribers)
+}
+
+// Test Rolling A ConsumerGroup Whose Previous Close Failed (Its StopChan Already Closed)
+func TestConfigChangedAfterFailedClose(t *testing.T) {
+
+	// Setup Environment
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, constants.KnativeEventingNamespace))
+
+	// Replace The NewConsumerGroupWrapper With A Mock & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(_ []string, _ string, _ *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Create A Dispatcher With A Subscriber Whose ConsumerGroup Failed To Close
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:       logtesting.TestLogger(t).Desugar(),
+		SaramaConfig: getValidSaramaConfig(t),
+	}).(*DispatcherImpl)
+	assert.Empty(t, dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{{UID: uid123}}))
+	defer dispatcher.Shutdown()
+	assert.Nil(t, dispatcher.ConfigChanged(getBaseConfigMap()))
+	subscriber := dispatcher.subscribers[uid123]
+	close(subscriber.StopChan)
+
+	// Verify The ConsumerGroup Is Rolled Without Closing Its StopChan Again
+	configMap := getBaseConfigMap()
+	configMap.Data[commonconfig.SaramaSettingsConfigKey] = TestConfigConsumerChange
+	assert.Nil(t, dispatcher.ConfigChanged(configMap))
+	assert.Equal(t, subscriber, dispatcher.subscribers[uid123])
+	select {
+	case <-subscriber.StopChan:
+		t.Fatal("The Rolled ConsumerGroup Is Stopped")
+	default:
+	}
+}
+
+// Wait For The Specified Channel To Be Closed, Failing The Test If It Takes Too Long
+func waitFor(t *testing.T, done chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed Out Waiting")
+	}
+}
+
+// A Mock ConsumerGroup Joining The Group Only Once Its Join Channel Is Closed
+type joinBlockingConsumerGroup struct {
+	*kafkatesting.MockConsumerGroup
+	consuming chan struct{} // Closed Once Consume() Is First Called
+	join      chan struct{}
+	once      sync.Once
+}
 
-	// Return either the new or original dispatcher for use by the rest of the TestConfigChanged test
-	if expectedNewDispatcher {
-		return newDispatcher
+func (m *joinBlockingConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	m.once.Do(func() { close(m.consuming) })
+	select {
+	case <-m.join:
+		return m.MockConsumerGroup.Consume(ctx, topics, handler)
+	case <-ctx.Done():
+		return nil
 	}
-	return originalDispatcher
 }
@@ -11,6 +11,8 @@ import (
 	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"go.uber.org/zap"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/delivery"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/kncloudevents"
@@ -24,17 +26,29 @@ var _ sarama.ConsumerGroupHandler = &Handler{}
 
 // Define A Sarama ConsumerGroupHandler Implementation
 type Handler struct {
+	DispatchCtx       context.Context // Canceled To Abort In-Flight Dispatches (Leaving Their Messages Unmarked)
 	Logger            *zap.Logger
 	Subscriber        *eventingduck.SubscriberSpec
 	MessageDispatcher channel.MessageDispatcher
+	PauseGate         *commonconsumer.PauseGate
+	Limiter           *delivery.Limiter
+	RetryTopics       *delivery.RetryTopics
+	Deduplicator      *delivery.Deduplicator // Nil For The Retry Topics (Retried Messages Are Not Deduplicated)
+	Stats             *commonconsumer.Stats  // Optional (Tracks The Offsets & Dispatches For The Admin Endpoints)
+	Health            *delivery.Health       // Optional (Tracks The Outcome Of The Dispatches For The Subscriber Status)
 }
 
 // Create A New Handler
-func NewHandler(logger *zap.Logger, subscriber *eventingduck.SubscriberSpec) *Handler {
+func NewHandler(dispatchCtx context.Context, logger *zap.Logger, subscriber *eventingduck.SubscriberSpec, pauseGate *commonconsumer.PauseGate, limiter *delivery.Limiter, retryTopics *delivery.RetryTopics, deduplicator *delivery.Deduplicator) *Handler {
 	return &Handler{
+		DispatchCtx:       dispatchCtx,
 		Logger:            logger,
 		Subscriber:        subscriber,
 		MessageDispatcher: newMessageDispatcherWrapper(logger),
+		PauseGate:         pauseGate,
+		Limiter:           limiter,
+		RetryTopics:       retryTopics,
+		Deduplicator:      deduplicator,
 	}
 }
 
@@ -91,20 +105,80 @@ func (h *Handler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama
 		}
 	}
 
+	// Rebuild The Events Delivered From The Partition Before Its Assignment (When Deduplicating)
+	h.Deduplicator.Rebuild(session.Context(), claim.Topic(), claim.Partition(), claim.InitialOffset())
+
 	// Pull Any Available Messages From The ConsumerGroupClaim (Until The Channel Closes)
 	for message := range claim.Messages() {
 
+		// Leave The Buffered Messages Unmarked Once The Session Ends (Draining Only The In-Flight Dispatches)
+		if session.Context().Err() != nil {
+			break
+		}
+		h.Stats.Consumed(message, claim.HighWaterMarkOffset())
+
+		// Hold The Message While The Subscription Is Paused (Unmarked Messages Are Claimed Again After A Re-Balance)
+		if !h.PauseGate.Wait(session.Context()) {
+			break
+		}
+
+		// Hold A Retried Message Until Its Retry Time (Skipping The Messages Retried For Other Subscriptions)
+		if deliver, err := h.RetryTopics.Wait(session.Context(), message); err != nil {
+			break
+		} else if !deliver {
+			h.markMessage(session, message)
+			continue
+		}
+
+		// Skip The Messages Whose Event Was Already Delivered To The Subscriber Within The Deduplication Window
+		if h.Deduplicator.IsDuplicate(session.Context(), message) {
+			h.Logger.Debug("Skipping Message Already Delivered", zap.Int32("Partition", message.Partition), zap.Int64("Offset", message.Offset))
+			h.markMessage(session, message)
+			continue
+		}
+
+		// Wait For The Subscriber's Rate Limit & Max In-Flight Events
+		release, err := h.Limiter.Acquire(session.Context())
+		if err != nil {
+			break
+		}
+
+		// Apply The Subscriber's Current RetryPolicy (Jittered Backoff Honoring Retry-After) & Observe Its Responses
+		messageRetryConfig := h.Limiter.RetryConfig(retryConfig)
+		attempt := h.Health.Attempt(deadLetterURL)
+		messageRetryConfig = attempt.RetryConfig(messageRetryConfig)
+
 		// Consume The Message (Ignore Errors - Will have already been retried and we're moving on so as not to block further Topic processing.)
-		_ = h.consumeMessage(message, destinationURL, replyURL, deadLetterURL, &retryConfig)
+		dispatched := h.Stats.Handling(message)
+		err = h.consumeMessage(message, destinationURL, replyURL, deadLetterURL, &messageRetryConfig)
+		dispatched(err)
+		if h.DispatchCtx.Err() == nil {
+			attempt.Done(err) // The Aborted Dispatches Are Not Failures Of The Subscriber
+		}
+		if err == nil {
+			h.Deduplicator.Delivered(session.Context(), message)
+		}
+		release()
+
+		// Leave The Message Unmarked If Its Dispatch Was Aborted (To Be Consumed Again Rather Than Lost)
+		if h.DispatchCtx.Err() != nil {
+			break
+		}
 
 		// Mark The Message As Having Been Consumed (Does Not Imply Successful Delivery - Only Full Retry Attempts Made)
-		session.MarkMessage(message, "")
+		h.markMessage(session, message)
 	}
 
 	// Return Success
 	return nil
 }
 
+// Mark The Message As Having Been Consumed & Track Its Offset
+func (h *Handler) markMessage(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) {
+	session.MarkMessage(message, "")
+	h.Stats.Marked(message)
+}
+
 // Consume A Single Message
 func (h *Handler) consumeMessage(consumerMessage *sarama.ConsumerMessage, destinationURL *url.URL, replyURL *url.URL, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) error {
 
@@ -124,8 +198,14 @@ func (h *Handler) consumeMessage(consumerMessage *sarama.ConsumerMessage, destin
 		return errors.New("received a message with unknown encoding - skipping")
 	}
 
+	// Move The Retries To The Retry Topics When Enabled (Retried Messages Always Go On Through The Retry Topics)
+	if _, retried := delivery.RetryAttemptOf(consumerMessage); h.RetryTopics != nil && (retried || (h.RetryTopics.IsReady() && h.Limiter.RetryPolicy().RetryTopics)) {
+		destinations := delivery.Destinations{Subscriber: destinationURL, Reply: replyURL, DeadLetter: deadLetterURL}
+		return h.RetryTopics.Deliver(h.DispatchCtx, h.MessageDispatcher, message, consumerMessage, destinations, *retryConfig)
+	}
+
 	// Dispatch The Message With Configured Retries & Return Any Errors
-	return h.MessageDispatcher.DispatchMessageWithRetries(context.Background(), message, nil, destinationURL, replyURL, deadLetterURL, retryConfig)
+	return h.MessageDispatcher.DispatchMessageWithRetries(h.DispatchCtx, message, nil, destinationURL, replyURL, deadLetterURL, retryConfig)
 }
 
 //
@@ -15,6 +15,8 @@ import (
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
 	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/delivery"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/kncloudevents"
@@ -211,6 +213,175 @@ func performHandlerConsumeClaimTest(t *testing.T, destinationUri, replyUri, dead
 	verifyDispatchedMessage(t, mockMessageDispatcher.Message())
 }
 
+// Test The Handler's ConsumeClaim() Functionality While Paused
+func TestHandlerConsumeClaimPaused(t *testing.T) {
+
+	// Create Mocks For Testing
+	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &kncloudevents.RetryConfig{}, nil)
+
+	// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+	newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+		return mockMessageDispatcher
+	}
+	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+	// Create The Paused Handler To Test
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	handler.PauseGate = commonconsumer.NewPauseGate()
+	handler.PauseGate.SetPaused(true)
+
+	// Background Start Consuming Claims
+	go func() {
+		err := handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+		assert.Nil(t, err)
+	}()
+
+	// Perform The Test (Add ConsumerMessages To Claims)
+	consumerMessage := createConsumerMessage(t)
+	mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+	// Verify The Message Is Held While Paused
+	select {
+	case <-mockConsumerGroupSession.MarkMessageChan:
+		t.Fatal("Message marked while paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Resume & Wait For Message To Be Marked As Complete
+	handler.PauseGate.SetPaused(false)
+	markedMessage := <-mockConsumerGroupSession.MarkMessageChan
+	close(mockConsumerGroupClaim.MessageChan)
+
+	// Verify The Results
+	assert.Equal(t, consumerMessage, markedMessage)
+	assert.NotNil(t, mockMessageDispatcher.Message())
+}
+
+// Test The Handler's ConsumeClaim() Functionality With Deduplication
+func TestHandlerConsumeClaimDeduplicated(t *testing.T) {
+
+	// Create Mocks For Testing
+	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &kncloudevents.RetryConfig{}, nil)
+
+	// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+	newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+		return mockMessageDispatcher
+	}
+	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+	// Create The Deduplicating Handler To Test
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	handler.Deduplicator = delivery.NewDeduplicator(zap.NewNop(), handler.Subscriber.UID)
+	handler.Deduplicator.SetWindow(time.Minute)
+
+	// Background Start Consuming Claims
+	go func() {
+		err := handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+		assert.Nil(t, err)
+	}()
+
+	// The First Message Is Dispatched
+	consumerMessage := createConsumerMessage(t)
+	mockConsumerGroupClaim.MessageChan <- consumerMessage
+	assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
+	assert.NotNil(t, mockMessageDispatcher.Message())
+
+	// The Same Event Is Marked Without Being Dispatched Again
+	duplicateDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &kncloudevents.RetryConfig{}, nil)
+	handler.MessageDispatcher = duplicateDispatcher
+	duplicateMessage := createConsumerMessage(t)
+	mockConsumerGroupClaim.MessageChan <- duplicateMessage
+	assert.Equal(t, duplicateMessage, <-mockConsumerGroupSession.MarkMessageChan)
+	close(mockConsumerGroupClaim.MessageChan)
+	assert.Nil(t, duplicateDispatcher.Message())
+}
+
+// Test The Handler's ConsumeClaim() Functionality Tracking The Delivery Health
+func TestHandlerConsumeClaimHealth(t *testing.T) {
+
+	// Create Mocks For Testing (Failing The Dispatches)
+	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &kncloudevents.RetryConfig{}, errors.New("unable to complete request"))
+
+	// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+	newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+		return mockMessageDispatcher
+	}
+	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+	// Create The Handler To Test Tracking Its Delivery Health
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	handler.Health = delivery.NewHealth(delivery.HealthThresholds{ConsecutiveFailures: 2})
+
+	// Background Start Consuming Claims
+	go func() {
+		err := handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+		assert.Nil(t, err)
+	}()
+
+	// The Failed Dispatches Make The Delivery Unhealthy
+	for i := 0; i < 2; i++ {
+		consumerMessage := createConsumerMessage(t)
+		mockConsumerGroupClaim.MessageChan <- consumerMessage
+		assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
+	}
+	close(mockConsumerGroupClaim.MessageChan)
+	status := handler.Health.Status()
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.Equal(t, "unable to complete request", status.LastError)
+	assert.Equal(t, delivery.DeliveryFailingReason, status.Reason)
+}
+
+// Test The Handler's ConsumeClaim() Functionality When Its Dispatches Are Aborted
+func TestHandlerConsumeClaimAborted(t *testing.T) {
+
+	// Create Mocks For Testing
+	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &kncloudevents.RetryConfig{}, nil)
+
+	// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+	newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+		return mockMessageDispatcher
+	}
+	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+	// Create The Handler To Test With Its Dispatches Aborted
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	dispatchCtx, abort := context.WithCancel(context.Background())
+	abort()
+	handler.DispatchCtx = dispatchCtx
+
+	// Background Start Consuming Claims
+	consumeClaimDone := make(chan struct{})
+	go func() {
+		err := handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+		assert.Nil(t, err)
+		close(consumeClaimDone)
+	}()
+
+	// Perform The Test (Add ConsumerMessages To Claims)
+	mockConsumerGroupClaim.MessageChan <- createConsumerMessage(t)
+
+	// Verify The ConsumeClaim Returned Without Marking The Aborted Message
+	select {
+	case <-mockConsumerGroupSession.MarkMessageChan:
+		t.Fatal("Aborted message marked")
+	case <-consumeClaimDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ConsumeClaim did not return")
+	}
+}
+
 // Test The Custom CheckRetry() Implementation
 func TestCheckRetry(t *testing.T) {
 
@@ -397,7 +568,7 @@ func createTestHandler(t *testing.T, subscriberURL *apis.URL, replyUrl *apis.URL
 	}
 
 	// Perform The Test Create The Test Handler
-	handler := NewHandler(logger, testSubscriber)
+	handler := NewHandler(context.Background(), logger, testSubscriber, nil, nil, nil, nil)
 
 	// Verify The Results
 	assert.NotNil(t, handler)
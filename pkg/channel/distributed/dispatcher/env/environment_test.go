@@ -14,30 +14,32 @@ import (
 
 // Test Constants
 const (
-	metricsPort   = "9999"
-	metricsDomain = "kafka-eventing"
-	healthPort    = "1234"
-	kafkaBrokers  = "TestKafkaBrokers"
-	kafkaTopic    = "TestKafkaTopic"
-	channelKey    = "TestChannelKey"
-	serviceName   = "TestServiceName"
-	kafkaUsername = "TestKafkaUsername"
-	kafkaPassword = "TestKafkaPassword"
+	metricsPort     = "9999"
+	metricsDomain   = "kafka-eventing"
+	healthPort      = "1234"
+	kafkaBrokers    = "TestKafkaBrokers"
+	kafkaTopic      = "TestKafkaTopic"
+	channelKey      = "TestChannelKey"
+	serviceName     = "TestServiceName"
+	kafkaUsername   = "TestKafkaUsername"
+	kafkaPassword   = "TestKafkaPassword"
+	kafkaSecretPath = "/etc/kafka-secret"
 )
 
 // Define The TestCase Struct
 type TestCase struct {
-	name          string
-	metricsPort   string
-	metricsDomain string
-	healthPort    string
-	kafkaBrokers  string
-	kafkaTopic    string
-	channelKey    string
-	serviceName   string
-	kafkaUsername string
-	kafkaPassword string
-	expectedError error
+	name            string
+	metricsPort     string
+	metricsDomain   string
+	healthPort      string
+	kafkaBrokers    string
+	kafkaTopic      string
+	channelKey      string
+	serviceName     string
+	kafkaUsername   string
+	kafkaPassword   string
+	kafkaSecretPath string
+	expectedError   error
 }
 
 // Test All Permutations Of The GetEnvironment() Functionality
@@ -110,6 +112,7 @@ func TestGetEnvironment(t *testing.T) {
 		assertSetenv(t, commonenv.ServiceNameEnvVarKey, testCase.serviceName)
 		assertSetenv(t, commonenv.KafkaUsernameEnvVarKey, testCase.kafkaUsername)
 		assertSetenv(t, commonenv.KafkaPasswordEnvVarKey, testCase.kafkaPassword)
+		assertSetenv(t, commonenv.KafkaSecretPathEnvVarKey, testCase.kafkaSecretPath)
 
 		// Perform The Test
 		environment, err := GetEnvironment(logger)
@@ -127,6 +130,7 @@ func TestGetEnvironment(t *testing.T) {
 			assert.Equal(t, testCase.serviceName, environment.ServiceName)
 			assert.Equal(t, testCase.kafkaUsername, environment.KafkaUsername)
 			assert.Equal(t, testCase.kafkaPassword, environment.KafkaPassword)
+			assert.Equal(t, testCase.kafkaSecretPath, environment.KafkaSecretPath)
 
 		} else {
 			assert.Equal(t, testCase.expectedError, err)
@@ -149,17 +153,18 @@ func assertSetenvNonempty(t *testing.T, envKey string, value string) {
 // Get The Base / Valid Test Case - All Config Specified / No Errors
 func getValidTestCase(name string) TestCase {
 	return TestCase{
-		name:          name,
-		metricsPort:   metricsPort,
-		metricsDomain: metricsDomain,
-		healthPort:    healthPort,
-		kafkaBrokers:  kafkaBrokers,
-		kafkaTopic:    kafkaTopic,
-		channelKey:    channelKey,
-		serviceName:   serviceName,
-		kafkaUsername: kafkaUsername,
-		kafkaPassword: kafkaPassword,
-		expectedError: nil,
+		name:            name,
+		metricsPort:     metricsPort,
+		metricsDomain:   metricsDomain,
+		healthPort:      healthPort,
+		kafkaBrokers:    kafkaBrokers,
+		kafkaTopic:      kafkaTopic,
+		channelKey:      channelKey,
+		serviceName:     serviceName,
+		kafkaUsername:   kafkaUsername,
+		kafkaPassword:   kafkaPassword,
+		kafkaSecretPath: kafkaSecretPath,
+		expectedError:   nil,
 	}
 }
 
@@ -6,6 +6,13 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/health"
 )
 
+// The Names Of The Dispatcher's Health Checks
+const (
+	BrokersCheck        = "kafka-brokers"   // The Connectivity Of The Dispatcher To The Kafka Brokers
+	ConsumerGroupsCheck = "consumer-groups" // The Sessions Of The Subscribers' ConsumerGroups
+	InformersCheck      = "informers"       // The Sync Of The KafkaChannel & Subscription Informers
+)
+
 // Start The HTTP Server Listening For Requests
 
 type Server struct {
@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -83,3 +84,53 @@ func WithSubscriberReady(uid types.UID) KafkaChannelOption {
 		})
 	}
 }
+
+func WithSubscriberPaused(uid types.UID) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Status.SubscribableStatus.Subscribers == nil {
+			kafkachannel.Status.SubscribableStatus.Subscribers = []eventingduck.SubscriberStatus{}
+		}
+		kafkachannel.Status.SubscribableStatus.Subscribers = append(kafkachannel.Status.SubscribableStatus.Subscribers, eventingduck.SubscriberStatus{
+			Ready:   corev1.ConditionTrue,
+			UID:     uid,
+			Message: "The subscription is paused by the " + v1beta1.KafkaSubscriptionPausedAnnotation + " annotation",
+		})
+	}
+}
+
+// NewPausedSubscription creates a Subscription to a KafkaChannel annotated as paused.
+func NewPausedSubscription(uid types.UID, name string, namespace string, channelName string) *messagingv1.Subscription {
+	return NewAnnotatedSubscription(uid, name, namespace, channelName, map[string]string{v1beta1.KafkaSubscriptionPausedAnnotation: "true"})
+}
+
+// NewAnnotatedSubscription creates a Subscription to a KafkaChannel with the given annotations.
+func NewAnnotatedSubscription(uid types.UID, name string, namespace string, channelName string, annotations map[string]string) *messagingv1.Subscription {
+	return &messagingv1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			UID:         uid,
+			Annotations: annotations,
+		},
+		Spec: messagingv1.SubscriptionSpec{
+			Channel: corev1.ObjectReference{
+				APIVersion: v1beta1.SchemeGroupVersion.String(),
+				Kind:       "KafkaChannel",
+				Name:       channelName,
+			},
+		},
+	}
+}
+
+func WithSubscriberFailed(uid types.UID, message string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Status.SubscribableStatus.Subscribers == nil {
+			kafkachannel.Status.SubscribableStatus.Subscribers = []eventingduck.SubscriberStatus{}
+		}
+		kafkachannel.Status.SubscribableStatus.Subscribers = append(kafkachannel.Status.SubscribableStatus.Subscribers, eventingduck.SubscriberStatus{
+			Ready:   corev1.ConditionFalse,
+			UID:     uid,
+			Message: message,
+		})
+	}
+}
@@ -12,6 +12,9 @@ import (
 	fakemessagingclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
 	versionedscheme "knative.dev/eventing-kafka/pkg/client/clientset/versioned/scheme"
 	messaginglisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	fakeeventingclientset "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	eventingmessaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
 	"knative.dev/pkg/reconciler/testing"
 )
 
@@ -19,6 +22,7 @@ var clientSetSchemes = []func(*runtime.Scheme) error{
 	fakekubeclientset.AddToScheme,
 	fakemessagingclientset.AddToScheme,
 	versionedscheme.AddToScheme,
+	fakeeventingclientset.AddToScheme,
 }
 
 type Listers struct {
@@ -74,3 +78,7 @@ func (l *Listers) GetKafkaChannelLister() messaginglisters.KafkaChannelLister {
 func (l *Listers) GetDeploymentLister() appsv1listers.DeploymentLister {
 	return appsv1listers.NewDeploymentLister(l.indexerFor(&appsv1.Deployment{}))
 }
+
+func (l *Listers) GetSubscriptionLister() eventingmessaginglisters.SubscriptionLister {
+	return eventingmessaginglisters.NewSubscriptionLister(l.indexerFor(&messagingv1.Subscription{}))
+}
@@ -3,14 +3,20 @@ package channel
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"go.uber.org/zap"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	k8sclientcmd "k8s.io/client-go/tools/clientcmd"
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	kafkainformers "knative.dev/eventing-kafka/pkg/client/informers/externalversions"
 	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	commonhealth "knative.dev/eventing-kafka/pkg/common/health"
+	"knative.dev/eventing-kafka/pkg/common/ingress"
+	"knative.dev/eventing-kafka/pkg/common/partitioning"
 	eventingChannel "knative.dev/eventing/pkg/channel"
 	knativecontroller "knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -21,8 +27,18 @@ var (
 	logger             *zap.Logger
 	kafkaChannelLister kafkalisters.KafkaChannelLister
 	stopChan           chan struct{}
+
+	// The Validation Rules Of The KafkaChannels (Parsed Again Only When A KafkaChannel Changes)
+	validationRules     = make(map[eventingChannel.ChannelReference]cachedRules)
+	validationRulesLock sync.Mutex
 )
 
+// The Validation Rules Of A KafkaChannel As Of A ResourceVersion
+type cachedRules struct {
+	resourceVersion string
+	rules           ingress.Rules
+}
+
 // Wrapper Around Kafka Client Creation To Facilitate Unit Testing
 var getKafkaClient = func(ctx context.Context, serverUrl string, kubeconfigPath string) (kafkaclientset.Interface, error) {
 
@@ -60,6 +76,7 @@ func InitializeKafkaChannelLister(ctx context.Context, serverUrl string, kubecon
 	// Get A KafkaChannel Informer From The SharedInformerFactory - Start The Informer & Wait For It
 	kafkaChannelInformer := sharedInformerFactory.Messaging().V1beta1().KafkaChannels()
 	go kafkaChannelInformer.Informer().Run(stopChan)
+	healthServer.Checker().AddCheck(health.ChannelListerCheck, commonhealth.Readiness, commonhealth.SyncCheck(kafkaChannelInformer.Informer().HasSynced))
 	sharedInformerFactory.WaitForCacheSync(stopChan)
 
 	// Get A KafkaChannel Lister From The Informer
@@ -106,6 +123,89 @@ func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) err
 	return nil
 }
 
+// Get The Partitioning Settings Of The Specified KafkaChannel's Spec (The Default Settings If Unavailable / Invalid)
+func GetPartitioningSettings(channelReference eventingChannel.ChannelReference) partitioning.Settings {
+
+	// Attempt To Get The KafkaChannel From The KafkaChannel Lister
+	kafkaChannel, err := kafkaChannelLister.KafkaChannels(channelReference.Namespace).Get(channelReference.Name)
+	if err != nil {
+		logger.Warn("Failed To Get KafkaChannel - Using Default Partitioning Settings", zap.Any("ChannelReference", channelReference), zap.Error(err))
+		return partitioning.Settings{}
+	}
+
+	// Parse The Partitioning Settings From The KafkaChannel's Partitioning Policy (Validated By The Webhook)
+	settings, err := partitioning.SettingsFromPolicy(kafkaChannel.Spec.Partitioning)
+	if err != nil {
+		logger.Warn("Invalid KafkaChannel Partitioning Policy - Using Default Partitioning Settings", zap.Any("ChannelReference", channelReference), zap.Error(err))
+		return partitioning.Settings{}
+	}
+	return settings
+}
+
+// Get The Exactly Once Ingress Settings Of The Specified KafkaChannel's Spec (Disabled If Unavailable / Invalid)
+func GetExactlyOnceSettings(channelReference eventingChannel.ChannelReference) producer.ExactlyOnceSettings {
+
+	// Attempt To Get The KafkaChannel From The KafkaChannel Lister
+	kafkaChannel, err := kafkaChannelLister.KafkaChannels(channelReference.Namespace).Get(channelReference.Name)
+	if err != nil {
+		logger.Warn("Failed To Get KafkaChannel - Exactly Once Ingress Disabled", zap.Any("ChannelReference", channelReference), zap.Error(err))
+		return producer.ExactlyOnceSettings{}
+	}
+
+	// Get The Exactly Once Settings From The KafkaChannel's Exactly Once Ingress Policy (Validated By The Webhook)
+	settings, err := producer.ExactlyOnceSettingsFromPolicy(kafkaChannel.Spec.ExactlyOnceIngress)
+	if err != nil {
+		logger.Warn("Invalid KafkaChannel Exactly Once Ingress Policy - Exactly Once Ingress Disabled", zap.Any("ChannelReference", channelReference), zap.Error(err))
+		return producer.ExactlyOnceSettings{}
+	}
+	return settings
+}
+
+// Get The Ingress Validation Rules Of The Specified KafkaChannel's Spec (Accepting All Events If Unavailable / Invalid)
+func GetValidationRules(channelReference eventingChannel.ChannelReference) ingress.Rules {
+
+	// Attempt To Get The KafkaChannel From The KafkaChannel Lister
+	kafkaChannel, err := kafkaChannelLister.KafkaChannels(channelReference.Namespace).Get(channelReference.Name)
+	if err != nil {
+		logger.Debug("Failed To Get KafkaChannel - Not Validating Events", zap.Any("ChannelReference", channelReference), zap.Error(err))
+		return ingress.Rules{}
+	}
+
+	// Reuse The Rules Parsed From The Same Version Of The KafkaChannel (The JSON Schemas Are Costly To Parse For Each Event)
+	validationRulesLock.Lock()
+	defer validationRulesLock.Unlock()
+	if cached, ok := validationRules[channelReference]; ok && cached.resourceVersion == kafkaChannel.ResourceVersion {
+		return cached.rules
+	}
+
+	// Parse The Validation Rules From The KafkaChannel's Event Rules (Validated By The Webhook)
+	rules, err := ingress.RulesFromSpec(kafkaChannel.Spec.EventRules)
+	if err != nil {
+		logger.Warn("Invalid KafkaChannel Event Rules - Not Validating Events", zap.Any("ChannelReference", channelReference), zap.Error(err))
+		rules = ingress.Rules{}
+	}
+	validationRules[channelReference] = cachedRules{resourceVersion: kafkaChannel.ResourceVersion, rules: rules}
+	return rules
+}
+
+// Get The Ingress Policy Of The Specified KafkaChannel's Spec (Nil, Accepting Any Sender, If Unspecified), Or An Error
+// If The KafkaChannel Cannot Be Retrieved (The Sender Then Being Rejected Rather Than Accepted)
+func GetIngressPolicy(channelReference eventingChannel.ChannelReference) (*v1beta1.IngressPolicy, error) {
+
+	// Attempt To Get The KafkaChannel From The KafkaChannel Lister (Unknown Channels Are Rejected By The MessageReceiver)
+	kafkaChannel, err := kafkaChannelLister.KafkaChannels(channelReference.Namespace).Get(channelReference.Name)
+	if k8serrors.IsNotFound(err) {
+		logger.Debug("KafkaChannel Not Found - No Ingress Policy", zap.Any("ChannelReference", channelReference))
+		return nil, nil
+	} else if err != nil {
+		logger.Error("Failed To Get KafkaChannel - Unknown Ingress Policy", zap.Any("ChannelReference", channelReference), zap.Error(err))
+		return nil, err
+	}
+
+	// Return The Ingress Policy (Validated By The Webhook)
+	return kafkaChannel.Spec.Ingress, nil
+}
+
 // Close The Channel Lister (Stop Processing)
 func Close() {
 	if stopChan != nil {
@@ -2,14 +2,25 @@ package channel
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
 	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	fakeclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/ingress"
+	"knative.dev/eventing-kafka/pkg/common/partitioning"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -70,6 +81,152 @@ func performValidateKafkaChannelTest(t *testing.T, channelName string, channelNa
 	assert.Equal(t, err, validationError != nil)
 }
 
+// Test The GetPartitioningSettings() Functionality
+func TestGetPartitioningSettings(t *testing.T) {
+
+	// Set The Package Level Logger To A Test Logger
+	logger = logtesting.TestLogger(t).Desugar()
+
+	// Test Data
+	validChannel := receivertesting.CreateKafkaChannel("valid", "TestChannelNamespace", corev1.ConditionTrue)
+	validChannel.Spec.Partitioning = &kafkav1beta1.PartitioningPolicy{
+		KeyAttribute: "subject",
+		Partitioner:  kafkav1beta1.KafkaChannelPartitionerMurmur2,
+	}
+	invalidChannel := receivertesting.CreateKafkaChannel("invalid", "TestChannelNamespace", corev1.ConditionTrue)
+	invalidChannel.Spec.Partitioning = &kafkav1beta1.PartitioningPolicy{Partitioner: "sticky"}
+
+	// Populate The Package Level KafkaChannel Lister
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, indexer.Add(validChannel))
+	assert.Nil(t, indexer.Add(invalidChannel))
+	kafkaChannelLister = kafkalisters.NewKafkaChannelLister(indexer)
+
+	// Perform The Tests & Verify The Results
+	assert.Equal(t, partitioning.Settings{KeyAttribute: "subject", Partitioner: kafkav1beta1.KafkaChannelPartitionerMurmur2},
+		GetPartitioningSettings(receivertesting.CreateChannelReference("valid", "TestChannelNamespace")))
+	assert.Equal(t, partitioning.Settings{}, GetPartitioningSettings(receivertesting.CreateChannelReference("invalid", "TestChannelNamespace")))
+	assert.Equal(t, partitioning.Settings{}, GetPartitioningSettings(receivertesting.CreateChannelReference("missing", "TestChannelNamespace")))
+}
+
+// Test The GetExactlyOnceSettings() Functionality
+func TestGetExactlyOnceSettings(t *testing.T) {
+
+	// Set The Package Level Logger To A Test Logger
+	logger = logtesting.TestLogger(t).Desugar()
+
+	// Test Data
+	validChannel := receivertesting.CreateKafkaChannel("valid", "TestChannelNamespace", corev1.ConditionTrue)
+	validChannel.Spec.ExactlyOnceIngress = &kafkav1beta1.ExactlyOnceIngressPolicy{
+		DeduplicationWindow: &metav1.Duration{Duration: 5 * time.Minute},
+	}
+	invalidChannel := receivertesting.CreateKafkaChannel("invalid", "TestChannelNamespace", corev1.ConditionTrue)
+	invalidChannel.Spec.ExactlyOnceIngress = &kafkav1beta1.ExactlyOnceIngressPolicy{
+		DeduplicationWindow: &metav1.Duration{Duration: -time.Minute},
+	}
+
+	// Populate The Package Level KafkaChannel Lister
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, indexer.Add(validChannel))
+	assert.Nil(t, indexer.Add(invalidChannel))
+	kafkaChannelLister = kafkalisters.NewKafkaChannelLister(indexer)
+
+	// Perform The Tests & Verify The Results
+	assert.Equal(t, producer.ExactlyOnceSettings{Enabled: true, DeduplicationWindow: 5 * time.Minute},
+		GetExactlyOnceSettings(receivertesting.CreateChannelReference("valid", "TestChannelNamespace")))
+	assert.Equal(t, producer.ExactlyOnceSettings{}, GetExactlyOnceSettings(receivertesting.CreateChannelReference("invalid", "TestChannelNamespace")))
+	assert.Equal(t, producer.ExactlyOnceSettings{}, GetExactlyOnceSettings(receivertesting.CreateChannelReference("missing", "TestChannelNamespace")))
+}
+
+// Test The GetValidationRules() Functionality
+func TestGetValidationRules(t *testing.T) {
+
+	// Set The Package Level Logger To A Test Logger
+	logger = logtesting.TestLogger(t).Desugar()
+
+	// Test Data
+	validChannel := receivertesting.CreateKafkaChannel("valid", "TestChannelNamespace", corev1.ConditionTrue)
+	validChannel.ResourceVersion = "1"
+	validChannel.Spec.EventRules = &kafkav1beta1.EventRules{
+		MaxEventSize:       resource.NewQuantity(1024, resource.BinarySI),
+		RequiredAttributes: []string{"subject"},
+	}
+	invalidChannel := receivertesting.CreateKafkaChannel("invalid", "TestChannelNamespace", corev1.ConditionTrue)
+	invalidChannel.Spec.EventRules = &kafkav1beta1.EventRules{MaxEventSize: resource.NewQuantity(-1, resource.DecimalSI)}
+
+	// Populate The Package Level KafkaChannel Lister
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, indexer.Add(validChannel))
+	assert.Nil(t, indexer.Add(invalidChannel))
+	kafkaChannelLister = kafkalisters.NewKafkaChannelLister(indexer)
+
+	// Perform The Tests & Verify The Results
+	validReference := receivertesting.CreateChannelReference("valid", "TestChannelNamespace")
+	assert.Equal(t, ingress.Rules{MaxEventSize: 1024, RequiredAttributes: []string{"subject"}}, GetValidationRules(validReference))
+	assert.True(t, GetValidationRules(receivertesting.CreateChannelReference("invalid", "TestChannelNamespace")).IsZero())
+	assert.True(t, GetValidationRules(receivertesting.CreateChannelReference("missing", "TestChannelNamespace")).IsZero())
+
+	// The Rules Are Parsed Again Once The KafkaChannel Changes
+	updatedChannel := validChannel.DeepCopy()
+	updatedChannel.ResourceVersion = "2"
+	updatedChannel.Spec.EventRules = &kafkav1beta1.EventRules{MaxEventSize: resource.NewQuantity(2048, resource.BinarySI)}
+	assert.Nil(t, indexer.Update(updatedChannel))
+	assert.Equal(t, ingress.Rules{MaxEventSize: 2048}, GetValidationRules(validReference))
+}
+
+// Test The GetIngressPolicy() Functionality
+func TestGetIngressPolicy(t *testing.T) {
+
+	// Set The Package Level Logger To A Test Logger
+	logger = logtesting.TestLogger(t).Desugar()
+
+	// Test Data
+	policy := &kafkav1beta1.IngressPolicy{
+		ServiceAccount: &kafkav1beta1.ServiceAccountIngressPolicy{},
+		Namespaces:     []string{"TestSenderNamespace"},
+	}
+	securedChannel := receivertesting.CreateKafkaChannel("secured", "TestChannelNamespace", corev1.ConditionTrue)
+	securedChannel.Spec.Ingress = policy
+	openChannel := receivertesting.CreateKafkaChannel("open", "TestChannelNamespace", corev1.ConditionTrue)
+
+	// Populate The Package Level KafkaChannel Lister
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, indexer.Add(securedChannel))
+	assert.Nil(t, indexer.Add(openChannel))
+	kafkaChannelLister = kafkalisters.NewKafkaChannelLister(indexer)
+
+	// Perform The Tests & Verify The Results
+	for name, expected := range map[string]*kafkav1beta1.IngressPolicy{"secured": policy, "open": nil, "missing": nil} {
+		actual, err := GetIngressPolicy(receivertesting.CreateChannelReference(name, "TestChannelNamespace"))
+		assert.Nil(t, err)
+		assert.Equal(t, expected, actual)
+	}
+
+	// Verify The Lister Failures Are Returned Rather Than Accepting Any Sender
+	kafkaChannelLister = failingKafkaChannelLister{err: errors.New("test-error")}
+	actual, err := GetIngressPolicy(receivertesting.CreateChannelReference("secured", "TestChannelNamespace"))
+	assert.NotNil(t, err)
+	assert.Nil(t, actual)
+}
+
+// A KafkaChannel Lister Failing To Get Any KafkaChannel
+type failingKafkaChannelLister struct {
+	kafkalisters.KafkaChannelLister
+	err error
+}
+
+func (l failingKafkaChannelLister) KafkaChannels(string) kafkalisters.KafkaChannelNamespaceLister {
+	return l
+}
+
+func (l failingKafkaChannelLister) List(labels.Selector) ([]*kafkav1beta1.KafkaChannel, error) {
+	return nil, l.err
+}
+
+func (l failingKafkaChannelLister) Get(string) (*kafkav1beta1.KafkaChannel, error) {
+	return nil, l.err
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
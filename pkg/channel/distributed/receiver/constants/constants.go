@@ -8,6 +8,8 @@ const (
 
 	MetricsInterval = 5 * time.Second
 
+	DeduplicationCapacity = 10000 // Maximum Number Of Events Remembered Per KafkaChannel For Deduplication
+
 	ExtensionKeyPartitionKey = "partitionkey"
 
 	KafkaHeaderKeyContentType = "content-type"
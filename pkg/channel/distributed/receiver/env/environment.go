@@ -20,8 +20,9 @@ type Environment struct {
 	ServiceName  string // Required
 
 	// Kafka Authorization
-	KafkaUsername string // Optional
-	KafkaPassword string // Optional
+	KafkaUsername   string // Optional
+	KafkaPassword   string // Optional
+	KafkaSecretPath string // Optional (The Mounted Kafka Secret Watched For Credential Changes)
 }
 
 // Get The Environment
@@ -69,6 +70,9 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 	// Get The Optional KafkaPassword Config Value
 	environment.KafkaPassword = env.GetOptionalConfigValue(logger, env.KafkaPasswordEnvVarKey, "")
 
+	// Get The Optional KafkaSecretPath Config Value
+	environment.KafkaSecretPath = env.GetOptionalConfigValue(logger, env.KafkaSecretPathEnvVarKey, "")
+
 	// Clone The Environment & Mask The Password For Safe Logging
 	safeEnvironment := *environment
 	if len(safeEnvironment.KafkaPassword) > 0 {
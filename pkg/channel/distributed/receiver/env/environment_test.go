@@ -14,26 +14,28 @@ import (
 
 // Test Constants
 const (
-	metricsPort   = "9999"
-	metricsDomain = "kafka-eventing"
-	healthPort    = "1234"
-	kafkaBrokers  = "TestKafkaBrokers"
-	serviceName   = "TestServiceName"
-	kafkaUsername = "TestKafkaUsername"
-	kafkaPassword = "TestKafkaPassword"
+	metricsPort     = "9999"
+	metricsDomain   = "kafka-eventing"
+	healthPort      = "1234"
+	kafkaBrokers    = "TestKafkaBrokers"
+	serviceName     = "TestServiceName"
+	kafkaUsername   = "TestKafkaUsername"
+	kafkaPassword   = "TestKafkaPassword"
+	kafkaSecretPath = "/etc/kafka-secret"
 )
 
 // Define The TestCase Struct
 type TestCase struct {
-	name          string
-	metricsPort   string
-	metricsDomain string
-	healthPort    string
-	kafkaBrokers  string
-	serviceName   string
-	kafkaUsername string
-	kafkaPassword string
-	expectedError error
+	name            string
+	metricsPort     string
+	metricsDomain   string
+	healthPort      string
+	kafkaBrokers    string
+	serviceName     string
+	kafkaUsername   string
+	kafkaPassword   string
+	kafkaSecretPath string
+	expectedError   error
 }
 
 // Test All Permutations Of The GetEnvironment() Functionality
@@ -94,6 +96,7 @@ func TestGetEnvironment(t *testing.T) {
 		assertSetenv(t, env.ServiceNameEnvVarKey, testCase.serviceName)
 		assertSetenv(t, env.KafkaUsernameEnvVarKey, testCase.kafkaUsername)
 		assertSetenv(t, env.KafkaPasswordEnvVarKey, testCase.kafkaPassword)
+		assertSetenv(t, env.KafkaSecretPathEnvVarKey, testCase.kafkaSecretPath)
 
 		// Perform The Test
 		environment, err := GetEnvironment(logger)
@@ -109,6 +112,7 @@ func TestGetEnvironment(t *testing.T) {
 			assert.Equal(t, testCase.serviceName, environment.ServiceName)
 			assert.Equal(t, testCase.kafkaUsername, environment.KafkaUsername)
 			assert.Equal(t, testCase.kafkaPassword, environment.KafkaPassword)
+			assert.Equal(t, testCase.kafkaSecretPath, environment.KafkaSecretPath)
 
 		} else {
 			assert.Equal(t, testCase.expectedError, err)
@@ -131,15 +135,16 @@ func assertSetenvNonempty(t *testing.T, envKey string, value string) {
 // Get The Base / Valid Test Case - All Config Specified / No Errors
 func getValidTestCase(name string) TestCase {
 	return TestCase{
-		name:          name,
-		metricsPort:   metricsPort,
-		metricsDomain: metricsDomain,
-		healthPort:    healthPort,
-		kafkaBrokers:  kafkaBrokers,
-		serviceName:   serviceName,
-		kafkaUsername: kafkaUsername,
-		kafkaPassword: kafkaPassword,
-		expectedError: nil,
+		name:            name,
+		metricsPort:     metricsPort,
+		metricsDomain:   metricsDomain,
+		healthPort:      healthPort,
+		kafkaBrokers:    kafkaBrokers,
+		serviceName:     serviceName,
+		kafkaUsername:   kafkaUsername,
+		kafkaPassword:   kafkaPassword,
+		kafkaSecretPath: kafkaSecretPath,
+		expectedError:   nil,
 	}
 }
 
@@ -3,7 +3,16 @@ package health
 import (
 	"sync"
 
+	"github.com/Shopify/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/health"
+	commonhealth "knative.dev/eventing-kafka/pkg/common/health"
+)
+
+// The Names Of The Receiver's Health Checks
+const (
+	BrokersCheck        = "kafka-brokers"       // The Connectivity Of The Producer To The Kafka Brokers
+	ProducerErrorsCheck = "producer-errors"     // The Error Rate Of The Events Produced To Kafka
+	ChannelListerCheck  = "kafkachannel-lister" // The Sync Of The KafkaChannel Lister
 )
 
 // Start The HTTP Server Listening For Requests
@@ -18,11 +27,17 @@ type Server struct {
 	// Additional Internal Flags
 	producerReady bool // A flag that the producer sets when it is ready
 	channelReady  bool // A flag that the channel sets when it is ready
+
+	// Additional Health Checks
+	brokerCheck    *commonhealth.BrokerCheck // Created By The First Producer (Guarded By The producerMutex)
+	producerErrors *commonhealth.ErrorRate
 }
 
 // Creates A New Server With Specified Configuration
 func NewChannelHealthServer(httpPort string) *Server {
-	channelHealth := &Server{}
+	channelHealth := &Server{
+		producerErrors: commonhealth.NewErrorRate(commonhealth.DefaultErrorRateWindow, commonhealth.DefaultErrorRateThreshold, commonhealth.DefaultErrorRateMinCount),
+	}
 	channelHealth.Server = *health.NewHealthServer(httpPort, channelHealth)
 
 	// Return The Server
@@ -43,11 +58,32 @@ func (chs *Server) SetChannelReady(isReady bool) {
 	chs.channelMutex.Unlock()
 }
 
+// Check The Connectivity Of The Producer To The Kafka Brokers & Its Error Rate (Updated With The Configuration Of Each New Producer)
+func (chs *Server) SetProducerBrokers(brokers []string, config *sarama.Config) {
+	chs.producerMutex.Lock()
+	defer chs.producerMutex.Unlock()
+	if chs.brokerCheck == nil {
+		chs.brokerCheck = commonhealth.NewBrokerCheck(brokers, config)
+		chs.Checker().AddCheck(BrokersCheck, commonhealth.Readiness, chs.brokerCheck.Check)
+		chs.Checker().AddCheck(ProducerErrorsCheck, commonhealth.Readiness, chs.producerErrors.Check)
+	} else {
+		chs.brokerCheck.Update(brokers, config)
+	}
+}
+
+// Record The Outcome Of Producing An Event To Kafka (Failing The Readiness Above The Error Rate Threshold)
+func (chs *Server) RecordProducerResult(err error) {
+	chs.producerErrors.Record(err)
+}
+
 // Set All Liveness And Readiness Flags To False
 func (chs *Server) Shutdown() {
 	chs.Server.Shutdown()
 	chs.SetProducerReady(false)
 	chs.SetChannelReady(false)
+	chs.producerMutex.Lock()
+	chs.brokerCheck.Close()
+	chs.producerMutex.Unlock()
 }
 
 // Access Function For ProducerReady Flag
@@ -1,12 +1,15 @@
 package health
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
+	commonhealth "knative.dev/eventing-kafka/pkg/common/health"
 )
 
 const (
@@ -76,6 +79,36 @@ func TestChannelHealthHandler(t *testing.T) {
 	getEventToHandler(t, chs.HandleReadiness, readinessPath, http.StatusOK)
 }
 
+// Test The Producer Health Checks Registered By SetProducerBrokers()
+func TestSetProducerBrokers(t *testing.T) {
+
+	// Create A New Health Server
+	chs := NewChannelHealthServer(testHttpPort)
+	defer chs.Shutdown()
+	assert.Empty(t, chs.Checker().Report().Checks)
+
+	// Verify That The First Producer Registers The Broker & Error Rate Checks
+	chs.SetProducerBrokers([]string{"unreachable:9092"}, sarama.NewConfig())
+	report := chs.Checker().Report()
+	assert.Len(t, report.Checks, 2)
+	assert.Equal(t, BrokersCheck, report.Checks[0].Name)
+	assert.Equal(t, commonhealth.Readiness, report.Checks[0].Kind)
+	assert.Equal(t, ProducerErrorsCheck, report.Checks[1].Name)
+	assert.False(t, report.Ready)
+
+	// Verify That Subsequent Producers Only Update The Broker Check
+	brokerCheck := chs.brokerCheck
+	chs.SetProducerBrokers([]string{"other:9092"}, sarama.NewConfig())
+	assert.Same(t, brokerCheck, chs.brokerCheck)
+	assert.Len(t, chs.Checker().Report().Checks, 2)
+
+	// Verify That The Producer Results Are Recorded In The Error Rate
+	for i := 0; i < commonhealth.DefaultErrorRateMinCount; i++ {
+		chs.RecordProducerResult(sarama.ErrOutOfBrokers)
+	}
+	assert.Error(t, chs.producerErrors.Check(context.Background()))
+}
+
 //
 // Private Utility Functions
 //
@@ -0,0 +1,157 @@
+package producer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+	"knative.dev/eventing-kafka/pkg/common/deduplication"
+	"knative.dev/eventing-kafka/pkg/common/partitioning"
+	eventingChannel "knative.dev/eventing/pkg/channel"
+)
+
+// The Exactly Once Ingress Settings Of A KafkaChannel (The Zero Value Disables It)
+type ExactlyOnceSettings struct {
+	Enabled             bool          // Produce The Events With The Idempotent Producer
+	DeduplicationWindow time.Duration // Drop The Events Produced Again Within The Window (Disabled When Zero)
+}
+
+// Get The ExactlyOnceSettings Of The Specified KafkaChannel Exactly Once Ingress Policy (Disabled If Nil)
+func ExactlyOnceSettingsFromPolicy(policy *kafkav1beta1.ExactlyOnceIngressPolicy) (ExactlyOnceSettings, error) {
+	if policy == nil {
+		return ExactlyOnceSettings{}, nil
+	}
+	settings := ExactlyOnceSettings{Enabled: true}
+	if policy.DeduplicationWindow != nil {
+		if policy.DeduplicationWindow.Duration <= 0 {
+			return ExactlyOnceSettings{}, fmt.Errorf("invalid deduplication window %v: expected a positive duration", policy.DeduplicationWindow.Duration)
+		}
+		settings.DeduplicationWindow = policy.DeduplicationWindow.Duration
+	}
+	return settings, nil
+}
+
+// Create The Idempotent Variant Of The Specified Sarama Config (Validating The Kafka Version Supports It)
+func newIdempotentConfig(config *sarama.Config) (*sarama.Config, error) {
+
+	// Idempotence Requires Kafka 0.11 (Producer IDs & Sequence Numbers)
+	if !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+		return nil, fmt.Errorf("exactly once ingress requires Kafka version 0.11.0 or later, configured version is %s", config.Version)
+	}
+
+	// Copy The Config With The Settings Required By The Idempotent Producer
+	idempotentConfig := *config
+	idempotentConfig.Producer.Idempotent = true
+	idempotentConfig.Producer.RequiredAcks = sarama.WaitForAll
+	idempotentConfig.Net.MaxOpenRequests = 1
+	if idempotentConfig.Producer.Retry.Max < 1 {
+		idempotentConfig.Producer.Retry.Max = 1
+	}
+	return &idempotentConfig, idempotentConfig.Validate()
+}
+
+// Get The Idempotent Kafka Producer, Created When First Needed (Most KafkaChannels Do Not Use It)
+func (p *Producer) getIdempotentProducer() (sarama.SyncProducer, error) {
+	p.idempotentLock.Lock()
+	defer p.idempotentLock.Unlock()
+
+	if p.idempotentProducer != nil {
+		return p.idempotentProducer, nil
+	}
+	if p.released {
+		return nil, errors.New("kafka producer closed - unable to create idempotent producer")
+	}
+
+	idempotentConfig, err := newIdempotentConfig(p.configuration)
+	if err != nil {
+		return nil, err
+	}
+	idempotentConfig.Producer.Partitioner = partitioning.NewPartitionerConstructor(p.configuration.Producer.Partitioner)
+	idempotentProducer, _, err := createSyncProducerWrapper(idempotentConfig, p.brokers)
+	if err != nil {
+		return nil, err
+	}
+	p.logger.Info("Successfully Created Idempotent Kafka SyncProducer")
+	p.idempotentProducer = idempotentProducer
+	return idempotentProducer, nil
+}
+
+// The Interval Between The Evictions Of The Deduplication Caches Left Empty (Such As Those Of Deleted KafkaChannels)
+const deduplicationPruneInterval = time.Minute
+
+// The Deduplication Caches Of The KafkaChannels (Shared By The Successive Producers)
+type deduplicator struct {
+	lock    sync.Mutex
+	caches  map[eventingChannel.ChannelReference]*deduplication.Cache
+	sending map[eventingChannel.ChannelReference]map[string]bool // The Events Being Produced (Reserved But Not Yet Cached)
+	pruned  time.Time
+	now     func() time.Time
+}
+
+func newDeduplicator() *deduplicator {
+	return &deduplicator{
+		caches:  make(map[eventingChannel.ChannelReference]*deduplication.Cache),
+		sending: make(map[eventingChannel.ChannelReference]map[string]bool),
+		now:     time.Now,
+	}
+}
+
+// Reserve The Specified Event Of A KafkaChannel Before Producing It, Unless Already Produced Within The Window (Returning
+// Whether It Is A Duplicate) Or Being Produced Concurrently (Returning An Error For The Sender To Retry, As It May Still
+// Fail).  The Returned Function Must Be Called Once The Event Was Produced Or Failed To Be, Releasing The Reservation.
+func (d *deduplicator) reserve(channelReference eventingChannel.ChannelReference, window time.Duration, eventKey string) (release func(produced bool), duplicate bool, err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	cache := d.cache(channelReference, window)
+	if cache.Contains(eventKey) {
+		return nil, true, nil
+	}
+	sending := d.sending[channelReference]
+	if sending[eventKey] {
+		return nil, false, fmt.Errorf("the event %s is already being sent to kafka", eventKey)
+	}
+	if sending == nil {
+		sending = make(map[string]bool)
+		d.sending[channelReference] = sending
+	}
+	sending[eventKey] = true
+
+	return func(produced bool) {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		if sending := d.sending[channelReference]; len(sending) > 1 {
+			delete(sending, eventKey)
+		} else {
+			delete(d.sending, channelReference)
+		}
+		if produced {
+			cache.Add(eventKey) // Only Once Produced, So That Failed Events May Be Sent Again
+		}
+	}, false, nil
+}
+
+// Get The Deduplication Cache Of The Specified KafkaChannel (Recreated When Its Window Changes), Periodically Evicting
+// The Caches Left Empty Once Their Events Expired, So That Those Of The Deleted KafkaChannels Are Not Kept Forever.
+// Must Be Called With The Lock.
+func (d *deduplicator) cache(channelReference eventingChannel.ChannelReference, window time.Duration) *deduplication.Cache {
+	if now := d.now(); now.Sub(d.pruned) >= deduplicationPruneInterval {
+		for reference, cache := range d.caches {
+			if cache.Len() == 0 {
+				delete(d.caches, reference)
+			}
+		}
+		d.pruned = now
+	}
+
+	cache, ok := d.caches[channelReference]
+	if !ok || cache.Window() != window {
+		cache = deduplication.NewCache(window, constants.DeduplicationCapacity)
+		d.caches[channelReference] = cache
+	}
+	return cache
+}
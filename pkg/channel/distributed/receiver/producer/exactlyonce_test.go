@@ -0,0 +1,165 @@
+package producer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	"knative.dev/eventing-kafka/pkg/common/partitioning"
+)
+
+// Test The ExactlyOnceSettingsFromPolicy() Functionality
+func TestExactlyOnceSettingsFromPolicy(t *testing.T) {
+
+	// Disabled By Default
+	settings, err := ExactlyOnceSettingsFromPolicy(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, ExactlyOnceSettings{}, settings)
+
+	// Enabled Without A Deduplication Window
+	settings, err = ExactlyOnceSettingsFromPolicy(&kafkav1beta1.ExactlyOnceIngressPolicy{})
+	assert.Nil(t, err)
+	assert.Equal(t, ExactlyOnceSettings{Enabled: true}, settings)
+
+	// Enabled With A Deduplication Window
+	settings, err = ExactlyOnceSettingsFromPolicy(&kafkav1beta1.ExactlyOnceIngressPolicy{
+		DeduplicationWindow: &metav1.Duration{Duration: 10 * time.Minute},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ExactlyOnceSettings{Enabled: true, DeduplicationWindow: 10 * time.Minute}, settings)
+
+	// Invalid Deduplication Window
+	_, err = ExactlyOnceSettingsFromPolicy(&kafkav1beta1.ExactlyOnceIngressPolicy{
+		DeduplicationWindow: &metav1.Duration{Duration: -time.Minute},
+	})
+	assert.NotNil(t, err)
+}
+
+// Test The deduplicator's Reservation Of The Events Being Produced
+func TestDeduplicatorReserve(t *testing.T) {
+	deduplicator := newDeduplicator()
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+	// A Concurrent Duplicate Of An Event Being Produced Is Rejected
+	release, duplicate, err := deduplicator.reserve(channelReference, time.Minute, "source/id")
+	assert.Nil(t, err)
+	assert.False(t, duplicate)
+	_, _, err = deduplicator.reserve(channelReference, time.Minute, "source/id")
+	assert.NotNil(t, err)
+
+	// An Event Which Failed To Be Produced May Be Sent Again
+	release(false)
+	release, duplicate, err = deduplicator.reserve(channelReference, time.Minute, "source/id")
+	assert.Nil(t, err)
+	assert.False(t, duplicate)
+
+	// An Event Produced Is A Duplicate Within The Window
+	release(true)
+	_, duplicate, err = deduplicator.reserve(channelReference, time.Minute, "source/id")
+	assert.Nil(t, err)
+	assert.True(t, duplicate)
+	assert.Empty(t, deduplicator.sending)
+}
+
+// Test The deduplicator's Eviction Of The Caches Left Empty
+func TestDeduplicatorPrune(t *testing.T) {
+	now := time.Now()
+	deduplicator := newDeduplicator()
+	deduplicator.now = func() time.Time { return now }
+	deletedChannelReference := receivertesting.CreateChannelReference("deleted-channel", receivertesting.ChannelNamespace)
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+	// Produce An Event To A KafkaChannel Deleted Afterwards
+	release, _, err := deduplicator.reserve(deletedChannelReference, time.Millisecond, "source/id")
+	assert.Nil(t, err)
+	release(true)
+	assert.Len(t, deduplicator.caches, 1)
+
+	// Its Cache Is Evicted Once Its Events Expired
+	time.Sleep(10 * time.Millisecond)
+	now = now.Add(deduplicationPruneInterval)
+	_, _, err = deduplicator.reserve(channelReference, time.Minute, "source/id")
+	assert.Nil(t, err)
+	assert.Len(t, deduplicator.caches, 1)
+	assert.Contains(t, deduplicator.caches, channelReference)
+}
+
+// Test The newIdempotentConfig() Functionality
+func TestNewIdempotentConfig(t *testing.T) {
+
+	// The Kafka Version Must Support Idempotence
+	config := sarama.NewConfig()
+	config.Version = sarama.V0_10_2_0
+	_, err := newIdempotentConfig(config)
+	assert.NotNil(t, err)
+
+	// The Idempotent Settings Are Applied To A Copy Of The Config
+	config.Version = sarama.V2_0_0_0
+	config.Producer.RequiredAcks = sarama.WaitForLocal
+	config.Producer.Return.Successes = true
+	idempotentConfig, err := newIdempotentConfig(config)
+	assert.Nil(t, err)
+	assert.True(t, idempotentConfig.Producer.Idempotent)
+	assert.Equal(t, sarama.WaitForAll, idempotentConfig.Producer.RequiredAcks)
+	assert.Equal(t, 1, idempotentConfig.Net.MaxOpenRequests)
+	assert.False(t, config.Producer.Idempotent)
+	assert.Equal(t, sarama.WaitForLocal, config.Producer.RequiredAcks)
+}
+
+// Test The ProduceKafkaMessage() Functionality With Exactly Once Ingress & Deduplication
+func TestProduceKafkaMessageExactlyOnce(t *testing.T) {
+
+	// Create A Test Producer Supporting Idempotence
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducer(t, mockSyncProducer)
+	producer.configuration = sarama.NewConfig()
+	producer.configuration.Version = sarama.V2_0_0_0
+	producer.configuration.Producer.Return.Successes = true
+
+	// Stub The Kafka Producer Creation Wrapper With Test Version Returning The Idempotent SyncProducer
+	idempotentSyncProducer := receivertesting.NewMockSyncProducer()
+	createSyncProducerWrapperPlaceholder := createSyncProducerWrapper
+	createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (sarama.SyncProducer, gometrics.Registry, error) {
+		assert.True(t, config.Producer.Idempotent)
+		assert.NotNil(t, config.Producer.Partitioner)
+		return idempotentSyncProducer, gometrics.NewRegistry(), nil
+	}
+	defer func() { createSyncProducerWrapper = createSyncProducerWrapperPlaceholder }()
+
+	// Test Data
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	settings := ExactlyOnceSettings{Enabled: true, DeduplicationWindow: time.Minute}
+
+	// The Event Is Produced With The Idempotent Producer
+	err := producer.ProduceKafkaMessage(context.Background(), channelReference, partitioning.Settings{}, settings, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+	assert.Nil(t, err)
+	producerMessage := idempotentSyncProducer.GetMessage()
+	assert.Equal(t, receivertesting.TopicName, producerMessage.Topic)
+
+	// The Same Event Is Accepted Without Being Produced Again (The Mock Would Block Otherwise)
+	err = producer.ProduceKafkaMessage(context.Background(), channelReference, partitioning.Settings{}, settings, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+	assert.Nil(t, err)
+
+	// The Same Event Is Produced Again To Another KafkaChannel
+	otherChannelReference := receivertesting.CreateChannelReference("other-channel", receivertesting.ChannelNamespace)
+	err = producer.ProduceKafkaMessage(context.Background(), otherChannelReference, partitioning.Settings{}, settings, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+	assert.Nil(t, err)
+	idempotentSyncProducer.GetMessage()
+
+	// Closing The Producer Closes The Idempotent Producer
+	producer.Close()
+	assert.True(t, mockSyncProducer.Closed())
+	assert.True(t, idempotentSyncProducer.Closed())
+
+	// The Idempotent Producer Is Not Created Again Once Closed
+	producer.idempotentProducer = nil
+	_, err = producer.getIdempotentProducer()
+	assert.NotNil(t, err)
+}
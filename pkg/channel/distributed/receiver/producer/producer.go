@@ -3,10 +3,10 @@ package producer
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/Shopify/sarama"
-	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	gometrics "github.com/rcrowley/go-metrics"
 	"go.uber.org/zap"
@@ -17,6 +17,8 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/util"
+	"knative.dev/eventing-kafka/pkg/common/deduplication"
+	"knative.dev/eventing-kafka/pkg/common/partitioning"
 	eventingChannel "knative.dev/eventing/pkg/channel"
 )
 
@@ -31,17 +33,28 @@ type Producer struct {
 	metricsStoppedChan chan struct{}
 	configuration      *sarama.Config
 	brokers            []string
+	tlsCertificates    kafkasarama.TLSCertificates // Already Applied To The Configuration (Carried Forward Into New Ones)
+	idempotentProducer sarama.SyncProducer         // Exactly Once Ingress Producer (Created When First Needed)
+	idempotentLock     sync.Mutex
+	released           bool           // Guarded By The idempotentLock
+	deduplicator       *deduplicator  // Carried Forward Into New Producers
+	inFlight           sync.WaitGroup // Messages Being Produced, Drained Before Closing The Kafka Producers
 }
 
 // Initialize The Producer
 func NewProducer(logger *zap.Logger,
 	config *sarama.Config,
 	brokers []string,
+	tlsCertificates kafkasarama.TLSCertificates,
 	statsReporter metrics.StatsReporter,
 	healthServer *health.Server) (*Producer, error) {
 
+	// Partition Each Message With The Partitioner Of Its KafkaChannel (Defaulting To The Configured One)
+	producerConfig := *config
+	producerConfig.Producer.Partitioner = partitioning.NewPartitionerConstructor(config.Producer.Partitioner)
+
 	// Create The Kafka Producer Using The Specified Kafka Authentication
-	kafkaProducer, metricsRegistry, err := createSyncProducerWrapper(config, brokers)
+	kafkaProducer, metricsRegistry, err := createSyncProducerWrapper(&producerConfig, brokers)
 	if err != nil {
 		logger.Error("Failed To Create Kafka SyncProducer - Exiting", zap.Error(err), zap.Any("Brokers", brokers))
 		return nil, err
@@ -60,13 +73,16 @@ func NewProducer(logger *zap.Logger,
 		metricsStoppedChan: make(chan struct{}),
 		configuration:      config,
 		brokers:            brokers,
+		tlsCertificates:    tlsCertificates,
+		deduplicator:       newDeduplicator(),
 	}
 
 	// Start Observing Metrics
 	producer.ObserveMetrics(constants.MetricsInterval)
 
-	// Mark The Producer As Ready
+	// Mark The Producer As Ready & Check Its Connectivity To The Kafka Brokers
 	healthServer.SetProducerReady(true)
+	healthServer.SetProducerBrokers(brokers, &producerConfig)
 
 	// Return The New Producer
 	logger.Info("Successfully Started Kafka Producer")
@@ -79,7 +95,7 @@ var createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (s
 }
 
 // Produce A KafkaMessage From The Specified CloudEvent To The Specified Topic And Wait For The Delivery Report
-func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eventingChannel.ChannelReference, message binding.Message, transformers ...binding.Transformer) error {
+func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eventingChannel.ChannelReference, partitioningSettings partitioning.Settings, exactlyOnceSettings ExactlyOnceSettings, message binding.Message, transformers ...binding.Transformer) error {
 
 	// Validate The Kafka Producer (Must Be Pre-Initialized)
 	if p.kafkaProducer == nil {
@@ -91,19 +107,54 @@ func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eve
 	topicName := util.TopicName(channelReference)
 	logger := p.logger.With(zap.String("Topic", topicName))
 
+	// Use The Idempotent Kafka Producer For Exactly Once Ingress
+	kafkaProducer := p.kafkaProducer
+	if exactlyOnceSettings.Enabled {
+		idempotentProducer, err := p.getIdempotentProducer()
+		if err != nil {
+			logger.Error("Failed To Create Idempotent Kafka Producer - Unable To Produce Message Exactly Once", zap.Error(err))
+			return err
+		}
+		kafkaProducer = idempotentProducer
+	}
+
+	// Identify The Event By Its Source & ID When Deduplicating
+	var eventKey string
+	if exactlyOnceSettings.DeduplicationWindow > 0 {
+		transformers = append(transformers, deduplication.EventKeyTransformer(&eventKey))
+	}
+
 	// Initialize The Sarama ProducerMessage With The Specified Topic Name
 	producerMessage := &sarama.ProducerMessage{Topic: topicName}
 
-	// Use The SaramaKafka Protocol To Convert The Binding Message To A ProducerMessage
-	err := kafkasaramaprotocol.WriteProducerMessage(ctx, message, producerMessage, transformers...)
+	// Use The SaramaKafka Protocol To Convert The Binding Message To A ProducerMessage (Keyed & Partitioned As Per The KafkaChannel)
+	err := partitioningSettings.WriteProducerMessage(ctx, message, producerMessage, transformers...)
 	if err != nil {
 		p.logger.Error("Failed To Convert BindingMessage To Sarama ProducerMessage", zap.Error(err))
 		return err
 	}
 
+	// Drop Events Already Produced Within The Deduplication Window (Accepted As If Produced Again), Reserving The Others
+	var release func(produced bool)
+	if eventKey != "" {
+		var duplicate bool
+		release, duplicate, err = p.deduplicator.reserve(channelReference, exactlyOnceSettings.DeduplicationWindow, eventKey)
+		if err != nil {
+			logger.Warn("Duplicate Event Being Sent To Kafka - Rejecting", zap.String("Event", eventKey), zap.Error(err))
+			return err
+		} else if duplicate {
+			logger.Info("Duplicate Event Already Sent To Kafka - Ignoring", zap.String("Event", eventKey))
+			return nil
+		}
+	}
+
 	// Produce The Kafka Message To The Kafka Topic
 	logger.Debug("Producing Kafka Message", zap.Any("Headers", producerMessage.Headers), zap.Any("Message", producerMessage.Value))
-	partition, offset, err := p.kafkaProducer.SendMessage(producerMessage)
+	partition, offset, err := kafkaProducer.SendMessage(producerMessage)
+	p.healthServer.RecordProducerResult(err)
+	if release != nil {
+		release(err == nil)
+	}
 	if err != nil {
 		logger.Error("Failed To Send Message To Kafka", zap.Error(err))
 		return err
@@ -113,6 +164,13 @@ func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eve
 	}
 }
 
+// Hold The Producer Open Until The Returned Function Is Called, So That A Message Produced Meanwhile Is Not Sent To
+// A Closed Kafka Producer.  The Caller Must Ensure That The Producer Is Not Being Closed Yet (ie - Not Yet Replaced).
+func (p *Producer) Hold() (done func()) {
+	p.inFlight.Add(1)
+	return p.inFlight.Done
+}
+
 // Async Process For Observing Kafka Metrics
 func (p *Producer) ObserveMetrics(interval time.Duration) {
 
@@ -146,6 +204,21 @@ func (p *Producer) Close() {
 	// Mark The Producer As No Longer Ready
 	p.healthServer.SetProducerReady(false)
 
+	// Stop Observing Metrics & Close The Kafka Producer
+	p.release()
+}
+
+// Close A Producer Replaced By A New One (Which Remains Ready)
+func (p *Producer) CloseReplaced() {
+	p.release()
+}
+
+// Stop Observing Metrics & Close The Kafka Producer (Delivering The Messages Already Sent)
+func (p *Producer) release() {
+
+	// Wait For The Messages Being Produced On Hold
+	p.inFlight.Wait()
+
 	// Stop Observing Metrics
 	close(p.metricsStopChan)
 	<-p.metricsStoppedChan
@@ -157,6 +230,19 @@ func (p *Producer) Close() {
 	} else {
 		p.logger.Info("Successfully Closed Kafka Producer")
 	}
+
+	// Close The Idempotent Kafka Producer, If Created
+	p.idempotentLock.Lock()
+	defer p.idempotentLock.Unlock()
+	p.released = true
+	if p.idempotentProducer != nil {
+		err = p.idempotentProducer.Close()
+		if err != nil {
+			p.logger.Error("Failed To Close Idempotent Kafka Producer", zap.Error(err))
+		} else {
+			p.logger.Info("Successfully Closed Idempotent Kafka Producer")
+		}
+	}
 }
 
 // ConfigChanged is called by the configMapObserver handler function in main() so that
@@ -185,6 +271,12 @@ func (p *Producer) ConfigChanged(configMap *v1.ConfigMap) *Producer {
 		// Some of the current config settings may not be overridden by the configmap (username, password, etc.)
 		kafkasarama.UpdateSaramaConfig(newConfig, p.configuration.ClientID, p.configuration.Net.SASL.User, p.configuration.Net.SASL.Password)
 
+		// Nor may the TLS certificates of the kafka secret (validated on startup)
+		if err := kafkasarama.UpdateSaramaTLSConfig(newConfig, p.tlsCertificates); err != nil {
+			p.logger.Error("Unable to apply the kafka secret TLS certificates", zap.Error(err))
+			return nil
+		}
+
 		// Ignore the "Admin" and "Consumer" sections when comparing, as changes to those do not require restarting the Producer
 		if kafkasarama.ConfigEqual(newConfig, p.configuration, newConfig.Admin, newConfig.Consumer) {
 			p.logger.Info("No Producer Changes Detected In New Configuration - Ignoring")
@@ -195,13 +287,51 @@ func (p *Producer) ConfigChanged(configMap *v1.ConfigMap) *Producer {
 	// Create A New Producer With The New Configuration (Reusing All Other Existing Config)
 	p.logger.Info("Producer Changes Detected In New Configuration - Closing & Recreating Producer")
 	p.Close()
-	reconfiguredKafkaProducer, err := NewProducer(p.logger, newConfig, p.brokers, p.statsReporter, p.healthServer)
+	reconfiguredKafkaProducer, err := NewProducer(p.logger, newConfig, p.brokers, p.tlsCertificates, p.statsReporter, p.healthServer)
 	if err != nil {
 		p.logger.Fatal("Failed To Create Kafka Producer With New Configuration", zap.Error(err))
 		return nil
 	}
 
-	// Successfully Created New Producer - Close Old One And Return New One
+	// Successfully Created New Producer - Close Old One And Return New One (Keeping The Deduplicated Events)
 	p.logger.Info("Successfully Created New Producer")
+	reconfiguredKafkaProducer.deduplicator = p.deduplicator
 	return reconfiguredKafkaProducer
 }
+
+// CredentialsChanged is called by the credentials watcher in main() when the SASL username / password of the
+// mounted Kafka Secret change, so that a new Producer is created with them without restarting the pod.  Unlike
+// ConfigChanged, the current Producer is NOT closed, so that messages keep being produced while switching: the
+// caller is responsible for switching to the returned Producer before closing the current one with CloseReplaced().
+// Nil is returned (keeping the current Producer) if the credentials are unchanged or the new Producer fails.
+func (p *Producer) CredentialsChanged(username string, password string) *Producer {
+
+	// Validate Configuration (Should Always Be Present)
+	if p.configuration == nil {
+		p.logger.Warn("Producer Has No Configuration - Ignoring Credentials Change")
+		return nil
+	}
+
+	// Ignore Unchanged Credentials
+	if p.configuration.Net.SASL.User == username && p.configuration.Net.SASL.Password == password {
+		p.logger.Info("No Changes Detected In New Credentials - Ignoring")
+		return nil
+	}
+
+	// Copy The Current Configuration With The New Credentials
+	newConfig := *p.configuration
+	kafkasarama.UpdateSaramaConfig(&newConfig, newConfig.ClientID, username, password)
+
+	// Create A New Producer With The New Credentials (Reusing All Other Existing Config)
+	p.logger.Info("Credentials Changed - Creating New Producer", zap.String("Username", username))
+	newProducer, err := NewProducer(p.logger, &newConfig, p.brokers, p.tlsCertificates, p.statsReporter, p.healthServer)
+	if err != nil {
+		p.logger.Error("Failed To Create Kafka Producer With New Credentials - Keeping The Current One", zap.Error(err))
+		return nil
+	}
+
+	// Successfully Created New Producer - Return It For The Caller To Switch To (Keeping The Deduplicated Events)
+	p.logger.Info("Successfully Created New Producer With New Credentials")
+	newProducer.deduplicator = p.deduplicator
+	return newProducer
+}
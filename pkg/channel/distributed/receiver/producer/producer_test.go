@@ -3,6 +3,7 @@ package producer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 
 	"github.com/Shopify/sarama"
@@ -12,16 +13,20 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	"knative.dev/eventing-kafka/pkg/common/partitioning"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
 
 	"testing"
+	"time"
 )
 
 const (
@@ -118,7 +123,7 @@ func TestProduceKafkaMessage(t *testing.T) {
 	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
 
 	// Perform The Test & Verify Results
-	err := producer.ProduceKafkaMessage(context.Background(), channelReference, bindingMessage)
+	err := producer.ProduceKafkaMessage(context.Background(), channelReference, partitioning.Settings{}, ExactlyOnceSettings{}, bindingMessage)
 	assert.Nil(t, err)
 
 	// Verify Message Was Produced Correctly
@@ -141,6 +146,29 @@ func TestProduceKafkaMessage(t *testing.T) {
 	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyPartitionKey, receivertesting.PartitionKey)
 }
 
+// Test The ProduceKafkaMessage() Functionality With The Partitioning Settings Of The KafkaChannel
+func TestProduceKafkaMessageWithPartitioningSettings(t *testing.T) {
+
+	// Create Test Data
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducer(t, mockSyncProducer)
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
+	settings := partitioning.Settings{KeyAttribute: "subject", Partitioner: kafkav1beta1.KafkaChannelPartitionerMurmur2}
+
+	// Perform The Test & Verify Results
+	err := producer.ProduceKafkaMessage(context.Background(), channelReference, settings, ExactlyOnceSettings{}, bindingMessage)
+	assert.Nil(t, err)
+
+	// Verify The PartitionKey Extension Takes Precedence Over The Key Attribute & The Partitioner Is Selected
+	producerMessage := mockSyncProducer.GetMessage()
+	assert.NotNil(t, producerMessage)
+	key, err := producerMessage.Key.Encode()
+	assert.Nil(t, err)
+	assert.Equal(t, receivertesting.PartitionKey, string(key))
+	assert.NotNil(t, producerMessage.Metadata)
+}
+
 func getBaseConfigMap() *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		TypeMeta: v1.TypeMeta{
@@ -229,6 +257,75 @@ func TestClose(t *testing.T) {
 	assert.True(t, mockSyncProducer.Closed())
 }
 
+// Test The Producer's CredentialsChanged() & CloseReplaced() Functionality
+func TestCredentialsChanged(t *testing.T) {
+
+	// Create A Test Producer
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducer(t, mockSyncProducer)
+
+	// Stub The Kafka Producer Creation Wrapper With Test Version Returning A New SyncProducer Or Failing
+	newSyncProducer := receivertesting.NewMockSyncProducer()
+	var createErr error
+	createSyncProducerWrapperPlaceholder := createSyncProducerWrapper
+	createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (sarama.SyncProducer, gometrics.Registry, error) {
+		assert.Equal(t, "rotated-user", config.Net.SASL.User)
+		assert.Equal(t, "rotated-password", config.Net.SASL.Password)
+		assert.Equal(t, receivertesting.ClientId, config.ClientID)
+		return newSyncProducer, gometrics.NewRegistry(), createErr
+	}
+	defer func() { createSyncProducerWrapper = createSyncProducerWrapperPlaceholder }()
+
+	// Unchanged Credentials Do Not Create A New Producer
+	assert.Nil(t, producer.CredentialsChanged(receivertesting.KafkaUsername, receivertesting.KafkaPassword))
+
+	// The Current Producer Is Kept If The New One Fails
+	createErr = errors.New("test-error")
+	assert.Nil(t, producer.CredentialsChanged("rotated-user", "rotated-password"))
+	assert.False(t, mockSyncProducer.Closed())
+
+	// New Credentials Create A New Producer Without Closing The Current One
+	createErr = nil
+	newProducer := producer.CredentialsChanged("rotated-user", "rotated-password")
+	assert.NotNil(t, newProducer)
+	assert.Equal(t, newSyncProducer, newProducer.kafkaProducer)
+	assert.Equal(t, receivertesting.KafkaUsername, producer.configuration.Net.SASL.User)
+	assert.False(t, mockSyncProducer.Closed())
+
+	// Closing The Replaced Producer Keeps The New One Ready
+	producer.CloseReplaced()
+	assert.True(t, mockSyncProducer.Closed())
+	assert.True(t, newProducer.healthServer.ProducerReady())
+	newProducer.Close()
+}
+
+// Test That Closing A Producer Waits For The Messages Holding It
+func TestCloseReplacedWaitsForHolds(t *testing.T) {
+
+	// Create A Test Producer Held By A Message Being Produced
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducer(t, mockSyncProducer)
+	done := producer.Hold()
+
+	// Close The Replaced Producer Asynchronously
+	closed := make(chan struct{})
+	go func() {
+		producer.CloseReplaced()
+		close(closed)
+	}()
+
+	// Verify The Kafka Producer Is Only Closed Once The Message Is Done
+	select {
+	case <-closed:
+		t.Fatal("The Producer Was Closed While Held")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.False(t, mockSyncProducer.Closed())
+	done()
+	<-closed
+	assert.True(t, mockSyncProducer.Closed())
+}
+
 func getSaramaConfigFromYaml(t *testing.T, saramaYaml string) *sarama.Config {
 	var config *sarama.Config
 	jsonSettings, err := yaml.YAMLToJSON([]byte(saramaYaml))
@@ -245,7 +342,10 @@ func createTestProducer(t *testing.T, kafkaSyncProducer sarama.SyncProducer) *Pr
 	// Stub The Kafka Producer Creation Wrapper With Test Version Returning Specified SyncProducer
 	createSyncProducerWrapperPlaceholder := createSyncProducerWrapper
 	createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (sarama.SyncProducer, gometrics.Registry, error) {
-		assert.Equal(t, testConfig, config)
+		assert.NotNil(t, config.Producer.Partitioner) // Partitioning Per KafkaChannel
+		producerConfig := *config
+		producerConfig.Producer.Partitioner = testConfig.Producer.Partitioner
+		assert.Equal(t, testConfig, &producerConfig)
 		assert.Equal(t, []string{receivertesting.KafkaBrokers}, brokers)
 		registry := gometrics.NewRegistry()
 		return kafkaSyncProducer, registry, nil
@@ -260,7 +360,7 @@ func createTestProducer(t *testing.T, kafkaSyncProducer sarama.SyncProducer) *Pr
 	statsReporter := metrics.NewStatsReporter(logger)
 
 	// Create The Producer
-	producer, err := NewProducer(logger, testConfig, []string{receivertesting.KafkaBrokers}, statsReporter, healthServer)
+	producer, err := NewProducer(logger, testConfig, []string{receivertesting.KafkaBrokers}, kafkasarama.TLSCertificates{}, statsReporter, healthServer)
 	assert.Nil(t, err)
 	assert.Equal(t, kafkaSyncProducer, producer.kafkaProducer)
 	assert.Equal(t, healthServer, producer.healthServer)
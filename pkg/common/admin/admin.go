@@ -0,0 +1,307 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admin serves the admin endpoints of the dispatchers, next to their health probes: the state of the
+// consumer groups of their subscriptions, their effective sarama configuration, and the pausing and resuming of
+// the delivery to the subscribers.
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"sort"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+)
+
+const (
+	// PathPrefix is the prefix of all the admin endpoints.
+	PathPrefix = "/admin/"
+	// SubscriptionsPath lists the subscriptions, SubscriptionsPath/<uid> returns one of them, and
+	// SubscriptionsPath/<uid>/pause and SubscriptionsPath/<uid>/resume pause and resume it.
+	SubscriptionsPath = "/admin/subscriptions"
+	// ConfigPath returns the effective sarama configuration, without its secrets.
+	ConfigPath = "/admin/config"
+
+	// the verbs the callers are authorized for
+	verbGet    = "get"
+	verbUpdate = "update"
+)
+
+// newClusterAdmin creates the clients the committed offsets are fetched with, it is replaced in the tests.
+var newClusterAdmin = sarama.NewClusterAdmin
+
+// Dispatcher is a dispatcher whose state is served by the admin endpoints.
+type Dispatcher interface {
+	// Subscriptions returns the subscriptions of the dispatcher, sorted by UID.
+	Subscriptions() []Subscription
+
+	// SetAdminPaused pauses or resumes the delivery to the subscriber of the subscription, on top of the pausing
+	// of the subscription itself. It returns false when the subscription is unknown.
+	SetAdminPaused(uid types.UID, paused bool) bool
+
+	// KafkaConfig returns the brokers and the configuration the consumer groups are created with.
+	KafkaConfig() ([]string, *sarama.Config)
+}
+
+// Subscription is the state of the delivery to a subscriber.
+type Subscription struct {
+	UID        types.UID `json:"uid"`
+	Channel    string    `json:"channel,omitempty"`
+	Subscriber string    `json:"subscriber,omitempty"`
+	Reply      string    `json:"reply,omitempty"`
+	DeadLetter string    `json:"deadLetter,omitempty"`
+	// Paused is true while the delivery is paused, by the subscription or through the admin endpoints.
+	Paused bool `json:"paused"`
+	// AdminPaused is true while the delivery is paused through the admin endpoints.
+	AdminPaused    bool            `json:"adminPaused"`
+	ConsumerGroups []ConsumerGroup `json:"consumerGroups"`
+}
+
+// ConsumerGroup is the state of a consumer group of a subscription.
+type ConsumerGroup struct {
+	GroupID string   `json:"groupId"`
+	Topics  []string `json:"topics"`
+	// Stats tracks the consumption of the consumer group, it is set by the dispatcher.
+	Stats *consumer.Stats `json:"-"`
+
+	// Active is true during a session of the consumer group.
+	Active bool `json:"active"`
+	// Partitions are the partitions claimed by the current session.
+	Partitions []Partition `json:"partitions"`
+	// InFlight is the number of events being delivered.
+	InFlight int `json:"inFlight"`
+	// Handled and Failed count the events delivered since the consumer group started, and the ones which failed.
+	Handled   int64                   `json:"handled"`
+	Failed    int64                   `json:"failed"`
+	LastError *consumer.HandlingError `json:"lastError,omitempty"`
+	// CommittedError tells why the committed offsets are unknown.
+	CommittedError string `json:"committedError,omitempty"`
+}
+
+// Partition is the consumption of a claimed partition.
+type Partition struct {
+	consumer.PartitionStats
+	// Committed is the offset committed by the consumer group in the partition, -1 when unknown.
+	Committed int64 `json:"committed"`
+}
+
+// handler serves the admin endpoints of a dispatcher.
+type handler struct {
+	logger     *zap.Logger
+	dispatcher Dispatcher
+	authorizer *Authorizer
+}
+
+// NewHandler returns a handler serving the admin endpoints of the dispatcher under the PathPrefix, to the callers
+// authorized by the authorizer. They are answered with a 401 without a valid bearer token, and with a 403 when
+// not allowed.
+func NewHandler(logger *zap.Logger, dispatcher Dispatcher, authorizer *Authorizer) nethttp.Handler {
+	return &handler{logger: logger, dispatcher: dispatcher, authorizer: authorizer}
+}
+
+func (h *handler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Request) {
+	path := strings.TrimSuffix(request.URL.Path, "/")
+	switch {
+	case path == ConfigPath:
+		if _, ok := h.authorize(response, request, nethttp.MethodGet, verbGet); ok {
+			brokers, config := h.dispatcher.KafkaConfig()
+			writeJSON(response, nethttp.StatusOK, RedactConfig(brokers, config))
+		}
+
+	case path == SubscriptionsPath:
+		if _, ok := h.authorize(response, request, nethttp.MethodGet, verbGet); ok {
+			writeJSON(response, nethttp.StatusOK, h.subscriptions(""))
+		}
+
+	case strings.HasPrefix(path, SubscriptionsPath+"/"):
+		parts := strings.Split(strings.TrimPrefix(path, SubscriptionsPath+"/"), "/")
+		uid := types.UID(parts[0])
+		switch {
+		case len(parts) == 1:
+			if _, ok := h.authorize(response, request, nethttp.MethodGet, verbGet); ok {
+				h.writeSubscription(response, uid)
+			}
+		case len(parts) == 2 && (parts[1] == "pause" || parts[1] == "resume"):
+			if username, ok := h.authorize(response, request, nethttp.MethodPost, verbUpdate); ok {
+				paused := parts[1] == "pause"
+				if !h.dispatcher.SetAdminPaused(uid, paused) {
+					writeError(response, nethttp.StatusNotFound, "unknown subscription")
+					return
+				}
+				h.logger.Info("Subscription paused or resumed through the admin endpoint",
+					zap.Any("subscription", uid), zap.Bool("paused", paused), zap.String("user", username))
+				h.writeSubscription(response, uid)
+			}
+		default:
+			writeError(response, nethttp.StatusNotFound, "not found")
+		}
+
+	default:
+		writeError(response, nethttp.StatusNotFound, "not found")
+	}
+}
+
+// authorize returns the username of the caller if the request has the method and its bearer token is allowed the
+// verb, otherwise it writes the error response and returns false.
+func (h *handler) authorize(response nethttp.ResponseWriter, request *nethttp.Request, method string, verb string) (string, bool) {
+	if request.Method != method {
+		response.Header().Set("Allow", method)
+		writeError(response, nethttp.StatusMethodNotAllowed, "method not allowed")
+		return "", false
+	}
+	token := bearerToken(request.Header.Get("Authorization"))
+	if token == "" {
+		response.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(response, nethttp.StatusUnauthorized, "missing bearer token")
+		return "", false
+	}
+	username, err := h.authorizer.Authorize(request.Context(), token, verb)
+	if errors.Is(err, ErrForbidden) {
+		h.logger.Info("Rejected an admin request", zap.String("user", username), zap.String("path", request.URL.Path))
+		writeError(response, nethttp.StatusForbidden, err.Error())
+		return "", false
+	} else if err != nil {
+		response.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		writeError(response, nethttp.StatusUnauthorized, err.Error())
+		return "", false
+	}
+	h.logger.Debug("Serving an admin request", zap.String("user", username), zap.String("path", request.URL.Path))
+	return username, true
+}
+
+// writeSubscription writes the subscription, or a 404 when unknown.
+func (h *handler) writeSubscription(response nethttp.ResponseWriter, uid types.UID) {
+	subscriptions := h.subscriptions(uid)
+	if len(subscriptions) == 0 {
+		writeError(response, nethttp.StatusNotFound, "unknown subscription")
+		return
+	}
+	writeJSON(response, nethttp.StatusOK, subscriptions[0])
+}
+
+// subscriptions returns the subscriptions of the dispatcher, or the one with the UID unless empty, along with the
+// state of their consumer groups.
+func (h *handler) subscriptions(uid types.UID) []Subscription {
+	subscriptions := []Subscription{}
+	for _, subscription := range h.dispatcher.Subscriptions() {
+		if uid == "" || subscription.UID == uid {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	var groups []*ConsumerGroup
+	for i := range subscriptions {
+		if subscriptions[i].ConsumerGroups == nil {
+			subscriptions[i].ConsumerGroups = []ConsumerGroup{}
+		}
+		for j := range subscriptions[i].ConsumerGroups {
+			group := &subscriptions[i].ConsumerGroups[j]
+			snapshot := group.Stats.Snapshot()
+			group.Active = snapshot.Active
+			group.InFlight = snapshot.InFlight
+			group.Handled = snapshot.Handled
+			group.Failed = snapshot.Failed
+			group.LastError = snapshot.LastError
+			group.Partitions = make([]Partition, 0, len(snapshot.Partitions))
+			for _, partition := range snapshot.Partitions {
+				group.Partitions = append(group.Partitions, Partition{PartitionStats: partition, Committed: -1})
+			}
+			groups = append(groups, group)
+		}
+	}
+	h.fetchCommittedOffsets(groups)
+	return subscriptions
+}
+
+// fetchCommittedOffsets fetches the offsets committed by the consumer groups in their claimed partitions.
+func (h *handler) fetchCommittedOffsets(groups []*ConsumerGroup) {
+	var claiming []*ConsumerGroup
+	for _, group := range groups {
+		if len(group.Partitions) > 0 {
+			claiming = append(claiming, group)
+		}
+	}
+	if len(claiming) == 0 {
+		return
+	}
+
+	brokers, config := h.dispatcher.KafkaConfig()
+	clusterAdmin, err := newClusterAdmin(brokers, config)
+	if err != nil {
+		h.logger.Warn("Unable to create the admin client fetching the committed offsets", zap.Error(err))
+		for _, group := range claiming {
+			group.CommittedError = err.Error()
+		}
+		return
+	}
+	defer clusterAdmin.Close()
+
+	for _, group := range claiming {
+		partitions := make(map[string][]int32)
+		for _, partition := range group.Partitions {
+			partitions[partition.Topic] = append(partitions[partition.Topic], partition.Partition)
+		}
+		offsets, err := clusterAdmin.ListConsumerGroupOffsets(group.GroupID, partitions)
+		if err == nil && offsets.Err != sarama.ErrNoError {
+			err = offsets.Err
+		}
+		if err != nil {
+			group.CommittedError = err.Error()
+			continue
+		}
+		for i := range group.Partitions {
+			partition := &group.Partitions[i]
+			if block := offsets.GetBlock(partition.Topic, partition.Partition); block != nil && block.Err == sarama.ErrNoError {
+				partition.Committed = block.Offset
+			}
+		}
+	}
+}
+
+// SortSubscriptions sorts the subscriptions by UID, and their consumer groups by ID.
+func SortSubscriptions(subscriptions []Subscription) {
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].UID < subscriptions[j].UID })
+	for _, subscription := range subscriptions {
+		groups := subscription.ConsumerGroups
+		sort.Slice(groups, func(i, j int) bool { return groups[i].GroupID < groups[j].GroupID })
+	}
+}
+
+// bearerToken returns the token of the bearer Authorization header, or an empty string.
+func bearerToken(authorization string) string {
+	const prefix = "bearer "
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(authorization[len(prefix):])
+}
+
+func writeJSON(response nethttp.ResponseWriter, status int, body interface{}) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(status)
+	_ = json.NewEncoder(response).Encode(body)
+}
+
+func writeError(response nethttp.ResponseWriter, status int, message string) {
+	writeJSON(response, status, map[string]string{"error": message})
+}
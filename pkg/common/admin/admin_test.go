@@ -0,0 +1,306 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+)
+
+// mockDispatcher serves a subscription whose consumer group claims the partition 0 of the "topic" topic.
+type mockDispatcher struct {
+	stats  *consumer.Stats
+	paused map[types.UID]bool
+}
+
+func newMockDispatcher() *mockDispatcher {
+	stats := consumer.NewStats()
+	stats.SessionStarted(map[string][]int32{"topic": {0}})
+	stats.Consumed(&sarama.ConsumerMessage{Topic: "topic", Partition: 0, Offset: 4}, 10)
+	stats.Marked(&sarama.ConsumerMessage{Topic: "topic", Partition: 0, Offset: 2})
+	return &mockDispatcher{stats: stats, paused: make(map[types.UID]bool)}
+}
+
+func (m *mockDispatcher) Subscriptions() []Subscription {
+	return []Subscription{{
+		UID:         "sub-1",
+		Channel:     "ns/channel",
+		Subscriber:  "http://subscriber.ns.svc.cluster.local",
+		Paused:      m.paused["sub-1"],
+		AdminPaused: m.paused["sub-1"],
+		ConsumerGroups: []ConsumerGroup{{
+			GroupID: "kafka.ns.channel.sub-1",
+			Topics:  []string{"topic"},
+			Stats:   m.stats,
+		}},
+	}}
+}
+
+func (m *mockDispatcher) SetAdminPaused(uid types.UID, paused bool) bool {
+	if uid != "sub-1" {
+		return false
+	}
+	m.paused[uid] = paused
+	return true
+}
+
+func (m *mockDispatcher) KafkaConfig() ([]string, *sarama.Config) {
+	config := sarama.NewConfig()
+	config.Net.SASL.Enable = true
+	config.Net.SASL.User = "user"
+	config.Net.SASL.Password = "password"
+	return []string{"broker:9092"}, config
+}
+
+// mockClusterAdmin returns the committed offset 3 of the partitions of the consumer groups, or the error.
+type mockClusterAdmin struct {
+	sarama.ClusterAdmin
+	err error
+}
+
+func (m *mockClusterAdmin) ListConsumerGroupOffsets(_ string, partitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	response := &sarama.OffsetFetchResponse{}
+	for topic, ids := range partitions {
+		for _, id := range ids {
+			response.AddBlock(topic, id, &sarama.OffsetFetchResponseBlock{Offset: 3, Err: sarama.ErrNoError})
+		}
+	}
+	return response, nil
+}
+
+func (m *mockClusterAdmin) Close() error {
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	var reviews int32
+	authorizer := NewAuthorizer(zap.NewNop(), newKubeClient(t, &reviews), "knative-eventing")
+
+	testCases := map[string]struct {
+		method           string
+		path             string
+		token            string
+		wantCode         int
+		wantAuthenticate string
+		wantAllow        string
+		wantError        string
+		wantPaused       bool
+	}{
+		"missing token": {
+			method:           nethttp.MethodGet,
+			path:             SubscriptionsPath,
+			wantCode:         nethttp.StatusUnauthorized,
+			wantAuthenticate: "Bearer",
+			wantError:        "missing bearer token",
+		},
+		"invalid token": {
+			method:           nethttp.MethodGet,
+			path:             SubscriptionsPath,
+			token:            "invalid-token",
+			wantCode:         nethttp.StatusUnauthorized,
+			wantAuthenticate: `Bearer error="invalid_token"`,
+			wantError:        "unauthenticated: invalid token",
+		},
+		"forbidden": {
+			method:    nethttp.MethodGet,
+			path:      SubscriptionsPath,
+			token:     "other-token",
+			wantCode:  nethttp.StatusForbidden,
+			wantError: `forbidden: the user "other" cannot get kafkachannels/admin in the namespace "knative-eventing"`,
+		},
+		"list": {
+			method:   nethttp.MethodGet,
+			path:     SubscriptionsPath,
+			token:    "viewer-token",
+			wantCode: nethttp.StatusOK,
+		},
+		"get": {
+			method:   nethttp.MethodGet,
+			path:     SubscriptionsPath + "/sub-1/",
+			token:    "viewer-token",
+			wantCode: nethttp.StatusOK,
+		},
+		"get unknown": {
+			method:    nethttp.MethodGet,
+			path:      SubscriptionsPath + "/sub-2",
+			token:     "viewer-token",
+			wantCode:  nethttp.StatusNotFound,
+			wantError: "unknown subscription",
+		},
+		"pause forbidden": {
+			method:    nethttp.MethodPost,
+			path:      SubscriptionsPath + "/sub-1/pause",
+			token:     "viewer-token",
+			wantCode:  nethttp.StatusForbidden,
+			wantError: `forbidden: the user "viewer" cannot update kafkachannels/admin in the namespace "knative-eventing"`,
+		},
+		"pause with GET": {
+			method:    nethttp.MethodGet,
+			path:      SubscriptionsPath + "/sub-1/pause",
+			token:     "admin-token",
+			wantCode:  nethttp.StatusMethodNotAllowed,
+			wantAllow: nethttp.MethodPost,
+			wantError: "method not allowed",
+		},
+		"pause": {
+			method:     nethttp.MethodPost,
+			path:       SubscriptionsPath + "/sub-1/pause",
+			token:      "admin-token",
+			wantCode:   nethttp.StatusOK,
+			wantPaused: true,
+		},
+		"resume": {
+			method:   nethttp.MethodPost,
+			path:     SubscriptionsPath + "/sub-1/resume",
+			token:    "admin-token",
+			wantCode: nethttp.StatusOK,
+		},
+		"pause unknown": {
+			method:    nethttp.MethodPost,
+			path:      SubscriptionsPath + "/sub-2/pause",
+			token:     "admin-token",
+			wantCode:  nethttp.StatusNotFound,
+			wantError: "unknown subscription",
+		},
+		"unknown action": {
+			method:    nethttp.MethodPost,
+			path:      SubscriptionsPath + "/sub-1/stop",
+			token:     "admin-token",
+			wantCode:  nethttp.StatusNotFound,
+			wantError: "not found",
+		},
+		"unknown path": {
+			method:    nethttp.MethodGet,
+			path:      PathPrefix + "other",
+			token:     "admin-token",
+			wantCode:  nethttp.StatusNotFound,
+			wantError: "not found",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			newClusterAdmin = func([]string, *sarama.Config) (sarama.ClusterAdmin, error) { return &mockClusterAdmin{}, nil }
+			defer func() { newClusterAdmin = sarama.NewClusterAdmin }()
+
+			dispatcher := newMockDispatcher()
+			request := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			response := httptest.NewRecorder()
+			NewHandler(zap.NewNop(), dispatcher, authorizer).ServeHTTP(response, request)
+
+			assert.Equal(t, tc.wantCode, response.Code)
+			assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+			assert.Equal(t, tc.wantAuthenticate, response.Header().Get("WWW-Authenticate"))
+			assert.Equal(t, tc.wantAllow, response.Header().Get("Allow"))
+			if tc.wantError != "" {
+				assert.JSONEq(t, `{"error":"`+jsonEscape(t, tc.wantError)+`"}`, response.Body.String())
+				return
+			}
+
+			var subscription Subscription
+			if tc.path == SubscriptionsPath {
+				var subscriptions []Subscription
+				require.NoError(t, json.Unmarshal(response.Body.Bytes(), &subscriptions))
+				require.Len(t, subscriptions, 1)
+				subscription = subscriptions[0]
+			} else {
+				require.NoError(t, json.Unmarshal(response.Body.Bytes(), &subscription))
+			}
+			assert.Equal(t, types.UID("sub-1"), subscription.UID)
+			assert.Equal(t, tc.wantPaused, subscription.AdminPaused)
+			assert.Equal(t, tc.wantPaused, dispatcher.paused["sub-1"])
+			require.Len(t, subscription.ConsumerGroups, 1)
+			group := subscription.ConsumerGroups[0]
+			assert.True(t, group.Active)
+			assert.Empty(t, group.CommittedError)
+			require.Len(t, group.Partitions, 1)
+			assert.Equal(t, Partition{
+				PartitionStats: consumer.PartitionStats{Topic: "topic", Consumed: 5, Marked: 3, HighWaterMark: 10, Lag: 7},
+				Committed:      3,
+			}, group.Partitions[0])
+		})
+	}
+}
+
+func TestHandlerCommittedError(t *testing.T) {
+	var reviews int32
+	authorizer := NewAuthorizer(zap.NewNop(), newKubeClient(t, &reviews), "knative-eventing")
+	newClusterAdmin = func([]string, *sarama.Config) (sarama.ClusterAdmin, error) {
+		return &mockClusterAdmin{err: errors.New("unavailable")}, nil
+	}
+	defer func() { newClusterAdmin = sarama.NewClusterAdmin }()
+
+	request := httptest.NewRequest(nethttp.MethodGet, SubscriptionsPath+"/sub-1", nil)
+	request.Header.Set("Authorization", "Bearer viewer-token")
+	response := httptest.NewRecorder()
+	NewHandler(zap.NewNop(), newMockDispatcher(), authorizer).ServeHTTP(response, request)
+
+	assert.Equal(t, nethttp.StatusOK, response.Code)
+	var subscription Subscription
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &subscription))
+	require.Len(t, subscription.ConsumerGroups, 1)
+	assert.Equal(t, "unavailable", subscription.ConsumerGroups[0].CommittedError)
+	assert.Equal(t, int64(-1), subscription.ConsumerGroups[0].Partitions[0].Committed)
+}
+
+func TestHandlerConfig(t *testing.T) {
+	var reviews int32
+	authorizer := NewAuthorizer(zap.NewNop(), newKubeClient(t, &reviews), "knative-eventing")
+
+	request := httptest.NewRequest(nethttp.MethodGet, ConfigPath, nil)
+	request.Header.Set("Authorization", "Bearer viewer-token")
+	response := httptest.NewRecorder()
+	NewHandler(zap.NewNop(), newMockDispatcher(), authorizer).ServeHTTP(response, request)
+
+	assert.Equal(t, nethttp.StatusOK, response.Code)
+	assert.NotContains(t, response.Body.String(), `"password":"password"`)
+	var config Config
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &config))
+	assert.Equal(t, []string{"broker:9092"}, config.Brokers)
+	assert.Equal(t, "user", config.Net.SASL.User)
+	assert.Equal(t, redacted, config.Net.SASL.Password)
+}
+
+func TestBearerToken(t *testing.T) {
+	assert.Equal(t, "token", bearerToken("Bearer token"))
+	assert.Equal(t, "token", bearerToken("bearer  token "))
+	assert.Equal(t, "", bearerToken("Bearer "))
+	assert.Equal(t, "", bearerToken("Basic dXNlcjpwYXNzd29yZA=="))
+	assert.Equal(t, "", bearerToken(""))
+}
+
+func jsonEscape(t *testing.T, s string) string {
+	escaped, err := json.Marshal(s)
+	require.NoError(t, err)
+	return string(escaped[1 : len(escaped)-1])
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"knative.dev/eventing-kafka/pkg/common/tokenreview"
+)
+
+const (
+	// Group, Resource and Subresource are the resource attributes the callers of the admin endpoints are authorized
+	// for, with the get verb to read the state of the dispatcher and the update verb to pause and resume its
+	// subscriptions.
+	Group       = "messaging.knative.dev"
+	Resource    = "kafkachannels"
+	Subresource = "admin"
+
+	// authCacheTTL is how long the result of an authorization is reused for the same token and verb.
+	authCacheTTL = time.Minute
+	// authCacheSize caps the number of cached authorizations.
+	authCacheSize = 1000
+)
+
+var (
+	// ErrUnauthenticated is returned for the tokens which are not authenticated by the Kubernetes API server.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is returned for the users not allowed to use the admin endpoints.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Authorizer authenticates the bearer tokens of the callers of the admin endpoints with a TokenReview, and
+// authorizes them with a SubjectAccessReview on the admin subresource of the kafkachannels of its namespace. It
+// caches the results, and is safe for concurrent use.
+type Authorizer struct {
+	logger     *zap.Logger
+	kubeClient kubernetes.Interface
+	namespace  string
+	now        func() time.Time
+	cache      *tokenreview.Cache
+}
+
+// NewAuthorizer returns an Authorizer reviewing the tokens with the Kubernetes client, which rejects all the
+// callers when nil.
+func NewAuthorizer(logger *zap.Logger, kubeClient kubernetes.Interface, namespace string) *Authorizer {
+	return &Authorizer{
+		logger:     logger,
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		now:        time.Now,
+		cache:      tokenreview.NewCache(authCacheTTL, authCacheSize),
+	}
+}
+
+// Authorize returns the username of the token if it is allowed the verb, or an error wrapping ErrUnauthenticated
+// or ErrForbidden.
+func (a *Authorizer) Authorize(ctx context.Context, token string, verb string) (string, error) {
+	if a.kubeClient == nil {
+		return "", fmt.Errorf("%w: the tokens cannot be reviewed", ErrUnauthenticated)
+	}
+	if cached, ok := a.cache.Get(token, verb, a.now()); ok {
+		return cached.Username, cached.Err
+	}
+
+	username, err := a.review(ctx, token, verb)
+	if err != nil && !errors.Is(err, ErrUnauthenticated) && !errors.Is(err, ErrForbidden) {
+		// not cached, the token may well be allowed
+		a.logger.Error("Failed to review an admin token", zap.Error(err))
+		return "", fmt.Errorf("%w: the token could not be reviewed", ErrUnauthenticated)
+	}
+
+	a.cache.Add(token, verb, tokenreview.Result{Username: username, Err: err}, a.now())
+	return username, err
+}
+
+// review authenticates the token with a TokenReview and authorizes its user with a SubjectAccessReview.
+func (a *Authorizer) review(ctx context.Context, token string, verb string) (string, error) {
+	tokenReview, err := a.kubeClient.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !tokenReview.Status.Authenticated {
+		if tokenReview.Status.Error != "" {
+			return "", fmt.Errorf("%w: %s", ErrUnauthenticated, tokenReview.Status.Error)
+		}
+		return "", ErrUnauthenticated
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for name, values := range user.Extra {
+		extra[name] = authorizationv1.ExtraValue(values)
+	}
+	accessReview, err := a.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   a.namespace,
+				Verb:        verb,
+				Group:       Group,
+				Resource:    Resource,
+				Subresource: Subresource,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !accessReview.Status.Allowed {
+		return user.Username, fmt.Errorf("%w: the user %q cannot %s %s/%s in the namespace %q",
+			ErrForbidden, user.Username, verb, Resource, Subresource, a.namespace)
+	}
+	return user.Username, nil
+}
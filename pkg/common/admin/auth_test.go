@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+// newKubeClient returns a Kubernetes client authenticating the "<user>-token" tokens as the user, and allowing the
+// "admin" user all the verbs and the "viewer" user the get verb, counting the reviews.
+func newKubeClient(t *testing.T, reviews *int32) *fake.Clientset {
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "tokenreviews", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		atomic.AddInt32(reviews, 1)
+		review := action.(clientgotesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "admin-token", "viewer-token", "other-token":
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User: authenticationv1.UserInfo{
+					Username: review.Spec.Token[:len(review.Spec.Token)-len("-token")],
+					Groups:   []string{"system:authenticated"},
+				},
+			}
+		case "error-token":
+			return true, nil, errors.New("unavailable")
+		default:
+			review.Status = authenticationv1.TokenReviewStatus{Error: "invalid token"}
+		}
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		review := action.(clientgotesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		assert.Equal(t, "knative-eventing", attributes.Namespace)
+		assert.Equal(t, Group, attributes.Group)
+		assert.Equal(t, Resource, attributes.Resource)
+		assert.Equal(t, Subresource, attributes.Subresource)
+		assert.Equal(t, []string{"system:authenticated"}, review.Spec.Groups)
+		review.Status.Allowed = review.Spec.User == "admin" || (review.Spec.User == "viewer" && attributes.Verb == verbGet)
+		return true, review, nil
+	})
+	return kubeClient
+}
+
+func TestAuthorizer(t *testing.T) {
+	var reviews int32
+	authorizer := NewAuthorizer(zap.NewNop(), newKubeClient(t, &reviews), "knative-eventing")
+
+	username, err := authorizer.Authorize(context.Background(), "admin-token", verbUpdate)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", username)
+	username, err = authorizer.Authorize(context.Background(), "viewer-token", verbGet)
+	assert.NoError(t, err)
+	assert.Equal(t, "viewer", username)
+	username, err = authorizer.Authorize(context.Background(), "viewer-token", verbUpdate)
+	assert.True(t, errors.Is(err, ErrForbidden))
+	assert.EqualError(t, err, `forbidden: the user "viewer" cannot update kafkachannels/admin in the namespace "knative-eventing"`)
+	assert.Equal(t, "viewer", username)
+	_, err = authorizer.Authorize(context.Background(), "invalid-token", verbGet)
+	assert.EqualError(t, err, "unauthenticated: invalid token")
+	_, err = authorizer.Authorize(context.Background(), "error-token", verbGet)
+	assert.True(t, errors.Is(err, ErrUnauthenticated))
+	assert.EqualError(t, err, "unauthenticated: the token could not be reviewed")
+	assert.Equal(t, int32(5), atomic.LoadInt32(&reviews))
+
+	// The results are cached, but the failed reviews
+	_, err = authorizer.Authorize(context.Background(), "admin-token", verbUpdate)
+	assert.NoError(t, err)
+	_, err = authorizer.Authorize(context.Background(), "viewer-token", verbUpdate)
+	assert.True(t, errors.Is(err, ErrForbidden))
+	_, err = authorizer.Authorize(context.Background(), "invalid-token", verbGet)
+	assert.True(t, errors.Is(err, ErrUnauthenticated))
+	_, err = authorizer.Authorize(context.Background(), "error-token", verbGet)
+	assert.True(t, errors.Is(err, ErrUnauthenticated))
+	assert.Equal(t, int32(6), atomic.LoadInt32(&reviews))
+
+	// By verb
+	_, err = authorizer.Authorize(context.Background(), "admin-token", verbGet)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), atomic.LoadInt32(&reviews))
+
+	// Until they expire
+	authorizer.now = func() time.Time { return time.Now().Add(authCacheTTL) }
+	_, err = authorizer.Authorize(context.Background(), "admin-token", verbUpdate)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(8), atomic.LoadInt32(&reviews))
+
+	// The tokens cannot be reviewed without Kubernetes client
+	_, err = NewAuthorizer(zap.NewNop(), nil, "knative-eventing").Authorize(context.Background(), "admin-token", verbGet)
+	assert.EqualError(t, err, "unauthenticated: the tokens cannot be reviewed")
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"github.com/Shopify/sarama"
+)
+
+// redacted replaces the secrets of the configuration.
+const redacted = "<redacted>"
+
+// Config is the effective sarama configuration of a dispatcher, without its secrets: the SASL password and the
+// TLS certificates are left out, along with the settings which are functions, such as the partitioner.
+type Config struct {
+	Brokers  []string       `json:"brokers"`
+	ClientID string         `json:"clientId"`
+	Version  string         `json:"version"`
+	Net      NetConfig      `json:"net"`
+	Metadata MetadataConfig `json:"metadata"`
+	Producer ProducerConfig `json:"producer"`
+	Consumer ConsumerConfig `json:"consumer"`
+}
+
+// NetConfig holds the network settings.
+type NetConfig struct {
+	MaxOpenRequests int        `json:"maxOpenRequests"`
+	DialTimeout     string     `json:"dialTimeout"`
+	ReadTimeout     string     `json:"readTimeout"`
+	WriteTimeout    string     `json:"writeTimeout"`
+	KeepAlive       string     `json:"keepAlive"`
+	TLS             TLSConfig  `json:"tls"`
+	SASL            SASLConfig `json:"sasl"`
+}
+
+// TLSConfig holds the TLS settings, without the certificates.
+type TLSConfig struct {
+	Enable             bool `json:"enable"`
+	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+	// ClientCertificates is the number of client certificates, for the mutual TLS authentication.
+	ClientCertificates int `json:"clientCertificates"`
+}
+
+// SASLConfig holds the SASL settings, the password and the token provider are redacted.
+type SASLConfig struct {
+	Enable        bool   `json:"enable"`
+	Mechanism     string `json:"mechanism,omitempty"`
+	Version       int16  `json:"version"`
+	Handshake     bool   `json:"handshake"`
+	User          string `json:"user,omitempty"`
+	Password      string `json:"password,omitempty"`
+	TokenProvider string `json:"tokenProvider,omitempty"`
+}
+
+// MetadataConfig holds the metadata settings.
+type MetadataConfig struct {
+	RetryMax         int    `json:"retryMax"`
+	RetryBackoff     string `json:"retryBackoff"`
+	RefreshFrequency string `json:"refreshFrequency"`
+	Full             bool   `json:"full"`
+}
+
+// ProducerConfig holds the producer settings.
+type ProducerConfig struct {
+	MaxMessageBytes int    `json:"maxMessageBytes"`
+	RequiredAcks    int16  `json:"requiredAcks"`
+	Timeout         string `json:"timeout"`
+	Compression     string `json:"compression"`
+	Idempotent      bool   `json:"idempotent"`
+	RetryMax        int    `json:"retryMax"`
+	RetryBackoff    string `json:"retryBackoff"`
+	FlushBytes      int    `json:"flushBytes"`
+	FlushMessages   int    `json:"flushMessages"`
+	FlushFrequency  string `json:"flushFrequency"`
+}
+
+// ConsumerConfig holds the consumer settings.
+type ConsumerConfig struct {
+	SessionTimeout     string `json:"sessionTimeout"`
+	HeartbeatInterval  string `json:"heartbeatInterval"`
+	RebalanceStrategy  string `json:"rebalanceStrategy,omitempty"`
+	RebalanceTimeout   string `json:"rebalanceTimeout"`
+	FetchMin           int32  `json:"fetchMin"`
+	FetchDefault       int32  `json:"fetchDefault"`
+	FetchMax           int32  `json:"fetchMax"`
+	MaxWaitTime        string `json:"maxWaitTime"`
+	MaxProcessingTime  string `json:"maxProcessingTime"`
+	InitialOffset      string `json:"initialOffset"`
+	AutoCommit         bool   `json:"autoCommit"`
+	AutoCommitInterval string `json:"autoCommitInterval"`
+	Retention          string `json:"retention"`
+	IsolationLevel     int8   `json:"isolationLevel"`
+}
+
+// RedactConfig returns the configuration the dispatcher connects to the brokers with, without its secrets.
+func RedactConfig(brokers []string, config *sarama.Config) Config {
+	redactedConfig := Config{Brokers: brokers}
+	if config == nil {
+		return redactedConfig
+	}
+
+	redactedConfig.ClientID = config.ClientID
+	redactedConfig.Version = config.Version.String()
+
+	redactedConfig.Net = NetConfig{
+		MaxOpenRequests: config.Net.MaxOpenRequests,
+		DialTimeout:     config.Net.DialTimeout.String(),
+		ReadTimeout:     config.Net.ReadTimeout.String(),
+		WriteTimeout:    config.Net.WriteTimeout.String(),
+		KeepAlive:       config.Net.KeepAlive.String(),
+		TLS:             TLSConfig{Enable: config.Net.TLS.Enable},
+		SASL: SASLConfig{
+			Enable:    config.Net.SASL.Enable,
+			Mechanism: string(config.Net.SASL.Mechanism),
+			Version:   config.Net.SASL.Version,
+			Handshake: config.Net.SASL.Handshake,
+			User:      config.Net.SASL.User,
+		},
+	}
+	if tlsConfig := config.Net.TLS.Config; tlsConfig != nil {
+		redactedConfig.Net.TLS.InsecureSkipVerify = tlsConfig.InsecureSkipVerify
+		redactedConfig.Net.TLS.ClientCertificates = len(tlsConfig.Certificates)
+	}
+	if config.Net.SASL.Password != "" {
+		redactedConfig.Net.SASL.Password = redacted
+	}
+	if config.Net.SASL.TokenProvider != nil {
+		redactedConfig.Net.SASL.TokenProvider = redacted
+	}
+
+	redactedConfig.Metadata = MetadataConfig{
+		RetryMax:         config.Metadata.Retry.Max,
+		RetryBackoff:     config.Metadata.Retry.Backoff.String(),
+		RefreshFrequency: config.Metadata.RefreshFrequency.String(),
+		Full:             config.Metadata.Full,
+	}
+
+	redactedConfig.Producer = ProducerConfig{
+		MaxMessageBytes: config.Producer.MaxMessageBytes,
+		RequiredAcks:    int16(config.Producer.RequiredAcks),
+		Timeout:         config.Producer.Timeout.String(),
+		Compression:     config.Producer.Compression.String(),
+		Idempotent:      config.Producer.Idempotent,
+		RetryMax:        config.Producer.Retry.Max,
+		RetryBackoff:    config.Producer.Retry.Backoff.String(),
+		FlushBytes:      config.Producer.Flush.Bytes,
+		FlushMessages:   config.Producer.Flush.Messages,
+		FlushFrequency:  config.Producer.Flush.Frequency.String(),
+	}
+
+	redactedConfig.Consumer = ConsumerConfig{
+		SessionTimeout:     config.Consumer.Group.Session.Timeout.String(),
+		HeartbeatInterval:  config.Consumer.Group.Heartbeat.Interval.String(),
+		RebalanceTimeout:   config.Consumer.Group.Rebalance.Timeout.String(),
+		FetchMin:           config.Consumer.Fetch.Min,
+		FetchDefault:       config.Consumer.Fetch.Default,
+		FetchMax:           config.Consumer.Fetch.Max,
+		MaxWaitTime:        config.Consumer.MaxWaitTime.String(),
+		MaxProcessingTime:  config.Consumer.MaxProcessingTime.String(),
+		InitialOffset:      initialOffset(config.Consumer.Offsets.Initial),
+		AutoCommit:         config.Consumer.Offsets.AutoCommit.Enable,
+		AutoCommitInterval: config.Consumer.Offsets.AutoCommit.Interval.String(),
+		Retention:          config.Consumer.Offsets.Retention.String(),
+		IsolationLevel:     int8(config.Consumer.IsolationLevel),
+	}
+	if strategy := config.Consumer.Group.Rebalance.Strategy; strategy != nil {
+		redactedConfig.Consumer.RebalanceStrategy = strategy.Name()
+	}
+	return redactedConfig
+}
+
+// initialOffset names the initial offset of the consumer groups.
+func initialOffset(offset int64) string {
+	switch offset {
+	case sarama.OffsetNewest:
+		return "newest"
+	case sarama.OffsetOldest:
+		return "oldest"
+	default:
+		return "unknown"
+	}
+}
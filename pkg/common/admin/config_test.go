@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactConfig(t *testing.T) {
+	config := sarama.NewConfig()
+	config.ClientID = "dispatcher"
+	config.Version = sarama.V2_0_0_0
+	config.Net.TLS.Enable = true
+	config.Net.TLS.Config = &tls.Config{Certificates: []tls.Certificate{{}}}
+	config.Net.SASL.Enable = true
+	config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+	config.Net.SASL.User = "user"
+	config.Net.SASL.Password = "password"
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Group.Session.Timeout = 20 * time.Second
+
+	redactedConfig := RedactConfig([]string{"broker:9092"}, config)
+	assert.Equal(t, []string{"broker:9092"}, redactedConfig.Brokers)
+	assert.Equal(t, "dispatcher", redactedConfig.ClientID)
+	assert.Equal(t, "2.0.0", redactedConfig.Version)
+	assert.Equal(t, TLSConfig{Enable: true, ClientCertificates: 1}, redactedConfig.Net.TLS)
+	assert.Equal(t, SASLConfig{
+		Enable:    true,
+		Mechanism: sarama.SASLTypeSCRAMSHA512,
+		Version:   config.Net.SASL.Version,
+		Handshake: true,
+		User:      "user",
+		Password:  redacted,
+	}, redactedConfig.Net.SASL)
+	assert.Equal(t, "oldest", redactedConfig.Consumer.InitialOffset)
+	assert.Equal(t, "20s", redactedConfig.Consumer.SessionTimeout)
+	assert.Equal(t, "range", redactedConfig.Consumer.RebalanceStrategy)
+
+	// Without configuration
+	assert.Equal(t, Config{Brokers: []string{"broker:9092"}}, RedactConfig([]string{"broker:9092"}, nil))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
@@ -31,6 +32,63 @@ type KafkaConsumerHandler interface {
 	Handle(context context.Context, message *sarama.ConsumerMessage) (bool, error)
 }
 
+// BatchConfig defines how the messages of a partition are grouped in batches.
+type BatchConfig struct {
+	// MaxSize is the maximum number of messages in a batch.
+	MaxSize int
+	// MaxWait is the maximum time to wait for a batch to fill up.
+	MaxWait time.Duration
+}
+
+// KafkaBatchConsumerHandler is a KafkaConsumerHandler able to handle batches of messages.
+// Batching is used only when the returned BatchConfig has a MaxSize greater than one.
+type KafkaBatchConsumerHandler interface {
+	KafkaConsumerHandler
+
+	// GetBatchConfig returns the batch configuration used when consuming a partition.
+	GetBatchConfig() BatchConfig
+
+	// When this function returns true, the consumer group offset of the last message of the batch is committed.
+	// The messages all belong to the same partition. The returned error is enqueued in errors channel.
+	HandleBatch(context context.Context, messages []*sarama.ConsumerMessage) (bool, error)
+}
+
+// KafkaPausableConsumerHandler is a KafkaConsumerHandler whose consumption can be paused.
+type KafkaPausableConsumerHandler interface {
+	KafkaConsumerHandler
+
+	// GetPauseGate returns the gate the claimed messages wait on before being handled.
+	GetPauseGate() *PauseGate
+}
+
+// KafkaClaimSetupConsumerHandler is a KafkaConsumerHandler set up for each partition it claims.
+type KafkaClaimSetupConsumerHandler interface {
+	KafkaConsumerHandler
+
+	// SetupClaim is called before the messages of a claimed partition are handled, with the offset the
+	// consumption starts at: the committed offset, or sarama.OffsetNewest / sarama.OffsetOldest when none.
+	SetupClaim(context context.Context, topic string, partition int32, initialOffset int64)
+}
+
+// KafkaSessionConsumerHandler is a KafkaConsumerHandler notified of the sessions of its consumer group.
+type KafkaSessionConsumerHandler interface {
+	KafkaConsumerHandler
+
+	// SetupSession is called at the beginning of each session, once the consumer group has joined the group.
+	SetupSession()
+
+	// CleanupSession is called at the end of each session, such as when the consumer group is rebalanced.
+	CleanupSession()
+}
+
+// KafkaStatsConsumerHandler is a KafkaConsumerHandler whose consumption is tracked.
+type KafkaStatsConsumerHandler interface {
+	KafkaConsumerHandler
+
+	// GetStats returns the Stats the sessions, the claimed messages and their handling are recorded in.
+	GetStats() *Stats
+}
+
 // ConsumerHandler implements sarama.ConsumerGroupHandler and provides some glue code to simplify message handling
 // You must implement KafkaConsumerHandler and create a new SaramaConsumerHandler with it
 type SaramaConsumerHandler struct {
@@ -52,12 +110,20 @@ func NewConsumerHandler(logger *zap.SugaredLogger, handler KafkaConsumerHandler)
 }
 
 // Setup is run at the beginning of a new session, before ConsumeClaim
-func (consumer *SaramaConsumerHandler) Setup(sarama.ConsumerGroupSession) error {
+func (consumer *SaramaConsumerHandler) Setup(session sarama.ConsumerGroupSession) error {
+	consumer.stats().SessionStarted(session.Claims())
+	if sessionHandler, ok := consumer.handler.(KafkaSessionConsumerHandler); ok {
+		sessionHandler.SetupSession()
+	}
 	return nil
 }
 
 // Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
 func (consumer *SaramaConsumerHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	consumer.stats().SessionEnded()
+	if sessionHandler, ok := consumer.handler.(KafkaSessionConsumerHandler); ok {
+		sessionHandler.CleanupSession()
+	}
 	consumer.closeErrors.Do(func() {
 		close(consumer.errors)
 	})
@@ -68,6 +134,25 @@ func (consumer *SaramaConsumerHandler) Cleanup(session sarama.ConsumerGroupSessi
 func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	consumer.logger.Info(fmt.Sprintf("Starting partition consumer, topic: %s, partition: %d, initialOffset: %d", claim.Topic(), claim.Partition(), claim.InitialOffset()))
 
+	var pause *PauseGate
+	if pausableHandler, ok := consumer.handler.(KafkaPausableConsumerHandler); ok {
+		pause = pausableHandler.GetPauseGate()
+	}
+
+	if setupHandler, ok := consumer.handler.(KafkaClaimSetupConsumerHandler); ok {
+		setupHandler.SetupClaim(session.Context(), claim.Topic(), claim.Partition(), claim.InitialOffset())
+	}
+
+	stats := consumer.stats()
+
+	if batchHandler, ok := consumer.handler.(KafkaBatchConsumerHandler); ok {
+		if config := batchHandler.GetBatchConfig(); config.MaxSize > 1 {
+			consumer.consumeBatches(session, claim, batchHandler, config, pause, stats)
+			consumer.logger.Infof("Stopping partition consumer, topic: %s, partition: %d", claim.Topic(), claim.Partition())
+			return nil
+		}
+	}
+
 	// NOTE:
 	// Do not move the code below to a goroutine.
 	// The `ConsumeClaim` itself is called within a goroutine, see:
@@ -76,8 +161,16 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 		if ce := consumer.logger.Desugar().Check(zap.DebugLevel, "debugging"); ce != nil {
 			consumer.logger.Debugw("Message claimed", zap.String("topic", message.Topic), zap.Binary("value", message.Value))
 		}
+		stats.Consumed(message, claim.HighWaterMarkOffset())
+
+		// The message is not marked, it is claimed again by the next session
+		if !pause.Wait(session.Context()) {
+			break
+		}
 
+		handled := stats.Handling(message)
 		mustMark, err := consumer.handler.Handle(session.Context(), message)
+		handled(err)
 
 		if err != nil {
 			consumer.logger.Infow("Failure while handling a message", zap.String("topic", message.Topic), zap.Int32("partition", message.Partition), zap.Int64("offset", message.Offset), zap.Error(err))
@@ -85,6 +178,7 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 		}
 		if mustMark {
 			session.MarkMessage(message, "") // Mark kafka message as processed
+			stats.Marked(message)
 			if ce := consumer.logger.Desugar().Check(zap.DebugLevel, "debugging"); ce != nil {
 				consumer.logger.Debugw("Message marked", zap.String("topic", message.Topic), zap.Binary("value", message.Value))
 			}
@@ -96,4 +190,86 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 	return nil
 }
 
+// consumeBatches groups the claimed messages in batches of at most config.MaxSize messages, waiting at most
+// config.MaxWait after the first message of a batch, and hands them over to the batch handler once not paused.
+// It stops consuming the claim when a batch is not marked, since marking a later batch would also commit the
+// offsets of the failed one: ending the claim ends the session, so the partition is claimed again from the last
+// committed offset.
+func (consumer *SaramaConsumerHandler) consumeBatches(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, handler KafkaBatchConsumerHandler, config BatchConfig, pause *PauseGate, stats *Stats) {
+	batch := make([]*sarama.ConsumerMessage, 0, config.MaxSize)
+	var timer *time.Timer
+	var timeout <-chan time.Time
+
+	// flush returns false when the batch was not marked and the claim must not be consumed any further.
+	flush := func() bool {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		if len(batch) == 0 {
+			return true
+		}
+
+		// The batch is not marked, it is claimed again by the next session
+		if !pause.Wait(session.Context()) {
+			return false
+		}
+
+		last := batch[len(batch)-1]
+		handled := stats.Handling(last)
+		mustMark, err := handler.HandleBatch(session.Context(), batch)
+		handled(err)
+
+		if err != nil {
+			consumer.logger.Infow("Failure while handling a batch", zap.String("topic", last.Topic), zap.Int32("partition", last.Partition), zap.Int64("offset", last.Offset), zap.Int("size", len(batch)), zap.Error(err))
+			consumer.errors <- err
+		}
+		if !mustMark {
+			consumer.logger.Infow("Batch not accepted, stopping partition consumer to claim it again", zap.String("topic", last.Topic), zap.Int32("partition", last.Partition), zap.Int64("offset", batch[0].Offset), zap.Int("size", len(batch)))
+			return false
+		}
+		session.MarkMessage(last, "") // Marking the last message marks the whole batch as processed
+		stats.Marked(last)
+		if ce := consumer.logger.Desugar().Check(zap.DebugLevel, "debugging"); ce != nil {
+			consumer.logger.Debugw("Batch marked", zap.String("topic", last.Topic), zap.Int64("offset", last.Offset), zap.Int("size", len(batch)))
+		}
+		batch = make([]*sarama.ConsumerMessage, 0, config.MaxSize)
+		return true
+	}
+
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				flush()
+				return
+			}
+			if ce := consumer.logger.Desugar().Check(zap.DebugLevel, "debugging"); ce != nil {
+				consumer.logger.Debugw("Message claimed", zap.String("topic", message.Topic), zap.Binary("value", message.Value))
+			}
+			stats.Consumed(message, claim.HighWaterMarkOffset())
+			if len(batch) == 0 {
+				timer = time.NewTimer(config.MaxWait)
+				timeout = timer.C
+			}
+			batch = append(batch, message)
+			if len(batch) >= config.MaxSize && !flush() {
+				return
+			}
+		case <-timeout:
+			if !flush() {
+				return
+			}
+		}
+	}
+}
+
+// stats returns the Stats of the handler, nil when its consumption is not tracked.
+func (consumer *SaramaConsumerHandler) stats() *Stats {
+	if statsHandler, ok := consumer.handler.(KafkaStatsConsumerHandler); ok {
+		return statsHandler.GetStats()
+	}
+	return nil
+}
+
 var _ sarama.ConsumerGroupHandler = (*SaramaConsumerHandler)(nil)
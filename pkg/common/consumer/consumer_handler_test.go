@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
@@ -39,7 +40,10 @@ var mockMessage = sarama.ConsumerMessage{
 }
 
 type mockConsumerGroupSession struct {
-	marked bool
+	marked        bool
+	markedOffsets []int64
+	ctx           context.Context
+	claims        map[string][]int32
 }
 
 func (m *mockConsumerGroupSession) Commit() {
@@ -47,7 +51,7 @@ func (m *mockConsumerGroupSession) Commit() {
 }
 
 func (m *mockConsumerGroupSession) Claims() map[string][]int32 {
-	return nil
+	return m.claims
 }
 
 func (m *mockConsumerGroupSession) MemberID() string {
@@ -66,10 +70,11 @@ func (m *mockConsumerGroupSession) ResetOffset(topic string, partition int32, of
 
 func (m *mockConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
 	m.marked = true
+	m.markedOffsets = append(m.markedOffsets, msg.Offset)
 }
 
 func (m *mockConsumerGroupSession) Context() context.Context {
-	return nil
+	return m.ctx
 }
 
 var _ sarama.ConsumerGroupSession = (*mockConsumerGroupSession)(nil)
@@ -159,3 +164,202 @@ func Test(t *testing.T) {
 		})
 	}
 }
+
+type mockBatchMessageHandler struct {
+	mockMessageHandler
+	config   BatchConfig
+	batches  [][]*sarama.ConsumerMessage
+	handled  chan struct{}
+	rejected map[int]bool // The indexes of the batches which are not accepted
+}
+
+func (m *mockBatchMessageHandler) GetBatchConfig() BatchConfig {
+	return m.config
+}
+
+func (m *mockBatchMessageHandler) HandleBatch(ctx context.Context, messages []*sarama.ConsumerMessage) (bool, error) {
+	m.batches = append(m.batches, messages)
+	if m.handled != nil {
+		m.handled <- struct{}{}
+	}
+	if m.rejected != nil && m.rejected[len(m.batches)-1] {
+		return false, errors.New("batch rejected")
+	}
+	return m.Handle(ctx, messages[len(messages)-1])
+}
+
+type mockConsumerGroupBatchClaim struct {
+	mockConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (m mockConsumerGroupBatchClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return m.messages
+}
+
+func TestBatch(t *testing.T) {
+	messages := make(chan *sarama.ConsumerMessage, 5)
+	for i := 0; i < 5; i++ {
+		messages <- &sarama.ConsumerMessage{Offset: int64(i), Value: []byte("data")}
+	}
+	close(messages)
+
+	handler := &mockBatchMessageHandler{
+		mockMessageHandler: mockMessageHandler{shouldMark: true},
+		config:             BatchConfig{MaxSize: 2, MaxWait: time.Minute},
+	}
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler)
+
+	session := mockConsumerGroupSession{}
+	claim := mockConsumerGroupBatchClaim{messages: messages}
+
+	_ = cgh.Setup(&session)
+	_ = cgh.ConsumeClaim(&session, claim)
+	_ = cgh.Cleanup(&session)
+
+	if len(handler.batches) != 3 {
+		t.Fatalf("Expected 3 batches, got %d", len(handler.batches))
+	}
+	if len(handler.batches[0]) != 2 || len(handler.batches[1]) != 2 || len(handler.batches[2]) != 1 {
+		t.Errorf("Unexpected batch sizes %d, %d, %d", len(handler.batches[0]), len(handler.batches[1]), len(handler.batches[2]))
+	}
+	if !session.marked {
+		t.Errorf("Session was not marked")
+	}
+}
+
+func TestBatchNotAccepted(t *testing.T) {
+	messages := make(chan *sarama.ConsumerMessage, 6)
+	for i := 0; i < 6; i++ {
+		messages <- &sarama.ConsumerMessage{Offset: int64(i), Value: []byte("data")}
+	}
+	close(messages)
+
+	handler := &mockBatchMessageHandler{
+		mockMessageHandler: mockMessageHandler{shouldMark: true},
+		config:             BatchConfig{MaxSize: 2, MaxWait: time.Minute},
+		rejected:           map[int]bool{1: true},
+	}
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler)
+
+	session := mockConsumerGroupSession{}
+	claim := mockConsumerGroupBatchClaim{messages: messages}
+
+	_ = cgh.Setup(&session)
+	_ = cgh.ConsumeClaim(&session, claim)
+	_ = cgh.Cleanup(&session)
+
+	// The claim is not consumed any further once the second batch is not accepted, so that its offsets
+	// are not committed by marking the following batch
+	if len(handler.batches) != 2 {
+		t.Fatalf("Expected 2 batches, got %d", len(handler.batches))
+	}
+	if len(session.markedOffsets) != 1 || session.markedOffsets[0] != 1 {
+		t.Errorf("Expected only the offset 1 of the first batch to be marked, got %v", session.markedOffsets)
+	}
+}
+
+func TestBatchMaxWait(t *testing.T) {
+	messages := make(chan *sarama.ConsumerMessage, 1)
+	messages <- &sarama.ConsumerMessage{Value: []byte("data")}
+
+	handler := &mockBatchMessageHandler{
+		mockMessageHandler: mockMessageHandler{shouldMark: true},
+		config:             BatchConfig{MaxSize: 10, MaxWait: 10 * time.Millisecond},
+		handled:            make(chan struct{}, 1),
+	}
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler)
+
+	session := mockConsumerGroupSession{}
+	claim := mockConsumerGroupBatchClaim{messages: messages}
+
+	done := make(chan struct{})
+	go func() {
+		_ = cgh.ConsumeClaim(&session, claim)
+		close(done)
+	}()
+
+	// The batch is not full, so it is only sent once MaxWait elapsed
+	select {
+	case <-handler.handled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("The batch was not sent after MaxWait")
+	}
+	close(messages)
+	<-done
+
+	if len(handler.batches) != 1 || len(handler.batches[0]) != 1 {
+		t.Fatalf("Expected a single batch with one message, got %v", handler.batches)
+	}
+}
+
+type mockClaimSetupMessageHandler struct {
+	mockMessageHandler
+	setup   *int64
+	handled *bool
+}
+
+func (m mockClaimSetupMessageHandler) SetupClaim(ctx context.Context, topic string, partition int32, initialOffset int64) {
+	if *m.handled {
+		panic("claim set up after handling its messages")
+	}
+	*m.setup = initialOffset
+}
+
+func (m mockClaimSetupMessageHandler) Handle(ctx context.Context, message *sarama.ConsumerMessage) (bool, error) {
+	*m.handled = true
+	return m.mockMessageHandler.Handle(ctx, message)
+}
+
+func TestClaimSetup(t *testing.T) {
+	setup := int64(-1)
+	handled := false
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), mockClaimSetupMessageHandler{
+		mockMessageHandler: mockMessageHandler{shouldMark: true},
+		setup:              &setup,
+		handled:            &handled,
+	})
+
+	session := mockConsumerGroupSession{}
+	_ = cgh.Setup(&session)
+	_ = cgh.ConsumeClaim(&session, mockConsumerGroupClaim{msg: &mockMessage})
+
+	if setup != 0 {
+		t.Errorf("Claim was not set up with its initial offset, got %d", setup)
+	}
+	if !handled || !session.marked {
+		t.Errorf("Message was not handled after the claim was set up")
+	}
+
+	_ = cgh.Cleanup(&session)
+}
+
+type mockSessionMessageHandler struct {
+	mockMessageHandler
+	sessions *[]string
+}
+
+func (m mockSessionMessageHandler) SetupSession() {
+	*m.sessions = append(*m.sessions, "setup")
+}
+
+func (m mockSessionMessageHandler) CleanupSession() {
+	*m.sessions = append(*m.sessions, "cleanup")
+}
+
+func TestSessionSetup(t *testing.T) {
+	var sessions []string
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), mockSessionMessageHandler{
+		mockMessageHandler: mockMessageHandler{shouldMark: true},
+		sessions:           &sessions,
+	})
+
+	session := mockConsumerGroupSession{}
+	_ = cgh.Setup(&session)
+	_ = cgh.ConsumeClaim(&session, mockConsumerGroupClaim{msg: &mockMessage})
+	_ = cgh.Cleanup(&session)
+
+	if len(sessions) != 2 || sessions[0] != "setup" || sessions[1] != "cleanup" {
+		t.Errorf("Session was not set up and cleaned up, got %v", sessions)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"context"
+	"sync"
+)
+
+// PauseGate holds the claimed messages back while paused. The consumer stops reading the claimed messages,
+// so that sarama stops fetching once its buffers are full, but the consumer group session is kept: the
+// member keeps sending heartbeats and keeps its partitions.
+// A nil PauseGate is never paused.
+type PauseGate struct {
+	mu sync.Mutex
+	// resumed is closed on resume, it is nil when not paused
+	resumed chan struct{}
+}
+
+// NewPauseGate creates a PauseGate which is not paused.
+func NewPauseGate() *PauseGate {
+	return &PauseGate{}
+}
+
+// SetPaused pauses or resumes the consumption.
+func (g *PauseGate) SetPaused(paused bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if paused && g.resumed == nil {
+		g.resumed = make(chan struct{})
+	} else if !paused && g.resumed != nil {
+		close(g.resumed)
+		g.resumed = nil
+	}
+}
+
+// IsPaused returns true when the consumption is paused.
+func (g *PauseGate) IsPaused() bool {
+	return g.resumedChan() != nil
+}
+
+// Wait blocks while the consumption is paused. It returns false if ctx is done first.
+func (g *PauseGate) Wait(ctx context.Context) bool {
+	for {
+		resumed := g.resumedChan()
+		if resumed == nil {
+			return true
+		}
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (g *PauseGate) resumedChan() chan struct{} {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.resumed
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+type mockPausableMessageHandler struct {
+	pause   *PauseGate
+	handled chan *sarama.ConsumerMessage
+}
+
+func (m *mockPausableMessageHandler) Handle(_ context.Context, message *sarama.ConsumerMessage) (bool, error) {
+	m.handled <- message
+	return true, nil
+}
+
+func (m *mockPausableMessageHandler) GetPauseGate() *PauseGate {
+	return m.pause
+}
+
+func TestPauseGate(t *testing.T) {
+	var nilGate *PauseGate
+	if nilGate.IsPaused() || !nilGate.Wait(context.Background()) {
+		t.Fatal("A nil PauseGate must not be paused")
+	}
+
+	g := NewPauseGate()
+	g.SetPaused(true)
+	g.SetPaused(true)
+	if !g.IsPaused() {
+		t.Fatal("Expected the gate to be paused")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if g.Wait(ctx) {
+		t.Fatal("Wait must return false once the context is done")
+	}
+
+	waited := make(chan bool)
+	go func() {
+		waited <- g.Wait(context.Background())
+	}()
+	g.SetPaused(false)
+	if !<-waited {
+		t.Fatal("Wait must return true on resume")
+	}
+	if g.IsPaused() {
+		t.Fatal("Expected the gate to be resumed")
+	}
+}
+
+func TestConsumeClaimPaused(t *testing.T) {
+	handler := &mockPausableMessageHandler{
+		pause:   NewPauseGate(),
+		handled: make(chan *sarama.ConsumerMessage, 1),
+	}
+	handler.pause.SetPaused(true)
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler)
+
+	session := mockConsumerGroupSession{ctx: context.Background()}
+	claim := mockConsumerGroupClaim{msg: &sarama.ConsumerMessage{Value: []byte("data")}}
+
+	done := make(chan struct{})
+	go func() {
+		_ = cgh.ConsumeClaim(&session, claim)
+		close(done)
+	}()
+
+	select {
+	case <-handler.handled:
+		t.Fatal("The message was handled while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	handler.pause.SetPaused(false)
+	select {
+	case <-handler.handled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("The message was not handled after resume")
+	}
+	<-done
+
+	if !session.marked {
+		t.Errorf("Session was not marked")
+	}
+}
+
+func TestConsumeClaimPausedSessionEnd(t *testing.T) {
+	handler := &mockPausableMessageHandler{
+		pause:   NewPauseGate(),
+		handled: make(chan *sarama.ConsumerMessage, 1),
+	}
+	handler.pause.SetPaused(true)
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := mockConsumerGroupSession{ctx: ctx}
+	claim := mockConsumerGroupClaim{msg: &sarama.ConsumerMessage{Value: []byte("data")}}
+
+	done := make(chan struct{})
+	go func() {
+		_ = cgh.ConsumeClaim(&session, claim)
+		close(done)
+	}()
+
+	// A rebalance ends the session: the paused message is left for the next session
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ConsumeClaim did not return at the end of the session")
+	}
+	if len(handler.handled) != 0 || session.marked {
+		t.Errorf("The message must not be handled nor marked")
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// Stats tracks the consumption of a consumer group: the partitions claimed by its current session, the offsets
+// consumed and marked in each of them, the messages being handled and the last handling error.
+// A nil Stats tracks nothing.
+type Stats struct {
+	mu         sync.Mutex
+	active     bool
+	partitions map[topicPartition]*PartitionStats
+	inFlight   int
+	handled    int64
+	failed     int64
+	lastError  *HandlingError
+	now        func() time.Time
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// PartitionStats is the consumption of a claimed partition. The offsets are -1 until known.
+type PartitionStats struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	// Consumed is the offset following the last message read from the partition.
+	Consumed int64 `json:"consumed"`
+	// Marked is the offset following the last message marked as handled, to be committed.
+	Marked int64 `json:"marked"`
+	// HighWaterMark is the offset the next message produced to the partition will get.
+	HighWaterMark int64 `json:"highWaterMark"`
+	// Lag is the number of messages of the partition which are not marked yet.
+	Lag int64 `json:"lag"`
+
+	// start is the offset of the first message read by the session
+	start int64
+}
+
+// HandlingError is the last error returned by the handling of a message.
+type HandlingError struct {
+	Error     string    `json:"error"`
+	Topic     string    `json:"topic"`
+	Partition int32     `json:"partition"`
+	Offset    int64     `json:"offset"`
+	Time      time.Time `json:"time"`
+}
+
+// StatsSnapshot is the state of a Stats at a point in time.
+type StatsSnapshot struct {
+	// Active is true during a session of the consumer group.
+	Active bool `json:"active"`
+	// Partitions are the partitions claimed by the current session, sorted by topic and partition.
+	Partitions []PartitionStats `json:"partitions"`
+	// InFlight is the number of messages being handled.
+	InFlight int `json:"inFlight"`
+	// Handled and Failed count the messages handled since the consumer group started, and the ones which failed.
+	Handled   int64          `json:"handled"`
+	Failed    int64          `json:"failed"`
+	LastError *HandlingError `json:"lastError,omitempty"`
+}
+
+// NewStats creates a Stats without session.
+func NewStats() *Stats {
+	return &Stats{
+		partitions: make(map[topicPartition]*PartitionStats),
+		now:        time.Now,
+	}
+}
+
+// SessionStarted records the start of a session claiming the partitions, by topic.
+func (s *Stats) SessionStarted(claims map[string][]int32) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.active = true
+	s.partitions = make(map[topicPartition]*PartitionStats)
+	for topic, partitions := range claims {
+		for _, partition := range partitions {
+			s.partitions[topicPartition{topic, partition}] = &PartitionStats{
+				Topic:         topic,
+				Partition:     partition,
+				Consumed:      -1,
+				Marked:        -1,
+				HighWaterMark: -1,
+				start:         -1,
+			}
+		}
+	}
+}
+
+// SessionEnded records the end of the session, whose partitions are released.
+func (s *Stats) SessionEnded() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.active = false
+	s.partitions = make(map[topicPartition]*PartitionStats)
+}
+
+// Consumed records the message read from a claimed partition, with the high water mark of the partition.
+func (s *Stats) Consumed(message *sarama.ConsumerMessage, highWaterMark int64) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if partition, ok := s.partitions[topicPartition{message.Topic, message.Partition}]; ok {
+		if partition.start < 0 {
+			partition.start = message.Offset
+		}
+		partition.Consumed = message.Offset + 1
+		partition.HighWaterMark = highWaterMark
+	}
+}
+
+// Marked records the message marked as handled, along with the ones before it in its partition.
+func (s *Stats) Marked(message *sarama.ConsumerMessage) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if partition, ok := s.partitions[topicPartition{message.Topic, message.Partition}]; ok {
+		partition.Marked = message.Offset + 1
+	}
+}
+
+// Handling records the start of the handling of the message, the returned function records its outcome.
+func (s *Stats) Handling(message *sarama.ConsumerMessage) func(err error) {
+	if s == nil {
+		return func(error) {}
+	}
+	s.mu.Lock()
+	s.inFlight++
+	s.mu.Unlock()
+
+	return func(err error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.inFlight--
+		s.handled++
+		if err != nil {
+			s.failed++
+			s.lastError = &HandlingError{
+				Error:     err.Error(),
+				Topic:     message.Topic,
+				Partition: message.Partition,
+				Offset:    message.Offset,
+				Time:      s.now(),
+			}
+		}
+	}
+}
+
+// Snapshot returns the current state of the Stats.
+func (s *Stats) Snapshot() StatsSnapshot {
+	snapshot := StatsSnapshot{Partitions: []PartitionStats{}}
+	if s == nil {
+		return snapshot
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot.Active = s.active
+	snapshot.InFlight = s.inFlight
+	snapshot.Handled = s.handled
+	snapshot.Failed = s.failed
+	if s.lastError != nil {
+		lastError := *s.lastError
+		snapshot.LastError = &lastError
+	}
+	for _, partition := range s.partitions {
+		stats := *partition
+		if stats.HighWaterMark >= 0 {
+			// the messages before the first one read were marked by a previous session
+			stats.Lag = stats.HighWaterMark - stats.start
+			if stats.Marked >= 0 {
+				stats.Lag = stats.HighWaterMark - stats.Marked
+			}
+		}
+		snapshot.Partitions = append(snapshot.Partitions, stats)
+	}
+	sort.Slice(snapshot.Partitions, func(i, j int) bool {
+		if snapshot.Partitions[i].Topic != snapshot.Partitions[j].Topic {
+			return snapshot.Partitions[i].Topic < snapshot.Partitions[j].Topic
+		}
+		return snapshot.Partitions[i].Partition < snapshot.Partitions[j].Partition
+	})
+	return snapshot
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+type mockStatsMessageHandler struct {
+	mockMessageHandler
+	stats *Stats
+}
+
+func (m mockStatsMessageHandler) GetStats() *Stats {
+	return m.stats
+}
+
+func TestStats(t *testing.T) {
+	var nilStats *Stats
+	nilStats.SessionStarted(map[string][]int32{"topic": {0}})
+	nilStats.Consumed(&mockMessage, 1)
+	nilStats.Handling(&mockMessage)(errors.New("ignored"))
+	nilStats.Marked(&mockMessage)
+	nilStats.SessionEnded()
+	if snapshot := nilStats.Snapshot(); snapshot.Active || len(snapshot.Partitions) != 0 || snapshot.Handled != 0 {
+		t.Fatalf("A nil Stats must track nothing, got %+v", snapshot)
+	}
+
+	stats := NewStats()
+	stats.SessionStarted(map[string][]int32{"topic": {1, 0}})
+	snapshot := stats.Snapshot()
+	if !snapshot.Active || len(snapshot.Partitions) != 2 || snapshot.Partitions[0].Partition != 0 || snapshot.Partitions[0].Consumed != -1 {
+		t.Fatalf("Expected the claimed partitions without offsets, got %+v", snapshot)
+	}
+
+	first := &sarama.ConsumerMessage{Topic: "topic", Partition: 0, Offset: 10}
+	second := &sarama.ConsumerMessage{Topic: "topic", Partition: 0, Offset: 11}
+	stats.Consumed(first, 20)
+	stats.Consumed(second, 20)
+	firstHandled := stats.Handling(first)
+	secondHandled := stats.Handling(second)
+	if snapshot := stats.Snapshot(); snapshot.InFlight != 2 || snapshot.Partitions[0].Consumed != 12 || snapshot.Partitions[0].Lag != 10 {
+		t.Fatalf("Expected two messages in flight and a lag of 10, got %+v", snapshot)
+	}
+
+	firstHandled(nil)
+	stats.Marked(first)
+	secondHandled(errors.New("unreachable"))
+	stats.Marked(second)
+	snapshot = stats.Snapshot()
+	if snapshot.InFlight != 0 || snapshot.Handled != 2 || snapshot.Failed != 1 {
+		t.Fatalf("Expected two handled messages of which one failed, got %+v", snapshot)
+	}
+	if snapshot.Partitions[0].Marked != 12 || snapshot.Partitions[0].Lag != 8 {
+		t.Fatalf("Expected the marked offset 12 and a lag of 8, got %+v", snapshot.Partitions[0])
+	}
+	if snapshot.LastError == nil || snapshot.LastError.Error != "unreachable" || snapshot.LastError.Offset != 11 {
+		t.Fatalf("Expected the last error of the second message, got %+v", snapshot.LastError)
+	}
+
+	stats.SessionEnded()
+	if snapshot := stats.Snapshot(); snapshot.Active || len(snapshot.Partitions) != 0 || snapshot.Handled != 2 {
+		t.Fatalf("Expected the partitions to be released, got %+v", snapshot)
+	}
+}
+
+func TestStatsHandler(t *testing.T) {
+	stats := NewStats()
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), mockStatsMessageHandler{
+		mockMessageHandler: mockMessageHandler{shouldErr: true, shouldMark: true},
+		stats:              stats,
+	})
+
+	session := mockConsumerGroupSession{ctx: context.Background(), claims: map[string][]int32{"": {0}}}
+	_ = cgh.Setup(&session)
+	_ = cgh.ConsumeClaim(&session, mockConsumerGroupClaim{msg: &mockMessage})
+
+	snapshot := stats.Snapshot()
+	if !snapshot.Active || snapshot.Handled != 1 || snapshot.Failed != 1 || snapshot.LastError == nil {
+		t.Fatalf("Expected the failed message to be tracked, got %+v", snapshot)
+	}
+	if len(snapshot.Partitions) != 1 || snapshot.Partitions[0].Consumed != 1 || snapshot.Partitions[0].Marked != 1 {
+		t.Fatalf("Expected the message to be consumed and marked, got %+v", snapshot.Partitions)
+	}
+
+	_ = cgh.Cleanup(&session)
+	if stats.Snapshot().Active {
+		t.Fatal("Expected the session to be ended")
+	}
+}
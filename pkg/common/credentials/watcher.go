@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultPollInterval is the interval the credentials files are read at. The
+// kubelet itself only refreshes the mounted secrets periodically.
+const DefaultPollInterval = 10 * time.Second
+
+// Credentials are the SASL username and password authenticating with the brokers.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Read returns the credentials held by the username and password files. A
+// missing file, or an empty path, is read as an empty value.
+func Read(usernameFile, passwordFile string) (Credentials, error) {
+	username, err := readFile(usernameFile)
+	if err != nil {
+		return Credentials{}, err
+	}
+	password, err := readFile(passwordFile)
+	if err != nil {
+		return Credentials{}, err
+	}
+	return Credentials{Username: username, Password: password}, nil
+}
+
+func readFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return strings.TrimRight(string(data), "\r\n"), err
+}
+
+// Watcher polls the files holding the credentials, typically the keys of a
+// Secret mounted as a volume which the kubelet updates when the Secret
+// changes, and calls its handler once they changed. New credentials are only
+// handed over once read identically twice in a row, so that the handler is
+// not called with a username and a password from different updates.
+type Watcher struct {
+	logger       *zap.Logger
+	usernameFile string
+	passwordFile string
+	interval     time.Duration
+	handler      func(Credentials)
+
+	mu      sync.Mutex
+	current Credentials
+	pending *Credentials
+}
+
+// NewWatcher returns a Watcher of the username and password files, polled
+// at the interval (DefaultPollInterval when zero), which have to be readable.
+func NewWatcher(logger *zap.Logger, usernameFile, passwordFile string, interval time.Duration, handler func(Credentials)) (*Watcher, error) {
+	current, err := Read(usernameFile, passwordFile)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &Watcher{
+		logger:       logger,
+		usernameFile: usernameFile,
+		passwordFile: passwordFile,
+		interval:     interval,
+		handler:      handler,
+		current:      current,
+	}, nil
+}
+
+// Credentials returns the current credentials.
+func (w *Watcher) Credentials() Credentials {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Start polls the files until stopCh is closed.
+func (w *Watcher) Start(stopCh <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				w.poll()
+			}
+		}
+	}()
+}
+
+// poll reads the files and calls the handler when the credentials changed,
+// returning whether it was called.
+func (w *Watcher) poll() bool {
+	read, err := Read(w.usernameFile, w.passwordFile)
+	if err != nil {
+		w.logger.Warn("Failed to read the credentials, keeping the current ones", zap.Error(err))
+		return false
+	}
+
+	w.mu.Lock()
+	switch {
+	case read == w.current:
+		w.pending = nil
+		w.mu.Unlock()
+		return false
+	case w.pending == nil || *w.pending != read:
+		w.pending = &read
+		w.mu.Unlock()
+		return false
+	}
+	w.current = read
+	w.pending = nil
+	w.mu.Unlock()
+
+	w.logger.Info("Credentials changed", zap.String("Username", read.Username))
+	w.handler(read)
+	return true
+}
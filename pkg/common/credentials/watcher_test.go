@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func writeFile(t *testing.T, path, value string) {
+	require.NoError(t, ioutil.WriteFile(path, []byte(value), 0600))
+}
+
+func TestRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	usernameFile := filepath.Join(dir, "username")
+	passwordFile := filepath.Join(dir, "password")
+	writeFile(t, usernameFile, "user\n")
+	writeFile(t, passwordFile, "secret")
+
+	credentials, err := Read(usernameFile, passwordFile)
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "user", Password: "secret"}, credentials)
+
+	// Missing files and empty paths are empty values
+	credentials, err = Read(filepath.Join(dir, "missing"), "")
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{}, credentials)
+
+	// Unreadable files are errors
+	_, err = Read(dir, passwordFile)
+	assert.Error(t, err)
+}
+
+func TestWatcherPoll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	usernameFile := filepath.Join(dir, "username")
+	passwordFile := filepath.Join(dir, "password")
+	writeFile(t, usernameFile, "user")
+	writeFile(t, passwordFile, "secret")
+
+	var handled []Credentials
+	watcher, err := NewWatcher(zap.NewNop(), usernameFile, passwordFile, 0, func(c Credentials) {
+		handled = append(handled, c)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPollInterval, watcher.interval)
+	assert.Equal(t, Credentials{Username: "user", Password: "secret"}, watcher.Credentials())
+
+	// Unchanged
+	assert.False(t, watcher.poll())
+
+	// Changed credentials are handed over once read twice in a row
+	writeFile(t, passwordFile, "rotated")
+	assert.False(t, watcher.poll())
+	assert.True(t, watcher.poll())
+	assert.False(t, watcher.poll())
+	assert.Equal(t, []Credentials{{Username: "user", Password: "rotated"}}, handled)
+	assert.Equal(t, Credentials{Username: "user", Password: "rotated"}, watcher.Credentials())
+
+	// A partial update superseded before being read twice is not handed over
+	writeFile(t, usernameFile, "other")
+	assert.False(t, watcher.poll())
+	writeFile(t, passwordFile, "other-secret")
+	assert.False(t, watcher.poll())
+	assert.True(t, watcher.poll())
+	assert.Equal(t, []Credentials{{Username: "user", Password: "rotated"}, {Username: "other", Password: "other-secret"}}, handled)
+
+	// Unreadable files keep the current credentials
+	require.NoError(t, os.Remove(usernameFile))
+	require.NoError(t, os.Mkdir(usernameFile, 0700))
+	assert.False(t, watcher.poll())
+	assert.False(t, watcher.poll())
+	assert.Len(t, handled, 2)
+}
+
+func TestWatcherStart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	passwordFile := filepath.Join(dir, "password")
+	writeFile(t, passwordFile, "secret")
+
+	handled := make(chan Credentials, 1)
+	watcher, err := NewWatcher(zap.NewNop(), "", passwordFile, 10*time.Millisecond, func(c Credentials) {
+		handled <- c
+	})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	watcher.Start(stopCh)
+
+	writeFile(t, passwordFile, "rotated")
+	select {
+	case credentials := <-handled:
+		assert.Equal(t, Credentials{Password: "rotated"}, credentials)
+	case <-time.After(5 * time.Second):
+		t.Fatal("The rotated credentials were not handled")
+	}
+}
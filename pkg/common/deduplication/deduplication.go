@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deduplication holds the logic shared by the KafkaChannel components
+// to recognize the events they have already handled.
+package deduplication
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// Cache is a bounded set of the keys added within a time window. The keys
+// older than the window are forgotten, as well as the oldest keys once the
+// capacity is reached. It is safe for concurrent use.
+type Cache struct {
+	window   time.Duration
+	capacity int
+	now      func() time.Time
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // entry values, oldest first
+}
+
+type entry struct {
+	key   string
+	added time.Time
+}
+
+// NewCache returns an empty Cache remembering at most capacity keys for the window.
+func NewCache(window time.Duration, capacity int) *Cache {
+	return &Cache{
+		window:   window,
+		capacity: capacity,
+		now:      time.Now,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Window returns the time window of the cache.
+func (c *Cache) Window() time.Duration {
+	return c.window
+}
+
+// Contains returns true if the key has been added within the window.
+func (c *Cache) Contains(key string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expire()
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Add remembers the key for the window, from now on.
+func (c *Cache) Add(key string) {
+	c.AddAt(key, c.now())
+}
+
+// AddAt remembers the key for the window, from the time it was added at. The
+// keys are expected to be added in chronological order.
+func (c *Cache) AddAt(key string, added time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expire()
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+	}
+	if !added.After(c.now().Add(-c.window)) {
+		return
+	}
+	c.entries[key] = c.order.PushBack(entry{key: key, added: added})
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Front())
+	}
+}
+
+// Len returns the number of keys remembered.
+func (c *Cache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expire()
+	return c.order.Len()
+}
+
+// expire forgets the keys older than the window, the lock being held.
+func (c *Cache) expire() {
+	oldest := c.now().Add(-c.window)
+	for element := c.order.Front(); element != nil && !element.Value.(entry).added.After(oldest); element = c.order.Front() {
+		c.remove(element)
+	}
+}
+
+func (c *Cache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(entry).key)
+}
+
+// EventKeyTransformer returns a binding.Transformer setting the key to the
+// one identifying the event of the message, made of its source and id which
+// are unique per the CloudEvents specification. The key is left empty when
+// the message has no id.
+func EventKeyTransformer(key *string) binding.Transformer {
+	return binding.TransformerFunc(func(reader binding.MessageMetadataReader, _ binding.MessageMetadataWriter) error {
+		_, id := reader.GetAttribute(spec.ID)
+		if types.IsZero(id) {
+			return nil
+		}
+		_, source := reader.GetAttribute(spec.Source)
+		formattedSource, err := types.Format(source)
+		if err != nil {
+			return err
+		}
+		formattedID, err := types.Format(id)
+		if err != nil {
+			return err
+		}
+		*key = formattedSource + "/" + formattedID
+		return nil
+	})
+}
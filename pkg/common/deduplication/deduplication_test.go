@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deduplication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+	cache := NewCache(time.Minute, 2)
+	cache.now = func() time.Time { return now }
+
+	assert.False(t, cache.Contains("a"))
+	cache.Add("a")
+	assert.True(t, cache.Contains("a"))
+	assert.Equal(t, 1, cache.Len())
+
+	// The keys are forgotten after the window
+	now = now.Add(30 * time.Second)
+	cache.Add("b")
+	now = now.Add(30 * time.Second)
+	assert.False(t, cache.Contains("a"))
+	assert.True(t, cache.Contains("b"))
+
+	// The oldest keys are forgotten beyond the capacity
+	cache.Add("c")
+	cache.Add("d")
+	assert.False(t, cache.Contains("b"))
+	assert.True(t, cache.Contains("c"))
+	assert.True(t, cache.Contains("d"))
+	assert.Equal(t, 2, cache.Len())
+
+	// Adding a key again renews it
+	now = now.Add(30 * time.Second)
+	cache.Add("c")
+	cache.Add("e")
+	assert.True(t, cache.Contains("c"))
+	assert.False(t, cache.Contains("d"))
+	assert.Equal(t, time.Minute, cache.Window())
+
+	// The keys added at a time older than the window are ignored
+	cache = NewCache(time.Minute, 2)
+	cache.now = func() time.Time { return now }
+	cache.AddAt("f", now.Add(-2*time.Minute))
+	assert.False(t, cache.Contains("f"))
+	cache.AddAt("g", now.Add(-30*time.Second))
+	assert.True(t, cache.Contains("g"))
+	now = now.Add(30 * time.Second)
+	assert.False(t, cache.Contains("g"))
+}
+
+func TestEventKeyTransformer(t *testing.T) {
+	e := event.New()
+	e.SetID("the-id")
+	e.SetSource("the-source")
+	e.SetType("type")
+
+	var key string
+	_, err := binding.ToEvent(context.Background(), binding.ToMessage(&e), EventKeyTransformer(&key))
+	require.NoError(t, err)
+	assert.Equal(t, "the-source/the-id", key)
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/metrics"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/deduplication"
+)
+
+// DeduplicationCapacity is the maximum number of events remembered per partition by a Deduplicator.
+const DeduplicationCapacity = 10000
+
+// deduplicationScanTimeout bounds the time taken to scan back a partition when it is assigned.
+const deduplicationScanTimeout = 10 * time.Second
+
+var (
+	// deduplicatedEventCount counts the events skipped by the Deduplicators
+	deduplicatedEventCount = stats.Int64(
+		"deduplicated_event_count",
+		"Number of events skipped as already delivered to the subscriber",
+		stats.UnitDimensionless,
+	)
+	topicKey        = tag.MustNewKey("topic")
+	subscriptionKey = tag.MustNewKey("subscription")
+)
+
+func init() {
+	err := view.Register(&view.View{
+		Description: deduplicatedEventCount.Description(),
+		Measure:     deduplicatedEventCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topicKey, subscriptionKey},
+	})
+	if err != nil {
+		log.Printf("failed to register opencensus views, %v", err)
+	}
+}
+
+// DeduplicationWindowFromAnnotations returns the deduplication window set by
+// the annotations of a Subscription, zero when the deduplication is disabled.
+func DeduplicationWindowFromAnnotations(annotations map[string]string) (time.Duration, error) {
+	v, ok := annotations[v1beta1.KafkaSubscriptionDeduplicationWindowAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q: expected a positive duration", v1beta1.KafkaSubscriptionDeduplicationWindowAnnotation, v)
+	}
+	return d, nil
+}
+
+// partitionKey identifies a partition of a topic.
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+// Deduplicator skips the events delivered again to a subscriber within its
+// window, recognized by their CloudEvents source and id. It remembers the
+// events delivered from each partition, at most DeduplicationCapacity of them,
+// and rebuilds them when the partition is assigned by scanning it back from
+// the committed offset, so that the events delivered before a rebalance are
+// not delivered again. The events retried through the retry topics are not
+// deduplicated. A nil Deduplicator, or one without window, delivers all the
+// events.
+type Deduplicator struct {
+	logger       *zap.Logger
+	subscription types.UID
+
+	mu         sync.Mutex
+	window     time.Duration
+	newClient  func() (sarama.Client, error)
+	partitions map[partitionKey]*deduplication.Cache
+}
+
+// NewDeduplicator creates a Deduplicator for the subscription, disabled until its window is set.
+func NewDeduplicator(logger *zap.Logger, subscription types.UID) *Deduplicator {
+	return &Deduplicator{
+		logger:       logger,
+		subscription: subscription,
+		partitions:   make(map[partitionKey]*deduplication.Cache),
+	}
+}
+
+// SetWindow changes the window, forgetting the events delivered so far when it changes.
+func (d *Deduplicator) SetWindow(window time.Duration) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if window != d.window {
+		d.window = window
+		d.partitions = make(map[partitionKey]*deduplication.Cache)
+	}
+}
+
+// Window returns the current window, zero when disabled.
+func (d *Deduplicator) Window() time.Duration {
+	if d == nil {
+		return 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.window
+}
+
+// SetNewClient sets the function creating the clients the partitions are scanned back with.
+func (d *Deduplicator) SetNewClient(newClient func() (sarama.Client, error)) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.newClient = newClient
+}
+
+// Rebuild replaces the events remembered for the partition with the ones of
+// its messages preceding offset, the committed offset it is assigned at,
+// within the window. The partition is scanned back with a new client. The
+// events are forgotten when it fails, which is only logged.
+func (d *Deduplicator) Rebuild(ctx context.Context, topic string, partition int32, offset int64) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	window, newClient := d.window, d.newClient
+	d.mu.Unlock()
+	if window <= 0 {
+		return
+	}
+
+	cache := deduplication.NewCache(window, DeduplicationCapacity)
+	if newClient != nil && offset > 0 {
+		logger := d.logger.With(zap.String("topic", topic), zap.Int32("partition", partition), zap.Int64("offset", offset))
+		if scanned, err := d.scan(ctx, newClient, topic, partition, offset, cache); err != nil {
+			logger.Warn("Failed to scan back the partition for deduplication, the events delivered before may be delivered again", zap.Error(err))
+		} else {
+			logger.Info("Scanned back the partition for deduplication", zap.Int("messages", scanned), zap.Int("events", cache.Len()))
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.window == window {
+		d.partitions[partitionKey{topic: topic, partition: partition}] = cache
+	}
+}
+
+// scan adds the events of at most DeduplicationCapacity messages preceding offset to the cache, returning the
+// number of messages scanned.
+func (d *Deduplicator) scan(ctx context.Context, newClient func() (sarama.Client, error), topic string, partition int32, offset int64, cache *deduplication.Cache) (int, error) {
+	client, err := newClient()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = client.Close() }()
+
+	oldest, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, err
+	}
+	start := offset - DeduplicationCapacity
+	if start < oldest {
+		start = oldest
+	}
+	if start >= offset {
+		return 0, nil
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = consumer.Close() }()
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, start)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = partitionConsumer.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, deduplicationScanTimeout)
+	defer cancel()
+	scanned := 0
+	for {
+		select {
+		case message := <-partitionConsumer.Messages():
+			if message.Offset >= offset {
+				return scanned, nil
+			}
+			scanned++
+			if _, retried := RetryAttemptOf(message); retried {
+				// The retried events are not deduplicated
+			} else if key := eventKey(ctx, message); key != "" && message.Timestamp.IsZero() {
+				cache.Add(key) // The messages of the brokers older than 0.10 have no timestamp
+			} else if key != "" {
+				cache.AddAt(key, message.Timestamp)
+			}
+			if message.Offset == offset-1 {
+				return scanned, nil
+			}
+		case err := <-partitionConsumer.Errors():
+			return scanned, err
+		case <-ctx.Done():
+			return scanned, fmt.Errorf("scanned %d messages: %w", scanned, ctx.Err())
+		}
+	}
+}
+
+// IsDuplicate returns true if the event of the message has already been
+// delivered within the window, counting it as deduplicated.
+func (d *Deduplicator) IsDuplicate(ctx context.Context, message *sarama.ConsumerMessage) bool {
+	cache := d.cache(message, false)
+	if cache == nil {
+		return false
+	}
+	key := eventKey(ctx, message)
+	if key == "" || !cache.Contains(key) {
+		return false
+	}
+
+	if ctx, err := tag.New(context.Background(), tag.Insert(topicKey, message.Topic), tag.Insert(subscriptionKey, string(d.subscription))); err == nil {
+		metrics.Record(ctx, deduplicatedEventCount.M(1))
+	}
+	return true
+}
+
+// Delivered remembers the event of the message as delivered to the subscriber.
+func (d *Deduplicator) Delivered(ctx context.Context, message *sarama.ConsumerMessage) {
+	if cache := d.cache(message, true); cache != nil {
+		if key := eventKey(ctx, message); key != "" {
+			cache.Add(key)
+		}
+	}
+}
+
+// cache returns the events delivered from the partition of the message, nil
+// when the message is not deduplicated. A missing cache is created if asked to.
+func (d *Deduplicator) cache(message *sarama.ConsumerMessage, create bool) *deduplication.Cache {
+	if d == nil {
+		return nil
+	}
+	if _, retried := RetryAttemptOf(message); retried {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.window <= 0 {
+		return nil
+	}
+	key := partitionKey{topic: message.Topic, partition: message.Partition}
+	cache, ok := d.partitions[key]
+	if !ok && create {
+		cache = deduplication.NewCache(d.window, DeduplicationCapacity)
+		d.partitions[key] = cache
+	}
+	return cache
+}
+
+// eventKey returns the key of the event of the message, empty when it is not a CloudEvent.
+func eventKey(ctx context.Context, message *sarama.ConsumerMessage) string {
+	var key string
+	if _, err := binding.ToEvent(ctx, protocolkafka.NewMessageFromConsumerMessage(message), deduplication.EventKeyTransformer(&key)); err != nil {
+		return ""
+	}
+	return key
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+func TestDeduplicationWindowFromAnnotations(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		want        time.Duration
+		wantErr     bool
+	}{
+		"no annotations": {},
+		"window": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionDeduplicationWindowAnnotation: "10m"},
+			want:        10 * time.Minute,
+		},
+		"invalid window": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionDeduplicationWindowAnnotation: "a while"},
+			wantErr:     true,
+		},
+		"zero window": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionDeduplicationWindowAnnotation: "0s"},
+			wantErr:     true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got, err := DeduplicationWindowFromAnnotations(tc.annotations)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func eventMessage(partition int32, id string) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{Topic: "topic", Partition: partition, Headers: []*sarama.RecordHeader{
+		{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+		{Key: []byte("ce_id"), Value: []byte(id)},
+		{Key: []byte("ce_source"), Value: []byte("source")},
+		{Key: []byte("ce_type"), Value: []byte("type")},
+	}}
+}
+
+func TestDeduplicator(t *testing.T) {
+	ctx := context.TODO()
+
+	// A nil Deduplicator delivers all the events
+	var d *Deduplicator
+	d.Delivered(ctx, eventMessage(0, "1"))
+	assert.False(t, d.IsDuplicate(ctx, eventMessage(0, "1")))
+	assert.Equal(t, time.Duration(0), d.Window())
+
+	// The events are not deduplicated without window
+	d = NewDeduplicator(zap.NewNop(), "subscription")
+	d.Delivered(ctx, eventMessage(0, "1"))
+	assert.False(t, d.IsDuplicate(ctx, eventMessage(0, "1")))
+
+	d.SetWindow(time.Minute)
+	d.Delivered(ctx, eventMessage(0, "1"))
+	assert.True(t, d.IsDuplicate(ctx, eventMessage(0, "1")))
+	assert.False(t, d.IsDuplicate(ctx, eventMessage(0, "2")))
+
+	// The events are remembered per partition
+	assert.False(t, d.IsDuplicate(ctx, eventMessage(1, "1")))
+
+	// The retried events are not deduplicated
+	retried := eventMessage(0, "1")
+	retried.Headers = append(retried.Headers, &sarama.RecordHeader{Key: []byte(RetrySubscriptionHeader), Value: []byte("subscription")})
+	assert.False(t, d.IsDuplicate(ctx, retried))
+
+	// The messages which are not CloudEvents are not deduplicated
+	d.Delivered(ctx, &sarama.ConsumerMessage{Topic: "topic", Value: []byte("value")})
+	assert.False(t, d.IsDuplicate(ctx, &sarama.ConsumerMessage{Topic: "topic", Value: []byte("value")}))
+
+	// Changing the window forgets the delivered events
+	d.SetWindow(time.Hour)
+	assert.False(t, d.IsDuplicate(ctx, eventMessage(0, "1")))
+}
+
+func TestDeduplicatorRebuild(t *testing.T) {
+	ctx := context.TODO()
+	d := NewDeduplicator(zap.NewNop(), "subscription")
+	d.SetWindow(time.Minute)
+	d.Delivered(ctx, eventMessage(0, "1"))
+
+	// The partition is not scanned back from its first offset
+	clients := 0
+	d.SetNewClient(func() (sarama.Client, error) {
+		clients++
+		return nil, errors.New("no brokers")
+	})
+	d.Rebuild(ctx, "topic", 0, 0)
+	assert.Equal(t, 0, clients)
+	assert.False(t, d.IsDuplicate(ctx, eventMessage(0, "1")))
+
+	// The events are forgotten when the partition can not be scanned back
+	d.Delivered(ctx, eventMessage(0, "1"))
+	d.Rebuild(ctx, "topic", 0, 10)
+	assert.Equal(t, 1, clients)
+	assert.False(t, d.IsDuplicate(ctx, eventMessage(0, "1")))
+
+	// The partition is not scanned back without window
+	d.SetWindow(0)
+	d.Rebuild(ctx, "topic", 0, 10)
+	assert.Equal(t, 1, clients)
+}
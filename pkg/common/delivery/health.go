@@ -0,0 +1,268 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const (
+	// DeliveryFailingReason is the reason of the deliveries whose last ConsecutiveFailures attempts all failed.
+	DeliveryFailingReason = "DeliveryFailing"
+	// DeliveryStalledReason is the reason of the deliveries which kept on failing for the StallTimeout.
+	DeliveryStalledReason = "DeliveryStalled"
+)
+
+// HealthThresholds tell when the delivery to a subscriber becomes unhealthy.
+type HealthThresholds struct {
+	// ConsecutiveFailures is the number of failed deliveries in a row making the delivery unhealthy, zero disables it
+	ConsecutiveFailures int
+	// StallTimeout is how long the deliveries can keep on failing without any success before the delivery is
+	// unhealthy, zero disables it
+	StallTimeout time.Duration
+}
+
+// DefaultHealthThresholds make the delivery unhealthy after 10 failed deliveries in a row, or 5 minutes of failures.
+var DefaultHealthThresholds = HealthThresholds{ConsecutiveFailures: 10, StallTimeout: 5 * time.Minute}
+
+// HealthStatus is the rolling health of the delivery to a subscriber.
+type HealthStatus struct {
+	// ConsecutiveFailures is the number of failed deliveries since the last successful one
+	ConsecutiveFailures int
+	// FailingSince is the time of the first of the ConsecutiveFailures, zero when none
+	FailingSince time.Time
+	// LastStatusCode is the status code of the last failed response, zero when it failed without response
+	LastStatusCode int
+	// LastError is the error of the last failed delivery, if any
+	LastError string
+	// LastSuccess is the time of the last successful delivery, zero when none
+	LastSuccess time.Time
+	// DeadLettered is the number of events delivered to the dead letter sink instead of the subscriber
+	DeadLettered int64
+	// Reason tells why the delivery is unhealthy, it is empty while healthy
+	Reason string
+}
+
+// Healthy returns true unless a threshold is crossed.
+func (s HealthStatus) Healthy() bool {
+	return s.Reason == ""
+}
+
+// Message describes the unhealthy delivery, it is empty while healthy.
+func (s HealthStatus) Message() string {
+	if s.Healthy() {
+		return ""
+	}
+	var m strings.Builder
+	switch s.Reason {
+	case DeliveryFailingReason:
+		fmt.Fprintf(&m, "%s: the last %d deliveries to the subscriber failed", s.Reason, s.ConsecutiveFailures)
+	default:
+		fmt.Fprintf(&m, "%s: the deliveries to the subscriber have failed since %s", s.Reason, s.FailingSince.UTC().Format(time.RFC3339))
+	}
+	if s.LastStatusCode != 0 {
+		fmt.Fprintf(&m, ", the last one with the status code %d", s.LastStatusCode)
+	} else if s.LastError != "" {
+		fmt.Fprintf(&m, ", the last one with %q", s.LastError)
+	}
+	if s.DeadLettered > 0 {
+		fmt.Fprintf(&m, ", %d events were delivered to the dead letter sink", s.DeadLettered)
+	}
+	if s.LastSuccess.IsZero() {
+		m.WriteString(", no delivery succeeded yet")
+	} else {
+		fmt.Fprintf(&m, ", the last successful delivery was at %s", s.LastSuccess.UTC().Format(time.RFC3339))
+	}
+	return m.String()
+}
+
+// Health tracks the rolling health of the delivery to a subscriber: the
+// failed deliveries in a row, the status code of the last failed response,
+// the time of the last successful delivery and the number of events delivered
+// to the dead letter sink. A delivery is successful once the subscriber, and
+// the reply if any, accepted the event; the events delivered to the dead
+// letter sink or moved to the retry topics are failed deliveries.
+// A nil Health tracks nothing.
+type Health struct {
+	mu     sync.Mutex
+	status HealthStatus
+	// thresholds are the HealthThresholds the delivery is unhealthy past
+	thresholds HealthThresholds
+	// onChange is called after each delivery while, or once after, the delivery is unhealthy
+	onChange func()
+	now      func() time.Time
+}
+
+// NewHealth creates a Health becoming unhealthy past the thresholds.
+func NewHealth(thresholds HealthThresholds) *Health {
+	return &Health{thresholds: thresholds, now: time.Now}
+}
+
+// SetOnChange sets the function called after each delivery recorded while the delivery is unhealthy, and after the
+// first one once it is healthy again, so that the health can be reported. It must not block.
+func (h *Health) SetOnChange(onChange func()) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onChange = onChange
+}
+
+// Status returns the current HealthStatus.
+func (h *Health) Status() HealthStatus {
+	if h == nil {
+		return HealthStatus{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.statusLocked()
+}
+
+// Attempt returns an Attempt observing the responses to the delivery of an event, the ones of the deadLetter sink
+// telling that the event was dead-lettered.
+func (h *Health) Attempt(deadLetter *url.URL) *Attempt {
+	if h == nil {
+		return nil
+	}
+	return &Attempt{health: h, deadLetter: deadLetter}
+}
+
+// succeeded records a successful delivery.
+func (h *Health) succeeded() {
+	h.record(func(status *HealthStatus) {
+		status.ConsecutiveFailures = 0
+		status.FailingSince = time.Time{}
+		status.LastSuccess = h.now()
+	})
+}
+
+// failed records a failed delivery, with the status code of its last response and its error if any.
+func (h *Health) failed(statusCode int, err error, deadLettered bool) {
+	h.record(func(status *HealthStatus) {
+		if status.ConsecutiveFailures == 0 {
+			status.FailingSince = h.now()
+		}
+		status.ConsecutiveFailures++
+		status.LastStatusCode = statusCode
+		status.LastError = ""
+		if err != nil {
+			status.LastError = err.Error()
+		}
+		if deadLettered {
+			status.DeadLettered++
+		}
+	})
+}
+
+func (h *Health) record(update func(status *HealthStatus)) {
+	h.mu.Lock()
+	wasHealthy := h.statusLocked().Healthy()
+	update(&h.status)
+	healthy := h.statusLocked().Healthy()
+	onChange := h.onChange
+	h.mu.Unlock()
+
+	if onChange != nil && !(wasHealthy && healthy) {
+		onChange()
+	}
+}
+
+// statusLocked must be called with mu held.
+func (h *Health) statusLocked() HealthStatus {
+	status := h.status
+	status.Reason = ""
+	if h.thresholds.ConsecutiveFailures > 0 && status.ConsecutiveFailures >= h.thresholds.ConsecutiveFailures {
+		status.Reason = DeliveryFailingReason
+	} else if h.thresholds.StallTimeout > 0 && status.ConsecutiveFailures > 0 && h.now().Sub(status.FailingSince) >= h.thresholds.StallTimeout {
+		status.Reason = DeliveryStalledReason
+	}
+	return status
+}
+
+// Attempt observes the responses to the delivery of an event through the
+// CheckRetry of its RetryConfig, to record its outcome in a Health. It is not
+// safe for concurrent use, the event being delivered once at a time.
+// A nil Attempt observes nothing.
+type Attempt struct {
+	health     *Health
+	deadLetter *url.URL
+	// statusCode is the status code of the last response of the subscriber or the reply, zero without response
+	statusCode int
+	// failed is true when the last response of the subscriber or the reply failed
+	failed bool
+	// deadLettered is true once the dead letter sink accepted the event
+	deadLettered bool
+}
+
+// WrapCheckRetry returns a CheckRetry observing every response before deciding whether to retry with check.
+func (a *Attempt) WrapCheckRetry(check kncloudevents.CheckRetry) kncloudevents.CheckRetry {
+	if a == nil {
+		return check
+	}
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		a.observe(resp, err)
+		if check == nil {
+			return false, nil
+		}
+		return check(ctx, resp, err)
+	}
+}
+
+// RetryConfig returns config observing every response.
+func (a *Attempt) RetryConfig(config kncloudevents.RetryConfig) kncloudevents.RetryConfig {
+	config.CheckRetry = a.WrapCheckRetry(config.CheckRetry)
+	return config
+}
+
+// Done records the outcome of the delivery, which returned err.
+func (a *Attempt) Done(err error) {
+	if a == nil {
+		return
+	}
+	if err == nil && !a.failed && !a.deadLettered {
+		a.health.succeeded()
+		return
+	}
+	a.health.failed(a.statusCode, err, a.deadLettered)
+}
+
+func (a *Attempt) observe(resp *http.Response, err error) {
+	succeeded := err == nil && resp != nil && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices
+	if resp != nil && resp.Request != nil && sameURL(resp.Request.URL, a.deadLetter) {
+		a.deadLettered = succeeded
+		return
+	}
+	a.failed = !succeeded
+	a.statusCode = 0
+	if resp != nil {
+		a.statusCode = resp.StatusCode
+	}
+}
+
+// sameURL returns true when the URLs address the same endpoint.
+func sameURL(u1 *url.URL, u2 *url.URL) bool {
+	return u1 != nil && u2 != nil && u1.Host == u2.Host && strings.TrimSuffix(u1.Path, "/") == strings.TrimSuffix(u2.Path, "/")
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+var (
+	subscriberURL = &url.URL{Scheme: "http", Host: "subscriber.ns.svc.cluster.local"}
+	deadLetterURL = &url.URL{Scheme: "http", Host: "dead-letter.ns.svc.cluster.local", Path: "/"}
+)
+
+// respond observes a response of the URL with the status code through the RetryConfig of the attempt.
+func respond(attempt *Attempt, u *url.URL, statusCode int) {
+	config := attempt.RetryConfig(kncloudevents.NoRetries())
+	_, _ = config.CheckRetry(context.Background(), &http.Response{StatusCode: statusCode, Request: &http.Request{URL: u}}, nil)
+}
+
+func TestHealthNil(t *testing.T) {
+	var h *Health
+	h.SetOnChange(func() {})
+	attempt := h.Attempt(deadLetterURL)
+	assert.Nil(t, attempt)
+	respond(attempt, subscriberURL, http.StatusOK)
+	attempt.Done(nil)
+	assert.True(t, h.Status().Healthy())
+	assert.Empty(t, h.Status().Message())
+}
+
+func TestHealth(t *testing.T) {
+	now := time.Date(2020, 11, 20, 10, 0, 0, 0, time.UTC)
+	h := NewHealth(HealthThresholds{ConsecutiveFailures: 3, StallTimeout: time.Minute})
+	h.now = func() time.Time { return now }
+	changes := 0
+	h.SetOnChange(func() { changes++ })
+
+	// A successful delivery
+	attempt := h.Attempt(deadLetterURL)
+	respond(attempt, subscriberURL, http.StatusServiceUnavailable)
+	respond(attempt, subscriberURL, http.StatusAccepted)
+	attempt.Done(nil)
+	assert.Equal(t, HealthStatus{LastSuccess: now}, h.Status())
+	lastSuccess := now
+
+	// Failed deliveries, the second one to the dead letter sink
+	now = now.Add(time.Second)
+	attempt = h.Attempt(deadLetterURL)
+	respond(attempt, subscriberURL, http.StatusServiceUnavailable)
+	attempt.Done(errors.New("unable to complete request"))
+	attempt = h.Attempt(deadLetterURL)
+	respond(attempt, subscriberURL, http.StatusBadRequest)
+	respond(attempt, &url.URL{Scheme: "http", Host: "dead-letter.ns.svc.cluster.local"}, http.StatusOK)
+	attempt.Done(nil)
+	assert.Equal(t, HealthStatus{
+		ConsecutiveFailures: 2,
+		FailingSince:        now,
+		LastStatusCode:      http.StatusBadRequest,
+		LastSuccess:         lastSuccess,
+		DeadLettered:        1,
+	}, h.Status())
+	assert.Equal(t, 0, changes)
+
+	// Crossing the consecutive failures threshold, without response
+	attempt = h.Attempt(deadLetterURL)
+	attempt.Done(errors.New("connection refused"))
+	status := h.Status()
+	assert.Equal(t, DeliveryFailingReason, status.Reason)
+	assert.False(t, status.Healthy())
+	assert.Equal(t, `DeliveryFailing: the last 3 deliveries to the subscriber failed, the last one with "connection refused", `+
+		`1 events were delivered to the dead letter sink, the last successful delivery was at 2020-11-20T10:00:00Z`, status.Message())
+	assert.Equal(t, 1, changes)
+
+	// Until a delivery succeeds
+	attempt = h.Attempt(deadLetterURL)
+	respond(attempt, subscriberURL, http.StatusOK)
+	attempt.Done(nil)
+	assert.True(t, h.Status().Healthy())
+	assert.Equal(t, int64(1), h.Status().DeadLettered)
+	assert.Equal(t, 2, changes)
+
+	// Failing for the stall timeout, moved to the retry topics
+	attempt = h.Attempt(nil)
+	respond(attempt, subscriberURL, http.StatusInternalServerError)
+	attempt.Done(nil)
+	assert.True(t, h.Status().Healthy())
+	now = now.Add(time.Minute)
+	status = h.Status()
+	assert.Equal(t, DeliveryStalledReason, status.Reason)
+	assert.Equal(t, `DeliveryStalled: the deliveries to the subscriber have failed since 2020-11-20T10:00:01Z, the last one with the status code 500, `+
+		`1 events were delivered to the dead letter sink, the last successful delivery was at 2020-11-20T10:00:01Z`, status.Message())
+	assert.Equal(t, 2, changes)
+
+	// The failures of the reply fail the delivery
+	attempt = h.Attempt(nil)
+	respond(attempt, subscriberURL, http.StatusOK)
+	respond(attempt, &url.URL{Scheme: "http", Host: "reply"}, http.StatusNotFound)
+	attempt.Done(errors.New("failed to forward reply"))
+	assert.Equal(t, 2, h.Status().ConsecutiveFailures)
+	assert.Equal(t, http.StatusNotFound, h.Status().LastStatusCode)
+	assert.Equal(t, 3, changes)
+}
+
+func TestHealthMessageWithoutSuccess(t *testing.T) {
+	status := HealthStatus{ConsecutiveFailures: 10, LastStatusCode: http.StatusNotFound, Reason: DeliveryFailingReason}
+	assert.Equal(t, "DeliveryFailing: the last 10 deliveries to the subscriber failed, the last one with the status code 404, no delivery succeeded yet", status.Message())
+}
+
+func TestHealthDisabledThresholds(t *testing.T) {
+	h := NewHealth(HealthThresholds{})
+	for i := 0; i < 100; i++ {
+		h.Attempt(nil).Done(errors.New("failed"))
+	}
+	h.now = func() time.Time { return time.Now().Add(time.Hour) }
+	assert.True(t, h.Status().Healthy())
+	assert.Equal(t, 100, h.Status().ConsecutiveFailures)
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package delivery holds the logic shared by the KafkaChannel dispatchers to
+// deliver events to the subscribers.
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"knative.dev/eventing/pkg/kncloudevents"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+// Limits caps the delivery to a subscriber. The zero value is unlimited.
+type Limits struct {
+	// RateLimit is the maximum number of events per second, unlimited when zero
+	RateLimit float64
+	// MaxInFlight is the maximum number of events being delivered at once, unlimited when zero
+	MaxInFlight int
+}
+
+// LimitsFromAnnotations returns the Limits set by the annotations of a Subscription.
+func LimitsFromAnnotations(annotations map[string]string) (Limits, error) {
+	var limits Limits
+	if v, ok := annotations[v1beta1.KafkaSubscriptionRateLimitAnnotation]; ok {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil || r <= 0 || math.IsInf(r, 0) {
+			return Limits{}, fmt.Errorf("invalid %s annotation %q: expected a positive number of events per second", v1beta1.KafkaSubscriptionRateLimitAnnotation, v)
+		}
+		limits.RateLimit = r
+	}
+	if v, ok := annotations[v1beta1.KafkaSubscriptionMaxInFlightAnnotation]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return Limits{}, fmt.Errorf("invalid %s annotation %q: expected a positive number of events", v1beta1.KafkaSubscriptionMaxInFlightAnnotation, v)
+		}
+		limits.MaxInFlight = n
+	}
+	return limits, nil
+}
+
+// Limiter holds the events back so that the delivery to a subscriber stays
+// within its Limits, with a token bucket for the rate and a counter for the
+// events in flight. It also slows down when the subscriber answers 429 with a
+// Retry-After header, and holds the RetryPolicy of the subscriber.
+// A nil Limiter never holds events back.
+type Limiter struct {
+	mu       sync.Mutex
+	limits   Limits
+	rate     *rate.Limiter
+	inFlight int
+	// released is closed when an event is released or the limits change
+	released   chan struct{}
+	retryAfter time.Time
+	retry      RetryPolicy
+}
+
+// NewLimiter creates a Limiter with the given Limits.
+func NewLimiter(limits Limits) *Limiter {
+	l := &Limiter{released: make(chan struct{})}
+	l.SetLimits(limits)
+	return l
+}
+
+// SetLimits changes the Limits, including for the events already waiting.
+func (l *Limiter) SetLimits(limits Limits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limits == l.limits {
+		return
+	}
+	l.limits = limits
+
+	if limits.RateLimit > 0 {
+		// The bucket holds one second worth of events
+		burst := int(math.Ceil(limits.RateLimit))
+		if l.rate == nil {
+			l.rate = rate.NewLimiter(rate.Limit(limits.RateLimit), burst)
+		} else {
+			l.rate.SetLimit(rate.Limit(limits.RateLimit))
+			l.rate.SetBurst(burst)
+		}
+	} else {
+		l.rate = nil
+	}
+	l.wakeUp()
+}
+
+// Limits returns the current Limits.
+func (l *Limiter) Limits() Limits {
+	if l == nil {
+		return Limits{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limits
+}
+
+// SetRetryPolicy changes the RetryPolicy, including for the deliveries in progress.
+func (l *Limiter) SetRetryPolicy(policy RetryPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.retry = policy
+}
+
+// RetryPolicy returns the current RetryPolicy.
+func (l *Limiter) RetryPolicy() RetryPolicy {
+	if l == nil {
+		return RetryPolicy{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.retry
+}
+
+// Acquire blocks until an event can be delivered. The returned function must
+// be called once the delivery is over. It returns an error if ctx is done first.
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	for {
+		l.mu.Lock()
+		if wait := time.Until(l.retryAfter); wait > 0 {
+			l.mu.Unlock()
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if l.limits.MaxInFlight > 0 && l.inFlight >= l.limits.MaxInFlight {
+			released := l.released
+			l.mu.Unlock()
+			select {
+			case <-released:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		l.inFlight++
+		r := l.rate
+		l.mu.Unlock()
+
+		var once sync.Once
+		release := func() { once.Do(l.release) }
+
+		if r != nil {
+			if err := r.Wait(ctx); err != nil {
+				release()
+				return nil, err
+			}
+		}
+		return release, nil
+	}
+}
+
+// RetryAfter holds every event back for the given delay, capped to the
+// MaxBackoff of the RetryPolicy.
+func (l *Limiter) RetryAfter(delay time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if maxBackoff := l.retry.maxBackoff(); delay > maxBackoff {
+		delay = maxBackoff
+	}
+	if until := time.Now().Add(delay); until.After(l.retryAfter) {
+		l.retryAfter = until
+	}
+}
+
+// ObserveResponse slows the delivery down when the subscriber answered 429
+// with a Retry-After header.
+func (l *Limiter) ObserveResponse(resp *http.Response) {
+	if l == nil || resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	if delay, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		l.RetryAfter(delay)
+	}
+}
+
+// WrapCheckRetry returns a CheckRetry observing every response of the
+// subscriber before deciding whether to retry with check.
+func (l *Limiter) WrapCheckRetry(check kncloudevents.CheckRetry) kncloudevents.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		l.ObserveResponse(resp)
+		if check == nil {
+			return false, nil
+		}
+		return check(ctx, resp, err)
+	}
+}
+
+// RetryConfig returns config following the current RetryPolicy, and
+// observing every response of the subscriber.
+func (l *Limiter) RetryConfig(config kncloudevents.RetryConfig) kncloudevents.RetryConfig {
+	config = l.RetryPolicy().Apply(config)
+	config.CheckRetry = l.WrapCheckRetry(config.CheckRetry)
+	return config
+}
+
+func (l *Limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	l.wakeUp()
+}
+
+// wakeUp must be called with mu held.
+func (l *Limiter) wakeUp() {
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"knative.dev/eventing/pkg/kncloudevents"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+func TestLimitsFromAnnotations(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		want        Limits
+		wantErr     bool
+	}{
+		"no annotations": {},
+		"rate limit": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionRateLimitAnnotation: "2.5"},
+			want:        Limits{RateLimit: 2.5},
+		},
+		"max in flight": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionMaxInFlightAnnotation: "10"},
+			want:        Limits{MaxInFlight: 10},
+		},
+		"both": {
+			annotations: map[string]string{
+				v1beta1.KafkaSubscriptionRateLimitAnnotation:   "100",
+				v1beta1.KafkaSubscriptionMaxInFlightAnnotation: "4",
+			},
+			want: Limits{RateLimit: 100, MaxInFlight: 4},
+		},
+		"invalid rate limit": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionRateLimitAnnotation: "fast"},
+			wantErr:     true,
+		},
+		"zero rate limit": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionRateLimitAnnotation: "0"},
+			wantErr:     true,
+		},
+		"negative max in flight": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionMaxInFlightAnnotation: "-1"},
+			wantErr:     true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got, err := LimitsFromAnnotations(tc.annotations)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLimiterNil(t *testing.T) {
+	var l *Limiter
+	release, err := l.Acquire(context.TODO())
+	require.Nil(t, err)
+	release()
+	l.RetryAfter(time.Minute)
+	l.ObserveResponse(&http.Response{StatusCode: http.StatusTooManyRequests})
+	assert.Equal(t, Limits{}, l.Limits())
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	l := NewLimiter(Limits{MaxInFlight: 1})
+
+	release, err := l.Acquire(context.TODO())
+	require.Nil(t, err)
+
+	acquired := make(chan func())
+	go func() {
+		release, err := l.Acquire(context.TODO())
+		assert.Nil(t, err)
+		acquired <- release
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Acquired more than MaxInFlight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Releasing twice is harmless
+	release()
+	release()
+	(<-acquired)()
+
+	// The waiting events give up when ctx is done
+	release, err = l.Acquire(context.TODO())
+	require.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx)
+	assert.NotNil(t, err)
+	release()
+}
+
+func TestLimiterSetLimits(t *testing.T) {
+	l := NewLimiter(Limits{MaxInFlight: 1})
+
+	_, err := l.Acquire(context.TODO())
+	require.Nil(t, err)
+
+	acquired := make(chan struct{})
+	go func() {
+		_, err := l.Acquire(context.TODO())
+		assert.Nil(t, err)
+		close(acquired)
+	}()
+
+	// Raising the cap lets the waiting event through
+	l.SetLimits(Limits{MaxInFlight: 2})
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Waiting event not let through")
+	}
+	assert.Equal(t, Limits{MaxInFlight: 2}, l.Limits())
+}
+
+func TestLimiterRateLimit(t *testing.T) {
+	l := NewLimiter(Limits{RateLimit: 20})
+
+	// The burst of one second worth of events goes through at once, the next one waits for a token
+	start := time.Now()
+	for i := 0; i < 21; i++ {
+		release, err := l.Acquire(context.TODO())
+		require.Nil(t, err)
+		release()
+	}
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(40*time.Millisecond))
+
+	l.SetLimits(Limits{})
+	start = time.Now()
+	for i := 0; i < 100; i++ {
+		release, err := l.Acquire(context.TODO())
+		require.Nil(t, err)
+		release()
+	}
+	assert.Less(t, int64(time.Since(start)), int64(40*time.Millisecond))
+}
+
+func TestLimiterRetryAfter(t *testing.T) {
+	l := NewLimiter(Limits{})
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "1")
+	check := l.WrapCheckRetry(func(context.Context, *http.Response, error) (bool, error) {
+		return true, nil
+	})
+	retry, err := check(context.TODO(), resp, nil)
+	assert.True(t, retry)
+	assert.Nil(t, err)
+
+	// Held back until the Retry-After delay is over
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx)
+	assert.NotNil(t, err)
+
+	// Other responses do not slow down
+	l = NewLimiter(Limits{})
+	l.ObserveResponse(&http.Response{StatusCode: http.StatusServiceUnavailable, Header: resp.Header})
+	release, err := l.Acquire(ctx)
+	require.Nil(t, err)
+	release()
+
+	retry, err = l.WrapCheckRetry(nil)(context.TODO(), &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.False(t, retry)
+	assert.Nil(t, err)
+}
+
+func TestLimiterRetryPolicy(t *testing.T) {
+	l := NewLimiter(Limits{})
+	assert.Equal(t, RetryPolicy{}, l.RetryPolicy())
+
+	// The Retry-After delay is capped to the MaxBackoff
+	policy := RetryPolicy{MaxBackoff: 10 * time.Millisecond, RetryableStatusCodes: []int{http.StatusTooManyRequests}}
+	l.SetRetryPolicy(policy)
+	assert.Equal(t, policy, l.RetryPolicy())
+	l.RetryAfter(time.Hour)
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	release, err := l.Acquire(ctx)
+	require.Nil(t, err)
+	release()
+
+	// The retry config follows the policy and observes the responses
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"1"}}}
+	retryConfig := l.RetryConfig(kncloudevents.NoRetries())
+	retry, err := retryConfig.CheckRetry(context.TODO(), resp, nil)
+	assert.True(t, retry)
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Millisecond, retryConfig.Backoff(1, resp))
+	assert.True(t, time.Until(l.retryAfter) <= 10*time.Millisecond)
+
+	// A nil Limiter follows the default policy
+	var nilLimiter *Limiter
+	assert.Equal(t, RetryPolicy{}, nilLimiter.RetryPolicy())
+	retryConfig = nilLimiter.RetryConfig(kncloudevents.NoRetries())
+	retry, _ = retryConfig.CheckRetry(context.TODO(), resp, nil)
+	assert.False(t, retry)
+}
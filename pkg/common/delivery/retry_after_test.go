@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		header string
+		delay  time.Duration
+		ok     bool
+	}{
+		"missing":      {header: "", ok: false},
+		"seconds":      {header: "120", delay: 2 * time.Minute, ok: true},
+		"zero seconds": {header: "0", delay: 0, ok: true},
+		"negative":     {header: "-1", ok: false},
+		"http date":    {header: now.Add(30 * time.Second).Format(http.TimeFormat), delay: 30 * time.Second, ok: true},
+		"past date":    {header: now.Add(-time.Hour).Format(http.TimeFormat), delay: 0, ok: true},
+		"invalid":      {header: "soon", ok: false},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			delay, ok := ParseRetryAfter(tc.header, now)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.delay, delay)
+		})
+	}
+}
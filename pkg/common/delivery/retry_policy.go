@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"knative.dev/eventing/pkg/kncloudevents"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+// DefaultMaxBackoff caps the delay between two attempts when the RetryPolicy does not.
+const DefaultMaxBackoff = 5 * time.Minute
+
+// jitter returns a random duration in [0, d], replaced in the tests.
+var jitter = func(d time.Duration) time.Duration {
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// RetryPolicy tunes the retries of the delivery to a subscriber. The zero
+// value keeps the retryable status codes of the dispatcher and caps the
+// backoff to DefaultMaxBackoff.
+type RetryPolicy struct {
+	// MaxBackoff caps the delay between two attempts, including the ones asked for with Retry-After
+	MaxBackoff time.Duration
+	// RetryableStatusCodes are the status codes to retry, the ones of the dispatcher when empty
+	RetryableStatusCodes []int
+	// RetryTopics moves the retries to the retry topics instead of retrying in-line
+	RetryTopics bool
+}
+
+// RetryPolicyFromAnnotations returns the RetryPolicy set by the annotations of a Subscription.
+func RetryPolicyFromAnnotations(annotations map[string]string) (RetryPolicy, error) {
+	var policy RetryPolicy
+	if v, ok := annotations[v1beta1.KafkaSubscriptionMaxBackoffAnnotation]; ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return RetryPolicy{}, fmt.Errorf("invalid %s annotation %q: expected a positive duration", v1beta1.KafkaSubscriptionMaxBackoffAnnotation, v)
+		}
+		policy.MaxBackoff = d
+	}
+	if v, ok := annotations[v1beta1.KafkaSubscriptionRetryableStatusCodesAnnotation]; ok {
+		for _, s := range strings.Split(v, ",") {
+			code, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil || code < 100 || code > 599 {
+				return RetryPolicy{}, fmt.Errorf("invalid %s annotation %q: expected a comma separated list of HTTP status codes", v1beta1.KafkaSubscriptionRetryableStatusCodesAnnotation, v)
+			}
+			policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, code)
+		}
+		sort.Ints(policy.RetryableStatusCodes)
+	}
+	if v, ok := annotations[v1beta1.KafkaSubscriptionRetryTopicsAnnotation]; ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return RetryPolicy{}, fmt.Errorf("invalid %s annotation %q: expected true or false", v1beta1.KafkaSubscriptionRetryTopicsAnnotation, v)
+		}
+		policy.RetryTopics = b
+	}
+	return policy, nil
+}
+
+// maxBackoff returns MaxBackoff, or DefaultMaxBackoff when unset.
+func (p RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff > 0 {
+		return p.MaxBackoff
+	}
+	return DefaultMaxBackoff
+}
+
+// Apply returns config changed to follow the policy. When RetryableStatusCodes
+// is set, only these status codes and the failures to get a response are
+// retried. The delay between two attempts is taken at random up to the backoff
+// of config (full jitter), unless the subscriber asked for a longer one with
+// Retry-After, and never exceeds the MaxBackoff.
+func (p RetryPolicy) Apply(config kncloudevents.RetryConfig) kncloudevents.RetryConfig {
+	if len(p.RetryableStatusCodes) > 0 {
+		codes := make(map[int]bool, len(p.RetryableStatusCodes))
+		for _, code := range p.RetryableStatusCodes {
+			codes[code] = true
+		}
+		config.CheckRetry = func(_ context.Context, resp *http.Response, err error) (bool, error) {
+			if resp == nil || err != nil {
+				return true, nil
+			}
+			return codes[resp.StatusCode], nil
+		}
+	}
+
+	backoff := config.Backoff
+	maxBackoff := p.maxBackoff()
+	config.Backoff = func(attemptNum int, resp *http.Response) time.Duration {
+		var delay time.Duration
+		if backoff != nil {
+			delay = backoff(attemptNum, resp)
+		}
+		// The exponential backoff overflows after enough attempts
+		if delay < 0 || delay > maxBackoff {
+			delay = maxBackoff
+		}
+		delay = jitter(delay)
+
+		if resp != nil {
+			if retryAfter, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok && retryAfter > delay {
+				delay = retryAfter
+			}
+		}
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+		return delay
+	}
+	return config
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing/pkg/kncloudevents"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+func TestRetryPolicyFromAnnotations(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		want        RetryPolicy
+		wantErr     bool
+	}{
+		"no annotations": {},
+		"max backoff": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionMaxBackoffAnnotation: "30s"},
+			want:        RetryPolicy{MaxBackoff: 30 * time.Second},
+		},
+		"invalid max backoff": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionMaxBackoffAnnotation: "soon"},
+			wantErr:     true,
+		},
+		"negative max backoff": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionMaxBackoffAnnotation: "-1s"},
+			wantErr:     true,
+		},
+		"retryable status codes": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionRetryableStatusCodesAnnotation: "503, 429"},
+			want:        RetryPolicy{RetryableStatusCodes: []int{429, 503}},
+		},
+		"retry topics": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionRetryTopicsAnnotation: "true"},
+			want:        RetryPolicy{RetryTopics: true},
+		},
+		"invalid retry topics": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionRetryTopicsAnnotation: "yes please"},
+			wantErr:     true,
+		},
+		"invalid retryable status codes": {
+			annotations: map[string]string{v1beta1.KafkaSubscriptionRetryableStatusCodesAnnotation: "429,600"},
+			wantErr:     true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got, err := RetryPolicyFromAnnotations(tc.annotations)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRetryPolicyCheckRetry(t *testing.T) {
+	defaultCheck := func(context.Context, *http.Response, error) (bool, error) {
+		return true, nil
+	}
+	config := kncloudevents.RetryConfig{RetryMax: 3, CheckRetry: defaultCheck}
+
+	// The dispatcher's CheckRetry is kept without retryable status codes
+	retry, _ := RetryPolicy{}.Apply(config).CheckRetry(context.TODO(), &http.Response{StatusCode: http.StatusBadRequest}, nil)
+	assert.True(t, retry)
+
+	check := RetryPolicy{RetryableStatusCodes: []int{http.StatusTooManyRequests}}.Apply(config).CheckRetry
+	testCases := map[string]struct {
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		"retryable status code": {resp: &http.Response{StatusCode: http.StatusTooManyRequests}, want: true},
+		"other status code":     {resp: &http.Response{StatusCode: http.StatusBadRequest}, want: false},
+		"no response":           {err: errors.New("connection refused"), want: true},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			retry, err := check(context.TODO(), tc.resp, tc.err)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, retry)
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	// Take half of the delay instead of a random part of it
+	defer func(j func(time.Duration) time.Duration) { jitter = j }(jitter)
+	jitter = func(d time.Duration) time.Duration { return d / 2 }
+
+	config := kncloudevents.RetryConfig{
+		Backoff: func(attemptNum int, _ *http.Response) time.Duration {
+			return time.Duration(attemptNum) * time.Minute
+		},
+	}
+	backoff := RetryPolicy{MaxBackoff: 2 * time.Minute}.Apply(config).Backoff
+
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{value}}}
+	}
+	testCases := map[string]struct {
+		attemptNum int
+		resp       *http.Response
+		want       time.Duration
+	}{
+		"jittered":                 {attemptNum: 1, want: 30 * time.Second},
+		"capped before the jitter": {attemptNum: 10, want: time.Minute},
+		"retry after":              {attemptNum: 1, resp: retryAfter("45"), want: 45 * time.Second},
+		"shorter retry after":      {attemptNum: 1, resp: retryAfter("10"), want: 30 * time.Second},
+		"capped retry after":       {attemptNum: 1, resp: retryAfter("3600"), want: 2 * time.Minute},
+		"retry after as a date":    {attemptNum: 1, resp: retryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)), want: 2 * time.Minute},
+		"invalid retry after":      {attemptNum: 1, resp: retryAfter("later"), want: 30 * time.Second},
+		"overflowing backoff":      {attemptNum: -1, want: time.Minute},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			assert.Equal(t, tc.want, backoff(tc.attemptNum, tc.resp))
+		})
+	}
+
+	// Without a ceiling the default one applies
+	assert.Equal(t, DefaultMaxBackoff, RetryPolicy{}.Apply(kncloudevents.NoRetries()).Backoff(1, retryAfter("3600")))
+}
+
+func TestJitter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), jitter(0))
+	for i := 0; i < 100; i++ {
+		d := jitter(time.Second)
+		assert.True(t, d >= 0 && d <= time.Second, d)
+	}
+}
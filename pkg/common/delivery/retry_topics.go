@@ -0,0 +1,270 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const (
+	// RetrySubscriptionHeader holds the UID of the subscription an event of a retry topic is retried for.
+	RetrySubscriptionHeader = "kafkachannel-retry-subscription"
+	// RetryTierHeader holds the tier of the retry topic an event was produced to.
+	RetryTierHeader = "kafkachannel-retry-tier"
+	// RetryNotBeforeHeader holds the time before which an event of a retry topic is not retried, in RFC 3339.
+	RetryNotBeforeHeader = "kafkachannel-retry-not-before"
+)
+
+// RetryTopicName returns the name of the retry topic of topic for the given tier, starting at 1.
+func RetryTopicName(topic string, tier int) string {
+	return fmt.Sprintf("%s.retry.%d", topic, tier)
+}
+
+// RetryTopicNames returns the names of the retry topics of topic up to the given tier.
+func RetryTopicNames(topic string, tiers int) []string {
+	names := make([]string, 0, tiers)
+	for tier := 1; tier <= tiers; tier++ {
+		names = append(names, RetryTopicName(topic, tier))
+	}
+	return names
+}
+
+// EnsureRetryTopics creates the missing retry topics of topic up to the given
+// tier, with the partitions, replication factor and configuration of topic.
+func EnsureRetryTopics(admin sarama.ClusterAdmin, topic string, tiers int) error {
+	topics, err := admin.ListTopics()
+	if err != nil {
+		return fmt.Errorf("failed to list the topics: %w", err)
+	}
+	detail, ok := topics[topic]
+	if !ok {
+		return fmt.Errorf("topic %s does not exist", topic)
+	}
+	detail.ReplicaAssignment = nil
+
+	for _, name := range RetryTopicNames(topic, tiers) {
+		if _, ok := topics[name]; ok {
+			continue
+		}
+		retryDetail := detail
+		if err := admin.CreateTopic(name, &retryDetail, false); err != nil {
+			var topicErr *sarama.TopicError
+			if errors.As(err, &topicErr) && topicErr.Err == sarama.ErrTopicAlreadyExists {
+				continue
+			}
+			return fmt.Errorf("failed to create the retry topic %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// RetryAttempt describes an event of a retry topic.
+type RetryAttempt struct {
+	// Subscription is the UID of the subscription the event is retried for
+	Subscription types.UID
+	// Tier is the tier of the retry topic
+	Tier int
+	// NotBefore is the time before which the event is not retried
+	NotBefore time.Time
+}
+
+// RetryAttemptOf returns the RetryAttempt described by the headers of msg,
+// and false for the events of the channel topic.
+func RetryAttemptOf(msg *sarama.ConsumerMessage) (RetryAttempt, bool) {
+	var attempt RetryAttempt
+	var found bool
+	for _, header := range msg.Headers {
+		if header == nil {
+			continue
+		}
+		switch string(header.Key) {
+		case RetrySubscriptionHeader:
+			attempt.Subscription = types.UID(header.Value)
+			found = true
+		case RetryTierHeader:
+			attempt.Tier, _ = strconv.Atoi(string(header.Value))
+		case RetryNotBeforeHeader:
+			attempt.NotBefore, _ = time.Parse(time.RFC3339Nano, string(header.Value))
+		}
+	}
+	return attempt, found
+}
+
+// Destinations are where the events of a subscription are delivered.
+type Destinations struct {
+	Subscriber *url.URL
+	Reply      *url.URL
+	DeadLetter *url.URL
+}
+
+// RetryTopics moves the retries of the delivery to a subscriber to the retry
+// topics of the channel, so that the partition goes on with the next events
+// while an event is retried. A failed event is produced to the retry topic of
+// the next tier, one tier per retry of the RetryConfig, along with the time it
+// is to be retried at. The consumer of the retry topics delivers it again once
+// that time has come, and the dead letter sink receives it after the last tier.
+type RetryTopics struct {
+	Logger *zap.Logger
+	// Topic is the topic of the channel, which names the retry topics
+	Topic string
+	// Subscription is the UID of the subscription whose events are retried
+	Subscription types.UID
+	// Producer produces the events to the retry topics
+	Producer sarama.SyncProducer
+
+	// ready is 1 once the retry topics are consumed
+	ready int32
+}
+
+// SetReady marks the retry topics as consumed, or not. The events are only
+// produced to them once ready.
+func (r *RetryTopics) SetReady(ready bool) {
+	var v int32
+	if ready {
+		v = 1
+	}
+	atomic.StoreInt32(&r.ready, v)
+}
+
+// IsReady returns true when the retry topics are consumed. A nil RetryTopics is never ready.
+func (r *RetryTopics) IsReady() bool {
+	return r != nil && atomic.LoadInt32(&r.ready) == 1
+}
+
+// Wait blocks until msg is to be delivered. It returns false when msg is
+// retried for another subscription and must be skipped, and an error if ctx
+// is done first. A nil RetryTopics never waits.
+func (r *RetryTopics) Wait(ctx context.Context, msg *sarama.ConsumerMessage) (bool, error) {
+	attempt, ok := RetryAttemptOf(msg)
+	if r == nil || !ok {
+		return true, nil
+	}
+	if attempt.Subscription != r.Subscription {
+		return false, nil
+	}
+	if wait := time.Until(attempt.NotBefore); wait > 0 {
+		if err := sleep(ctx, wait); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Deliver delivers message, read from msg, once. When it fails it is produced
+// to the retry topic of the next tier, or delivered to the dead letter sink
+// after the last tier or when the subscriber's answer is not to be retried.
+// When producing fails, the remaining retries happen in-line.
+func (r *RetryTopics) Deliver(ctx context.Context, dispatcher channel.MessageDispatcher, message binding.Message, msg *sarama.ConsumerMessage, destinations Destinations, retryConfig kncloudevents.RetryConfig) error {
+	attempt, _ := RetryAttemptOf(msg)
+	if attempt.Tier >= retryConfig.RetryMax {
+		lastAttempt := retryConfig
+		lastAttempt.RetryMax = 0
+		return dispatcher.DispatchMessageWithRetries(ctx, message, nil, destinations.Subscriber, destinations.Reply, destinations.DeadLetter, &lastAttempt)
+	}
+
+	// Keep the answer of the subscriber, which may ask for a delay with Retry-After
+	var response *http.Response
+	var retryable bool
+	singleAttempt := retryConfig
+	singleAttempt.RetryMax = 0
+	singleAttempt.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		response = resp
+		if retryConfig.CheckRetry == nil {
+			retryable = true
+			return false, nil
+		}
+		retry, checkErr := retryConfig.CheckRetry(ctx, resp, err)
+		retryable = retry
+		return retry, checkErr
+	}
+	err := dispatcher.DispatchMessageWithRetries(ctx, message, nil, destinations.Subscriber, destinations.Reply, nil, &singleAttempt)
+	if err == nil {
+		return nil
+	}
+	if !retryable {
+		return r.deadLetter(ctx, dispatcher, message, destinations, singleAttempt, err)
+	}
+
+	tier := attempt.Tier + 1
+	var delay time.Duration
+	if retryConfig.Backoff != nil {
+		delay = retryConfig.Backoff(attempt.Tier, response)
+	}
+	if _, _, produceErr := r.Producer.SendMessage(r.retryMessage(msg, tier, time.Now().Add(delay))); produceErr != nil {
+		r.Logger.Warn("Failed to produce the event to the retry topic, retrying in-line",
+			zap.String("topic", RetryTopicName(r.Topic, tier)), zap.Error(produceErr))
+		remaining := retryConfig
+		remaining.RetryMax = retryConfig.RetryMax - tier
+		return dispatcher.DispatchMessageWithRetries(ctx, message, nil, destinations.Subscriber, destinations.Reply, destinations.DeadLetter, &remaining)
+	}
+	return nil
+}
+
+// deadLetter delivers message to the dead letter sink only, after the subscriber failed with err.
+func (r *RetryTopics) deadLetter(ctx context.Context, dispatcher channel.MessageDispatcher, message binding.Message, destinations Destinations, retryConfig kncloudevents.RetryConfig, err error) error {
+	if destinations.DeadLetter == nil {
+		return err
+	}
+	if deadLetterErr := dispatcher.DispatchMessageWithRetries(ctx, message, nil, destinations.DeadLetter, nil, nil, &retryConfig); deadLetterErr != nil {
+		return fmt.Errorf("unable to complete request to either the subscriber (%v) or the dead letter sink (%v)", err, deadLetterErr)
+	}
+	return nil
+}
+
+// retryMessage returns msg to produce to the retry topic of the given tier.
+func (r *RetryTopics) retryMessage(msg *sarama.ConsumerMessage, tier int, notBefore time.Time) *sarama.ProducerMessage {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers)+3)
+	for _, header := range msg.Headers {
+		if header == nil {
+			continue
+		}
+		switch string(header.Key) {
+		case RetrySubscriptionHeader, RetryTierHeader, RetryNotBeforeHeader:
+		default:
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(RetrySubscriptionHeader), Value: []byte(r.Subscription)},
+		sarama.RecordHeader{Key: []byte(RetryTierHeader), Value: []byte(strconv.Itoa(tier))},
+		sarama.RecordHeader{Key: []byte(RetryNotBeforeHeader), Value: []byte(notBefore.UTC().Format(time.RFC3339Nano))},
+	)
+
+	producerMessage := &sarama.ProducerMessage{
+		Topic:   RetryTopicName(r.Topic, tier),
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	}
+	if msg.Key != nil {
+		producerMessage.Key = sarama.ByteEncoder(msg.Key)
+	}
+	return producerMessage
+}
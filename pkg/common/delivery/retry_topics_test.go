@@ -0,0 +1,276 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// mockClusterAdmin lists and creates topics in memory
+type mockClusterAdmin struct {
+	sarama.ClusterAdmin
+	topics    map[string]sarama.TopicDetail
+	createErr error
+}
+
+func (m *mockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	return m.topics, nil
+}
+
+func (m *mockClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, _ bool) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	m.topics[topic] = *detail
+	return nil
+}
+
+// mockSyncProducer records the produced messages
+type mockSyncProducer struct {
+	sarama.SyncProducer
+	messages []*sarama.ProducerMessage
+	err      error
+}
+
+func (m *mockSyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	if m.err != nil {
+		return 0, 0, m.err
+	}
+	m.messages = append(m.messages, msg)
+	return 0, int64(len(m.messages)), nil
+}
+
+// mockMessageDispatcher answers with the status code of each destination
+type mockMessageDispatcher struct {
+	statusCodes map[string]int
+	retryAfter  string
+	// dispatched records the destinations and the retries of each dispatch
+	dispatched []string
+}
+
+func (m *mockMessageDispatcher) DispatchMessage(ctx context.Context, message binding.Message, headers http.Header, destination *url.URL, reply *url.URL, deadLetter *url.URL) error {
+	return m.DispatchMessageWithRetries(ctx, message, headers, destination, reply, deadLetter, nil)
+}
+
+func (m *mockMessageDispatcher) DispatchMessageWithRetries(ctx context.Context, _ binding.Message, _ http.Header, destination *url.URL, _ *url.URL, deadLetter *url.URL, config *kncloudevents.RetryConfig) error {
+	m.dispatched = append(m.dispatched, fmt.Sprintf("%s retries=%d deadLetter=%t", destination, config.RetryMax, deadLetter != nil))
+	statusCode := m.statusCodes[destination.String()]
+	if statusCode < 300 {
+		return nil
+	}
+	if config.CheckRetry != nil {
+		_, _ = config.CheckRetry(ctx, &http.Response{StatusCode: statusCode, Header: http.Header{"Retry-After": []string{m.retryAfter}}}, nil)
+	}
+	if deadLetter != nil && m.statusCodes[deadLetter.String()] < 300 {
+		return nil
+	}
+	return fmt.Errorf("unable to complete request to %s", destination)
+}
+
+func TestRetryTopicNames(t *testing.T) {
+	assert.Equal(t, "knative-messaging-kafka.default.channel.retry.2", RetryTopicName("knative-messaging-kafka.default.channel", 2))
+	assert.Equal(t, []string{"topic.retry.1", "topic.retry.2"}, RetryTopicNames("topic", 2))
+	assert.Empty(t, RetryTopicNames("topic", 0))
+}
+
+func TestEnsureRetryTopics(t *testing.T) {
+	admin := &mockClusterAdmin{topics: map[string]sarama.TopicDetail{
+		"topic":         {NumPartitions: 4, ReplicationFactor: 3, ReplicaAssignment: map[int32][]int32{0: {1}}},
+		"topic.retry.1": {NumPartitions: 1, ReplicationFactor: 1},
+	}}
+	require.Nil(t, EnsureRetryTopics(admin, "topic", 3))
+	assert.Equal(t, sarama.TopicDetail{NumPartitions: 1, ReplicationFactor: 1}, admin.topics["topic.retry.1"])
+	assert.Equal(t, sarama.TopicDetail{NumPartitions: 4, ReplicationFactor: 3}, admin.topics["topic.retry.2"])
+	assert.Equal(t, sarama.TopicDetail{NumPartitions: 4, ReplicationFactor: 3}, admin.topics["topic.retry.3"])
+
+	// Created in the meantime
+	admin.createErr = &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}
+	assert.Nil(t, EnsureRetryTopics(admin, "topic", 4))
+
+	admin.createErr = &sarama.TopicError{Err: sarama.ErrInvalidReplicationFactor}
+	assert.NotNil(t, EnsureRetryTopics(admin, "topic", 4))
+	assert.NotNil(t, EnsureRetryTopics(admin, "missing", 1))
+}
+
+func TestRetryTopicsWait(t *testing.T) {
+	r := &RetryTopics{Topic: "topic", Subscription: "sub-1"}
+
+	deliver, err := r.Wait(context.TODO(), &sarama.ConsumerMessage{})
+	assert.True(t, deliver)
+	assert.Nil(t, err)
+
+	// Retried for another subscription
+	other := &RetryTopics{Subscription: "sub-2"}
+	msg := &sarama.ConsumerMessage{Headers: other.retryMessageHeaders(1, time.Now())}
+	deliver, err = r.Wait(context.TODO(), msg)
+	assert.False(t, deliver)
+	assert.Nil(t, err)
+
+	// Held until the retry time
+	msg = &sarama.ConsumerMessage{Headers: r.retryMessageHeaders(1, time.Now().Add(time.Hour))}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, err = r.Wait(ctx, msg)
+	assert.NotNil(t, err)
+
+	msg = &sarama.ConsumerMessage{Headers: r.retryMessageHeaders(1, time.Now().Add(10*time.Millisecond))}
+	deliver, err = r.Wait(context.TODO(), msg)
+	assert.True(t, deliver)
+	assert.Nil(t, err)
+
+	var nilRetryTopics *RetryTopics
+	deliver, err = nilRetryTopics.Wait(context.TODO(), msg)
+	assert.True(t, deliver)
+	assert.Nil(t, err)
+	assert.False(t, nilRetryTopics.IsReady())
+}
+
+func TestRetryTopicsDeliver(t *testing.T) {
+	subscriber, _ := url.Parse("http://subscriber")
+	deadLetter, _ := url.Parse("http://dead-letter")
+	destinations := Destinations{Subscriber: subscriber, DeadLetter: deadLetter}
+	retryConfig := kncloudevents.RetryConfig{
+		RetryMax: 2,
+		CheckRetry: func(_ context.Context, resp *http.Response, _ error) (bool, error) {
+			return resp.StatusCode >= 500, nil
+		},
+		Backoff: RetryPolicy{}.Apply(kncloudevents.RetryConfig{
+			Backoff: func(attemptNum int, _ *http.Response) time.Duration {
+				return 0
+			},
+		}).Backoff,
+	}
+
+	testCases := map[string]struct {
+		tier           int
+		statusCode     int
+		retryAfter     string
+		produceErr     error
+		wantDispatched []string
+		wantTier       int
+		wantDelay      time.Duration
+		wantErr        bool
+	}{
+		"delivered": {
+			statusCode:     http.StatusOK,
+			wantDispatched: []string{"http://subscriber retries=0 deadLetter=false"},
+		},
+		"retried": {
+			statusCode:     http.StatusServiceUnavailable,
+			wantDispatched: []string{"http://subscriber retries=0 deadLetter=false"},
+			wantTier:       1,
+		},
+		"retried after the delay asked for": {
+			tier:           1,
+			statusCode:     http.StatusServiceUnavailable,
+			retryAfter:     "120",
+			wantDispatched: []string{"http://subscriber retries=0 deadLetter=false"},
+			wantTier:       2,
+			wantDelay:      2 * time.Minute,
+		},
+		"last tier": {
+			tier:           2,
+			statusCode:     http.StatusServiceUnavailable,
+			wantDispatched: []string{"http://subscriber retries=0 deadLetter=true"},
+		},
+		"not retryable": {
+			statusCode: http.StatusBadRequest,
+			wantDispatched: []string{
+				"http://subscriber retries=0 deadLetter=false",
+				"http://dead-letter retries=0 deadLetter=false",
+			},
+		},
+		"producing failed": {
+			statusCode: http.StatusServiceUnavailable,
+			produceErr: errors.New("broker unavailable"),
+			wantDispatched: []string{
+				"http://subscriber retries=0 deadLetter=false",
+				"http://subscriber retries=1 deadLetter=true",
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			producer := &mockSyncProducer{err: tc.produceErr}
+			r := &RetryTopics{Logger: zap.NewNop(), Topic: "topic", Subscription: "sub-1", Producer: producer}
+			dispatcher := &mockMessageDispatcher{
+				statusCodes: map[string]int{"http://subscriber": tc.statusCode, "http://dead-letter": http.StatusOK},
+				retryAfter:  tc.retryAfter,
+			}
+
+			msg := &sarama.ConsumerMessage{Topic: "topic", Key: []byte("key"), Value: []byte("value"), Headers: []*sarama.RecordHeader{
+				{Key: []byte("ce_id"), Value: []byte("1")},
+			}}
+			if tc.tier > 0 {
+				msg.Headers = append(msg.Headers, r.retryMessageHeaders(tc.tier, time.Now())...)
+			}
+			message := protocolkafka.NewMessageFromConsumerMessage(msg)
+
+			start := time.Now()
+			err := r.Deliver(context.TODO(), dispatcher, message, msg, destinations, retryConfig)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.Equal(t, tc.wantDispatched, dispatcher.dispatched)
+
+			if tc.wantTier == 0 {
+				assert.Empty(t, producer.messages)
+				return
+			}
+			require.Len(t, producer.messages, 1)
+			produced := producer.messages[0]
+			assert.Equal(t, RetryTopicName("topic", tc.wantTier), produced.Topic)
+			assert.Equal(t, sarama.ByteEncoder("key"), produced.Key)
+			assert.Equal(t, sarama.ByteEncoder("value"), produced.Value)
+
+			// The retry headers replace the previous ones
+			headers := make([]*sarama.RecordHeader, 0, len(produced.Headers))
+			for i := range produced.Headers {
+				headers = append(headers, &produced.Headers[i])
+			}
+			assert.Len(t, headers, 4)
+			attempt, ok := RetryAttemptOf(&sarama.ConsumerMessage{Headers: headers})
+			require.True(t, ok)
+			assert.Equal(t, r.Subscription, attempt.Subscription)
+			assert.Equal(t, tc.wantTier, attempt.Tier)
+			assert.WithinDuration(t, start.Add(tc.wantDelay), attempt.NotBefore, time.Second)
+		})
+	}
+}
+
+// retryMessageHeaders returns the headers of a message retried by r at the given tier.
+func (r *RetryTopics) retryMessageHeaders(tier int, notBefore time.Time) []*sarama.RecordHeader {
+	produced := r.retryMessage(&sarama.ConsumerMessage{}, tier, notBefore)
+	headers := make([]*sarama.RecordHeader, 0, len(produced.Headers))
+	for i := range produced.Headers {
+		headers = append(headers, &produced.Headers[i])
+	}
+	return headers
+}
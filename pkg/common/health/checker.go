@@ -0,0 +1,364 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health runs the health checks of the channel receivers and dispatchers, such as their connectivity to
+// the Kafka brokers, and aggregates their results into the liveness and readiness of the pods.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// LivenessPath is the endpoint of the liveness probe.
+	LivenessPath = "/healthz"
+	// ReadinessPath is the endpoint of the readiness probe.
+	ReadinessPath = "/healthy"
+	// DetailsPath is the endpoint reporting the results of the health checks in JSON.
+	DetailsPath = "/health"
+
+	// DefaultInterval is the default interval between two runs of a health check.
+	DefaultInterval = 10 * time.Second
+	// DefaultTimeout is the default time a health check is allowed to run for before failing.
+	DefaultTimeout = 5 * time.Second
+)
+
+// errNotChecked is the error of the checks which have not run yet, they are unhealthy until then.
+var errNotChecked = errors.New("not checked yet")
+
+// Kind tells whether a failing health check makes the pod not alive, which restarts it, or only not ready.
+type Kind string
+
+const (
+	// Liveness checks fail the liveness and the readiness probes.
+	Liveness Kind = "liveness"
+	// Readiness checks fail the readiness probe only.
+	Readiness Kind = "readiness"
+)
+
+// CheckFunc checks the health of a component, returning nil when it is healthy. It must return once the context
+// is done.
+type CheckFunc func(ctx context.Context) error
+
+// Result is the latest result of a health check.
+type Result struct {
+	Name    string `json:"name"`
+	Kind    Kind   `json:"kind"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+	// LastChecked is when the check last completed, nil until it first does.
+	LastChecked *time.Time `json:"lastChecked,omitempty"`
+	// Since is when the check became healthy or unhealthy.
+	Since time.Time `json:"since"`
+}
+
+// Report aggregates the results of the health checks.
+type Report struct {
+	Alive  bool     `json:"alive"`
+	Ready  bool     `json:"ready"`
+	Checks []Result `json:"checks"`
+}
+
+// Checker periodically runs named health checks in the background. A check still running when it is due again is
+// not run twice, and a check running for longer than the timeout fails. The checks are unhealthy until they first
+// complete, so that a pod is only ready once it has checked its dependencies. A nil Checker has no checks.
+type Checker struct {
+	logger   *zap.Logger
+	interval time.Duration
+	timeout  time.Duration
+	now      func() time.Time
+
+	lock    sync.Mutex
+	checks  map[string]*check
+	ctx     context.Context // set while started
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+// check is a registered health check and its latest result.
+type check struct {
+	fn      CheckFunc
+	running bool
+	result  Result
+}
+
+// NewChecker returns a Checker running each check every interval once started, failing them after the timeout.
+func NewChecker(interval time.Duration, timeout time.Duration) *Checker {
+	return &Checker{
+		logger:   zap.NewNop(),
+		interval: interval,
+		timeout:  timeout,
+		now:      time.Now,
+		checks:   make(map[string]*check),
+	}
+}
+
+// AddCheck registers the health check with the name, replacing any check having the same name. The check is
+// unhealthy until it first completes, it is run right away if the Checker is started.
+func (c *Checker) AddCheck(name string, kind Kind, fn CheckFunc) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	chk := &check{
+		fn:     fn,
+		result: Result{Name: name, Kind: kind, Error: errNotChecked.Error(), Since: c.now()},
+	}
+	c.checks[name] = chk
+	if c.ctx != nil {
+		go c.run(c.ctx, chk)
+	}
+}
+
+// RemoveCheck unregisters the health check with the name, if any.
+func (c *Checker) RemoveCheck(name string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.checks, name)
+}
+
+// Start runs the health checks right away, and then every interval until Stop is called, logging the changes of
+// their results.
+func (c *Checker) Start(logger *zap.Logger) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.ctx != nil {
+		return
+	}
+	c.logger = logger
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.stopped = make(chan struct{})
+	go c.loop(c.ctx, c.stopped)
+}
+
+// Stop stops running the health checks, canceling the running ones.
+func (c *Checker) Stop() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	if c.ctx == nil {
+		c.lock.Unlock()
+		return
+	}
+	c.cancel()
+	stopped := c.stopped
+	c.ctx, c.cancel, c.stopped = nil, nil, nil
+	c.lock.Unlock()
+	<-stopped
+}
+
+// loop runs all the health checks every interval until the context is done.
+func (c *Checker) loop(ctx context.Context, stopped chan struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.lock.Lock()
+		for _, chk := range c.checks {
+			go c.run(ctx, chk)
+		}
+		c.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run runs the health check unless it is already running, and records its result.
+func (c *Checker) run(ctx context.Context, chk *check) {
+	c.lock.Lock()
+	if chk.running {
+		c.lock.Unlock()
+		return
+	}
+	chk.running = true
+	c.lock.Unlock()
+
+	checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	done := make(chan error, 1)
+	go func() {
+		done <- chk.fn(checkCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+		cancel()
+		c.finish(chk)
+	case <-checkCtx.Done():
+		// the check is not run again until it returns
+		go c.drain(chk, done, cancel)
+		if ctx.Err() != nil {
+			return // stopped, the result is left as is
+		}
+		err = fmt.Errorf("timed out after %v", c.timeout)
+	}
+	c.record(chk, err)
+}
+
+// drain waits for the health check to return before allowing it to run again.
+func (c *Checker) drain(chk *check, done chan error, cancel context.CancelFunc) {
+	<-done
+	cancel()
+	c.finish(chk)
+}
+
+// finish allows the health check to run again.
+func (c *Checker) finish(chk *check) {
+	c.lock.Lock()
+	chk.running = false
+	c.lock.Unlock()
+}
+
+// record records the result of the health check, logging its changes.
+func (c *Checker) record(chk *check, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.checks[chk.result.Name] != chk {
+		return // removed or replaced meanwhile
+	}
+
+	now := c.now()
+	result := &chk.result
+	healthy := err == nil
+	if healthy != result.Healthy || result.LastChecked == nil {
+		result.Since = now
+		if healthy {
+			c.logger.Info("Health check passing", zap.String("check", result.Name))
+		} else {
+			c.logger.Warn("Health check failing", zap.String("check", result.Name), zap.Error(err))
+		}
+	}
+	result.Healthy = healthy
+	result.Error = ""
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.LastChecked = &now
+}
+
+// Alive returns true if all the liveness checks are healthy.
+func (c *Checker) Alive() bool {
+	return c.Report().Alive
+}
+
+// Ready returns true if all the checks are healthy.
+func (c *Checker) Ready() bool {
+	return c.Report().Ready
+}
+
+// Report returns the latest results of the health checks, sorted by name.
+func (c *Checker) Report() Report {
+	report := Report{Alive: true, Ready: true, Checks: []Result{}}
+	if c == nil {
+		return report
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, chk := range c.checks {
+		result := chk.result
+		if !result.Healthy {
+			report.Ready = false
+			if result.Kind == Liveness {
+				report.Alive = false
+			}
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	sort.Slice(report.Checks, func(i, j int) bool { return report.Checks[i].Name < report.Checks[j].Name })
+	return report
+}
+
+// WriteReport writes the report in JSON to the response, with a 200 status even when unhealthy, the probes being
+// served by the other endpoints.
+func WriteReport(response nethttp.ResponseWriter, report Report) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(nethttp.StatusOK)
+	_ = json.NewEncoder(response).Encode(report)
+}
+
+// NewHandler returns a handler serving the liveness and the readiness probes of the checker, with a 200 status
+// when healthy and a 503 otherwise, and its report on the DetailsPath.
+func NewHandler(c *Checker) nethttp.Handler {
+	mux := nethttp.NewServeMux()
+	mux.HandleFunc(LivenessPath, probeHandler(func() bool { return c.Alive() }))
+	mux.HandleFunc(ReadinessPath, probeHandler(func() bool { return c.Ready() }))
+	mux.HandleFunc(DetailsPath, func(response nethttp.ResponseWriter, request *nethttp.Request) {
+		if request.Method != nethttp.MethodGet {
+			response.WriteHeader(nethttp.StatusMethodNotAllowed)
+			return
+		}
+		WriteReport(response, c.Report())
+	})
+	return mux
+}
+
+func probeHandler(healthy func() bool) nethttp.HandlerFunc {
+	return func(response nethttp.ResponseWriter, request *nethttp.Request) {
+		if request.Method != nethttp.MethodGet {
+			response.WriteHeader(nethttp.StatusMethodNotAllowed)
+			return
+		}
+		if healthy() {
+			response.WriteHeader(nethttp.StatusOK)
+		} else {
+			response.WriteHeader(nethttp.StatusServiceUnavailable)
+		}
+	}
+}
+
+// ListenAndServe serves the probes of the checker on the port until the context is done.
+func (c *Checker) ListenAndServe(ctx context.Context, port int) error {
+	return ListenAndServe(ctx, port, NewHandler(c))
+}
+
+// ListenAndServe serves the handler on the port until the context is done, so that the probes can be served along
+// with other endpoints.
+func ListenAndServe(ctx context.Context, port int, handler nethttp.Handler) error {
+	server := &nethttp.Server{Addr: fmt.Sprintf(":%d", port), Handler: handler}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
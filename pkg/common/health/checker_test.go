@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// switchCheck is a health check whose result is switched by the tests.
+type switchCheck struct {
+	lock sync.Mutex
+	err  error
+	runs int32
+}
+
+func (s *switchCheck) set(err error) {
+	s.lock.Lock()
+	s.err = err
+	s.lock.Unlock()
+}
+
+func (s *switchCheck) check(context.Context) error {
+	atomic.AddInt32(&s.runs, 1)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.err
+}
+
+func TestChecker(t *testing.T) {
+	checker := NewChecker(10*time.Millisecond, time.Second)
+	live := &switchCheck{}
+	ready := &switchCheck{}
+	checker.AddCheck("live", Liveness, live.check)
+	checker.AddCheck("ready", Readiness, ready.check)
+
+	// pending until checked
+	assert.False(t, checker.Alive())
+	assert.False(t, checker.Ready())
+	report := checker.Report()
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, "live", report.Checks[0].Name)
+	assert.Equal(t, errNotChecked.Error(), report.Checks[0].Error)
+	assert.Nil(t, report.Checks[0].LastChecked)
+
+	checker.Start(zap.NewNop())
+	defer checker.Stop()
+	require.Eventually(t, checker.Ready, time.Second, time.Millisecond)
+	assert.True(t, checker.Alive())
+
+	ready.set(errors.New("unreachable"))
+	require.Eventually(t, func() bool { return !checker.Ready() }, time.Second, time.Millisecond)
+	assert.True(t, checker.Alive())
+	report = checker.Report()
+	assert.Equal(t, "unreachable", report.Checks[1].Error)
+	assert.NotNil(t, report.Checks[1].LastChecked)
+
+	live.set(errors.New("stuck"))
+	require.Eventually(t, func() bool { return !checker.Alive() }, time.Second, time.Millisecond)
+
+	live.set(nil)
+	ready.set(nil)
+	require.Eventually(t, checker.Ready, time.Second, time.Millisecond)
+
+	// the removed checks are no longer run nor reported
+	ready.set(errors.New("unreachable"))
+	checker.RemoveCheck("ready")
+	runs := atomic.LoadInt32(&ready.runs)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, runs, atomic.LoadInt32(&ready.runs))
+	assert.True(t, checker.Ready())
+	assert.Len(t, checker.Report().Checks, 1)
+}
+
+func TestCheckerAddCheckWhileStarted(t *testing.T) {
+	checker := NewChecker(time.Hour, time.Second)
+	checker.Start(zap.NewNop())
+	defer checker.Stop()
+
+	checker.AddCheck("added", Readiness, func(context.Context) error { return nil })
+	require.Eventually(t, checker.Ready, time.Second, time.Millisecond)
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	checker := NewChecker(10*time.Millisecond, 20*time.Millisecond)
+	release := make(chan struct{})
+	var runs int32
+	checker.AddCheck("slow", Readiness, func(context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		<-release // ignoring the context
+		return nil
+	})
+	checker.Start(zap.NewNop())
+	defer checker.Stop()
+
+	require.Eventually(t, func() bool { return checker.Report().Checks[0].LastChecked != nil }, time.Second, time.Millisecond)
+	assert.False(t, checker.Ready())
+	assert.Equal(t, "timed out after 20ms", checker.Report().Checks[0].Error)
+
+	// not run again while still running
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+
+	close(release)
+	require.Eventually(t, checker.Ready, time.Second, time.Millisecond)
+}
+
+func TestNilChecker(t *testing.T) {
+	var checker *Checker
+	checker.AddCheck("ignored", Liveness, func(context.Context) error { return errors.New("ignored") })
+	checker.RemoveCheck("ignored")
+	checker.Start(zap.NewNop())
+	checker.Stop()
+	assert.True(t, checker.Alive())
+	assert.True(t, checker.Ready())
+	assert.Empty(t, checker.Report().Checks)
+}
+
+func TestHandler(t *testing.T) {
+	checker := NewChecker(time.Hour, time.Second)
+	ready := &switchCheck{}
+	checker.AddCheck("ready", Readiness, ready.check)
+	handler := NewHandler(checker)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest(nethttp.MethodGet, path, nil))
+		return response
+	}
+
+	assert.Equal(t, nethttp.StatusOK, get(LivenessPath).Code)
+	assert.Equal(t, nethttp.StatusServiceUnavailable, get(ReadinessPath).Code)
+
+	checker.Start(zap.NewNop())
+	defer checker.Stop()
+	require.Eventually(t, checker.Ready, time.Second, time.Millisecond)
+	assert.Equal(t, nethttp.StatusOK, get(ReadinessPath).Code)
+
+	response := get(DetailsPath)
+	assert.Equal(t, nethttp.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	var report Report
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &report))
+	assert.True(t, report.Alive)
+	assert.True(t, report.Ready)
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, "ready", report.Checks[0].Name)
+	assert.Equal(t, Readiness, report.Checks[0].Kind)
+	assert.True(t, report.Checks[0].Healthy)
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(nethttp.MethodPost, ReadinessPath, nil))
+	assert.Equal(t, nethttp.StatusMethodNotAllowed, response.Code)
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+const (
+	// DefaultErrorRateWindow is the default window the error rates are computed over.
+	DefaultErrorRateWindow = time.Minute
+	// DefaultErrorRateThreshold is the default share of failed operations above which an error rate is unhealthy.
+	DefaultErrorRateThreshold = 0.5
+	// DefaultErrorRateMinCount is the default number of operations below which an error rate is always healthy.
+	DefaultErrorRateMinCount = 10
+	// DefaultSessionGracePeriod is the default time a consumer group may be without a session, while joining or
+	// rebalancing, before being unhealthy.
+	DefaultSessionGracePeriod = 2 * time.Minute
+
+	// errorRateBuckets is the number of buckets the window of an error rate slides by.
+	errorRateBuckets = 10
+)
+
+// newClient creates the clients the brokers are checked with, it is replaced in the tests.
+var newClient = sarama.NewClient
+
+// BrokerCheck checks the connectivity to the Kafka brokers by refreshing the metadata of the topics, or of the
+// whole cluster when there are none. Its client is kept between the checks, and recreated after a failure or an
+// update of the configuration. A nil BrokerCheck checks nothing.
+type BrokerCheck struct {
+	topics []string
+
+	lock    sync.Mutex
+	brokers []string
+	config  *sarama.Config
+	client  sarama.Client
+	stale   bool // the client uses a previous configuration
+}
+
+// NewBrokerCheck returns a BrokerCheck connecting to the brokers with the configuration.
+func NewBrokerCheck(brokers []string, config *sarama.Config, topics ...string) *BrokerCheck {
+	b := &BrokerCheck{topics: topics}
+	b.Update(brokers, config)
+	return b
+}
+
+// Update replaces the brokers and the configuration the next checks connect with, such as rotated credentials.
+func (b *BrokerCheck) Update(brokers []string, config *sarama.Config) {
+	if b == nil {
+		return
+	}
+	// only the checks refresh the metadata
+	checkConfig := *config
+	checkConfig.Metadata.Full = false
+	checkConfig.Metadata.RefreshFrequency = 0
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.brokers = brokers
+	b.config = &checkConfig
+	b.stale = true
+}
+
+// Check refreshes the metadata, failing if none of the brokers answers.
+func (b *BrokerCheck) Check(_ context.Context) error {
+	if b == nil {
+		return nil
+	}
+	client, err := b.getClient()
+	if err != nil {
+		return fmt.Errorf("unable to connect to the Kafka brokers: %w", err)
+	}
+	if err := client.RefreshMetadata(b.topics...); err != nil {
+		b.discard(client)
+		return fmt.Errorf("unable to refresh the metadata of the Kafka brokers: %w", err)
+	}
+	return nil
+}
+
+// getClient returns the current client, creating it if there is none or if it uses a previous configuration.
+func (b *BrokerCheck) getClient() (sarama.Client, error) {
+	b.lock.Lock()
+	if b.client != nil && !b.stale {
+		defer b.lock.Unlock()
+		return b.client, nil
+	}
+	previous := b.client
+	brokers, config := b.brokers, b.config
+	b.client, b.stale = nil, false
+	b.lock.Unlock()
+
+	if previous != nil {
+		_ = previous.Close()
+	}
+	client, err := newClient(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.client = client
+	return client, nil
+}
+
+// discard closes the client, unless it was replaced meanwhile.
+func (b *BrokerCheck) discard(client sarama.Client) {
+	b.lock.Lock()
+	if b.client == client {
+		b.client = nil
+	}
+	b.lock.Unlock()
+	_ = client.Close()
+}
+
+// Close closes the client of the check.
+func (b *BrokerCheck) Close() {
+	if b == nil {
+		return
+	}
+	b.lock.Lock()
+	client := b.client
+	b.client = nil
+	b.lock.Unlock()
+	if client != nil {
+		_ = client.Close()
+	}
+}
+
+// ErrorRate tracks the outcome of the recent operations, such as the events produced to Kafka, over a sliding
+// window. Its check fails when the share of the failed operations exceeds the threshold, once enough operations
+// have been recorded. A nil ErrorRate records nothing.
+type ErrorRate struct {
+	window    time.Duration
+	threshold float64
+	minCount  int
+	now       func() time.Time
+
+	lock    sync.Mutex
+	buckets []rateBucket
+}
+
+// rateBucket counts the operations of a slice of the window.
+type rateBucket struct {
+	start  time.Time
+	total  int
+	failed int
+}
+
+// NewErrorRate returns an ErrorRate failing when more than the threshold, between 0 and 1, of at least minCount
+// operations failed within the window.
+func NewErrorRate(window time.Duration, threshold float64, minCount int) *ErrorRate {
+	return &ErrorRate{
+		window:    window,
+		threshold: threshold,
+		minCount:  minCount,
+		now:       time.Now,
+	}
+}
+
+// Record records the outcome of an operation, failed if the error is not nil.
+func (r *ErrorRate) Record(err error) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := r.now()
+	r.expire(now)
+	if len(r.buckets) == 0 || now.Sub(r.buckets[len(r.buckets)-1].start) >= r.window/errorRateBuckets {
+		r.buckets = append(r.buckets, rateBucket{start: now})
+	}
+	bucket := &r.buckets[len(r.buckets)-1]
+	bucket.total++
+	if err != nil {
+		bucket.failed++
+	}
+}
+
+// Check fails if the share of the operations which failed within the window exceeds the threshold.
+func (r *ErrorRate) Check(_ context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.expire(r.now())
+	var total, failed int
+	for _, bucket := range r.buckets {
+		total += bucket.total
+		failed += bucket.failed
+	}
+	if total < r.minCount || total == 0 || float64(failed)/float64(total) <= r.threshold {
+		return nil
+	}
+	return fmt.Errorf("%d of the last %d operations failed within %v", failed, total, r.window)
+}
+
+// expire drops the buckets which are out of the window.
+func (r *ErrorRate) expire(now time.Time) {
+	i := 0
+	for i < len(r.buckets) && now.Sub(r.buckets[i].start) >= r.window {
+		i++
+	}
+	r.buckets = r.buckets[i:]
+}
+
+// Sessions tracks the sessions of consumer groups. Its check fails when a consumer group has had no session for
+// longer than the grace period, such as when its brokers are unreachable or it keeps on rebalancing. A nil
+// Sessions tracks nothing.
+type Sessions struct {
+	grace time.Duration
+	now   func() time.Time
+
+	lock    sync.Mutex
+	tracked map[*Session]struct{}
+}
+
+// Session tracks the sessions of a consumer group, until it is closed. A nil Session tracks nothing.
+type Session struct {
+	sessions *Sessions
+	group    string
+	active   bool
+	since    time.Time // when the session started or ended
+}
+
+// NewSessions returns a Sessions allowing the consumer groups to be without a session for the grace period.
+func NewSessions(grace time.Duration) *Sessions {
+	return &Sessions{
+		grace:   grace,
+		now:     time.Now,
+		tracked: make(map[*Session]struct{}),
+	}
+}
+
+// Track starts tracking the sessions of the consumer group, which is without session until Started is called.
+func (s *Sessions) Track(group string) *Session {
+	if s == nil {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	session := &Session{sessions: s, group: group, since: s.now()}
+	s.tracked[session] = struct{}{}
+	return session
+}
+
+// Started records the start of a session of the consumer group, once it has joined the group.
+func (s *Session) Started() {
+	s.set(true)
+}
+
+// Ended records the end of a session of the consumer group, such as when it is rebalanced.
+func (s *Session) Ended() {
+	s.set(false)
+}
+
+func (s *Session) set(active bool) {
+	if s == nil {
+		return
+	}
+	s.sessions.lock.Lock()
+	defer s.sessions.lock.Unlock()
+	if s.active != active {
+		s.active = active
+		s.since = s.sessions.now()
+	}
+}
+
+// Close stops tracking the sessions of the consumer group, once it is closed.
+func (s *Session) Close() {
+	if s == nil {
+		return
+	}
+	s.sessions.lock.Lock()
+	defer s.sessions.lock.Unlock()
+	delete(s.sessions.tracked, s)
+}
+
+// Check fails if a consumer group has had no session for longer than the grace period.
+func (s *Sessions) Check(_ context.Context) error {
+	if s == nil {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	var groups []string
+	for session := range s.tracked {
+		if !session.active && now.Sub(session.since) > s.grace {
+			groups = append(groups, session.group)
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	sort.Strings(groups)
+	return fmt.Errorf("no session of the consumer groups %v for more than %v", groups, s.grace)
+}
+
+// SyncCheck returns a check failing until the informers have synced.
+func SyncCheck(hasSynced ...func() bool) CheckFunc {
+	return func(_ context.Context) error {
+		for _, synced := range hasSynced {
+			if !synced() {
+				return errors.New("the informers have not synced yet")
+			}
+		}
+		return nil
+	}
+}
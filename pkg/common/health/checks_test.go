@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrokerCheck(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("topic", 0, broker.BrokerID()),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	config.Metadata.Retry.Max = 0
+	config.Net.DialTimeout = 100 * time.Millisecond
+	check := NewBrokerCheck([]string{broker.Addr()}, config, "topic")
+	defer check.Close()
+
+	require.NoError(t, check.Check(context.Background()))
+	client := check.client
+	require.NoError(t, check.Check(context.Background()))
+	assert.Same(t, client, check.client, "the client is kept between the checks")
+
+	// the configuration is not changed by the check
+	assert.True(t, config.Metadata.Full)
+
+	// a new client is created with the updated configuration
+	check.Update([]string{broker.Addr()}, config)
+	require.NoError(t, check.Check(context.Background()))
+	assert.NotSame(t, client, check.client)
+
+	// the client is discarded once the brokers are unreachable
+	broker.Close()
+	assert.Error(t, check.Check(context.Background()))
+	assert.Nil(t, check.client)
+	assert.Error(t, check.Check(context.Background()))
+}
+
+func TestBrokerCheckClientError(t *testing.T) {
+	defer func(previous func([]string, *sarama.Config) (sarama.Client, error)) { newClient = previous }(newClient)
+	newClient = func([]string, *sarama.Config) (sarama.Client, error) {
+		return nil, sarama.ErrOutOfBrokers
+	}
+
+	check := NewBrokerCheck([]string{"unreachable:9092"}, sarama.NewConfig())
+	err := check.Check(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, sarama.ErrOutOfBrokers))
+}
+
+func TestErrorRate(t *testing.T) {
+	now := time.Now()
+	rate := NewErrorRate(time.Minute, 0.5, 4)
+	rate.now = func() time.Time { return now }
+
+	// too few operations
+	rate.Record(errors.New("failed"))
+	rate.Record(errors.New("failed"))
+	rate.Record(errors.New("failed"))
+	assert.NoError(t, rate.Check(context.Background()))
+
+	rate.Record(nil)
+	assert.EqualError(t, rate.Check(context.Background()), "3 of the last 4 operations failed within 1m0s")
+
+	// at the threshold
+	now = now.Add(30 * time.Second)
+	rate.Record(nil)
+	rate.Record(nil)
+	assert.NoError(t, rate.Check(context.Background()))
+
+	// the first operations slide out of the window
+	now = now.Add(45 * time.Second)
+	rate.Record(errors.New("failed"))
+	rate.Record(errors.New("failed"))
+	rate.Record(errors.New("failed"))
+	assert.EqualError(t, rate.Check(context.Background()), "3 of the last 5 operations failed within 1m0s")
+	now = now.Add(time.Minute)
+	assert.NoError(t, rate.Check(context.Background()))
+	assert.Empty(t, rate.buckets)
+
+	var nilRate *ErrorRate
+	nilRate.Record(errors.New("ignored"))
+	assert.NoError(t, nilRate.Check(context.Background()))
+}
+
+func TestSessions(t *testing.T) {
+	now := time.Now()
+	sessions := NewSessions(time.Minute)
+	sessions.now = func() time.Time { return now }
+
+	first := sessions.Track("first")
+	second := sessions.Track("second")
+	assert.NoError(t, sessions.Check(context.Background()), "joining within the grace period")
+
+	first.Started()
+	now = now.Add(2 * time.Minute)
+	assert.EqualError(t, sessions.Check(context.Background()), "no session of the consumer groups [second] for more than 1m0s")
+
+	second.Started()
+	assert.NoError(t, sessions.Check(context.Background()))
+
+	first.Ended()
+	first.Started() // rebalanced
+	second.Ended()
+	now = now.Add(2 * time.Minute)
+	assert.EqualError(t, sessions.Check(context.Background()), "no session of the consumer groups [second] for more than 1m0s")
+
+	second.Close()
+	assert.NoError(t, sessions.Check(context.Background()))
+
+	var nilSessions *Sessions
+	nilSession := nilSessions.Track("ignored")
+	nilSession.Started()
+	nilSession.Ended()
+	nilSession.Close()
+	assert.NoError(t, nilSessions.Check(context.Background()))
+}
+
+func TestSyncCheck(t *testing.T) {
+	synced := false
+	check := SyncCheck(func() bool { return true }, func() bool { return synced })
+	assert.Error(t, check(context.Background()))
+	synced = true
+	assert.NoError(t, check(context.Background()))
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/tokenreview"
+)
+
+const (
+	// reviewCacheTTL is how long the result of a TokenReview is reused for the same token.
+	reviewCacheTTL = time.Minute
+	// reviewCacheSize caps the number of cached TokenReview results.
+	reviewCacheSize = 10000
+	// jwksFetchTimeout caps the time spent fetching a JSON Web Key Set.
+	jwksFetchTimeout = 10 * time.Second
+)
+
+// jwtSigningMethods are the asymmetric signing methods accepted for the JSON Web Tokens, the public keys of the
+// JSON Web Key Sets cannot verify the others.
+var jwtSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// PolicyFunc returns the ingress policy of the channel addressed by the host, nil when any sender is accepted, or an
+// error when the policy cannot be known, the requests then being rejected.
+type PolicyFunc func(host string) (*v1beta1.IngressPolicy, error)
+
+// Authenticator verifies the bearer tokens of the senders against the ingress policies of the channels. It caches
+// the JSON Web Key Sets and the results of the TokenReviews, and is safe for concurrent use.
+type Authenticator struct {
+	logger     *zap.Logger
+	kubeClient kubernetes.Interface
+	httpClient *nethttp.Client
+	now        func() time.Time
+
+	keySetsLock   sync.Mutex
+	keySets       map[string]*keySet
+	keySetFetches singleflight.Group
+
+	reviews *tokenreview.Cache
+}
+
+// NewAuthenticator returns an Authenticator reviewing the ServiceAccount tokens with the Kubernetes client, which
+// may be nil when the ServiceAccount policies are not supported.
+func NewAuthenticator(logger *zap.Logger, kubeClient kubernetes.Interface) *Authenticator {
+	return &Authenticator{
+		logger:     logger,
+		kubeClient: kubeClient,
+		httpClient: &nethttp.Client{Timeout: jwksFetchTimeout},
+		now:        time.Now,
+		keySets:    make(map[string]*keySet),
+		reviews:    tokenreview.NewCache(reviewCacheTTL, reviewCacheSize),
+	}
+}
+
+// Authenticate returns the subject of the token if one of the verifiers of the policy accepts it, the policies only
+// restricting the namespaces accepting the ServiceAccount tokens valid for the audiences of the API server.
+func (a *Authenticator) Authenticate(ctx context.Context, policy *v1beta1.IngressPolicy, token string) (string, error) {
+	serviceAccount := policy.ServiceAccount
+	if policy.JWT == nil && serviceAccount == nil && len(policy.Namespaces) > 0 {
+		serviceAccount = &v1beta1.ServiceAccountIngressPolicy{}
+	}
+
+	var errs []string
+	if policy.JWT != nil {
+		subject, err := a.verifyJWT(ctx, policy.JWT, token)
+		if err == nil {
+			return subject, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	if serviceAccount != nil {
+		subject, err := a.reviewToken(ctx, serviceAccount, token)
+		if err == nil {
+			return subject, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	if len(errs) == 0 {
+		return "", errors.New("the ingress policy has no verifier")
+	}
+	return "", fmt.Errorf("invalid bearer token: %s", strings.Join(errs, "; "))
+}
+
+// verifyJWT verifies the signature, the validity period, the issuer and the audience of the JSON Web Token,
+// returning its subject.
+func (a *Authenticator) verifyJWT(ctx context.Context, policy *v1beta1.JWTIngressPolicy, token string) (string, error) {
+	if policy.JWKSURL == nil {
+		return "", errors.New("no JSON Web Key Set")
+	}
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{ValidMethods: jwtSigningMethods}
+	_, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return a.publicKey(ctx, policy.JWKSURL.String(), kid)
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, ok := claims["exp"]; !ok {
+		return "", errors.New("the token has no expiration time")
+	}
+	if policy.Issuer != "" && !claims.VerifyIssuer(policy.Issuer, true) {
+		return "", fmt.Errorf("the token is not issued by %q", policy.Issuer)
+	}
+	if len(policy.Audiences) > 0 && !hasAudience(claims["aud"], policy.Audiences) {
+		return "", fmt.Errorf("the token is not intended for the audiences %q", policy.Audiences)
+	}
+	subject, _ := claims["sub"].(string)
+	return subject, nil
+}
+
+// hasAudience returns true if the aud claim, a string or an array of strings, holds one of the audiences.
+func hasAudience(claim interface{}, audiences []string) bool {
+	var values []interface{}
+	switch aud := claim.(type) {
+	case string:
+		values = []interface{}{aud}
+	case []interface{}:
+		values = aud
+	}
+	for _, value := range values {
+		for _, audience := range audiences {
+			if value == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reviewToken verifies the ServiceAccount token with a TokenReview, returning the username of the ServiceAccount.
+func (a *Authenticator) reviewToken(ctx context.Context, policy *v1beta1.ServiceAccountIngressPolicy, token string) (string, error) {
+	if a.kubeClient == nil {
+		return "", errors.New("the ServiceAccount tokens cannot be reviewed")
+	}
+	audiences := strings.Join(policy.Audiences, ",")
+	if cached, ok := a.reviews.Get(token, audiences, a.now()); ok {
+		return cached.Username, cached.Err
+	}
+
+	result, err := a.kubeClient.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: policy.Audiences,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		// not cached, the token may well be valid
+		a.logger.Error("Failed to review a ServiceAccount token", zap.Error(err))
+		return "", errors.New("the ServiceAccount token could not be reviewed")
+	}
+
+	var username string
+	switch {
+	case !result.Status.Authenticated:
+		err = errors.New("the ServiceAccount token is not authenticated")
+		if result.Status.Error != "" {
+			err = fmt.Errorf("the ServiceAccount token is not authenticated: %s", result.Status.Error)
+		}
+	case serviceAccountNamespace(result.Status.User.Username) == "":
+		err = fmt.Errorf("the user %q is not a ServiceAccount", result.Status.User.Username)
+	default:
+		username = result.Status.User.Username
+	}
+
+	a.reviews.Add(token, audiences, tokenreview.Result{Username: username, Err: err}, a.now())
+	return username, err
+}
+
+// serviceAccountNamespace returns the namespace of the ServiceAccount named by the subject, in the
+// system:serviceaccount:<namespace>:<name> form, or an empty string if the subject is not a ServiceAccount.
+func serviceAccountNamespace(subject string) string {
+	parts := strings.Split(subject, ":")
+	if len(parts) != 4 || parts[0] != "system" || parts[1] != "serviceaccount" || parts[2] == "" || parts[3] == "" {
+		return ""
+	}
+	return parts[2]
+}
+
+// authHandler authenticates the senders before handing the requests to the next handler.
+type authHandler struct {
+	authenticator *Authenticator
+	policies      PolicyFunc
+	next          nethttp.Handler
+}
+
+// NewAuthHandler returns a handler rejecting the requests to the channels having an ingress policy with a 401,
+// unless they present a bearer token accepted by the policy, or with a 403 if the sender is not in one of the
+// allowed namespaces, and the requests to the channels whose policy cannot be known with a 503. The other requests
+// are handed to next, without their Authorization header.
+func NewAuthHandler(authenticator *Authenticator, policies PolicyFunc, next nethttp.Handler) nethttp.Handler {
+	return &authHandler{authenticator: authenticator, policies: policies, next: next}
+}
+
+func (h *authHandler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Request) {
+	if request.Method != nethttp.MethodPost {
+		h.next.ServeHTTP(response, request)
+		return
+	}
+	logger := h.authenticator.logger
+	policy, err := h.policies(request.Host)
+	if err != nil {
+		// not accepting any sender, the channel may well have a policy
+		logger.Error("Failed to get the ingress policy", zap.String("host", request.Host), zap.Error(err))
+		reject(request.Context(), logger, response, nethttp.StatusServiceUnavailable, ReasonUnavailable, "the ingress policy of the channel is unavailable")
+		return
+	}
+	if policy == nil {
+		h.next.ServeHTTP(response, request)
+		return
+	}
+
+	token := bearerToken(request.Header.Get("Authorization"))
+	if token == "" {
+		response.Header().Set("WWW-Authenticate", "Bearer")
+		reject(request.Context(), logger, response, nethttp.StatusUnauthorized, ReasonUnauthenticated, "missing bearer token")
+		return
+	}
+	subject, err := h.authenticator.Authenticate(request.Context(), policy, token)
+	if err != nil {
+		response.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		reject(request.Context(), logger, response, nethttp.StatusUnauthorized, ReasonUnauthenticated, err.Error())
+		return
+	}
+	if len(policy.Namespaces) > 0 && !contains(policy.Namespaces, serviceAccountNamespace(subject)) {
+		reject(request.Context(), logger, response, nethttp.StatusForbidden, ReasonForbidden,
+			fmt.Sprintf("the sender %q is not allowed to send events to the channel", subject))
+		return
+	}
+
+	// the token is not propagated any further, in particular not to the subscribers
+	request.Header.Del("Authorization")
+	h.next.ServeHTTP(response, request)
+}
+
+// bearerToken returns the token of the bearer Authorization header, or an empty string.
+func bearerToken(authorization string) string {
+	const prefix = "bearer "
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(authorization[len(prefix):])
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
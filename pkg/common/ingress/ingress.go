@@ -0,0 +1,265 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ingress authenticates the senders of the events to the
+// KafkaChannels, and validates the events, before their receivers write them
+// to Kafka.
+package ingress
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	nethttp "net/http"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/metrics"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/jsonschema"
+)
+
+// Port is the port the channel receivers listen to, as the MessageReceiver does.
+const Port = 8080
+
+// The reasons the events are rejected for.
+const (
+	ReasonInvalid    = "invalid"
+	ReasonSize       = "size"
+	ReasonAttributes = "attributes"
+	ReasonSchema     = "schema"
+
+	ReasonUnauthenticated = "unauthenticated"
+	ReasonForbidden       = "forbidden"
+	ReasonUnavailable     = "unavailable"
+)
+
+var (
+	// rejectedEventCount counts the events rejected by the ingress, by reason
+	rejectedEventCount = stats.Int64(
+		"rejected_event_count",
+		"Number of events rejected by the ingress of the channel",
+		stats.UnitDimensionless,
+	)
+	reasonKey = tag.MustNewKey("reason")
+)
+
+func init() {
+	err := view.Register(&view.View{
+		Description: rejectedEventCount.Description(),
+		Measure:     rejectedEventCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{reasonKey},
+	})
+	if err != nil {
+		log.Printf("failed to register opencensus views, %v", err)
+	}
+}
+
+// Rules are the rules the events sent to a channel must follow. The zero value accepts all the events.
+type Rules struct {
+	// MaxEventSize caps the size of the data and the attributes of the events, in bytes, unless zero
+	MaxEventSize int64
+	// RequiredAttributes are the names of the CloudEvents attributes, or extensions, the events must have
+	RequiredAttributes []string
+	// DataSchemas are the JSON Schemas the data of the events must match, by CloudEvents type
+	DataSchemas map[string]*jsonschema.Schema
+}
+
+// IsZero returns true if the rules accept all the events.
+func (r Rules) IsZero() bool {
+	return r.MaxEventSize == 0 && len(r.RequiredAttributes) == 0 && len(r.DataSchemas) == 0
+}
+
+// RulesFromSpec returns the rules set by the event rules of a KafkaChannel, which may be nil.
+func RulesFromSpec(eventRules *v1beta1.EventRules) (Rules, error) {
+	var rules Rules
+	if eventRules == nil {
+		return rules, nil
+	}
+	if eventRules.MaxEventSize != nil {
+		if eventRules.MaxEventSize.Sign() <= 0 {
+			return Rules{}, fmt.Errorf("invalid maximum event size %q: expected a positive quantity of bytes", eventRules.MaxEventSize.String())
+		}
+		rules.MaxEventSize = eventRules.MaxEventSize.Value()
+	}
+	rules.RequiredAttributes = append(rules.RequiredAttributes, eventRules.RequiredAttributes...)
+	if len(eventRules.DataSchemas) > 0 {
+		rules.DataSchemas = make(map[string]*jsonschema.Schema, len(eventRules.DataSchemas))
+		for eventType, schema := range eventRules.DataSchemas {
+			parsed, err := jsonschema.Parse(schema.Raw)
+			if err != nil {
+				return Rules{}, fmt.Errorf("invalid JSON Schema of the type %q: %w", eventType, err)
+			}
+			rules.DataSchemas[eventType] = parsed
+		}
+	}
+	return rules, nil
+}
+
+// RulesFunc returns the rules of the channel addressed by the host.
+type RulesFunc func(host string) Rules
+
+// handler validates the events before handing them to the next handler.
+type handler struct {
+	logger *zap.Logger
+	rules  RulesFunc
+	next   nethttp.Handler
+}
+
+// NewHandler returns a handler rejecting the events which do not follow the rules of their channel with a 400, and
+// handing the others to next.
+func NewHandler(logger *zap.Logger, rules RulesFunc, next nethttp.Handler) nethttp.Handler {
+	return &handler{logger: logger, rules: rules, next: next}
+}
+
+// Start serves the handler, wrapping the MessageReceiver of the channels, until the context is done, as
+// MessageReceiver.Start does.
+func Start(ctx context.Context, handler nethttp.Handler) error {
+	return kncloudevents.NewHTTPMessageReceiver(Port).StartListen(ctx, handler)
+}
+
+func (h *handler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Request) {
+	if request.Method != nethttp.MethodPost {
+		h.next.ServeHTTP(response, request)
+		return
+	}
+	rules := h.rules(request.Host)
+	if rules.IsZero() {
+		h.next.ServeHTTP(response, request)
+		return
+	}
+
+	if rules.MaxEventSize > 0 && request.ContentLength > rules.MaxEventSize {
+		h.reject(request.Context(), response, ReasonSize, fmt.Sprintf("the event exceeds the maximum size of %d bytes", rules.MaxEventSize))
+		return
+	}
+	body, err := h.readBody(request, rules.MaxEventSize)
+	if err != nil {
+		h.logger.Info("Failed to read the request", zap.Error(err))
+		response.WriteHeader(nethttp.StatusBadRequest)
+		return
+	}
+	if size := int64(len(body)) + attributesSize(request.Header); rules.MaxEventSize > 0 && size > rules.MaxEventSize {
+		h.reject(request.Context(), response, ReasonSize, fmt.Sprintf("the event exceeds the maximum size of %d bytes", rules.MaxEventSize))
+		return
+	}
+
+	// the requests which are not CloudEvents are left to the MessageReceiver
+	message := http.NewMessage(request.Header, ioutil.NopCloser(bytes.NewReader(body)))
+	if message.ReadEncoding() != binding.EncodingUnknown {
+		e, err := binding.ToEvent(request.Context(), message)
+		if err != nil {
+			h.reject(request.Context(), response, ReasonInvalid, fmt.Sprintf("invalid CloudEvent: %v", err))
+			return
+		}
+		if reason, err := rules.validate(e); err != nil {
+			h.reject(request.Context(), response, reason, err.Error())
+			return
+		}
+	}
+
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	request.ContentLength = int64(len(body))
+	h.next.ServeHTTP(response, request)
+}
+
+// readBody reads the body of the request, at most one byte more than maxSize unless zero.
+func (h *handler) readBody(request *nethttp.Request, maxSize int64) ([]byte, error) {
+	defer request.Body.Close()
+	if maxSize > 0 {
+		return ioutil.ReadAll(io.LimitReader(request.Body, maxSize+1))
+	}
+	return ioutil.ReadAll(request.Body)
+}
+
+// reject answers the request with a 400 describing the violated rule, counting the event as rejected.
+func (h *handler) reject(ctx context.Context, response nethttp.ResponseWriter, reason string, description string) {
+	reject(ctx, h.logger, response, nethttp.StatusBadRequest, reason, description)
+}
+
+// reject answers the request with the status code and the description of the rejection, counting the event as
+// rejected.
+func reject(ctx context.Context, logger *zap.Logger, response nethttp.ResponseWriter, code int, reason string, description string) {
+	logger.Info("Rejected an event", zap.String("reason", reason), zap.String("description", description))
+	if ctx, err := tag.New(ctx, tag.Insert(reasonKey, reason)); err == nil {
+		metrics.Record(ctx, rejectedEventCount.M(1))
+	}
+	nethttp.Error(response, description, code)
+}
+
+// validate returns the reason and a description of the first rule the event violates, if any.
+func (r Rules) validate(e *event.Event) (string, error) {
+	for _, attribute := range r.RequiredAttributes {
+		if !hasAttribute(e, attribute) {
+			return ReasonAttributes, fmt.Errorf("the event is missing the required attribute %q", attribute)
+		}
+	}
+	if schema, ok := r.DataSchemas[e.Type()]; ok {
+		if contentType := e.DataContentType(); contentType != "" && !strings.Contains(contentType, "json") {
+			return ReasonSchema, fmt.Errorf("the data of the events of type %q must be JSON, got %q", e.Type(), contentType)
+		}
+		if len(e.Data()) == 0 {
+			return ReasonSchema, fmt.Errorf("the events of type %q must have data", e.Type())
+		}
+		if err := schema.Validate(e.Data()); err != nil {
+			return ReasonSchema, fmt.Errorf("the data does not match the schema of the type %q: %v", e.Type(), err)
+		}
+	}
+	return "", nil
+}
+
+// hasAttribute returns true if the event has the attribute, or extension.
+func hasAttribute(e *event.Event, name string) bool {
+	switch name {
+	case "specversion", "id", "source", "type":
+		return true // required by the specification
+	case "datacontenttype":
+		return e.DataContentType() != ""
+	case "dataschema":
+		return e.DataSchema() != ""
+	case "subject":
+		return e.Subject() != ""
+	case "time":
+		return !e.Time().IsZero()
+	}
+	_, ok := e.Extensions()[name]
+	return ok
+}
+
+// attributesSize returns the size of the CloudEvents attributes sent as headers, in binary mode.
+func attributesSize(header nethttp.Header) int64 {
+	var size int64
+	for key, values := range header {
+		if len(key) > 3 && strings.EqualFold(key[:3], "ce-") {
+			for _, value := range values {
+				size += int64(len(key) - 3 + len(value))
+			}
+		}
+	}
+	return size
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"io/ioutil"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+func TestRulesFromSpec(t *testing.T) {
+	rules, err := RulesFromSpec(nil)
+	require.NoError(t, err)
+	assert.True(t, rules.IsZero())
+
+	rules, err = RulesFromSpec(&v1beta1.EventRules{
+		MaxEventSize:       resource.NewQuantity(1024, resource.BinarySI),
+		RequiredAttributes: []string{"subject", "tenant"},
+		DataSchemas:        map[string]runtime.RawExtension{"order": {Raw: []byte(`{"type": "object"}`)}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), rules.MaxEventSize)
+	assert.Equal(t, []string{"subject", "tenant"}, rules.RequiredAttributes)
+	assert.Contains(t, rules.DataSchemas, "order")
+	assert.False(t, rules.IsZero())
+
+	invalid := []*v1beta1.EventRules{
+		{MaxEventSize: resource.NewQuantity(-1, resource.DecimalSI)},
+		{DataSchemas: map[string]runtime.RawExtension{"order": {Raw: []byte(`[]`)}}},
+		{DataSchemas: map[string]runtime.RawExtension{"order": {Raw: []byte(`{"not": {}}`)}}},
+	}
+	for _, eventRules := range invalid {
+		_, err := RulesFromSpec(eventRules)
+		assert.Error(t, err, eventRules)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	rules, err := RulesFromSpec(&v1beta1.EventRules{
+		MaxEventSize:       resource.NewQuantity(200, resource.DecimalSI),
+		RequiredAttributes: []string{"subject"},
+		DataSchemas:        map[string]runtime.RawExtension{"order": {Raw: []byte(`{"type": "object", "required": ["id"]}`)}},
+	})
+	require.NoError(t, err)
+
+	binaryRequest := func(eventType string, subject string, body string) *nethttp.Request {
+		request := httptest.NewRequest(nethttp.MethodPost, "http://channel.example.com/", strings.NewReader(body))
+		request.Header.Set("ce-specversion", "1.0")
+		request.Header.Set("ce-id", "1")
+		request.Header.Set("ce-source", "source")
+		request.Header.Set("ce-type", eventType)
+		if subject != "" {
+			request.Header.Set("ce-subject", subject)
+		}
+		request.Header.Set("content-type", "application/json")
+		return request
+	}
+	structuredRequest := func(event string) *nethttp.Request {
+		request := httptest.NewRequest(nethttp.MethodPost, "http://channel.example.com/", strings.NewReader(event))
+		request.Header.Set("content-type", "application/cloudevents+json")
+		return request
+	}
+
+	testCases := map[string]struct {
+		request  *nethttp.Request
+		wantCode int
+		wantBody string
+	}{
+		"valid": {
+			request:  binaryRequest("order", "s", `{"id": 1}`),
+			wantCode: nethttp.StatusAccepted,
+		},
+		"valid structured": {
+			request:  structuredRequest(`{"specversion": "1.0", "id": "1", "source": "source", "type": "order", "subject": "s", "data": {"id": 1}}`),
+			wantCode: nethttp.StatusAccepted,
+		},
+		"other type": {
+			request:  binaryRequest("other", "s", `"text"`),
+			wantCode: nethttp.StatusAccepted,
+		},
+		"not a cloudevent": {
+			request:  httptest.NewRequest(nethttp.MethodPost, "http://channel.example.com/", strings.NewReader("body")),
+			wantCode: nethttp.StatusAccepted,
+		},
+		"too large": {
+			request:  binaryRequest("order", "s", `{"id": "`+strings.Repeat("a", 200)+`"}`),
+			wantCode: nethttp.StatusBadRequest,
+			wantBody: "the event exceeds the maximum size of 200 bytes\n",
+		},
+		"too large with the attributes": {
+			request:  binaryRequest("order", strings.Repeat("s", 180), `{"id": 1}`),
+			wantCode: nethttp.StatusBadRequest,
+			wantBody: "the event exceeds the maximum size of 200 bytes\n",
+		},
+		"missing attribute": {
+			request:  binaryRequest("order", "", `{"id": 1}`),
+			wantCode: nethttp.StatusBadRequest,
+			wantBody: "the event is missing the required attribute \"subject\"\n",
+		},
+		"schema mismatch": {
+			request:  binaryRequest("order", "s", `{"name": "a"}`),
+			wantCode: nethttp.StatusBadRequest,
+			wantBody: "the data does not match the schema of the type \"order\": /: missing required property \"id\"\n",
+		},
+		"no data": {
+			request:  binaryRequest("order", "s", ``),
+			wantCode: nethttp.StatusBadRequest,
+			wantBody: "the events of type \"order\" must have data\n",
+		},
+		"invalid cloudevent": {
+			request:  structuredRequest(`{"specversion": "1.0"`),
+			wantCode: nethttp.StatusBadRequest,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var received string
+			next := nethttp.HandlerFunc(func(response nethttp.ResponseWriter, request *nethttp.Request) {
+				body, _ := ioutil.ReadAll(request.Body)
+				received = string(body)
+				response.WriteHeader(nethttp.StatusAccepted)
+			})
+			var host string
+			handler := NewHandler(zap.NewNop(), func(h string) Rules {
+				host = h
+				return rules
+			}, next)
+
+			body, _ := ioutil.ReadAll(tc.request.Body)
+			tc.request.Body = ioutil.NopCloser(strings.NewReader(string(body)))
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, tc.request)
+
+			assert.Equal(t, "channel.example.com", host)
+			assert.Equal(t, tc.wantCode, response.Code)
+			if tc.wantCode == nethttp.StatusAccepted {
+				// The next handler receives the whole request
+				assert.Equal(t, string(body), received)
+			} else if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, response.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerWithoutRules(t *testing.T) {
+	called := false
+	next := nethttp.HandlerFunc(func(nethttp.ResponseWriter, *nethttp.Request) { called = true })
+	handler := NewHandler(zap.NewNop(), func(string) Rules { return Rules{} }, next)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(nethttp.MethodPost, "/", strings.NewReader(strings.Repeat("a", 1024))))
+	assert.True(t, called)
+}
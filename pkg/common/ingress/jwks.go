@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	nethttp "net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// jwksRefreshInterval is how long the keys of a JSON Web Key Set are used before being fetched again.
+	jwksRefreshInterval = 5 * time.Minute
+	// jwksMinRefreshInterval is how long to wait before fetching a JSON Web Key Set again for an unknown key id,
+	// so that the tokens signed by unknown keys do not hammer the issuer.
+	jwksMinRefreshInterval = 10 * time.Second
+)
+
+// keySet holds the public keys of a JSON Web Key Set, by key id.
+type keySet struct {
+	keys    map[string]interface{}
+	fetched time.Time
+}
+
+// jsonWebKey is a JSON Web Key (RFC 7517) holding an RSA or an elliptic curve public key.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey returns the public key of the JSON Web Key Set at the URL with the key id, fetching the key set when
+// it is too old, or when it does not hold the key. The key id may be empty when the key set holds a single key.
+func (a *Authenticator) publicKey(ctx context.Context, url string, kid string) (interface{}, error) {
+	a.keySetsLock.Lock()
+	set := a.keySets[url]
+	a.keySetsLock.Unlock()
+
+	key, ok := set.key(kid)
+	if set == nil || a.now().Sub(set.fetched) > jwksRefreshInterval || (!ok && a.now().Sub(set.fetched) > jwksMinRefreshInterval) {
+		fetched, err := a.refreshKeySet(ctx, url)
+		if err != nil {
+			if ok {
+				// keep on using the previous keys until the issuer is back
+				a.logger.Warn("Failed to refresh the JSON Web Key Set", zap.String("url", url), zap.Error(err))
+				return key, nil
+			}
+			return nil, err
+		}
+		key, ok = fetched.key(kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// refreshKeySet fetches and caches the JSON Web Key Set at the URL. The fetch does not hold the lock of the key
+// sets, so that a slow issuer does not delay the others, and is shared by the concurrent callers for the URL.
+func (a *Authenticator) refreshKeySet(ctx context.Context, url string) (*keySet, error) {
+	result := a.keySetFetches.DoChan(url, func() (interface{}, error) {
+		// not bound to the context of the first caller, the fetch is bounded by the timeout of the HTTP client
+		keys, err := a.fetchKeySet(context.Background(), url)
+		if err != nil {
+			return nil, err
+		}
+		set := &keySet{keys: keys, fetched: a.now()}
+		a.keySetsLock.Lock()
+		a.keySets[url] = set
+		a.keySetsLock.Unlock()
+		return set, nil
+	})
+	select {
+	case r := <-result:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*keySet), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// key returns the key with the id, or the only key of the set if the id is empty.
+func (s *keySet) key(kid string) (interface{}, bool) {
+	if s == nil {
+		return nil, false
+	}
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// fetchKeySet fetches the JSON Web Key Set at the URL, returning its signing keys by key id.
+func (a *Authenticator) fetchKeySet(ctx context.Context, url string) (map[string]interface{}, error) {
+	request, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := a.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the JSON Web Key Set: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != nethttp.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the JSON Web Key Set: %s", response.Status)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("invalid JSON Web Key Set: %w", err)
+	}
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// the other keys of the set are still usable
+			a.logger.Warn("Ignoring an invalid JSON Web Key", zap.String("url", url), zap.String("kid", jwk.Kid), zap.Error(err))
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+// publicKey returns the *rsa.PublicKey or *ecdsa.PublicKey of the JSON Web Key.
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() {
+			return nil, fmt.Errorf("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("the point is not on the curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// decodeBigInt decodes the base64url encoded unsigned big-endian integer.
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,398 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jsonschema validates JSON documents against the subset of JSON
+// Schema (draft 7) describing the shape of the data of the events: the type,
+// enum, const, required, properties, additionalProperties, items, minItems,
+// maxItems, minLength, maxLength, pattern, minimum, maximum, exclusiveMinimum
+// and exclusiveMaximum keywords. The annotation keywords, such as title or
+// format, are ignored. The schemas using any other keyword are rejected rather
+// than partially enforced.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// annotationKeywords are the keywords which do not constrain the documents.
+var annotationKeywords = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+	"default":     true,
+	"examples":    true,
+	"format":      true,
+	"readOnly":    true,
+	"writeOnly":   true,
+}
+
+// typeNames are the names of the JSON Schema types.
+var typeNames = map[string]bool{
+	"null":    true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+	"number":  true,
+	"integer": true,
+	"string":  true,
+}
+
+// Schema is a parsed JSON Schema. It is safe for concurrent use.
+type Schema struct {
+	// never rejects all the documents, for the false schema
+	never bool
+
+	types    []string
+	enum     []interface{}
+	constant interface{}
+	hasConst bool
+
+	required             []string
+	properties           map[string]*Schema
+	additionalProperties *Schema
+
+	items    *Schema
+	minItems *int
+	maxItems *int
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+}
+
+// Parse parses the JSON Schema, returning an error if it is invalid or uses an unsupported keyword.
+func Parse(data []byte) (*Schema, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return FromValue(v)
+}
+
+// FromValue parses the JSON Schema already decoded by encoding/json.
+func FromValue(v interface{}) (*Schema, error) {
+	return parse(v, "")
+}
+
+func parse(v interface{}, path string) (*Schema, error) {
+	switch schema := v.(type) {
+	case bool:
+		return &Schema{never: !schema}, nil
+	case map[string]interface{}:
+		s := &Schema{}
+		// the keywords are parsed in order for the errors to be stable
+		keywords := make([]string, 0, len(schema))
+		for keyword := range schema {
+			keywords = append(keywords, keyword)
+		}
+		sort.Strings(keywords)
+		for _, keyword := range keywords {
+			if err := s.parseKeyword(keyword, schema[keyword], path+"/"+keyword); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("%s: expected a schema object or boolean", pathOrRoot(path))
+	}
+}
+
+func (s *Schema) parseKeyword(keyword string, value interface{}, path string) error {
+	var err error
+	switch keyword {
+	case "type":
+		s.types, err = parseTypes(value, path)
+	case "enum":
+		values, ok := value.([]interface{})
+		if !ok || len(values) == 0 {
+			return fmt.Errorf("%s: expected a non empty array", path)
+		}
+		s.enum = values
+	case "const":
+		s.constant, s.hasConst = value, true
+	case "required":
+		s.required, err = parseStrings(value, path)
+	case "properties":
+		properties, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an object", path)
+		}
+		s.properties = make(map[string]*Schema, len(properties))
+		for name, property := range properties {
+			if s.properties[name], err = parse(property, path+"/"+name); err != nil {
+				return err
+			}
+		}
+	case "additionalProperties":
+		s.additionalProperties, err = parse(value, path)
+	case "items":
+		s.items, err = parse(value, path)
+	case "minItems":
+		s.minItems, err = parseCount(value, path)
+	case "maxItems":
+		s.maxItems, err = parseCount(value, path)
+	case "minLength":
+		s.minLength, err = parseCount(value, path)
+	case "maxLength":
+		s.maxLength, err = parseCount(value, path)
+	case "pattern":
+		pattern, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string", path)
+		}
+		if s.pattern, err = regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%s: invalid regular expression: %w", path, err)
+		}
+	case "minimum":
+		s.minimum, err = parseNumber(value, path)
+	case "maximum":
+		s.maximum, err = parseNumber(value, path)
+	case "exclusiveMinimum":
+		s.exclusiveMinimum, err = parseNumber(value, path)
+	case "exclusiveMaximum":
+		s.exclusiveMaximum, err = parseNumber(value, path)
+	default:
+		if !annotationKeywords[keyword] {
+			return fmt.Errorf("%s: unsupported keyword", path)
+		}
+	}
+	return err
+}
+
+func parseTypes(value interface{}, path string) ([]string, error) {
+	var types []string
+	if t, ok := value.(string); ok {
+		types = []string{t}
+	} else {
+		var err error
+		if types, err = parseStrings(value, path); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range types {
+		if !typeNames[t] {
+			return nil, fmt.Errorf("%s: unknown type %q", path, t)
+		}
+	}
+	return types, nil
+}
+
+func parseStrings(value interface{}, path string) ([]string, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: expected an array of strings", path)
+	}
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected an array of strings", path)
+		}
+		strs = append(strs, str)
+	}
+	return strs, nil
+}
+
+func parseCount(value interface{}, path string) (*int, error) {
+	n, ok := value.(float64)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		return nil, fmt.Errorf("%s: expected a non negative integer", path)
+	}
+	count := int(n)
+	return &count, nil
+}
+
+func parseNumber(value interface{}, path string) (*float64, error) {
+	n, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected a number", path)
+	}
+	return &n, nil
+}
+
+// Validate returns an error describing the first violation of the schema by the JSON document, if any.
+func (s *Schema) Validate(data []byte) error {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if decoder.More() {
+		return fmt.Errorf("invalid JSON: unexpected data after the document")
+	}
+	return s.validate(v, "")
+}
+
+func (s *Schema) validate(v interface{}, path string) error {
+	if s.never {
+		return fmt.Errorf("%s: not allowed", pathOrRoot(path))
+	}
+	if len(s.types) > 0 && !hasType(v, s.types) {
+		return fmt.Errorf("%s: expected %s, got %s", pathOrRoot(path), strings.Join(s.types, " or "), typeOf(v))
+	}
+	if s.enum != nil && !contains(s.enum, v) {
+		return fmt.Errorf("%s: expected one of the enumerated values", pathOrRoot(path))
+	}
+	if s.hasConst && !reflect.DeepEqual(s.constant, v) {
+		return fmt.Errorf("%s: expected the constant value", pathOrRoot(path))
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		return s.validateObject(value, path)
+	case []interface{}:
+		return s.validateArray(value, path)
+	case string:
+		return s.validateString(value, path)
+	case float64:
+		return s.validateNumber(value, path)
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(object map[string]interface{}, path string) error {
+	for _, name := range s.required {
+		if _, ok := object[name]; !ok {
+			return fmt.Errorf("%s: missing required property %q", pathOrRoot(path), name)
+		}
+	}
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property, ok := s.properties[name]
+		if !ok {
+			property = s.additionalProperties
+		}
+		if property != nil {
+			if err := property.validate(object[name], path+"/"+name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateArray(array []interface{}, path string) error {
+	if s.minItems != nil && len(array) < *s.minItems {
+		return fmt.Errorf("%s: expected at least %d items, got %d", pathOrRoot(path), *s.minItems, len(array))
+	}
+	if s.maxItems != nil && len(array) > *s.maxItems {
+		return fmt.Errorf("%s: expected at most %d items, got %d", pathOrRoot(path), *s.maxItems, len(array))
+	}
+	if s.items != nil {
+		for i, item := range array {
+			if err := s.items.validate(item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateString(str string, path string) error {
+	length := utf8.RuneCountInString(str)
+	if s.minLength != nil && length < *s.minLength {
+		return fmt.Errorf("%s: expected at least %d characters, got %d", pathOrRoot(path), *s.minLength, length)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		return fmt.Errorf("%s: expected at most %d characters, got %d", pathOrRoot(path), *s.maxLength, length)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return fmt.Errorf("%s: expected to match %q", pathOrRoot(path), s.pattern.String())
+	}
+	return nil
+}
+
+func (s *Schema) validateNumber(n float64, path string) error {
+	if s.minimum != nil && n < *s.minimum {
+		return fmt.Errorf("%s: expected at least %v, got %v", pathOrRoot(path), *s.minimum, n)
+	}
+	if s.maximum != nil && n > *s.maximum {
+		return fmt.Errorf("%s: expected at most %v, got %v", pathOrRoot(path), *s.maximum, n)
+	}
+	if s.exclusiveMinimum != nil && n <= *s.exclusiveMinimum {
+		return fmt.Errorf("%s: expected more than %v, got %v", pathOrRoot(path), *s.exclusiveMinimum, n)
+	}
+	if s.exclusiveMaximum != nil && n >= *s.exclusiveMaximum {
+		return fmt.Errorf("%s: expected less than %v, got %v", pathOrRoot(path), *s.exclusiveMaximum, n)
+	}
+	return nil
+}
+
+func hasType(v interface{}, types []string) bool {
+	actual := typeOf(v)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf returns the JSON Schema type of the value decoded by encoding/json, integer for the integral numbers.
+func typeOf(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func contains(values []interface{}, v interface{}) bool {
+	for _, value := range values {
+		if reflect.DeepEqual(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		schema  string
+		wantErr string
+	}{
+		"empty":            {schema: `{}`},
+		"boolean":          {schema: `false`},
+		"annotations":      {schema: `{"$schema": "http://json-schema.org/draft-07/schema#", "title": "order", "format": "uuid"}`},
+		"invalid json":     {schema: `{`, wantErr: "invalid JSON: unexpected end of JSON input"},
+		"not a schema":     {schema: `"string"`, wantErr: "/: expected a schema object or boolean"},
+		"unknown type":     {schema: `{"type": "float"}`, wantErr: `/type: unknown type "float"`},
+		"unsupported":      {schema: `{"properties": {"a": {"anyOf": []}}}`, wantErr: "/properties/a/anyOf: unsupported keyword"},
+		"invalid pattern":  {schema: `{"pattern": "("}`, wantErr: "/pattern: invalid regular expression: error parsing regexp: missing closing ): `(`"},
+		"negative count":   {schema: `{"minLength": -1}`, wantErr: "/minLength: expected a non negative integer"},
+		"empty enum":       {schema: `{"enum": []}`, wantErr: "/enum: expected a non empty array"},
+		"invalid required": {schema: `{"required": [1]}`, wantErr: "/required: expected an array of strings"},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			_, err := Parse([]byte(tc.schema))
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema, err := Parse([]byte(`{
+		"type": "object",
+		"required": ["id", "amount"],
+		"properties": {
+			"id": {"type": "string", "pattern": "^[a-z]+-[0-9]+$", "maxLength": 12},
+			"amount": {"type": "number", "minimum": 0, "exclusiveMaximum": 1000},
+			"quantity": {"type": "integer"},
+			"currency": {"enum": ["EUR", "USD"]},
+			"version": {"const": 1},
+			"tags": {"type": "array", "items": {"type": "string", "minLength": 1}, "maxItems": 2}
+		},
+		"additionalProperties": false
+	}`))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		data    string
+		wantErr string
+	}{
+		"valid":                  {data: `{"id": "order-1", "amount": 10.5, "quantity": 2, "currency": "EUR", "version": 1, "tags": ["a"]}`},
+		"invalid json":           {data: `{"id": `, wantErr: "invalid JSON: unexpected EOF"},
+		"trailing data":          {data: `{} {}`, wantErr: "invalid JSON: unexpected data after the document"},
+		"wrong type":             {data: `[]`, wantErr: "/: expected object, got array"},
+		"missing required":       {data: `{"id": "order-1"}`, wantErr: `/: missing required property "amount"`},
+		"pattern":                {data: `{"id": "order", "amount": 1}`, wantErr: `/id: expected to match "^[a-z]+-[0-9]+$"`},
+		"max length":             {data: `{"id": "order-1234567", "amount": 1}`, wantErr: "/id: expected at most 12 characters, got 13"},
+		"minimum":                {data: `{"id": "order-1", "amount": -1}`, wantErr: "/amount: expected at least 0, got -1"},
+		"exclusive maximum":      {data: `{"id": "order-1", "amount": 1000}`, wantErr: "/amount: expected less than 1000, got 1000"},
+		"integer":                {data: `{"id": "order-1", "amount": 1, "quantity": 1.5}`, wantErr: "/quantity: expected integer, got number"},
+		"enum":                   {data: `{"id": "order-1", "amount": 1, "currency": "GBP"}`, wantErr: "/currency: expected one of the enumerated values"},
+		"const":                  {data: `{"id": "order-1", "amount": 1, "version": 2}`, wantErr: "/version: expected the constant value"},
+		"items":                  {data: `{"id": "order-1", "amount": 1, "tags": ["a", ""]}`, wantErr: "/tags/1: expected at least 1 characters, got 0"},
+		"max items":              {data: `{"id": "order-1", "amount": 1, "tags": ["a", "b", "c"]}`, wantErr: "/tags: expected at most 2 items, got 3"},
+		"additional properties":  {data: `{"id": "order-1", "amount": 1, "note": "hello"}`, wantErr: "/note: not allowed"},
+		"number accepts integer": {data: `{"id": "order-1", "amount": 1}`},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := schema.Validate([]byte(tc.data))
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
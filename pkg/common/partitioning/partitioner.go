@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partitioning
+
+import (
+	"github.com/Shopify/sarama"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+// partitionerMetadata is the Metadata of the producer messages selecting their partitioner.
+type partitionerMetadata string
+
+// NewPartitionerConstructor returns a sarama.PartitionerConstructor whose
+// partitioners partition each message with the partitioner selected by its
+// Settings (see Settings.WriteProducerMessage), or with the partitioner of the
+// defaultConstructor when none is selected. A single producer can then write
+// to the topics of channels with different partitioners.
+func NewPartitionerConstructor(defaultConstructor sarama.PartitionerConstructor) sarama.PartitionerConstructor {
+	if defaultConstructor == nil {
+		defaultConstructor = sarama.NewHashPartitioner
+	}
+	return func(topic string) sarama.Partitioner {
+		return &channelPartitioner{
+			topic:        topic,
+			defaultPart:  defaultConstructor(topic),
+			partitioners: make(map[partitionerMetadata]sarama.Partitioner),
+		}
+	}
+}
+
+// channelPartitioner partitions the messages of a topic. Sarama calls it from
+// the single goroutine producing to the topic, it is not safe for concurrent use.
+type channelPartitioner struct {
+	topic        string
+	defaultPart  sarama.Partitioner
+	partitioners map[partitionerMetadata]sarama.Partitioner
+}
+
+var _ sarama.DynamicConsistencyPartitioner = (*channelPartitioner)(nil)
+
+// partitioner returns the partitioner selected by the message, created when first needed.
+func (p *channelPartitioner) partitioner(message *sarama.ProducerMessage) sarama.Partitioner {
+	selected, ok := message.Metadata.(partitionerMetadata)
+	if !ok {
+		return p.defaultPart
+	}
+	if partitioner, ok := p.partitioners[selected]; ok {
+		return partitioner
+	}
+
+	var partitioner sarama.Partitioner
+	switch selected {
+	case v1beta1.KafkaChannelPartitionerHash:
+		partitioner = sarama.NewHashPartitioner(p.topic)
+	case v1beta1.KafkaChannelPartitionerMurmur2:
+		partitioner = newMurmur2Partitioner(p.topic)
+	case v1beta1.KafkaChannelPartitionerRoundRobin:
+		partitioner = sarama.NewRoundRobinPartitioner(p.topic)
+	default:
+		partitioner = p.defaultPart
+	}
+	p.partitioners[selected] = partitioner
+	return partitioner
+}
+
+func (p *channelPartitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	return p.partitioner(message).Partition(message, numPartitions)
+}
+
+// RequiresConsistency is only used by sarama for the partitioners which are not
+// a DynamicConsistencyPartitioner.
+func (p *channelPartitioner) RequiresConsistency() bool {
+	return p.defaultPart.RequiresConsistency()
+}
+
+func (p *channelPartitioner) MessageRequiresConsistency(message *sarama.ProducerMessage) bool {
+	partitioner := p.partitioner(message)
+	if dynamic, ok := partitioner.(sarama.DynamicConsistencyPartitioner); ok {
+		return dynamic.MessageRequiresConsistency(message)
+	}
+	return partitioner.RequiresConsistency()
+}
+
+// murmur2Partitioner partitions the messages by the murmur2 hash of their key
+// alike the default partitioner of the Java clients, so that the messages they
+// write with the same key are written to the same partition. The messages
+// without key are partitioned randomly.
+type murmur2Partitioner struct {
+	random sarama.Partitioner
+}
+
+func newMurmur2Partitioner(topic string) sarama.Partitioner {
+	return &murmur2Partitioner{random: sarama.NewRandomPartitioner(topic)}
+}
+
+func (p *murmur2Partitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if message.Key == nil {
+		return p.random.Partition(message, numPartitions)
+	}
+	key, err := message.Key.Encode()
+	if err != nil {
+		return -1, err
+	}
+	// Utils.toPositive of the Java clients
+	return int32(murmur2(key)&0x7fffffff) % numPartitions, nil
+}
+
+func (p *murmur2Partitioner) RequiresConsistency() bool {
+	return true
+}
+
+func (p *murmur2Partitioner) MessageRequiresConsistency(message *sarama.ProducerMessage) bool {
+	return message.Key != nil
+}
+
+// murmur2 is the 32 bits murmur2 hash of the Java clients (Utils.murmur2).
+func murmur2(data []byte) uint32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+
+	length := len(data)
+	h := seed ^ uint32(length)
+
+	for i := 0; i+4 <= length; i += 4 {
+		k := uint32(data[i]) | uint32(data[i+1])<<8 | uint32(data[i+2])<<16 | uint32(data[i+3])<<24
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	tail := length &^ 3
+	switch length % 4 {
+	case 3:
+		h ^= uint32(data[tail+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[tail+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[tail])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return h
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partitioning
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+func TestMurmur2(t *testing.T) {
+	// The hashes of the Java clients (UtilsTest.testMurmur2)
+	tests := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	}
+	for key, want := range tests {
+		assert.Equal(t, want, int32(murmur2([]byte(key))), key)
+	}
+}
+
+func TestPartitionerConstructor(t *testing.T) {
+	partitioner := NewPartitionerConstructor(sarama.NewManualPartitioner)("topic")
+	dynamic, ok := partitioner.(sarama.DynamicConsistencyPartitioner)
+	require.True(t, ok)
+
+	// The default partitioner partitions the messages without partitioner
+	partition, err := partitioner.Partition(&sarama.ProducerMessage{Partition: 3}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), partition)
+	assert.True(t, dynamic.MessageRequiresConsistency(&sarama.ProducerMessage{}))
+
+	// The murmur2 partitioner partitions the keyed messages alike the Java clients
+	murmur2Message := &sarama.ProducerMessage{
+		Key:      sarama.StringEncoder("foobar"),
+		Metadata: partitionerMetadata(v1beta1.KafkaChannelPartitionerMurmur2),
+	}
+	partition, err = partitioner.Partition(murmur2Message, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int32((-790332482&0x7fffffff)%10), partition)
+	assert.True(t, dynamic.MessageRequiresConsistency(murmur2Message))
+	assert.False(t, dynamic.MessageRequiresConsistency(&sarama.ProducerMessage{Metadata: murmur2Message.Metadata}))
+
+	// The hash partitioner partitions the keyed messages consistently
+	hashMessage := &sarama.ProducerMessage{
+		Key:      sarama.StringEncoder("foobar"),
+		Metadata: partitionerMetadata(v1beta1.KafkaChannelPartitionerHash),
+	}
+	first, err := partitioner.Partition(hashMessage, 10)
+	assert.NoError(t, err)
+	second, err := partitioner.Partition(hashMessage, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.True(t, dynamic.MessageRequiresConsistency(hashMessage))
+
+	// The round robin partitioner ignores the keys
+	roundRobinMessage := &sarama.ProducerMessage{
+		Key:      sarama.StringEncoder("foobar"),
+		Metadata: partitionerMetadata(v1beta1.KafkaChannelPartitionerRoundRobin),
+	}
+	for want := int32(0); want < 3; want++ {
+		partition, err = partitioner.Partition(roundRobinMessage, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, want, partition)
+	}
+	assert.False(t, dynamic.MessageRequiresConsistency(roundRobinMessage))
+}
+
+func TestPartitionerConstructorDefault(t *testing.T) {
+	partitioner := NewPartitionerConstructor(nil)("topic")
+	assert.True(t, partitioner.RequiresConsistency())
+}
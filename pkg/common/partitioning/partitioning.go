@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package partitioning holds the logic shared by the KafkaChannel receivers to
+// select the partition of the events written to the channel topics.
+package partitioning
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Shopify/sarama"
+	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/types"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+// attributeNameRegexp matches the names of the CloudEvents attributes and extensions.
+var attributeNameRegexp = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// Settings select the partition of the events written to a KafkaChannel. The
+// events are keyed by their partitionkey extension, or by their KeyAttribute
+// when they have none, and partitioned by the Partitioner. The zero value keys
+// the events by their partitionkey extension only, and partitions them with the
+// partitioner of the sarama settings.
+type Settings struct {
+	// KeyAttribute is the name of the CloudEvents attribute, or extension, keying the events without partitionkey
+	KeyAttribute string
+	// Partitioner is one of the v1beta1.KafkaChannelPartitioner values, the sarama settings one when empty
+	Partitioner string
+}
+
+// SettingsFromPolicy returns the Settings set by the partitioning policy of a KafkaChannel, which may be nil.
+func SettingsFromPolicy(policy *v1beta1.PartitioningPolicy) (Settings, error) {
+	if policy == nil {
+		return Settings{}, nil
+	}
+	if policy.KeyAttribute != "" && !attributeNameRegexp.MatchString(policy.KeyAttribute) {
+		return Settings{}, fmt.Errorf("invalid partitioning key attribute %q: expected the name of a CloudEvents attribute or extension", policy.KeyAttribute)
+	}
+	switch policy.Partitioner {
+	case "", v1beta1.KafkaChannelPartitionerHash, v1beta1.KafkaChannelPartitionerMurmur2, v1beta1.KafkaChannelPartitionerRoundRobin:
+	default:
+		return Settings{}, fmt.Errorf("invalid partitioner %q: expected %q, %q or %q", policy.Partitioner,
+			v1beta1.KafkaChannelPartitionerHash, v1beta1.KafkaChannelPartitionerMurmur2, v1beta1.KafkaChannelPartitionerRoundRobin)
+	}
+	return Settings{KeyAttribute: policy.KeyAttribute, Partitioner: policy.Partitioner}, nil
+}
+
+// WriteProducerMessage fills the producerMessage with the message alike the
+// kafka_sarama protocol, which keys it by its partitionkey extension, keying it
+// by the KeyAttribute otherwise. The Partitioner is held in the Metadata of the
+// producerMessage, for the partitioner created by NewPartitionerConstructor.
+func (s Settings) WriteProducerMessage(ctx context.Context, message binding.Message, producerMessage *sarama.ProducerMessage, transformers ...binding.Transformer) error {
+	var key string
+	if s.KeyAttribute != "" {
+		transformers = append(transformers, binding.TransformerFunc(func(r binding.MessageMetadataReader, _ binding.MessageMetadataWriter) error {
+			var value interface{}
+			if attribute := spec.V1.Attribute(s.KeyAttribute); attribute != nil {
+				_, value = r.GetAttribute(attribute.Kind())
+			} else {
+				value = r.GetExtension(s.KeyAttribute)
+			}
+			if types.IsZero(value) {
+				return nil
+			}
+			formatted, err := types.Format(value)
+			if err != nil {
+				return err
+			}
+			key = formatted
+			return nil
+		}))
+	}
+
+	if err := protocolkafka.WriteProducerMessage(ctx, message, producerMessage, transformers...); err != nil {
+		return err
+	}
+
+	if producerMessage.Key == nil && key != "" {
+		producerMessage.Key = sarama.StringEncoder(key)
+	}
+	if s.Partitioner != "" {
+		producerMessage.Metadata = partitionerMetadata(s.Partitioner)
+	}
+	return nil
+}
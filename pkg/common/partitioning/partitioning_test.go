@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partitioning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+func TestSettingsFromPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy  *v1beta1.PartitioningPolicy
+		want    Settings
+		wantErr bool
+	}{
+		"none": {
+			policy: nil,
+			want:   Settings{},
+		},
+		"valid": {
+			policy: &v1beta1.PartitioningPolicy{KeyAttribute: "subject", Partitioner: v1beta1.KafkaChannelPartitionerMurmur2},
+			want:   Settings{KeyAttribute: "subject", Partitioner: v1beta1.KafkaChannelPartitionerMurmur2},
+		},
+		"invalid attribute": {
+			policy:  &v1beta1.PartitioningPolicy{KeyAttribute: "data.id"},
+			wantErr: true,
+		},
+		"invalid partitioner": {
+			policy:  &v1beta1.PartitioningPolicy{Partitioner: "sticky"},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			settings, err := SettingsFromPolicy(tc.policy)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.want, settings)
+		})
+	}
+}
+
+func TestWriteProducerMessage(t *testing.T) {
+	newMessage := func(extensions map[string]interface{}) binding.Message {
+		e := event.New()
+		e.SetID("id")
+		e.SetSource("source")
+		e.SetType("type")
+		e.SetSubject("the-subject")
+		for name, value := range extensions {
+			e.SetExtension(name, value)
+		}
+		return binding.ToMessage(&e)
+	}
+
+	tests := map[string]struct {
+		settings     Settings
+		extensions   map[string]interface{}
+		wantKey      sarama.Encoder
+		wantMetadata interface{}
+	}{
+		"no key": {
+			settings: Settings{},
+			wantKey:  nil,
+		},
+		"partitionkey extension": {
+			settings:   Settings{},
+			extensions: map[string]interface{}{"partitionkey": "the-key"},
+			wantKey:    sarama.StringEncoder("the-key"),
+		},
+		"partitionkey extension before key attribute": {
+			settings:   Settings{KeyAttribute: "subject"},
+			extensions: map[string]interface{}{"partitionkey": "the-key"},
+			wantKey:    sarama.StringEncoder("the-key"),
+		},
+		"key attribute": {
+			settings: Settings{KeyAttribute: "subject"},
+			wantKey:  sarama.StringEncoder("the-subject"),
+		},
+		"key extension": {
+			settings:   Settings{KeyAttribute: "tenant"},
+			extensions: map[string]interface{}{"tenant": "the-tenant"},
+			wantKey:    sarama.StringEncoder("the-tenant"),
+		},
+		"missing key extension": {
+			settings: Settings{KeyAttribute: "tenant"},
+			wantKey:  nil,
+		},
+		"partitioner": {
+			settings:     Settings{Partitioner: v1beta1.KafkaChannelPartitionerRoundRobin},
+			wantMetadata: partitionerMetadata(v1beta1.KafkaChannelPartitionerRoundRobin),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			producerMessage := &sarama.ProducerMessage{Topic: "topic"}
+			require.NoError(t, tc.settings.WriteProducerMessage(context.Background(), newMessage(tc.extensions), producerMessage))
+			assert.Equal(t, tc.wantKey, producerMessage.Key)
+			assert.Equal(t, tc.wantMetadata, producerMessage.Metadata)
+		})
+	}
+}
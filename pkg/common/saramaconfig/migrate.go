@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saramaconfig
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/ghodss/yaml"
+)
+
+// FromSaramaConfig returns the spec of the fields of the sarama.Config which
+// differ from the sarama defaults, the Kafka version is always included. It
+// is the migration path from the raw sarama.Config YAML: the settings which
+// cannot be represented (TLS certificates, custom partitioners and rebalance
+// strategies, SASL credentials) are left out.
+func FromSaramaConfig(config *sarama.Config) *SaramaConfigSpec {
+	defaults := sarama.NewConfig()
+	spec := &SaramaConfigSpec{
+		APIVersion:   SpecVersion,
+		KafkaVersion: config.Version.String(),
+	}
+
+	spec.Admin.Timeout = diffDuration(config.Admin.Timeout, defaults.Admin.Timeout)
+
+	// Net
+	spec.Net.MaxOpenRequests = diffInt(config.Net.MaxOpenRequests, defaults.Net.MaxOpenRequests)
+	spec.Net.DialTimeout = diffDuration(config.Net.DialTimeout, defaults.Net.DialTimeout)
+	spec.Net.ReadTimeout = diffDuration(config.Net.ReadTimeout, defaults.Net.ReadTimeout)
+	spec.Net.WriteTimeout = diffDuration(config.Net.WriteTimeout, defaults.Net.WriteTimeout)
+	spec.Net.KeepAlive = diffDuration(config.Net.KeepAlive, defaults.Net.KeepAlive)
+	spec.Net.TLS.Enable = config.Net.TLS.Enable
+	spec.Net.TLS.InsecureSkipVerify = config.Net.TLS.Config != nil && config.Net.TLS.Config.InsecureSkipVerify
+	spec.Net.SASL.Enable = config.Net.SASL.Enable
+	if config.Net.SASL.Mechanism != defaults.Net.SASL.Mechanism {
+		spec.Net.SASL.Mechanism = SASLMechanism(config.Net.SASL.Mechanism)
+	}
+	if config.Net.SASL.Version != defaults.Net.SASL.Version {
+		spec.Net.SASL.Version = &config.Net.SASL.Version
+	}
+	if config.Net.SASL.Handshake != defaults.Net.SASL.Handshake {
+		spec.Net.SASL.Handshake = &config.Net.SASL.Handshake
+	}
+
+	// Metadata
+	spec.Metadata.RefreshFrequency = diffDuration(config.Metadata.RefreshFrequency, defaults.Metadata.RefreshFrequency)
+	if config.Metadata.Full != defaults.Metadata.Full {
+		spec.Metadata.Full = &config.Metadata.Full
+	}
+	spec.Metadata.Retry = diffRetry(config.Metadata.Retry.Max, defaults.Metadata.Retry.Max, config.Metadata.Retry.Backoff, defaults.Metadata.Retry.Backoff)
+
+	// Producer
+	spec.Producer.Idempotent = config.Producer.Idempotent
+	if config.Producer.RequiredAcks != defaults.Producer.RequiredAcks {
+		switch config.Producer.RequiredAcks {
+		case sarama.NoResponse:
+			spec.Producer.RequiredAcks = RequiredAcksNone
+		case sarama.WaitForLocal:
+			spec.Producer.RequiredAcks = RequiredAcksLeader
+		default:
+			spec.Producer.RequiredAcks = RequiredAcksAll
+		}
+	}
+	spec.Producer.Timeout = diffDuration(config.Producer.Timeout, defaults.Producer.Timeout)
+	spec.Producer.MaxMessageBytes = diffInt(config.Producer.MaxMessageBytes, defaults.Producer.MaxMessageBytes)
+	if config.Producer.Compression != defaults.Producer.Compression {
+		spec.Producer.Compression = Compression(config.Producer.Compression.String())
+	}
+	if config.Producer.CompressionLevel != defaults.Producer.CompressionLevel {
+		spec.Producer.CompressionLevel = &config.Producer.CompressionLevel
+	}
+	spec.Producer.Flush.Bytes = config.Producer.Flush.Bytes
+	spec.Producer.Flush.Messages = config.Producer.Flush.Messages
+	spec.Producer.Flush.MaxMessages = config.Producer.Flush.MaxMessages
+	spec.Producer.Flush.Frequency = Duration(config.Producer.Flush.Frequency)
+	spec.Producer.Retry = diffRetry(config.Producer.Retry.Max, defaults.Producer.Retry.Max, config.Producer.Retry.Backoff, defaults.Producer.Retry.Backoff)
+
+	// Consumer
+	spec.Consumer.Group.SessionTimeout = diffDuration(config.Consumer.Group.Session.Timeout, defaults.Consumer.Group.Session.Timeout)
+	spec.Consumer.Group.HeartbeatInterval = diffDuration(config.Consumer.Group.Heartbeat.Interval, defaults.Consumer.Group.Heartbeat.Interval)
+	spec.Consumer.Group.RebalanceTimeout = diffDuration(config.Consumer.Group.Rebalance.Timeout, defaults.Consumer.Group.Rebalance.Timeout)
+	if config.Consumer.Offsets.Initial != defaults.Consumer.Offsets.Initial {
+		spec.Consumer.Offsets.Initial = InitialOffsetOldest
+	}
+	if config.Consumer.Offsets.AutoCommit.Enable != defaults.Consumer.Offsets.AutoCommit.Enable {
+		spec.Consumer.Offsets.AutoCommit = &config.Consumer.Offsets.AutoCommit.Enable
+	}
+	spec.Consumer.Offsets.AutoCommitInterval = diffDuration(config.Consumer.Offsets.AutoCommit.Interval, defaults.Consumer.Offsets.AutoCommit.Interval)
+	spec.Consumer.Offsets.Retention = diffDuration(config.Consumer.Offsets.Retention, defaults.Consumer.Offsets.Retention)
+	if config.Consumer.Fetch.Min != defaults.Consumer.Fetch.Min {
+		spec.Consumer.Fetch.Min = config.Consumer.Fetch.Min
+	}
+	if config.Consumer.Fetch.Default != defaults.Consumer.Fetch.Default {
+		spec.Consumer.Fetch.Default = config.Consumer.Fetch.Default
+	}
+	spec.Consumer.Fetch.Max = config.Consumer.Fetch.Max
+	spec.Consumer.MaxWaitTime = diffDuration(config.Consumer.MaxWaitTime, defaults.Consumer.MaxWaitTime)
+	spec.Consumer.MaxProcessingTime = diffDuration(config.Consumer.MaxProcessingTime, defaults.Consumer.MaxProcessingTime)
+	spec.Consumer.RetryBackoff = diffDuration(config.Consumer.Retry.Backoff, defaults.Consumer.Retry.Backoff)
+
+	return spec
+}
+
+// YAML returns the spec as a YAML string, without the empty sections.
+func (s *SaramaConfigSpec) YAML() (string, error) {
+	jsonBytes, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(jsonBytes, &fields); err != nil {
+		return "", err
+	}
+	yamlBytes, err := yaml.Marshal(pruneEmpty(fields))
+	return string(yamlBytes), err
+}
+
+// pruneEmpty removes the empty objects from the fields, recursively.
+func pruneEmpty(fields map[string]interface{}) map[string]interface{} {
+	for key, value := range fields {
+		if object, ok := value.(map[string]interface{}); ok {
+			if len(pruneEmpty(object)) == 0 {
+				delete(fields, key)
+			}
+		}
+	}
+	return fields
+}
+
+func diffDuration(value, defaultValue time.Duration) Duration {
+	if value == defaultValue {
+		return 0
+	}
+	return Duration(value)
+}
+
+func diffInt(value, defaultValue int) int {
+	if value == defaultValue {
+		return 0
+	}
+	return value
+}
+
+func diffRetry(max, defaultMax int, backoff, defaultBackoff time.Duration) RetrySpec {
+	retry := RetrySpec{Backoff: diffDuration(backoff, defaultBackoff)}
+	if max != defaultMax {
+		retry.Max = &max
+	}
+	return retry
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saramaconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromSaramaConfigDefaults(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+
+	out, err := FromSaramaConfig(config).YAML()
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: v1\nkafkaVersion: 2.0.0\n", out)
+}
+
+func TestFromSaramaConfigRoundTrip(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_3_0_0
+	config.Admin.Timeout = 10 * time.Second
+	config.Net.MaxOpenRequests = 1
+	config.Net.SASL.Enable = true
+	config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	config.Net.SASL.Version = sarama.SASLHandshakeV1
+	config.Net.TLS.Enable = true
+	config.Metadata.Full = false
+	config.Metadata.Retry.Max = 0
+	config.Producer.Idempotent = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Compression = sarama.CompressionLZ4
+	config.Producer.Flush.Frequency = 50 * time.Millisecond
+	config.Producer.Retry.Max = 10
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Offsets.AutoCommit.Enable = false
+	config.Consumer.Offsets.Retention = 24 * time.Hour
+	config.Consumer.Fetch.Default = 2048
+
+	out, err := FromSaramaConfig(config).YAML()
+	require.NoError(t, err)
+	spec, err := Parse(out)
+	require.NoError(t, err)
+
+	migrated := sarama.NewConfig()
+	require.NoError(t, spec.ApplyTo(migrated))
+	assert.Equal(t, config.Version, migrated.Version)
+	assert.Equal(t, config.Admin, migrated.Admin)
+	assert.Equal(t, config.Net.MaxOpenRequests, migrated.Net.MaxOpenRequests)
+	assert.Equal(t, config.Net.SASL, migrated.Net.SASL)
+	assert.Equal(t, config.Net.TLS.Enable, migrated.Net.TLS.Enable)
+	assert.Equal(t, config.Metadata, migrated.Metadata)
+	assert.Equal(t, config.Producer.Idempotent, migrated.Producer.Idempotent)
+	assert.Equal(t, config.Producer.RequiredAcks, migrated.Producer.RequiredAcks)
+	assert.Equal(t, config.Producer.Compression, migrated.Producer.Compression)
+	assert.Equal(t, config.Producer.Flush, migrated.Producer.Flush)
+	assert.Equal(t, config.Producer.Retry.Max, migrated.Producer.Retry.Max)
+	assert.Equal(t, config.Consumer.Offsets, migrated.Consumer.Offsets)
+	assert.Equal(t, config.Consumer.Fetch, migrated.Consumer.Fetch)
+}
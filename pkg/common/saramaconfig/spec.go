@@ -0,0 +1,362 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package saramaconfig provides SaramaConfigSpec, a typed and documented
+// representation of the sarama client settings shared by the KafkaChannel
+// implementations and the KafkaSource.
+package saramaconfig
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/ghodss/yaml"
+	"knative.dev/pkg/apis"
+)
+
+// SpecVersion is the only supported apiVersion of the SaramaConfigSpec.
+const SpecVersion = "v1"
+
+// SaramaConfigSpec holds the sarama client settings. Unset (zero) fields keep
+// the value of the sarama.Config it is applied to. Durations are written as
+// Go duration strings (e.g. "10s") and the SASL credentials are never part of
+// the spec, they are provided by the components from their Kafka secrets.
+type SaramaConfigSpec struct {
+	// APIVersion is the version of the spec, it must be SpecVersion.
+	APIVersion string `json:"apiVersion"`
+	// KafkaVersion is the version of the Kafka protocol, e.g. "2.0.0".
+	KafkaVersion string       `json:"kafkaVersion,omitempty"`
+	Admin        AdminSpec    `json:"admin,omitempty"`
+	Net          NetSpec      `json:"net,omitempty"`
+	Metadata     MetadataSpec `json:"metadata,omitempty"`
+	Producer     ProducerSpec `json:"producer,omitempty"`
+	Consumer     ConsumerSpec `json:"consumer,omitempty"`
+}
+
+// AdminSpec holds the settings of the ClusterAdmin.
+type AdminSpec struct {
+	Timeout Duration `json:"timeout,omitempty"`
+}
+
+// NetSpec holds the network settings.
+type NetSpec struct {
+	// MaxOpenRequests must be 1 when the producer is idempotent.
+	MaxOpenRequests int      `json:"maxOpenRequests,omitempty"`
+	DialTimeout     Duration `json:"dialTimeout,omitempty"`
+	ReadTimeout     Duration `json:"readTimeout,omitempty"`
+	WriteTimeout    Duration `json:"writeTimeout,omitempty"`
+	KeepAlive       Duration `json:"keepAlive,omitempty"`
+	TLS             TLSSpec  `json:"tls,omitempty"`
+	SASL            SASLSpec `json:"sasl,omitempty"`
+}
+
+// TLSSpec holds the TLS settings.
+type TLSSpec struct {
+	Enable bool `json:"enable,omitempty"`
+	// RootPEMs are the PEM encoded certificates used to verify the brokers.
+	RootPEMs           []string `json:"rootPEMs,omitempty"`
+	InsecureSkipVerify bool     `json:"insecureSkipVerify,omitempty"`
+}
+
+// SASLSpec holds the SASL settings.
+type SASLSpec struct {
+	Enable    bool          `json:"enable,omitempty"`
+	Mechanism SASLMechanism `json:"mechanism,omitempty"`
+	// Version is the SASL handshake version, 0 or 1.
+	Version   *int16 `json:"version,omitempty"`
+	Handshake *bool  `json:"handshake,omitempty"`
+}
+
+// MetadataSpec holds the cluster metadata settings.
+type MetadataSpec struct {
+	RefreshFrequency Duration  `json:"refreshFrequency,omitempty"`
+	Full             *bool     `json:"full,omitempty"`
+	Retry            RetrySpec `json:"retry,omitempty"`
+}
+
+// RetrySpec holds the settings of retried operations.
+type RetrySpec struct {
+	Max     *int     `json:"max,omitempty"`
+	Backoff Duration `json:"backoff,omitempty"`
+}
+
+// ProducerSpec holds the producer settings.
+type ProducerSpec struct {
+	Idempotent       bool         `json:"idempotent,omitempty"`
+	RequiredAcks     RequiredAcks `json:"requiredAcks,omitempty"`
+	Timeout          Duration     `json:"timeout,omitempty"`
+	MaxMessageBytes  int          `json:"maxMessageBytes,omitempty"`
+	Compression      Compression  `json:"compression,omitempty"`
+	CompressionLevel *int         `json:"compressionLevel,omitempty"`
+	Partitioner      Partitioner  `json:"partitioner,omitempty"`
+	Flush            FlushSpec    `json:"flush,omitempty"`
+	Retry            RetrySpec    `json:"retry,omitempty"`
+}
+
+// FlushSpec holds the batching settings of the producer.
+type FlushSpec struct {
+	Bytes       int      `json:"bytes,omitempty"`
+	Messages    int      `json:"messages,omitempty"`
+	MaxMessages int      `json:"maxMessages,omitempty"`
+	Frequency   Duration `json:"frequency,omitempty"`
+}
+
+// ConsumerSpec holds the consumer settings.
+type ConsumerSpec struct {
+	Group             GroupSpec   `json:"group,omitempty"`
+	Offsets           OffsetsSpec `json:"offsets,omitempty"`
+	Fetch             FetchSpec   `json:"fetch,omitempty"`
+	MaxWaitTime       Duration    `json:"maxWaitTime,omitempty"`
+	MaxProcessingTime Duration    `json:"maxProcessingTime,omitempty"`
+	RetryBackoff      Duration    `json:"retryBackoff,omitempty"`
+}
+
+// GroupSpec holds the consumer group settings.
+type GroupSpec struct {
+	SessionTimeout    Duration          `json:"sessionTimeout,omitempty"`
+	HeartbeatInterval Duration          `json:"heartbeatInterval,omitempty"`
+	RebalanceStrategy RebalanceStrategy `json:"rebalanceStrategy,omitempty"`
+	RebalanceTimeout  Duration          `json:"rebalanceTimeout,omitempty"`
+}
+
+// OffsetsSpec holds the offset management settings of the consumer.
+type OffsetsSpec struct {
+	Initial            InitialOffset `json:"initial,omitempty"`
+	AutoCommit         *bool         `json:"autoCommit,omitempty"`
+	AutoCommitInterval Duration      `json:"autoCommitInterval,omitempty"`
+	Retention          Duration      `json:"retention,omitempty"`
+}
+
+// FetchSpec holds the fetch sizes of the consumer, in bytes.
+type FetchSpec struct {
+	Min     int32 `json:"min,omitempty"`
+	Default int32 `json:"default,omitempty"`
+	Max     int32 `json:"max,omitempty"`
+}
+
+// Parse strictly unmarshals the YAML (or JSON) string into a SaramaConfigSpec
+// and validates it. Unknown fields are rejected.
+func Parse(yamlString string) (*SaramaConfigSpec, error) {
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	if err != nil {
+		return nil, err
+	}
+	spec := &SaramaConfigSpec{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the sarama config spec: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// IsSpec returns whether the YAML (or JSON) string is a SaramaConfigSpec, as
+// opposed to the raw sarama.Config YAML, based on its apiVersion field.
+func IsSpec(yamlString string) bool {
+	shell := struct {
+		APIVersion string `json:"apiVersion"`
+	}{}
+	if err := yaml.Unmarshal([]byte(yamlString), &shell); err != nil {
+		return false
+	}
+	return shell.APIVersion != ""
+}
+
+// Validate returns the errors of the spec, nil when it is valid.
+func (s *SaramaConfigSpec) Validate() *apis.FieldError {
+	var errs *apis.FieldError
+	if s.APIVersion != SpecVersion {
+		errs = errs.Also(apis.ErrInvalidValue(s.APIVersion, "apiVersion"))
+	}
+	if s.KafkaVersion != "" {
+		if _, err := sarama.ParseKafkaVersion(s.KafkaVersion); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(s.KafkaVersion, "kafkaVersion"))
+		}
+	}
+	if _, err := s.Net.TLS.certPool(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(err.Error(), "net.tls.rootPEMs"))
+	}
+	if _, err := s.Net.SASL.Mechanism.toSarama(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(s.Net.SASL.Mechanism, "net.sasl.mechanism"))
+	}
+	if _, err := s.Producer.RequiredAcks.toSarama(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(s.Producer.RequiredAcks, "producer.requiredAcks"))
+	}
+	if _, err := s.Producer.Compression.toSarama(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(s.Producer.Compression, "producer.compression"))
+	}
+	if _, err := s.Producer.Partitioner.toSarama(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(s.Producer.Partitioner, "producer.partitioner"))
+	}
+	if _, err := s.Consumer.Group.RebalanceStrategy.toSarama(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(s.Consumer.Group.RebalanceStrategy, "consumer.group.rebalanceStrategy"))
+	}
+	if _, err := s.Consumer.Offsets.Initial.toSarama(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(s.Consumer.Offsets.Initial, "consumer.offsets.initial"))
+	}
+	return errs
+}
+
+// ApplyTo sets the fields of the spec on the sarama.Config, leaving the fields
+// which are not set in the spec untouched. A nil spec is a no-op.
+func (s *SaramaConfigSpec) ApplyTo(config *sarama.Config) error {
+	if s == nil {
+		return nil
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	if s.KafkaVersion != "" {
+		config.Version, _ = sarama.ParseKafkaVersion(s.KafkaVersion)
+	}
+	setDuration(&config.Admin.Timeout, s.Admin.Timeout)
+
+	// Net
+	setInt(&config.Net.MaxOpenRequests, s.Net.MaxOpenRequests)
+	setDuration(&config.Net.DialTimeout, s.Net.DialTimeout)
+	setDuration(&config.Net.ReadTimeout, s.Net.ReadTimeout)
+	setDuration(&config.Net.WriteTimeout, s.Net.WriteTimeout)
+	setDuration(&config.Net.KeepAlive, s.Net.KeepAlive)
+	if s.Net.TLS.Enable {
+		config.Net.TLS.Enable = true
+	}
+	if certPool, _ := s.Net.TLS.certPool(); certPool != nil || s.Net.TLS.InsecureSkipVerify {
+		if config.Net.TLS.Config == nil {
+			config.Net.TLS.Config = &tls.Config{}
+		}
+		if certPool != nil {
+			config.Net.TLS.Config.RootCAs = certPool
+		}
+		if s.Net.TLS.InsecureSkipVerify {
+			config.Net.TLS.Config.InsecureSkipVerify = true
+		}
+	}
+	if s.Net.SASL.Enable {
+		config.Net.SASL.Enable = true
+	}
+	if mechanism, _ := s.Net.SASL.Mechanism.toSarama(); mechanism != "" {
+		config.Net.SASL.Mechanism = mechanism
+	}
+	if s.Net.SASL.Version != nil {
+		config.Net.SASL.Version = *s.Net.SASL.Version
+	}
+	if s.Net.SASL.Handshake != nil {
+		config.Net.SASL.Handshake = *s.Net.SASL.Handshake
+	}
+
+	// Metadata
+	setDuration(&config.Metadata.RefreshFrequency, s.Metadata.RefreshFrequency)
+	if s.Metadata.Full != nil {
+		config.Metadata.Full = *s.Metadata.Full
+	}
+	s.Metadata.Retry.applyTo(&config.Metadata.Retry.Max, &config.Metadata.Retry.Backoff)
+
+	// Producer
+	if s.Producer.Idempotent {
+		config.Producer.Idempotent = true
+	}
+	if acks, _ := s.Producer.RequiredAcks.toSarama(); s.Producer.RequiredAcks != "" {
+		config.Producer.RequiredAcks = acks
+	}
+	setDuration(&config.Producer.Timeout, s.Producer.Timeout)
+	setInt(&config.Producer.MaxMessageBytes, s.Producer.MaxMessageBytes)
+	if codec, _ := s.Producer.Compression.toSarama(); s.Producer.Compression != "" {
+		config.Producer.Compression = codec
+	}
+	if s.Producer.CompressionLevel != nil {
+		config.Producer.CompressionLevel = *s.Producer.CompressionLevel
+	}
+	if partitioner, _ := s.Producer.Partitioner.toSarama(); partitioner != nil {
+		config.Producer.Partitioner = partitioner
+	}
+	setInt(&config.Producer.Flush.Bytes, s.Producer.Flush.Bytes)
+	setInt(&config.Producer.Flush.Messages, s.Producer.Flush.Messages)
+	setInt(&config.Producer.Flush.MaxMessages, s.Producer.Flush.MaxMessages)
+	setDuration(&config.Producer.Flush.Frequency, s.Producer.Flush.Frequency)
+	s.Producer.Retry.applyTo(&config.Producer.Retry.Max, &config.Producer.Retry.Backoff)
+
+	// Consumer
+	setDuration(&config.Consumer.Group.Session.Timeout, s.Consumer.Group.SessionTimeout)
+	setDuration(&config.Consumer.Group.Heartbeat.Interval, s.Consumer.Group.HeartbeatInterval)
+	if strategy, _ := s.Consumer.Group.RebalanceStrategy.toSarama(); strategy != nil {
+		config.Consumer.Group.Rebalance.Strategy = strategy
+	}
+	setDuration(&config.Consumer.Group.Rebalance.Timeout, s.Consumer.Group.RebalanceTimeout)
+	if initial, _ := s.Consumer.Offsets.Initial.toSarama(); initial != 0 {
+		config.Consumer.Offsets.Initial = initial
+	}
+	if s.Consumer.Offsets.AutoCommit != nil {
+		config.Consumer.Offsets.AutoCommit.Enable = *s.Consumer.Offsets.AutoCommit
+	}
+	setDuration(&config.Consumer.Offsets.AutoCommit.Interval, s.Consumer.Offsets.AutoCommitInterval)
+	setDuration(&config.Consumer.Offsets.Retention, s.Consumer.Offsets.Retention)
+	setInt32(&config.Consumer.Fetch.Min, s.Consumer.Fetch.Min)
+	setInt32(&config.Consumer.Fetch.Default, s.Consumer.Fetch.Default)
+	setInt32(&config.Consumer.Fetch.Max, s.Consumer.Fetch.Max)
+	setDuration(&config.Consumer.MaxWaitTime, s.Consumer.MaxWaitTime)
+	setDuration(&config.Consumer.MaxProcessingTime, s.Consumer.MaxProcessingTime)
+	setDuration(&config.Consumer.Retry.Backoff, s.Consumer.RetryBackoff)
+
+	return nil
+}
+
+// certPool returns the pool of the RootPEMs, nil when there are none.
+func (t *TLSSpec) certPool() (*x509.CertPool, error) {
+	if len(t.RootPEMs) == 0 {
+		return nil, nil
+	}
+	certPool := x509.NewCertPool()
+	for _, rootPEM := range t.RootPEMs {
+		if !certPool.AppendCertsFromPEM([]byte(rootPEM)) {
+			return nil, fmt.Errorf("failed to parse root certificate PEM: %s", rootPEM)
+		}
+	}
+	return certPool, nil
+}
+
+func (r *RetrySpec) applyTo(max *int, backoff *time.Duration) {
+	if r.Max != nil {
+		*max = *r.Max
+	}
+	setDuration(backoff, r.Backoff)
+}
+
+func setDuration(target *time.Duration, d Duration) {
+	if d != 0 {
+		*target = time.Duration(d)
+	}
+}
+
+func setInt(target *int, i int) {
+	if i != 0 {
+		*target = i
+	}
+}
+
+func setInt32(target *int32, i int32) {
+	if i != 0 {
+		*target = i
+	}
+}
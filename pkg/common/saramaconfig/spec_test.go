@@ -0,0 +1,200 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saramaconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const specYaml = `
+apiVersion: v1
+kafkaVersion: 2.3.0
+admin:
+  timeout: 10s
+net:
+  maxOpenRequests: 1
+  keepAlive: 30s
+  tls:
+    enable: true
+    insecureSkipVerify: true
+  sasl:
+    enable: true
+    mechanism: SCRAM-SHA-512
+    version: 1
+metadata:
+  refreshFrequency: 5m
+  retry:
+    max: 0
+producer:
+  idempotent: true
+  requiredAcks: all
+  compression: zstd
+  partitioner: roundRobin
+  flush:
+    frequency: 100ms
+consumer:
+  group:
+    rebalanceStrategy: sticky
+  offsets:
+    initial: oldest
+    autoCommit: false
+    retention: 168h
+`
+
+// newRootPEM returns a self signed certificate
+func newRootPEM(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kafka"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestParseAndApply(t *testing.T) {
+	spec, err := Parse(specYaml)
+	require.NoError(t, err)
+
+	config := sarama.NewConfig()
+	config.ClientID = "test-client"
+	require.NoError(t, spec.ApplyTo(config))
+
+	assert.Equal(t, sarama.V2_3_0_0, config.Version)
+	assert.Equal(t, "test-client", config.ClientID)
+	assert.Equal(t, 10*time.Second, config.Admin.Timeout)
+	assert.Equal(t, 1, config.Net.MaxOpenRequests)
+	assert.Equal(t, 30*time.Second, config.Net.KeepAlive)
+	assert.True(t, config.Net.TLS.Enable)
+	assert.True(t, config.Net.TLS.Config.InsecureSkipVerify)
+	assert.True(t, config.Net.SASL.Enable)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), config.Net.SASL.Mechanism)
+	assert.Equal(t, sarama.SASLHandshakeV1, config.Net.SASL.Version)
+	assert.Equal(t, 5*time.Minute, config.Metadata.RefreshFrequency)
+	assert.Equal(t, 0, config.Metadata.Retry.Max)
+	assert.True(t, config.Producer.Idempotent)
+	assert.Equal(t, sarama.WaitForAll, config.Producer.RequiredAcks)
+	assert.Equal(t, sarama.CompressionZSTD, config.Producer.Compression)
+	assert.NotNil(t, config.Producer.Partitioner)
+	assert.Equal(t, 100*time.Millisecond, config.Producer.Flush.Frequency)
+	assert.Equal(t, sarama.BalanceStrategySticky, config.Consumer.Group.Rebalance.Strategy)
+	assert.Equal(t, sarama.OffsetOldest, config.Consumer.Offsets.Initial)
+	assert.False(t, config.Consumer.Offsets.AutoCommit.Enable)
+	assert.Equal(t, 7*24*time.Hour, config.Consumer.Offsets.Retention)
+
+	// Unset fields keep their value
+	defaults := sarama.NewConfig()
+	assert.Equal(t, defaults.Net.DialTimeout, config.Net.DialTimeout)
+	assert.Equal(t, defaults.Producer.Retry.Max, config.Producer.Retry.Max)
+	assert.Equal(t, defaults.Consumer.Offsets.AutoCommit.Interval, config.Consumer.Offsets.AutoCommit.Interval)
+}
+
+func TestParseRootPEMs(t *testing.T) {
+	rootPEM := newRootPEM(t)
+	spec, err := Parse("apiVersion: v1\nnet:\n  tls:\n    rootPEMs:\n    - |\n" + indent(rootPEM, "      "))
+	require.NoError(t, err)
+
+	config := sarama.NewConfig()
+	require.NoError(t, spec.ApplyTo(config))
+	require.NotNil(t, config.Net.TLS.Config)
+	assert.Len(t, config.Net.TLS.Config.RootCAs.Subjects(), 1)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]struct {
+		yaml string
+		want []string
+	}{
+		"invalid yaml": {
+			yaml: "apiVersion: [",
+			want: []string{"yaml"},
+		},
+		"missing apiVersion": {
+			yaml: "kafkaVersion: 2.0.0",
+			want: []string{"apiVersion"},
+		},
+		"unknown field": {
+			yaml: "apiVersion: v1\nnet:\n  keepalive: 10s\n  tls:\n    enabled: true",
+			want: []string{"unknown field"},
+		},
+		"nanosecond duration": {
+			yaml: "apiVersion: v1\nadmin:\n  timeout: 1h1x",
+			want: []string{"duration"},
+		},
+		"invalid enums": {
+			yaml: "apiVersion: v1\nkafkaVersion: 0.0.0.1.2\nproducer:\n  compression: brotli\n  requiredAcks: some\n  partitioner: sticky\nconsumer:\n  group:\n    rebalanceStrategy: fair\n  offsets:\n    initial: latest\nnet:\n  sasl:\n    mechanism: GSSAPI\n  tls:\n    rootPEMs: [invalid]",
+			want: []string{"kafkaVersion", "consumer.group.rebalanceStrategy", "consumer.offsets.initial", "net.sasl.mechanism", "net.tls.rootPEMs", "producer.compression", "producer.partitioner", "producer.requiredAcks"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec, err := Parse(tc.yaml)
+			assert.Nil(t, spec)
+			require.Error(t, err)
+			for _, want := range tc.want {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestDurations(t *testing.T) {
+	spec, err := Parse("apiVersion: v1\nadmin:\n  timeout: 10000000000")
+	require.NoError(t, err)
+	assert.Equal(t, Duration(10*time.Second), spec.Admin.Timeout)
+
+	out, err := spec.YAML()
+	require.NoError(t, err)
+	assert.Contains(t, out, "timeout: 10s")
+}
+
+func TestIsSpec(t *testing.T) {
+	assert.True(t, IsSpec(specYaml))
+	assert.False(t, IsSpec("Net:\n  KeepAlive: 30000000000"))
+	assert.False(t, IsSpec(""))
+	assert.False(t, IsSpec("apiVersion: ["))
+}
+
+func TestApplyNilSpec(t *testing.T) {
+	var spec *SaramaConfigSpec
+	config := sarama.NewConfig()
+	assert.NoError(t, spec.ApplyTo(config))
+}
+
+func indent(s, prefix string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	return prefix + strings.Join(lines, "\n"+prefix) + "\n"
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saramaconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// Duration is a time.Duration written as a Go duration string (e.g. "1m30s").
+// Integers are read as nanoseconds for compatibility with the raw sarama YAML.
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case string:
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = Duration(duration)
+	case float64:
+		*d = Duration(v)
+	default:
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	return nil
+}
+
+// SASLMechanism is the SASL mechanism used to authenticate with the brokers.
+type SASLMechanism string
+
+const (
+	SASLMechanismPlain       SASLMechanism = "PLAIN"
+	SASLMechanismScramSHA256 SASLMechanism = "SCRAM-SHA-256"
+	SASLMechanismScramSHA512 SASLMechanism = "SCRAM-SHA-512"
+)
+
+func (m SASLMechanism) toSarama() (sarama.SASLMechanism, error) {
+	switch m {
+	case "":
+		return "", nil
+	case SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512:
+		return sarama.SASLMechanism(m), nil
+	}
+	return "", fmt.Errorf("unknown SASL mechanism %q", m)
+}
+
+// RequiredAcks is the level of acknowledgement required from the brokers.
+type RequiredAcks string
+
+const (
+	RequiredAcksNone   RequiredAcks = "none"
+	RequiredAcksLeader RequiredAcks = "leader"
+	RequiredAcksAll    RequiredAcks = "all"
+)
+
+func (a RequiredAcks) toSarama() (sarama.RequiredAcks, error) {
+	switch a {
+	case "", RequiredAcksNone:
+		return sarama.NoResponse, nil
+	case RequiredAcksLeader:
+		return sarama.WaitForLocal, nil
+	case RequiredAcksAll:
+		return sarama.WaitForAll, nil
+	}
+	return 0, fmt.Errorf("unknown required acks %q", a)
+}
+
+// Compression is the compression codec of the produced messages.
+type Compression string
+
+const (
+	CompressionNone   Compression = "none"
+	CompressionGZIP   Compression = "gzip"
+	CompressionSnappy Compression = "snappy"
+	CompressionLZ4    Compression = "lz4"
+	CompressionZSTD   Compression = "zstd"
+)
+
+func (c Compression) toSarama() (sarama.CompressionCodec, error) {
+	switch c {
+	case "", CompressionNone:
+		return sarama.CompressionNone, nil
+	case CompressionGZIP:
+		return sarama.CompressionGZIP, nil
+	case CompressionSnappy:
+		return sarama.CompressionSnappy, nil
+	case CompressionLZ4:
+		return sarama.CompressionLZ4, nil
+	case CompressionZSTD:
+		return sarama.CompressionZSTD, nil
+	}
+	return 0, fmt.Errorf("unknown compression %q", c)
+}
+
+// Partitioner selects the partition of the produced messages.
+type Partitioner string
+
+const (
+	PartitionerHash          Partitioner = "hash"
+	PartitionerReferenceHash Partitioner = "referenceHash"
+	PartitionerRandom        Partitioner = "random"
+	PartitionerRoundRobin    Partitioner = "roundRobin"
+	PartitionerManual        Partitioner = "manual"
+)
+
+func (p Partitioner) toSarama() (sarama.PartitionerConstructor, error) {
+	switch p {
+	case "":
+		return nil, nil
+	case PartitionerHash:
+		return sarama.NewHashPartitioner, nil
+	case PartitionerReferenceHash:
+		return sarama.NewReferenceHashPartitioner, nil
+	case PartitionerRandom:
+		return sarama.NewRandomPartitioner, nil
+	case PartitionerRoundRobin:
+		return sarama.NewRoundRobinPartitioner, nil
+	case PartitionerManual:
+		return sarama.NewManualPartitioner, nil
+	}
+	return nil, fmt.Errorf("unknown partitioner %q", p)
+}
+
+// RebalanceStrategy assigns the partitions to the members of a consumer group.
+type RebalanceStrategy string
+
+const (
+	RebalanceStrategyRange      RebalanceStrategy = "range"
+	RebalanceStrategyRoundRobin RebalanceStrategy = "roundRobin"
+	RebalanceStrategySticky     RebalanceStrategy = "sticky"
+)
+
+func (r RebalanceStrategy) toSarama() (sarama.BalanceStrategy, error) {
+	switch r {
+	case "":
+		return nil, nil
+	case RebalanceStrategyRange:
+		return sarama.BalanceStrategyRange, nil
+	case RebalanceStrategyRoundRobin:
+		return sarama.BalanceStrategyRoundRobin, nil
+	case RebalanceStrategySticky:
+		return sarama.BalanceStrategySticky, nil
+	}
+	return nil, fmt.Errorf("unknown rebalance strategy %q", r)
+}
+
+// InitialOffset is the offset a consumer group starts from when it has none committed.
+type InitialOffset string
+
+const (
+	InitialOffsetNewest InitialOffset = "newest"
+	InitialOffsetOldest InitialOffset = "oldest"
+)
+
+func (o InitialOffset) toSarama() (int64, error) {
+	switch o {
+	case "":
+		return 0, nil
+	case InitialOffsetNewest:
+		return sarama.OffsetNewest, nil
+	case InitialOffsetOldest:
+		return sarama.OffsetOldest, nil
+	}
+	return 0, fmt.Errorf("unknown initial offset %q", o)
+}
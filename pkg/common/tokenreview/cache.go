@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tokenreview caches the results of the reviews of the bearer tokens by the Kubernetes API server, so that
+// the tokens presented on every request are not reviewed again until the results expire.
+package tokenreview
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Result is the result of the review of a token, the username it authenticates or the error rejecting it.
+type Result struct {
+	Username string
+	Err      error
+}
+
+// Cache holds the results of the reviews of the tokens for a TTL, up to a size. It is safe for concurrent use.
+type Cache struct {
+	ttl  time.Duration
+	size int
+
+	lock    sync.Mutex
+	entries map[key]entry
+}
+
+// key identifies a review by the hash of the token and the qualifier of the review, e.g. the verb or the audiences
+// the token is reviewed for.
+type key struct {
+	token     [sha256.Size]byte
+	qualifier string
+}
+
+// entry is a cached result with its expiration time.
+type entry struct {
+	result  Result
+	expires time.Time
+}
+
+// NewCache returns a Cache reusing the results for the ttl, and holding up to size of them.
+func NewCache(ttl time.Duration, size int) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[key]entry),
+	}
+}
+
+// Get returns the result of the review of the token for the qualifier, if it has not expired at now.
+func (c *Cache) Get(token string, qualifier string, now time.Time) (Result, bool) {
+	c.lock.Lock()
+	cached, ok := c.entries[key{token: sha256.Sum256([]byte(token)), qualifier: qualifier}]
+	c.lock.Unlock()
+	if !ok || !now.Before(cached.expires) {
+		return Result{}, false
+	}
+	return cached.result, true
+}
+
+// Add caches the result of the review of the token for the qualifier, reviewed at now.
+func (c *Cache) Add(token string, qualifier string, result Result, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.entries) >= c.size {
+		c.purge(now)
+	}
+	c.entries[key{token: sha256.Sum256([]byte(token)), qualifier: qualifier}] = entry{result: result, expires: now.Add(c.ttl)}
+}
+
+// purge removes the expired results, or all of them if none is expired.
+func (c *Cache) purge(now time.Time) {
+	for k, cached := range c.entries {
+		if !now.Before(cached.expires) {
+			delete(c.entries, k)
+		}
+	}
+	if len(c.entries) >= c.size {
+		c.entries = make(map[key]entry)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenreview
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	cache := NewCache(time.Minute, 2)
+	now := time.Now()
+
+	_, ok := cache.Get("token", "get", now)
+	assert.False(t, ok)
+
+	cache.Add("token", "get", Result{Username: "admin"}, now)
+	result, ok := cache.Get("token", "get", now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, Result{Username: "admin"}, result)
+
+	// The results are per qualifier
+	_, ok = cache.Get("token", "update", now)
+	assert.False(t, ok)
+	errForbidden := errors.New("forbidden")
+	cache.Add("token", "update", Result{Username: "admin", Err: errForbidden}, now.Add(time.Second))
+	result, ok = cache.Get("token", "update", now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, errForbidden, result.Err)
+
+	// The results expire after the TTL
+	_, ok = cache.Get("token", "get", now.Add(time.Minute))
+	assert.False(t, ok)
+
+	// The expired results are purged when the cache is full
+	cache.Add("other", "get", Result{Username: "viewer"}, now.Add(time.Minute))
+	_, ok = cache.Get("token", "update", now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Len(t, cache.entries, 2)
+
+	// And all the results when none is expired
+	cache.Add("third", "get", Result{Username: "viewer"}, now.Add(time.Minute))
+	assert.Len(t, cache.entries, 1)
+	_, ok = cache.Get("third", "get", now.Add(time.Minute))
+	assert.True(t, ok)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	kafkasource "knative.dev/eventing-kafka/pkg/source"
 
@@ -31,13 +32,19 @@ import (
 	"context"
 
 	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"go.uber.org/zap"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/credentials"
 	"knative.dev/pkg/logging"
 )
 
 const (
 	resourceGroup = "kafkasources.sources.knative.dev"
+
+	// filteredReason is the error reported to the stats reporter for the
+	// events dropped by the filters.
+	filteredReason = "filtered"
 )
 
 type adapterConfig struct {
@@ -47,6 +54,15 @@ type adapterConfig struct {
 	ConsumerGroup string   `envconfig:"KAFKA_CONSUMER_GROUP" required:"true"`
 	Name          string   `envconfig:"NAME" required:"true"`
 	KeyType       string   `envconfig:"KEY_TYPE" required:"false"`
+	CEMapping     string   `envconfig:"KAFKA_CE_MAPPING" required:"false"`
+	Filters       string   `envconfig:"KAFKA_FILTERS" required:"false"`
+
+	MigrateOffsetsFrom string `envconfig:"KAFKA_MIGRATE_OFFSETS_FROM" required:"false"`
+
+	BatchMaxSize int           `envconfig:"KAFKA_BATCH_MAX_SIZE" required:"false"`
+	BatchMaxWait time.Duration `envconfig:"KAFKA_BATCH_MAX_WAIT" default:"1s"`
+
+	Paused bool `envconfig:"KAFKA_PAUSED" required:"false"`
 }
 
 func NewEnvConfig() adapter.EnvConfigAccessor {
@@ -59,21 +75,42 @@ type Adapter struct {
 	reporter          pkgsource.StatsReporter
 	logger            *zap.SugaredLogger
 	keyTypeMapper     func([]byte) interface{}
+	ceMapper          *cloudEventMapper
+	filter            eventFilter
+	pause             *consumer.PauseGate
 }
 
 var _ adapter.MessageAdapter = (*Adapter)(nil)
+var _ consumer.KafkaBatchConsumerHandler = (*Adapter)(nil)
+var _ consumer.KafkaPausableConsumerHandler = (*Adapter)(nil)
 var _ adapter.MessageAdapterConstructor = NewAdapter
 
 func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, httpMessageSender *kncloudevents.HTTPMessageSender, reporter pkgsource.StatsReporter) adapter.MessageAdapter {
 	logger := logging.FromContext(ctx)
 	config := processed.(*adapterConfig)
 
+	ceMapper, err := newCloudEventMapper(config.CEMapping)
+	if err != nil {
+		logger.Fatalw("Failed to create the CloudEvent mapper", zap.Error(err))
+	}
+
+	filter, err := newEventFilter(config.Filters)
+	if err != nil {
+		logger.Fatalw("Failed to create the event filter", zap.Error(err))
+	}
+
+	pause := consumer.NewPauseGate()
+	pause.SetPaused(config.Paused)
+
 	return &Adapter{
 		config:            config,
 		httpMessageSender: httpMessageSender,
 		reporter:          reporter,
 		logger:            logger,
 		keyTypeMapper:     getKeyTypeMapper(config.KeyType),
+		ceMapper:          ceMapper,
+		filter:            filter,
+		pause:             pause,
 	}
 }
 
@@ -95,14 +132,79 @@ func (a *Adapter) start(stopCh <-chan struct{}) error {
 	if err != nil {
 		return fmt.Errorf("failed to create the config: %w", err)
 	}
+
+	group, err := a.StartConsumerGroup(addrs, config)
+	if err != nil {
+		panic(err)
+	}
+	defer func() { _ = group.Close() }()
+
+	// Rotate the SASL credentials mounted from the secrets without restarting
+	credentialsChanged := make(chan struct{}, 1)
+	watcher, err := kafkasource.NewCredentialsWatcher(a.logger.Desugar(), func(credentials.Credentials) {
+		select {
+		case credentialsChanged <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch the credentials: %w", err)
+	}
+	if watcher != nil {
+		watcher.Start(stopCh)
+	}
+
+	for {
+		select {
+		case <-stopCh:
+			a.logger.Info("Shutting down...")
+			return nil
+		case <-credentialsChanged:
+			group = a.rotateCredentials(group)
+		}
+	}
+}
+
+// rotateCredentials starts consuming with the new credentials before closing
+// group, which keeps consuming when the new consumer group fails to start.
+// The messages of group whose offsets are not committed yet are consumed
+// again by the new consumer group, so that none is lost.
+func (a *Adapter) rotateCredentials(group sarama.ConsumerGroup) sarama.ConsumerGroup {
+	a.logger.Info("Rotating the credentials")
+	addrs, config, err := kafkasource.NewConfig(context.Background())
+	if err != nil {
+		a.logger.Errorw("Failed to create the config with the new credentials, consuming with the previous ones", zap.Error(err))
+		return group
+	}
+
+	newGroup, err := a.StartConsumerGroup(addrs, config)
+	if err != nil {
+		a.logger.Errorw("Failed to consume with the new credentials, consuming with the previous ones", zap.Error(err))
+		return group
+	}
+	if err := group.Close(); err != nil {
+		a.logger.Errorw("Failed to close the consumer group with the previous credentials", zap.Error(err))
+	}
+	return newGroup
+}
+
+// StartConsumerGroup starts consuming the topics of the source with the given
+// brokers and client configuration, after migrating the committed offsets if
+// requested. The caller must close the returned consumer group.
+func (a *Adapter) StartConsumerGroup(addrs []string, config *sarama.Config) (sarama.ConsumerGroup, error) {
 	config.Consumer.Offsets.AutoCommit.Enable = false
 
+	if a.config.MigrateOffsetsFrom != "" && a.config.MigrateOffsetsFrom != a.config.ConsumerGroup {
+		if err := a.migrateOffsets(addrs, config); err != nil {
+			return nil, fmt.Errorf("failed to migrate the offsets of consumer group %s: %w", a.config.MigrateOffsetsFrom, err)
+		}
+	}
+
 	consumerGroupFactory := consumer.NewConsumerGroupFactory(addrs, config)
 	group, err := consumerGroupFactory.StartConsumerGroup(a.config.ConsumerGroup, a.config.Topics, a.logger, a)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	defer func() { _ = group.Close() }()
 
 	// Track errors
 	go func() {
@@ -110,22 +212,53 @@ func (a *Adapter) start(stopCh <-chan struct{}) error {
 			a.logger.Errorw("An error has occurred while consuming messages occurred: ", zap.Error(err))
 		}
 	}()
+	return group, nil
+}
+
+func (a *Adapter) migrateOffsets(addrs []string, config *sarama.Config) error {
+	a.logger.Infow("Migrating the committed offsets",
+		zap.String("From", a.config.MigrateOffsetsFrom),
+		zap.String("To", a.config.ConsumerGroup),
+	)
+
+	client, err := sarama.NewClient(addrs, config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
 
-	<-stopCh
-	a.logger.Info("Shutting down...")
-	return nil
+	return migrateOffsets(client, a.config.MigrateOffsetsFrom, a.config.ConsumerGroup, a.config.Topics, a.logger)
 }
 
 func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool, error) {
 	ctx, span := trace.StartSpan(ctx, "kafka-source")
 	defer span.End()
 
+	// The event matched by the filters is reused for the request.
+	var event *cloudevents.Event
+	if a.filter != nil {
+		var err error
+		event, err = a.consumerMessageToEvent(ctx, msg)
+		if err != nil {
+			a.logger.Debug("failed to create event", zap.Error(err))
+			return true, err
+		}
+		if !a.filter.Match(event) {
+			a.reportFiltered(event)
+			return true, nil // Filtered out, commit offset
+		}
+	}
+
 	req, err := a.httpMessageSender.NewCloudEventRequest(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	err = a.ConsumerMessageToHttpRequest(ctx, span, msg, req)
+	if event != nil {
+		err = a.eventToHttpRequest(ctx, span, event, req)
+	} else {
+		err = a.ConsumerMessageToHttpRequest(ctx, span, msg, req)
+	}
 	if err != nil {
 		a.logger.Debug("failed to create request", zap.Error(err))
 		return true, err
@@ -152,3 +285,16 @@ func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool
 	_ = a.reporter.ReportEventCount(reportArgs, res.StatusCode)
 	return true, nil
 }
+
+// reportFiltered reports an event dropped by the filters.
+func (a *Adapter) reportFiltered(event *cloudevents.Event) {
+	a.logger.Debug("Event filtered out", zap.String("id", event.ID()))
+	_ = a.reporter.ReportEventCount(&pkgsource.ReportArgs{
+		Namespace:     a.config.Namespace,
+		Name:          a.config.Name,
+		ResourceGroup: resourceGroup,
+		EventType:     event.Type(),
+		EventSource:   event.Source(),
+		Error:         filteredReason,
+	}, 0)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+	pkgsource "knative.dev/pkg/source"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+)
+
+// GetBatchConfig returns the batch configuration of the source. Batching is
+// disabled unless KAFKA_BATCH_MAX_SIZE is greater than one.
+func (a *Adapter) GetBatchConfig() consumer.BatchConfig {
+	return consumer.BatchConfig{
+		MaxSize: a.config.BatchMaxSize,
+		MaxWait: a.config.BatchMaxWait,
+	}
+}
+
+// HandleBatch sends the messages of a partition to the sink in a single
+// CloudEvents batch request.
+func (a *Adapter) HandleBatch(ctx context.Context, messages []*sarama.ConsumerMessage) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "kafka-source-batch")
+	defer span.End()
+
+	tracingExt := extensions.FromSpanContext(span.SpanContext())
+
+	events := make([]*cloudevents.Event, 0, len(messages))
+	for _, msg := range messages {
+		event, err := a.consumerMessageToEvent(ctx, msg)
+		if err != nil {
+			// Same as a single message which can't be converted: skip it
+			a.logger.Debug("failed to create event", zap.Int64("offset", msg.Offset), zap.Error(err))
+			continue
+		}
+		if a.filter != nil && !a.filter.Match(event) {
+			a.reportFiltered(event)
+			continue
+		}
+		tracingExt.AddTracingAttributes(event)
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		return true, nil // Nothing to send, commit offset
+	}
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		a.logger.Debug("failed to marshal the batch", zap.Error(err))
+		return true, err
+	}
+
+	req, err := a.httpMessageSender.NewCloudEventRequest(ctx)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", sourcesv1beta1.KafkaBatchContentType)
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	res, err := a.httpMessageSender.Send(req)
+	if err != nil {
+		a.logger.Debug("Error while sending the batch", zap.Error(err))
+		return false, err // Error while sending, don't commit offset
+	}
+	_ = res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		a.logger.Debug("Unexpected status code", zap.Int("status code", res.StatusCode))
+		return false, fmt.Errorf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
+	}
+
+	for _, event := range events {
+		_ = a.reporter.ReportEventCount(&pkgsource.ReportArgs{
+			Namespace:     a.config.Namespace,
+			Name:          a.config.Name,
+			ResourceGroup: resourceGroup,
+			EventType:     event.Type(),
+			EventSource:   event.Source(),
+		}, res.StatusCode)
+	}
+	return true, nil
+}
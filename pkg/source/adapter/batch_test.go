@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/source"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+)
+
+func TestAdapterHandleBatch(t *testing.T) {
+	messages := []*sarama.ConsumerMessage{{
+		Key:       []byte("first"),
+		Topic:     "topic1",
+		Value:     []byte(`{"n":1}`),
+		Partition: 1,
+		Offset:    10,
+	}, {
+		Key:       []byte("dropped"),
+		Topic:     "topic1",
+		Value:     []byte(`{"n":2}`),
+		Partition: 1,
+		Offset:    11,
+	}, {
+		Key:       []byte("second"),
+		Topic:     "topic1",
+		Value:     []byte(`{"n":3}`),
+		Partition: 1,
+		Offset:    12,
+	}}
+
+	testCases := map[string]struct {
+		sink     func(http.ResponseWriter, *http.Request)
+		messages []*sarama.ConsumerMessage
+		sent     bool
+		commit   bool
+		error    bool
+	}{
+		"accepted": {
+			sink:     sinkAccepted,
+			messages: messages,
+			sent:     true,
+			commit:   true,
+		},
+		"rejected": {
+			sink:     sinkRejected,
+			messages: messages,
+			sent:     true,
+			commit:   false,
+			error:    true,
+		},
+		"all filtered": {
+			sink:     sinkAccepted,
+			messages: messages[1:2],
+			sent:     false,
+			commit:   true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			h := &fakeHandler{
+				handler: tc.sink,
+			}
+			sinkServer := httptest.NewServer(h)
+			defer sinkServer.Close()
+
+			statsReporter, _ := source.NewStatsReporter()
+
+			s, err := kncloudevents.NewHTTPMessageSender(nil, sinkServer.URL)
+			require.Nil(t, err)
+
+			filter, err := newEventFilter(`[{"not":{"exact":{"key":"dropped"}}}]`)
+			require.Nil(t, err)
+
+			a := &Adapter{
+				config: &adapterConfig{
+					EnvConfig: adapter.EnvConfig{
+						Sink:      sinkServer.URL,
+						Namespace: "test",
+					},
+					Topics:        []string{"topic1"},
+					ConsumerGroup: "group",
+					Name:          "test",
+					BatchMaxSize:  10,
+					BatchMaxWait:  time.Second,
+				},
+				httpMessageSender: s,
+				logger:            zap.NewNop().Sugar(),
+				reporter:          statsReporter,
+				keyTypeMapper:     getKeyTypeMapper(""),
+				filter:            filter,
+			}
+
+			assert.Equal(t, consumer.BatchConfig{MaxSize: 10, MaxWait: time.Second}, a.GetBatchConfig())
+
+			commit, err := a.HandleBatch(context.TODO(), tc.messages)
+			assert.Equal(t, tc.commit, commit)
+			assert.Equal(t, tc.error, err != nil)
+
+			if !tc.sent {
+				assert.Nil(t, h.header)
+				return
+			}
+
+			assert.Equal(t, sourcesv1beta1.KafkaBatchContentType, h.header.Get("Content-Type"))
+
+			var events []cloudevents.Event
+			require.Nil(t, json.Unmarshal(h.body, &events))
+			require.Len(t, events, 2)
+			assert.Equal(t, makeEventId(1, 10), events[0].ID())
+			assert.Equal(t, "first", events[0].Extensions()["key"])
+			assert.Equal(t, makeEventId(1, 12), events[1].ID())
+			assert.Equal(t, `{"n":3}`, string(events[1].Data()))
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+// eventFilter decides whether an event is sent to the sink.
+type eventFilter interface {
+	Match(event *cloudevents.Event) bool
+}
+
+type allFilter []eventFilter
+
+func (f allFilter) Match(event *cloudevents.Event) bool {
+	for _, nested := range f {
+		if !nested.Match(event) {
+			return false
+		}
+	}
+	return true
+}
+
+type anyFilter []eventFilter
+
+func (f anyFilter) Match(event *cloudevents.Event) bool {
+	for _, nested := range f {
+		if nested.Match(event) {
+			return true
+		}
+	}
+	return false
+}
+
+type notFilter struct {
+	filter eventFilter
+}
+
+func (f notFilter) Match(event *cloudevents.Event) bool {
+	return !f.filter.Match(event)
+}
+
+// attributesFilter matches when every attribute satisfies the compare function
+// against its expected value.
+type attributesFilter struct {
+	attributes map[string]string
+	compare    func(value, expected string) bool
+}
+
+func (f attributesFilter) Match(event *cloudevents.Event) bool {
+	for name, expected := range f.attributes {
+		value, ok := attributeValue(event, name)
+		if !ok || !f.compare(value, expected) {
+			return false
+		}
+	}
+	return true
+}
+
+// newEventFilter builds an eventFilter from the JSON serialized list of
+// filters. It returns nil when there are no filters.
+func newEventFilter(spec string) (eventFilter, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	var filters []sourcesv1beta1.KafkaSourceFilter
+	if err := json.Unmarshal([]byte(spec), &filters); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the filters: %w", err)
+	}
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	return newAllFilter(filters)
+}
+
+func newAllFilter(filters []sourcesv1beta1.KafkaSourceFilter) (allFilter, error) {
+	all := make(allFilter, 0, len(filters))
+	for i := range filters {
+		f, err := newFilter(&filters[i])
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, f)
+	}
+	return all, nil
+}
+
+func newFilter(filter *sourcesv1beta1.KafkaSourceFilter) (eventFilter, error) {
+	switch {
+	case len(filter.Exact) > 0:
+		return attributesFilter{attributes: filter.Exact, compare: func(value, expected string) bool {
+			return value == expected
+		}}, nil
+	case len(filter.Prefix) > 0:
+		return attributesFilter{attributes: filter.Prefix, compare: strings.HasPrefix}, nil
+	case len(filter.Suffix) > 0:
+		return attributesFilter{attributes: filter.Suffix, compare: strings.HasSuffix}, nil
+	case len(filter.All) > 0:
+		return newAllFilter(filter.All)
+	case len(filter.Any) > 0:
+		all, err := newAllFilter(filter.Any)
+		if err != nil {
+			return nil, err
+		}
+		return anyFilter(all), nil
+	case filter.Not != nil:
+		nested, err := newFilter(filter.Not)
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{filter: nested}, nil
+	default:
+		return nil, fmt.Errorf("filter has no dialect set")
+	}
+}
+
+// attributeValue returns the string value of the CloudEvent attribute or
+// extension with the given name.
+func attributeValue(event *cloudevents.Event, name string) (string, bool) {
+	switch name {
+	case "specversion":
+		return event.SpecVersion(), true
+	case "id":
+		return event.ID(), true
+	case "source":
+		return event.Source(), true
+	case "type":
+		return event.Type(), true
+	case "subject":
+		return event.Subject(), event.Subject() != ""
+	case "datacontenttype":
+		return event.DataContentType(), event.DataContentType() != ""
+	case "dataschema":
+		return event.DataSchema(), event.DataSchema() != ""
+	case "time":
+		if event.Time().IsZero() {
+			return "", false
+		}
+		return types.FormatTime(event.Time()), true
+	}
+
+	ext, ok := event.Extensions()[name]
+	if !ok {
+		return "", false
+	}
+	// Non string extensions are compared using their canonical string form
+	value, err := types.Format(ext)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
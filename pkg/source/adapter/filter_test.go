@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/source"
+)
+
+func TestNewEventFilter(t *testing.T) {
+	filter, err := newEventFilter("")
+	assert.Nil(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = newEventFilter("[]")
+	assert.Nil(t, err)
+	assert.Nil(t, filter)
+
+	_, err = newEventFilter("{not json")
+	assert.NotNil(t, err)
+
+	_, err = newEventFilter(`[{}]`)
+	assert.NotNil(t, err)
+}
+
+func TestEventFilterMatch(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("id")
+	event.SetSource("/apis/v1/namespaces/test/kafkasources/test#audit")
+	event.SetType("com.example.audit.login")
+	event.SetExtension("key", "user-42")
+	event.SetExtension("kafkaheaderregion", "eu-west-1")
+	event.SetExtension("count", 5)
+
+	testCases := map[string]struct {
+		filters string
+		match   bool
+	}{
+		"exact match": {
+			filters: `[{"exact":{"type":"com.example.audit.login"}}]`,
+			match:   true,
+		},
+		"exact mismatch": {
+			filters: `[{"exact":{"type":"com.example.audit.logout"}}]`,
+			match:   false,
+		},
+		"exact on all attributes": {
+			filters: `[{"exact":{"type":"com.example.audit.login","key":"user-42"}}]`,
+			match:   true,
+		},
+		"exact on missing attribute": {
+			filters: `[{"exact":{"subject":""}}]`,
+			match:   false,
+		},
+		"exact on non string extension": {
+			filters: `[{"exact":{"count":"5"}}]`,
+			match:   true,
+		},
+		"prefix on header": {
+			filters: `[{"prefix":{"kafkaheaderregion":"eu-"}}]`,
+			match:   true,
+		},
+		"suffix on source": {
+			filters: `[{"suffix":{"source":"#audit"}}]`,
+			match:   true,
+		},
+		"all filters must match": {
+			filters: `[{"prefix":{"type":"com.example."}},{"exact":{"key":"user-1"}}]`,
+			match:   false,
+		},
+		"any": {
+			filters: `[{"any":[{"exact":{"key":"user-1"}},{"exact":{"key":"user-42"}}]}]`,
+			match:   true,
+		},
+		"not": {
+			filters: `[{"not":{"suffix":{"type":".login"}}}]`,
+			match:   false,
+		},
+		"nested all": {
+			filters: `[{"all":[{"prefix":{"type":"com.example."}},{"not":{"exact":{"key":"user-1"}}}]}]`,
+			match:   true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			filter, err := newEventFilter(tc.filters)
+			require.Nil(t, err)
+			require.NotNil(t, filter)
+			assert.Equal(t, tc.match, filter.Match(&event))
+		})
+	}
+}
+
+func TestAdapterHandleFiltered(t *testing.T) {
+	h := &fakeHandler{
+		handler: sinkAccepted,
+	}
+	sinkServer := httptest.NewServer(h)
+	defer sinkServer.Close()
+
+	statsReporter, _ := source.NewStatsReporter()
+
+	s, err := kncloudevents.NewHTTPMessageSender(nil, sinkServer.URL)
+	require.Nil(t, err)
+
+	filter, err := newEventFilter(`[{"exact":{"key":"keep"}}]`)
+	require.Nil(t, err)
+
+	a := &Adapter{
+		config: &adapterConfig{
+			EnvConfig: adapter.EnvConfig{
+				Sink:      sinkServer.URL,
+				Namespace: "test",
+			},
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+			Name:          "test",
+		},
+		httpMessageSender: s,
+		logger:            zap.NewNop().Sugar(),
+		reporter:          statsReporter,
+		keyTypeMapper:     getKeyTypeMapper(""),
+		filter:            filter,
+	}
+
+	commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+		Key:   []byte("drop"),
+		Topic: "topic1",
+		Value: []byte(`{}`),
+	})
+	assert.Nil(t, err)
+	assert.True(t, commit)
+	assert.Nil(t, h.header, "filtered event must not be sent to the sink")
+
+	commit, err = a.Handle(context.TODO(), &sarama.ConsumerMessage{
+		Key:   []byte("keep"),
+		Topic: "topic1",
+		Value: []byte(`{}`),
+	})
+	assert.Nil(t, err)
+	assert.True(t, commit)
+	assert.Equal(t, "keep", h.header.Get("ce-key"))
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"k8s.io/client-go/util/jsonpath"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+// cloudEventMapper computes CloudEvent attributes of raw Kafka records
+// according to the KafkaSource CloudEvent mapping rules.
+type cloudEventMapper struct {
+	typ     *attributeMapper
+	subject *attributeMapper
+	id      *attributeMapper
+	time    *attributeMapper
+}
+
+// attributeMapper resolves a single attribute, trying each location in order.
+type attributeMapper struct {
+	locations    []attributeLocation
+	defaultValue string
+}
+
+type attributeLocation struct {
+	header   string
+	key      bool
+	jsonPath *jsonpath.JSONPath
+}
+
+// recordView lazily decodes the record value so that it is parsed at most
+// once, and only when a JSONPath location is evaluated.
+type recordView struct {
+	msg     *sarama.ConsumerMessage
+	decoded bool
+	value   interface{}
+}
+
+// newCloudEventMapper builds a cloudEventMapper from the JSON serialized
+// mapping spec. It returns nil when the spec is empty.
+func newCloudEventMapper(spec string) (*cloudEventMapper, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	mapping := &sourcesv1beta1.KafkaCloudEventMappingSpec{}
+	if err := json.Unmarshal([]byte(spec), mapping); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the CloudEvent mapping: %w", err)
+	}
+	return newCloudEventMapperFromSpec(mapping)
+}
+
+// newCloudEventMapperFromSpec builds a cloudEventMapper from the mapping spec.
+// It returns nil when the spec is nil.
+func newCloudEventMapperFromSpec(mapping *sourcesv1beta1.KafkaCloudEventMappingSpec) (*cloudEventMapper, error) {
+	if mapping == nil {
+		return nil, nil
+	}
+
+	mapper := &cloudEventMapper{}
+	var err error
+	if mapper.typ, err = newAttributeMapper(mapping.Type); err != nil {
+		return nil, fmt.Errorf("invalid type mapping: %w", err)
+	}
+	if mapper.subject, err = newAttributeMapper(mapping.Subject); err != nil {
+		return nil, fmt.Errorf("invalid subject mapping: %w", err)
+	}
+	if mapper.id, err = newAttributeMapper(mapping.ID); err != nil {
+		return nil, fmt.Errorf("invalid id mapping: %w", err)
+	}
+	if mapper.time, err = newAttributeMapper(mapping.Time); err != nil {
+		return nil, fmt.Errorf("invalid time mapping: %w", err)
+	}
+	return mapper, nil
+}
+
+func newAttributeMapper(mapping *sourcesv1beta1.KafkaAttributeMapping) (*attributeMapper, error) {
+	if mapping == nil {
+		return nil, nil
+	}
+
+	mapper := &attributeMapper{defaultValue: mapping.Default}
+	for _, from := range mapping.From {
+		location := attributeLocation{header: from.Header, key: from.Key}
+		if from.JSONPath != "" {
+			location.jsonPath = jsonpath.New(from.JSONPath).AllowMissingKeys(true)
+			if err := location.jsonPath.Parse(from.JSONPath); err != nil {
+				return nil, err
+			}
+		}
+		mapper.locations = append(mapper.locations, location)
+	}
+	return mapper, nil
+}
+
+// apply overrides the attributes of event having a mapping rule. Attributes
+// whose rule does not resolve keep their current value.
+func (m *cloudEventMapper) apply(event *cloudevents.Event, cm *sarama.ConsumerMessage) {
+	if m == nil {
+		return
+	}
+
+	record := &recordView{msg: cm}
+	if v, ok := m.typ.resolve(record); ok {
+		event.SetType(v)
+	}
+	if v, ok := m.subject.resolve(record); ok {
+		event.SetSubject(v)
+	}
+	if v, ok := m.id.resolve(record); ok {
+		event.SetID(v)
+	}
+	if v, ok := m.time.resolve(record); ok {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			event.SetTime(t)
+		}
+	}
+}
+
+func (m *attributeMapper) resolve(record *recordView) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+
+	for _, location := range m.locations {
+		if v := location.lookup(record); v != "" {
+			return v, true
+		}
+	}
+	return m.defaultValue, m.defaultValue != ""
+}
+
+func (l *attributeLocation) lookup(record *recordView) string {
+	switch {
+	case l.header != "":
+		for _, h := range record.msg.Headers {
+			if string(h.Key) == l.header {
+				return string(h.Value)
+			}
+		}
+	case l.key:
+		return string(record.msg.Key)
+	case l.jsonPath != nil:
+		value := record.jsonValue()
+		if value == nil {
+			return ""
+		}
+		results, err := l.jsonPath.FindResults(value)
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			return ""
+		}
+		result := results[0][0]
+		if !result.IsValid() || !result.CanInterface() || result.Interface() == nil {
+			return ""
+		}
+		return fmt.Sprint(result.Interface())
+	}
+	return ""
+}
+
+func (r *recordView) jsonValue() interface{} {
+	if !r.decoded {
+		r.decoded = true
+		decoder := json.NewDecoder(bytes.NewReader(r.msg.Value))
+		// Keep numbers as written in the record instead of float64
+		decoder.UseNumber()
+		if err := decoder.Decode(&r.value); err != nil {
+			r.value = nil
+		}
+	}
+	return r.value
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestNewCloudEventMapper(t *testing.T) {
+	mapper, err := newCloudEventMapper("")
+	assert.Nil(t, err)
+	assert.Nil(t, mapper)
+
+	_, err = newCloudEventMapper("{not json")
+	assert.NotNil(t, err)
+
+	_, err = newCloudEventMapper(`{"type":{"from":[{"jsonPath":"{.unclosed"}]}}`)
+	assert.NotNil(t, err)
+
+	mapper, err = newCloudEventMapper(`{"type":{"from":[{"header":"type"}],"default":"com.example"}}`)
+	assert.Nil(t, err)
+	require.NotNil(t, mapper)
+	assert.NotNil(t, mapper.typ)
+	assert.Nil(t, mapper.subject)
+}
+
+func TestCloudEventMapperApply(t *testing.T) {
+	aTimestamp := time.Now()
+	otherTimestamp := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		mapping         string
+		message         *sarama.ConsumerMessage
+		expectedType    string
+		expectedSubject string
+		expectedID      string
+		expectedTime    time.Time
+	}{
+		"no mapping": {
+			message:         &sarama.ConsumerMessage{Value: []byte(`{}`)},
+			expectedType:    sourcesv1beta1.KafkaEventType,
+			expectedSubject: "subject",
+			expectedID:      "id",
+			expectedTime:    aTimestamp,
+		},
+		"from header": {
+			mapping: `{"type":{"from":[{"header":"event-type"}]}}`,
+			message: &sarama.ConsumerMessage{
+				Headers: []*sarama.RecordHeader{{Key: []byte("event-type"), Value: []byte("com.example.created")}},
+			},
+			expectedType:    "com.example.created",
+			expectedSubject: "subject",
+			expectedID:      "id",
+			expectedTime:    aTimestamp,
+		},
+		"from key": {
+			mapping:         `{"subject":{"from":[{"key":true}]}}`,
+			message:         &sarama.ConsumerMessage{Key: []byte("order-1")},
+			expectedType:    sourcesv1beta1.KafkaEventType,
+			expectedSubject: "order-1",
+			expectedID:      "id",
+			expectedTime:    aTimestamp,
+		},
+		"from json path": {
+			mapping: `{"id":{"from":[{"jsonPath":"{.meta.id}"}]},"time":{"from":[{"jsonPath":"{.meta.time}"}]}}`,
+			message: &sarama.ConsumerMessage{
+				Value: []byte(`{"meta":{"id":12345678901234567890,"time":"2020-10-01T12:00:00Z"}}`),
+			},
+			expectedType:    sourcesv1beta1.KafkaEventType,
+			expectedSubject: "subject",
+			expectedID:      "12345678901234567890",
+			expectedTime:    otherTimestamp,
+		},
+		"fallback to next location": {
+			mapping: `{"type":{"from":[{"header":"missing"},{"jsonPath":"{.missing}"},{"jsonPath":"{.kind}"}]}}`,
+			message: &sarama.ConsumerMessage{
+				Value: []byte(`{"kind":"com.example.kind"}`),
+			},
+			expectedType:    "com.example.kind",
+			expectedSubject: "subject",
+			expectedID:      "id",
+			expectedTime:    aTimestamp,
+		},
+		"fallback to default": {
+			mapping: `{"type":{"from":[{"jsonPath":"{.kind}"}],"default":"com.example.default"}}`,
+			message: &sarama.ConsumerMessage{
+				Value: []byte(`not json`),
+			},
+			expectedType:    "com.example.default",
+			expectedSubject: "subject",
+			expectedID:      "id",
+			expectedTime:    aTimestamp,
+		},
+		"invalid time keeps timestamp": {
+			mapping: `{"time":{"from":[{"header":"time"}]}}`,
+			message: &sarama.ConsumerMessage{
+				Headers: []*sarama.RecordHeader{{Key: []byte("time"), Value: []byte("yesterday")}},
+			},
+			expectedType:    sourcesv1beta1.KafkaEventType,
+			expectedSubject: "subject",
+			expectedID:      "id",
+			expectedTime:    aTimestamp,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			mapper, err := newCloudEventMapper(tc.mapping)
+			require.Nil(t, err)
+
+			event := cloudevents.NewEvent()
+			event.SetType(sourcesv1beta1.KafkaEventType)
+			event.SetSubject("subject")
+			event.SetID("id")
+			event.SetTime(aTimestamp)
+
+			mapper.apply(&event, tc.message)
+
+			assert.Equal(t, tc.expectedType, event.Type())
+			assert.Equal(t, tc.expectedSubject, event.Subject())
+			assert.Equal(t, tc.expectedID, event.ID())
+			assert.True(t, tc.expectedTime.Equal(event.Time()), "expected time %v, got %v", tc.expectedTime, event.Time())
+		})
+	}
+}
@@ -55,8 +55,41 @@ func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, span *trace.
 	}
 
 	a.logger.Debug("Message is not a CloudEvent -> We need to translate it to a valid CloudEvent")
-	kafkaMsg := msg
+	event, err := a.makeEvent(cm, msg)
+	if err != nil {
+		return err
+	}
+
+	return http.WriteRequest(ctx, binding.ToMessage(event), req, tracingExt.WriteTransformer())
+}
+
+// eventToHttpRequest writes the CloudEvent already built from the consumer
+// message to the HTTP request.
+func (a *Adapter) eventToHttpRequest(ctx context.Context, span *trace.Span, event *cloudevents.Event, req *nethttp.Request) error {
+	tracingExt := extensions.FromSpanContext(span.SpanContext())
+	return http.WriteRequest(ctx, binding.ToMessage(event), req, tracingExt.WriteTransformer())
+}
+
+// consumerMessageToEvent returns the CloudEvent sent to the sink for the
+// consumer message.
+func (a *Adapter) consumerMessageToEvent(ctx context.Context, cm *sarama.ConsumerMessage) (*cloudevents.Event, error) {
+	msg := protocolkafka.NewMessageFromConsumerMessage(cm)
+
+	defer func() {
+		err := msg.Finish(nil)
+		if err != nil {
+			a.logger.Warnw("Something went wrong while trying to finalizing the message", zap.Error(err))
+		}
+	}()
+
+	if msg.ReadEncoding() != binding.EncodingUnknown {
+		return binding.ToEvent(ctx, msg)
+	}
+	return a.makeEvent(cm, msg)
+}
 
+// makeEvent translates a Kafka record that is not a CloudEvent to a CloudEvent.
+func (a *Adapter) makeEvent(cm *sarama.ConsumerMessage, kafkaMsg *protocolkafka.Message) (*cloudevents.Event, error) {
 	event := cloudevents.NewEvent()
 
 	event.SetID(makeEventId(cm.Partition, cm.Offset))
@@ -65,14 +98,15 @@ func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, span *trace.
 	event.SetSource(sourcesv1beta1.KafkaEventSource(a.config.Namespace, a.config.Name, cm.Topic))
 	event.SetSubject(makeEventSubject(cm.Partition, cm.Offset))
 
+	a.ceMapper.apply(&event, cm)
+
 	dumpKafkaMetaToEvent(&event, a.keyTypeMapper, cm.Key, kafkaMsg)
 
 	err := event.SetData(kafkaMsg.ContentType, kafkaMsg.Value)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	return http.WriteRequest(ctx, binding.ToMessage(&event), req, tracingExt.WriteTransformer())
+	return &event, nil
 }
 
 func makeEventId(partition int32, offset int64) string {
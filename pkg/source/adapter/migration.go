@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// migrateOffsets copies the committed offsets of the from consumer group to
+// the to consumer group. Partitions for which the to consumer group already
+// committed an offset are left untouched, so that migrating twice is harmless.
+func migrateOffsets(client sarama.Client, from, to string, topics []string, logger *zap.SugaredLogger) error {
+	fromManager, err := sarama.NewOffsetManagerFromClient(from, client)
+	if err != nil {
+		return fmt.Errorf("failed to create the offset manager of consumer group %s: %w", from, err)
+	}
+	defer fromManager.Close()
+
+	toManager, err := sarama.NewOffsetManagerFromClient(to, client)
+	if err != nil {
+		return fmt.Errorf("failed to create the offset manager of consumer group %s: %w", to, err)
+	}
+
+	migrated := make([]sarama.PartitionOffsetManager, 0)
+	for _, topic := range topics {
+		partitions, err := client.Partitions(topic)
+		if err != nil {
+			_ = toManager.Close()
+			return fmt.Errorf("failed to list the partitions of topic %s: %w", topic, err)
+		}
+
+		for _, partition := range partitions {
+			offset, metadata, err := nextOffset(fromManager, topic, partition)
+			if err != nil {
+				_ = toManager.Close()
+				return err
+			}
+			if offset < 0 {
+				continue // Nothing committed
+			}
+
+			toPartition, err := toManager.ManagePartition(topic, partition)
+			if err != nil {
+				_ = toManager.Close()
+				return fmt.Errorf("failed to fetch the offset of consumer group %s, topic %s, partition %d: %w", to, topic, partition, err)
+			}
+			if current, _ := toPartition.NextOffset(); current >= 0 {
+				toPartition.AsyncClose()
+				continue // Already committed
+			}
+
+			logger.Infow("Migrating committed offset", zap.String("topic", topic), zap.Int32("partition", partition), zap.Int64("offset", offset))
+			toPartition.MarkOffset(offset, metadata)
+			toPartition.AsyncClose()
+			migrated = append(migrated, toPartition)
+		}
+	}
+
+	// Closing the offset manager commits the marked offsets
+	_ = toManager.Close()
+
+	var errs error
+	for _, partition := range migrated {
+		for err := range partition.Errors() {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// nextOffset returns the committed offset of the partition, or a negative
+// offset when none was committed.
+func nextOffset(manager sarama.OffsetManager, topic string, partition int32) (int64, string, error) {
+	partitionManager, err := manager.ManagePartition(topic, partition)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to fetch the offset of topic %s, partition %d: %w", topic, partition, err)
+	}
+	defer partitionManager.AsyncClose()
+
+	offset, metadata := partitionManager.NextOffset()
+	return offset, metadata, nil
+}
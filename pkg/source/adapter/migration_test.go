@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMigrateOffsets(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("topic1", 0, broker.BrokerID()).
+			SetLeader("topic1", 1, broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, "old-group", broker).
+			SetCoordinator(sarama.CoordinatorGroup, "new-group", broker),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("old-group", "topic1", 0, 10, "", sarama.ErrNoError).
+			SetOffset("old-group", "topic1", 1, 20, "", sarama.ErrNoError).
+			SetOffset("new-group", "topic1", 0, -1, "", sarama.ErrNoError).
+			SetOffset("new-group", "topic1", 1, 5, "", sarama.ErrNoError),
+		"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	config.Consumer.Return.Errors = true
+	config.Consumer.Offsets.AutoCommit.Enable = false
+
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	require.Nil(t, err)
+	defer client.Close()
+
+	err = migrateOffsets(client, "old-group", "new-group", []string{"topic1"}, zap.NewNop().Sugar())
+	require.Nil(t, err)
+
+	var commits []*sarama.OffsetCommitRequest
+	for _, rr := range broker.History() {
+		if req, ok := rr.Request.(*sarama.OffsetCommitRequest); ok {
+			commits = append(commits, req)
+		}
+	}
+	require.Len(t, commits, 1)
+	assert.Equal(t, "new-group", commits[0].ConsumerGroup)
+	offset, _, err := commits[0].Offset("topic1", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), offset)
+	// Partition 1 already has a committed offset in the new group
+	_, _, err = commits[0].Offset("topic1", 1)
+	assert.NotNil(t, err)
+}
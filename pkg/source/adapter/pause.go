@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package kafka
+
+import (
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+)
+
+// GetPauseGate returns the gate holding the consumption back while the
+// source is paused.
+func (a *Adapter) GetPauseGate() *consumer.PauseGate {
+	return a.pause
+}
+
+// SetPaused pauses or resumes the consumption of the source. The consumer
+// group membership is kept while paused.
+func (a *Adapter) SetPaused(paused bool) {
+	a.pause.SetPaused(paused)
+}
+
+// IsPaused returns true when the consumption of the source is paused.
+func (a *Adapter) IsPaused() bool {
+	return a.pause.IsPaused()
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/period"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/logging"
+	pkgsource "knative.dev/pkg/source"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+)
+
+// defaultBatchMaxWait matches the default of KAFKA_BATCH_MAX_WAIT.
+const defaultBatchMaxWait = time.Second
+
+// NewSourceAdapter creates the Adapter consuming the topics of src, as the
+// shared receive adapter does for each of its sources. Events are sent to
+// the sink resolved in the status of src.
+func NewSourceAdapter(ctx context.Context, src *sourcesv1beta1.KafkaSource, migrateOffsetsFrom string, httpMessageSender *kncloudevents.HTTPMessageSender, reporter pkgsource.StatsReporter) (*Adapter, error) {
+	config := &adapterConfig{
+		EnvConfig: adapter.EnvConfig{
+			Namespace: src.Namespace,
+			Name:      src.Name,
+			Sink:      src.Status.SinkURI.String(),
+		},
+		ConsumerGroup:      src.Spec.ConsumerGroup,
+		Name:               src.Name,
+		KeyType:            src.GetLabels()[sourcesv1beta1.KafkaKeyTypeLabel],
+		MigrateOffsetsFrom: migrateOffsetsFrom,
+		BatchMaxWait:       defaultBatchMaxWait,
+		Paused:             src.IsPaused(),
+	}
+	// Same as KAFKA_TOPICS, each entry can hold a comma separated list
+	for _, topics := range src.Spec.Topics {
+		config.Topics = append(config.Topics, strings.Split(topics, ",")...)
+	}
+	if batch := src.Spec.Batch; batch != nil {
+		config.BatchMaxSize = int(batch.MaxSize)
+		if batch.MaxWait != nil {
+			maxWait, err := period.Parse(*batch.MaxWait)
+			if err != nil {
+				return nil, fmt.Errorf("invalid batch max wait: %w", err)
+			}
+			config.BatchMaxWait = maxWait.DurationApprox()
+		}
+	}
+
+	ceMapper, err := newCloudEventMapperFromSpec(src.Spec.CloudEventMapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the CloudEvent mapper: %w", err)
+	}
+
+	var filter eventFilter
+	if len(src.Spec.Filters) > 0 {
+		if filter, err = newAllFilter(src.Spec.Filters); err != nil {
+			return nil, fmt.Errorf("failed to create the event filter: %w", err)
+		}
+	}
+
+	pause := consumer.NewPauseGate()
+	pause.SetPaused(config.Paused)
+
+	return &Adapter{
+		config:            config,
+		httpMessageSender: httpMessageSender,
+		reporter:          reporter,
+		logger:            logging.FromContext(ctx).With(zap.String("namespace", src.Namespace), zap.String("name", src.Name)),
+		keyTypeMapper:     getKeyTypeMapper(config.KeyType),
+		ceMapper:          ceMapper,
+		filter:            filter,
+		pause:             pause,
+	}, nil
+}
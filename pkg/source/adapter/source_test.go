@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestNewSourceAdapter(t *testing.T) {
+	maxWait := "PT0.5S"
+	src := &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "source",
+			Labels:    map[string]string{sourcesv1beta1.KafkaKeyTypeLabel: "int"},
+		},
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			Topics:        []string{"a,b", "c"},
+			ConsumerGroup: "group",
+			CloudEventMapping: &sourcesv1beta1.KafkaCloudEventMappingSpec{
+				Type: &sourcesv1beta1.KafkaAttributeMapping{Default: "com.example"},
+			},
+			Filters: []sourcesv1beta1.KafkaSourceFilter{{Exact: map[string]string{"type": "com.example"}}},
+			Batch:   &sourcesv1beta1.KafkaSourceBatchSpec{MaxSize: 10, MaxWait: &maxWait},
+		},
+	}
+	src.Status.MarkSink(apis.HTTP("sink"))
+
+	a, err := NewSourceAdapter(context.TODO(), src, "previous", nil, nil)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, a.config.Topics)
+	assert.Equal(t, "group", a.config.ConsumerGroup)
+	assert.Equal(t, "previous", a.config.MigrateOffsetsFrom)
+	assert.Equal(t, "http://sink", a.config.Sink)
+	assert.Equal(t, "ns", a.config.Namespace)
+	assert.Equal(t, "source", a.config.Name)
+	assert.Equal(t, "int", a.config.KeyType)
+	assert.Equal(t, 10, a.config.BatchMaxSize)
+	assert.Equal(t, 500*time.Millisecond, a.config.BatchMaxWait)
+	assert.NotNil(t, a.ceMapper)
+	assert.NotNil(t, a.filter)
+	assert.False(t, a.IsPaused())
+
+	src.Spec.Batch = nil
+	src.Spec.Filters = nil
+	src.Spec.CloudEventMapping = nil
+	a, err = NewSourceAdapter(context.TODO(), src, "", nil, nil)
+	require.Nil(t, err)
+	assert.Equal(t, time.Second, a.config.BatchMaxWait)
+	assert.Nil(t, a.ceMapper)
+	assert.Nil(t, a.filter)
+
+	src.Annotations = map[string]string{sourcesv1beta1.KafkaPausedAnnotation: "true"}
+	a, err = NewSourceAdapter(context.TODO(), src, "", nil, nil)
+	require.Nil(t, err)
+	assert.True(t, a.IsPaused())
+	assert.Same(t, a.pause, a.GetPauseGate())
+
+	a.SetPaused(false)
+	assert.False(t, a.IsPaused())
+}
@@ -24,12 +24,21 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/kelseyhightower/envconfig"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka/pkg/common/credentials"
+	"knative.dev/eventing-kafka/pkg/common/saramaconfig"
 )
 
 type AdapterSASL struct {
 	Enable   bool   `envconfig:"KAFKA_NET_SASL_ENABLE" required:"false"`
 	User     string `envconfig:"KAFKA_NET_SASL_USER" required:"false"`
 	Password string `envconfig:"KAFKA_NET_SASL_PASSWORD" required:"false"`
+	// UserFile and PasswordFile are the paths of the user and password
+	// mounted from their secrets, which take precedence over User and
+	// Password and are watched for changes.
+	UserFile     string `envconfig:"KAFKA_NET_SASL_USER_FILE" required:"false"`
+	PasswordFile string `envconfig:"KAFKA_NET_SASL_PASSWORD_FILE" required:"false"`
 }
 
 type AdapterTLS struct {
@@ -47,35 +56,85 @@ type AdapterNet struct {
 type envConfig struct {
 	BootstrapServers []string `envconfig:"KAFKA_BOOTSTRAP_SERVERS" required:"true"`
 	Net              AdapterNet
+	// SaramaConfig is the optional SaramaConfigSpec YAML.
+	SaramaConfig string `envconfig:"KAFKA_SARAMA_CONFIG" required:"false"`
 }
 
 // NewConfig extracts the Kafka configuration from the environment.
 func NewConfig(ctx context.Context) ([]string, *sarama.Config, error) {
-	cfg := sarama.NewConfig()
-	cfg.Version = sarama.V2_0_0_0
-	cfg.Consumer.Return.Errors = true
-
 	var env envConfig
 	if err := envconfig.Process("", &env); err != nil {
 		return nil, nil, err
 	}
 
-	if env.Net.SASL.Enable {
+	cfg, err := NewConfigWithNet(env.Net)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if env.SaramaConfig != "" {
+		spec, err := saramaconfig.Parse(env.SaramaConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		// The spec cannot disable the network settings of the source
+		if err := spec.ApplyTo(cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+	return env.BootstrapServers, cfg, nil
+}
+
+// NewConfigWithNet returns the Kafka configuration using the given network
+// and authentication settings.
+func NewConfigWithNet(net AdapterNet) (*sarama.Config, error) {
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V2_0_0_0
+	cfg.Consumer.Return.Errors = true
+
+	if net.SASL.Enable {
 		cfg.Net.SASL.Enable = true
-		cfg.Net.SASL.User = env.Net.SASL.User
-		cfg.Net.SASL.Password = env.Net.SASL.Password
+		cfg.Net.SASL.User = net.SASL.User
+		cfg.Net.SASL.Password = net.SASL.Password
+		if net.SASL.UserFile != "" || net.SASL.PasswordFile != "" {
+			mounted, err := credentials.Read(net.SASL.UserFile, net.SASL.PasswordFile)
+			if err != nil {
+				return nil, err
+			}
+			if net.SASL.UserFile != "" {
+				cfg.Net.SASL.User = mounted.Username
+			}
+			if net.SASL.PasswordFile != "" {
+				cfg.Net.SASL.Password = mounted.Password
+			}
+		}
 	}
 
-	if env.Net.TLS.Enable {
+	if net.TLS.Enable {
 		cfg.Net.TLS.Enable = true
-		tlsConfig, err := newTLSConfig(env.Net.TLS.Cert, env.Net.TLS.Key, env.Net.TLS.CACert)
+		tlsConfig, err := newTLSConfig(net.TLS.Cert, net.TLS.Key, net.TLS.CACert)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		cfg.Net.TLS.Config = tlsConfig
 	}
 
-	return env.BootstrapServers, cfg, nil
+	return cfg, nil
+}
+
+// NewCredentialsWatcher returns a watcher of the SASL user and password
+// files mounted from the secrets of the source, calling handler once they
+// changed. It returns nil when SASL is disabled or the files are not mounted.
+func NewCredentialsWatcher(logger *zap.Logger, handler func(credentials.Credentials)) (*credentials.Watcher, error) {
+	var env envConfig
+	if err := envconfig.Process("", &env); err != nil {
+		return nil, err
+	}
+	sasl := env.Net.SASL
+	if !sasl.Enable || (sasl.UserFile == "" && sasl.PasswordFile == "") {
+		return nil, nil
+	}
+	return credentials.NewWatcher(logger, sasl.UserFile, sasl.PasswordFile, credentials.DefaultPollInterval, handler)
 }
 
 // NewProducer is a helper method for constructing a client for producing kafka methods.
@@ -24,12 +24,18 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"io/ioutil"
 	"math/big"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka/pkg/common/credentials"
 )
 
 func TestNewTLSConfig(t *testing.T) {
@@ -220,3 +226,71 @@ func TestNewConfig(t *testing.T) {
 	require.NotNil(t, config)
 	require.Equal(t, []string{"my-cluster-kafka-bootstrap.my-kafka-namespace:9092"}, servers)
 }
+
+func TestNewConfigWithSaramaConfig(t *testing.T) {
+	ctx := context.Background()
+
+	_ = os.Setenv("KAFKA_BOOTSTRAP_SERVERS", "my-cluster-kafka-bootstrap.my-kafka-namespace:9092")
+	_ = os.Setenv("KAFKA_NET_SASL_ENABLE", "true")
+	defer os.Unsetenv("KAFKA_NET_SASL_ENABLE")
+	_ = os.Setenv("KAFKA_SARAMA_CONFIG", "apiVersion: v1\nkafkaVersion: 2.3.0\nnet:\n  sasl:\n    mechanism: SCRAM-SHA-256\n")
+	defer os.Unsetenv("KAFKA_SARAMA_CONFIG")
+
+	_, config, err := NewConfig(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, sarama.V2_3_0_0, config.Version)
+	require.True(t, config.Net.SASL.Enable)
+	require.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA256), config.Net.SASL.Mechanism)
+
+	_ = os.Setenv("KAFKA_SARAMA_CONFIG", "apiVersion: v1\nnet:\n  sasl:\n    mechanism: NONE\n")
+
+	_, _, err = NewConfig(ctx)
+
+	require.Error(t, err)
+}
+
+func TestNewConfigWithCredentialsFiles(t *testing.T) {
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "kafka-sasl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	userFile := filepath.Join(dir, "user")
+	require.NoError(t, ioutil.WriteFile(userFile, []byte("mounted-user"), 0600))
+
+	_ = os.Setenv("KAFKA_BOOTSTRAP_SERVERS", "my-cluster-kafka-bootstrap.my-kafka-namespace:9092")
+	_ = os.Setenv("KAFKA_NET_SASL_ENABLE", "true")
+	defer os.Unsetenv("KAFKA_NET_SASL_ENABLE")
+	_ = os.Setenv("KAFKA_NET_SASL_USER", "env-user")
+	defer os.Unsetenv("KAFKA_NET_SASL_USER")
+	_ = os.Setenv("KAFKA_NET_SASL_PASSWORD", "env-password")
+	defer os.Unsetenv("KAFKA_NET_SASL_PASSWORD")
+
+	// Without mounted files, the credentials are not watched
+	watcher, err := NewCredentialsWatcher(zap.NewNop(), func(credentials.Credentials) {})
+	require.NoError(t, err)
+	require.Nil(t, watcher)
+
+	// The mounted files take precedence over the env
+	_ = os.Setenv("KAFKA_NET_SASL_USER_FILE", userFile)
+	defer os.Unsetenv("KAFKA_NET_SASL_USER_FILE")
+
+	_, config, err := NewConfig(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, "mounted-user", config.Net.SASL.User)
+	require.Equal(t, "env-password", config.Net.SASL.Password)
+
+	watcher, err = NewCredentialsWatcher(zap.NewNop(), func(credentials.Credentials) {})
+	require.NoError(t, err)
+	require.NotNil(t, watcher)
+	require.Equal(t, "mounted-user", watcher.Credentials().Username)
+
+	// Unreadable files are errors
+	_ = os.Setenv("KAFKA_NET_SASL_USER_FILE", dir)
+
+	_, _, err = NewConfig(ctx)
+
+	require.Error(t, err)
+}
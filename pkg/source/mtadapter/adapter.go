@@ -0,0 +1,244 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mtadapter implements the shared, multi-tenant receive adapter
+// running the consumer groups of the KafkaSources annotated with
+// kafkasources.sources.knative.dev/receive-adapter: shared.
+package mtadapter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/logging"
+	pkgsource "knative.dev/pkg/source"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/saramaconfig"
+	kafkasource "knative.dev/eventing-kafka/pkg/source"
+	kadapter "knative.dev/eventing-kafka/pkg/source/adapter"
+)
+
+type adapterConfig struct {
+	adapter.EnvConfig
+
+	// SaramaConfig is the optional SaramaConfigSpec YAML of the consumer groups.
+	SaramaConfig string `envconfig:"KAFKA_SARAMA_CONFIG" required:"false"`
+}
+
+func NewEnvConfig() adapter.EnvConfigAccessor {
+	return &adapterConfig{}
+}
+
+// consumerGroup is a running consumer group, which can be paused without
+// leaving the group.
+type consumerGroup interface {
+	io.Closer
+	SetPaused(paused bool)
+}
+
+// startFunc starts consuming the topics of src, returning what stops it.
+type startFunc func(ctx context.Context, src *v1beta1.KafkaSource, migrateOffsetsFrom string, net kafkasource.AdapterNet) (consumerGroup, error)
+
+// sourceConsumerGroup pauses the consumer group by pausing the handler of
+// the source.
+type sourceConsumerGroup struct {
+	io.Closer
+	source *kadapter.Adapter
+}
+
+func (g *sourceConsumerGroup) SetPaused(paused bool) {
+	g.source.SetPaused(paused)
+}
+
+// sourceConsumer is a running consumer group of a KafkaSource.
+type sourceConsumer struct {
+	// hash identifies the configuration the consumer group was started with
+	hash          string
+	consumerGroup string
+	group         consumerGroup
+}
+
+// Adapter runs one consumer group per KafkaSource. Consumer groups are
+// started, restarted and stopped by the KafkaSource controller of the
+// shared receive adapter.
+type Adapter struct {
+	logger     *zap.SugaredLogger
+	reporter   pkgsource.StatsReporter
+	saramaSpec *saramaconfig.SaramaConfigSpec
+	start      startFunc
+
+	mu        sync.Mutex
+	consumers map[types.NamespacedName]*sourceConsumer
+}
+
+var _ adapter.Adapter = (*Adapter)(nil)
+var _ adapter.AdapterConstructor = NewAdapter
+
+// NewAdapter creates the shared receive adapter. The CloudEvents client is
+// not used since every source has its own sink.
+func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, _ cloudevents.Client) adapter.Adapter {
+	logger := logging.FromContext(ctx)
+
+	reporter, err := pkgsource.NewStatsReporter()
+	if err != nil {
+		logger.Errorw("Error building statsreporter", zap.Error(err))
+	}
+
+	var saramaSpec *saramaconfig.SaramaConfigSpec
+	if config, ok := processed.(*adapterConfig); ok && config.SaramaConfig != "" {
+		if saramaSpec, err = saramaconfig.Parse(config.SaramaConfig); err != nil {
+			logger.Errorw("Ignoring the invalid sarama configuration", zap.Error(err))
+		}
+	}
+
+	a := &Adapter{
+		logger:     logger,
+		reporter:   reporter,
+		saramaSpec: saramaSpec,
+		consumers:  make(map[types.NamespacedName]*sourceConsumer),
+	}
+	a.start = a.startConsumerGroup
+	return a
+}
+
+// Start blocks until ctx is done and then stops all the consumer groups.
+func (a *Adapter) Start(ctx context.Context) error {
+	<-ctx.Done()
+	a.logger.Info("Shutting down...")
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, c := range a.consumers {
+		a.closeConsumer(key, c)
+	}
+	a.consumers = make(map[types.NamespacedName]*sourceConsumer)
+	return nil
+}
+
+// Update starts the consumer group of src, or restarts it when the
+// configuration of src changed since it was started. Pausing and resuming
+// src does not restart the consumer group.
+func (a *Adapter) Update(ctx context.Context, src *v1beta1.KafkaSource, net kafkasource.AdapterNet) error {
+	key := types.NamespacedName{Namespace: src.Namespace, Name: src.Name}
+	hash, err := configHash(src, net)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	running, ok := a.consumers[key]
+	if ok && running.hash == hash {
+		running.group.SetPaused(src.IsPaused())
+		return nil
+	}
+
+	var migrateOffsetsFrom string
+	if ok {
+		if running.consumerGroup != src.Spec.ConsumerGroup && src.GetAnnotations()[v1beta1.KafkaMigrateOffsetsAnnotation] == "true" {
+			migrateOffsetsFrom = running.consumerGroup
+		}
+		a.closeConsumer(key, running)
+		delete(a.consumers, key)
+	}
+
+	a.logger.Infow("Starting consumer group", zap.Any("source", key), zap.String("consumerGroup", src.Spec.ConsumerGroup))
+	group, err := a.start(ctx, src, migrateOffsetsFrom, net)
+	if err != nil {
+		return err
+	}
+	a.consumers[key] = &sourceConsumer{
+		hash:          hash,
+		consumerGroup: src.Spec.ConsumerGroup,
+		group:         group,
+	}
+	return nil
+}
+
+// Remove stops the consumer group of the source, if running.
+func (a *Adapter) Remove(key types.NamespacedName) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if running, ok := a.consumers[key]; ok {
+		a.closeConsumer(key, running)
+		delete(a.consumers, key)
+	}
+}
+
+func (a *Adapter) closeConsumer(key types.NamespacedName, c *sourceConsumer) {
+	a.logger.Infow("Stopping consumer group", zap.Any("source", key), zap.String("consumerGroup", c.consumerGroup))
+	if err := c.group.Close(); err != nil {
+		a.logger.Warnw("Failed to close the consumer group", zap.Any("source", key), zap.Error(err))
+	}
+}
+
+func (a *Adapter) startConsumerGroup(ctx context.Context, src *v1beta1.KafkaSource, migrateOffsetsFrom string, net kafkasource.AdapterNet) (consumerGroup, error) {
+	config, err := kafkasource.NewConfigWithNet(net)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.saramaSpec.ApplyTo(config); err != nil {
+		return nil, err
+	}
+
+	httpMessageSender, err := kncloudevents.NewHTTPMessageSender(nil, src.Status.SinkURI.String())
+	if err != nil {
+		return nil, err
+	}
+
+	sourceAdapter, err := kadapter.NewSourceAdapter(ctx, src, migrateOffsetsFrom, httpMessageSender, a.reporter)
+	if err != nil {
+		return nil, err
+	}
+	group, err := sourceAdapter.StartConsumerGroup(src.Spec.BootstrapServers, config)
+	if err != nil {
+		return nil, err
+	}
+	return &sourceConsumerGroup{Closer: group, source: sourceAdapter}, nil
+}
+
+// configHash returns a digest of everything the consumer group of src
+// depends on.
+func configHash(src *v1beta1.KafkaSource, net kafkasource.AdapterNet) (string, error) {
+	b, err := json.Marshal(struct {
+		Spec    v1beta1.KafkaSourceSpec
+		SinkURI string
+		KeyType string
+		Net     kafkasource.AdapterNet
+	}{
+		Spec:    src.Spec,
+		SinkURI: src.Status.SinkURI.String(),
+		KeyType: src.GetLabels()[v1beta1.KafkaKeyTypeLabel],
+		Net:     net,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtadapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkasource "knative.dev/eventing-kafka/pkg/source"
+)
+
+type fakeConsumerGroup struct {
+	closed bool
+	paused bool
+}
+
+func (c *fakeConsumerGroup) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *fakeConsumerGroup) SetPaused(paused bool) {
+	c.paused = paused
+}
+
+type startCall struct {
+	consumerGroup      string
+	migrateOffsetsFrom string
+	group              *fakeConsumerGroup
+}
+
+func newTestAdapter(calls *[]*startCall) *Adapter {
+	a := &Adapter{
+		logger:    zap.NewNop().Sugar(),
+		consumers: make(map[types.NamespacedName]*sourceConsumer),
+	}
+	a.start = func(_ context.Context, src *v1beta1.KafkaSource, migrateOffsetsFrom string, _ kafkasource.AdapterNet) (consumerGroup, error) {
+		call := &startCall{
+			consumerGroup:      src.Spec.ConsumerGroup,
+			migrateOffsetsFrom: migrateOffsetsFrom,
+			group:              &fakeConsumerGroup{paused: src.IsPaused()},
+		}
+		*calls = append(*calls, call)
+		return call.group, nil
+	}
+	return a
+}
+
+func newSharedSource() *v1beta1.KafkaSource {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "source",
+			Annotations: map[string]string{
+				v1beta1.KafkaReceiveAdapterAnnotation: v1beta1.KafkaReceiveAdapterShared,
+			},
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"kafka:9092"},
+			},
+			Topics:        []string{"topic"},
+			ConsumerGroup: "group",
+		},
+	}
+	src.Status.InitializeConditions()
+	src.Status.MarkSink(apis.HTTP("sink"))
+	return src
+}
+
+func TestAdapterUpdate(t *testing.T) {
+	var calls []*startCall
+	a := newTestAdapter(&calls)
+	src := newSharedSource()
+
+	require.Nil(t, a.Update(context.TODO(), src, kafkasource.AdapterNet{}))
+	require.Len(t, calls, 1)
+
+	// Same configuration, the consumer group keeps running
+	require.Nil(t, a.Update(context.TODO(), src.DeepCopy(), kafkasource.AdapterNet{}))
+	require.Len(t, calls, 1)
+
+	// New topic, the consumer group is restarted
+	src.Spec.Topics = append(src.Spec.Topics, "other")
+	require.Nil(t, a.Update(context.TODO(), src, kafkasource.AdapterNet{}))
+	require.Len(t, calls, 2)
+	assert.True(t, calls[0].group.closed)
+	assert.False(t, calls[1].group.closed)
+
+	// Rotated credentials, the consumer group is restarted
+	net := kafkasource.AdapterNet{SASL: kafkasource.AdapterSASL{Enable: true, User: "user", Password: "rotated"}}
+	require.Nil(t, a.Update(context.TODO(), src, net))
+	require.Len(t, calls, 3)
+	assert.True(t, calls[1].group.closed)
+}
+
+func TestAdapterUpdatePaused(t *testing.T) {
+	var calls []*startCall
+	a := newTestAdapter(&calls)
+	src := newSharedSource()
+	src.Annotations[v1beta1.KafkaPausedAnnotation] = "true"
+
+	require.Nil(t, a.Update(context.TODO(), src, kafkasource.AdapterNet{}))
+	require.Len(t, calls, 1)
+	assert.True(t, calls[0].group.paused)
+
+	// Resumed without restarting the consumer group
+	src.Annotations[v1beta1.KafkaPausedAnnotation] = "false"
+	require.Nil(t, a.Update(context.TODO(), src, kafkasource.AdapterNet{}))
+	require.Len(t, calls, 1)
+	assert.False(t, calls[0].group.paused)
+	assert.False(t, calls[0].group.closed)
+
+	// Paused again
+	src.Annotations[v1beta1.KafkaPausedAnnotation] = "true"
+	require.Nil(t, a.Update(context.TODO(), src, kafkasource.AdapterNet{}))
+	require.Len(t, calls, 1)
+	assert.True(t, calls[0].group.paused)
+}
+
+func TestAdapterUpdateMigrateOffsets(t *testing.T) {
+	var calls []*startCall
+	a := newTestAdapter(&calls)
+	src := newSharedSource()
+	require.Nil(t, a.Update(context.TODO(), src, kafkasource.AdapterNet{}))
+
+	src.Spec.ConsumerGroup = "group2"
+	require.Nil(t, a.Update(context.TODO(), src, kafkasource.AdapterNet{}))
+	require.Len(t, calls, 2)
+	assert.Equal(t, "", calls[1].migrateOffsetsFrom)
+
+	src.Annotations[v1beta1.KafkaMigrateOffsetsAnnotation] = "true"
+	src.Spec.ConsumerGroup = "group3"
+	require.Nil(t, a.Update(context.TODO(), src, kafkasource.AdapterNet{}))
+	require.Len(t, calls, 3)
+	assert.Equal(t, "group3", calls[2].consumerGroup)
+	assert.Equal(t, "group2", calls[2].migrateOffsetsFrom)
+}
+
+func TestAdapterUpdateError(t *testing.T) {
+	a := &Adapter{
+		logger:    zap.NewNop().Sugar(),
+		consumers: make(map[types.NamespacedName]*sourceConsumer),
+		start: func(context.Context, *v1beta1.KafkaSource, string, kafkasource.AdapterNet) (consumerGroup, error) {
+			return nil, errors.New("no brokers")
+		},
+	}
+
+	assert.NotNil(t, a.Update(context.TODO(), newSharedSource(), kafkasource.AdapterNet{}))
+	assert.Empty(t, a.consumers)
+}
+
+func TestAdapterRemoveAndStart(t *testing.T) {
+	var calls []*startCall
+	a := newTestAdapter(&calls)
+
+	src := newSharedSource()
+	other := newSharedSource()
+	other.Name = "other"
+	require.Nil(t, a.Update(context.TODO(), src, kafkasource.AdapterNet{}))
+	require.Nil(t, a.Update(context.TODO(), other, kafkasource.AdapterNet{}))
+
+	a.Remove(types.NamespacedName{Namespace: "ns", Name: "source"})
+	assert.True(t, calls[0].group.closed)
+	assert.False(t, calls[1].group.closed)
+
+	// Removing an unknown source is a no-op
+	a.Remove(types.NamespacedName{Namespace: "ns", Name: "unknown"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Nil(t, a.Start(ctx))
+	assert.True(t, calls[1].group.closed)
+	assert.Empty(t, a.consumers)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtadapter
+
+import (
+	"context"
+
+	"knative.dev/eventing/pkg/adapter/v2"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client"
+	kafkainformer "knative.dev/eventing-kafka/pkg/client/injection/informers/sources/v1beta1/kafkasource"
+)
+
+var _ adapter.ControllerConstructor = NewController
+
+// NewController creates the controller driving the consumer groups of the
+// shared receive adapter.
+func NewController(ctx context.Context, a adapter.Adapter) *controller.Impl {
+	kafkaInformer := kafkainformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+
+	r := &Reconciler{
+		kafkaClientSet: kafkaclient.Get(ctx),
+		kafkaLister:    kafkaInformer.Lister(),
+		secretLister:   secretInformer.Lister(),
+		consumers:      a.(*Adapter),
+	}
+	impl := controller.NewImpl(r, logging.FromContext(ctx), "KafkaSources")
+
+	logging.FromContext(ctx).Info("Setting up kafka event handlers")
+
+	kafkaInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Restart the consumer groups of the sources referencing a secret when
+	// it changes.
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		secret, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			return
+		}
+		impl.FilteredGlobalResync(func(obj interface{}) bool {
+			src, ok := obj.(*v1beta1.KafkaSource)
+			return ok && src.Namespace == secret.GetNamespace() && src.IsSharedReceiveAdapter()
+		}, kafkaInformer.Informer())
+	}))
+
+	return impl
+}
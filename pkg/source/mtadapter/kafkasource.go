@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtadapter
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/sources/v1beta1"
+	kafkasource "knative.dev/eventing-kafka/pkg/source"
+)
+
+const consumerGroupFailed = "ConsumerGroupFailed"
+
+// consumerManager runs the consumer groups of the KafkaSources.
+type consumerManager interface {
+	Update(ctx context.Context, src *v1beta1.KafkaSource, net kafkasource.AdapterNet) error
+	Remove(key types.NamespacedName)
+}
+
+// Reconciler keeps the consumer groups of the shared receive adapter in sync
+// with the KafkaSources, and reports whether each source is consuming in its
+// Deployed condition. The rest of the status is owned by the KafkaSource
+// controller.
+type Reconciler struct {
+	kafkaClientSet versioned.Interface
+	kafkaLister    listers.KafkaSourceLister
+	secretLister   corev1listers.SecretLister
+
+	consumers consumerManager
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	logger := logging.FromContext(ctx)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		logger.Errorw("Invalid resource key", zap.String("key", key))
+		return nil
+	}
+	nn := types.NamespacedName{Namespace: namespace, Name: name}
+
+	original, err := r.kafkaLister.KafkaSources(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		r.consumers.Remove(nn)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// The sink is resolved by the KafkaSource controller. Sources without a
+	// sink are not consumed.
+	src := original.DeepCopy()
+	if !original.IsSharedReceiveAdapter() || original.DeletionTimestamp != nil ||
+		!original.Status.GetCondition(v1beta1.KafkaConditionSinkProvided).IsTrue() {
+		r.consumers.Remove(nn)
+		if original.DeletionTimestamp != nil || original.Status.GetCondition(v1beta1.KafkaConditionConsuming) == nil {
+			return nil
+		}
+		// The sources no longer served do not keep a stale condition
+		if err := src.Status.ClearConsuming(); err != nil {
+			return err
+		}
+		return r.updateStatus(ctx, src)
+	}
+
+	net, err := r.resolveNet(src)
+	if err == nil {
+		err = r.consumers.Update(ctx, src, net)
+	}
+	if err != nil {
+		src.Status.MarkNotConsuming(consumerGroupFailed, "%v", err)
+	} else {
+		src.Status.MarkConsuming()
+	}
+
+	if !equality.Semantic.DeepEqual(original.Status, src.Status) {
+		if uerr := r.updateStatus(ctx, src); uerr != nil {
+			return uerr
+		}
+	}
+	return err
+}
+
+// updateStatus updates the status of src with its Consuming condition.
+func (r *Reconciler) updateStatus(ctx context.Context, src *v1beta1.KafkaSource) error {
+	if _, err := r.kafkaClientSet.SourcesV1beta1().KafkaSources(src.Namespace).UpdateStatus(ctx, src, metav1.UpdateOptions{}); err != nil {
+		logging.FromContext(ctx).Warnw("Failed to update the KafkaSource status", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// resolveNet returns the network and authentication settings of src with the
+// values of the referenced secrets.
+func (r *Reconciler) resolveNet(src *v1beta1.KafkaSource) (kafkasource.AdapterNet, error) {
+	spec := src.Spec.Net
+	net := kafkasource.AdapterNet{
+		SASL: kafkasource.AdapterSASL{Enable: spec.SASL.Enable},
+		TLS:  kafkasource.AdapterTLS{Enable: spec.TLS.Enable},
+	}
+
+	values := []struct {
+		ref   *corev1.SecretKeySelector
+		value *string
+	}{
+		{spec.SASL.User.SecretKeyRef, &net.SASL.User},
+		{spec.SASL.Password.SecretKeyRef, &net.SASL.Password},
+		{spec.TLS.Cert.SecretKeyRef, &net.TLS.Cert},
+		{spec.TLS.Key.SecretKeyRef, &net.TLS.Key},
+		{spec.TLS.CACert.SecretKeyRef, &net.TLS.CACert},
+	}
+	for _, v := range values {
+		value, err := r.secretValue(src.Namespace, v.ref)
+		if err != nil {
+			return net, err
+		}
+		*v.value = value
+	}
+	return net, nil
+}
+
+// secretValue returns the value of the secret key described by ref, or an
+// empty string when ref is nil or optional and missing.
+func (r *Reconciler) secretValue(namespace string, ref *corev1.SecretKeySelector) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	optional := ref.Optional != nil && *ref.Optional
+
+	secret, err := r.secretLister.Secrets(namespace).Get(ref.Name)
+	if apierrors.IsNotFound(err) && optional {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", ref.Name, err)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok && !optional {
+		return "", fmt.Errorf("key %s not found in secret %s", ref.Key, ref.Name)
+	}
+	return string(value), nil
+}
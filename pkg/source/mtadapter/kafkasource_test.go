@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtadapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/sources/v1beta1"
+	kafkasource "knative.dev/eventing-kafka/pkg/source"
+)
+
+type fakeConsumers struct {
+	err     error
+	updated map[types.NamespacedName]kafkasource.AdapterNet
+	removed []types.NamespacedName
+}
+
+func (f *fakeConsumers) Update(_ context.Context, src *v1beta1.KafkaSource, net kafkasource.AdapterNet) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.updated[types.NamespacedName{Namespace: src.Namespace, Name: src.Name}] = net
+	return nil
+}
+
+func (f *fakeConsumers) Remove(key types.NamespacedName) {
+	f.removed = append(f.removed, key)
+}
+
+func newTestReconciler(t *testing.T, consumers consumerManager, objs ...interface{}) (*Reconciler, *fake.Clientset) {
+	kafkaIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	client := fake.NewSimpleClientset()
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *v1beta1.KafkaSource:
+			require.Nil(t, kafkaIndexer.Add(o))
+			_, err := client.SourcesV1beta1().KafkaSources(o.Namespace).Create(context.TODO(), o, metav1.CreateOptions{})
+			require.Nil(t, err)
+		case *corev1.Secret:
+			require.Nil(t, secretIndexer.Add(o))
+		}
+	}
+
+	return &Reconciler{
+		kafkaClientSet: client,
+		kafkaLister:    listers.NewKafkaSourceLister(kafkaIndexer),
+		secretLister:   corev1listers.NewSecretLister(secretIndexer),
+		consumers:      consumers,
+	}, client
+}
+
+func TestReconcileConsuming(t *testing.T) {
+	src := newSharedSource()
+	src.Spec.Net = bindingsv1beta1.KafkaNetSpec{
+		SASL: bindingsv1beta1.KafkaSASLSpec{
+			Enable: true,
+			User: bindingsv1beta1.SecretValueFromSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "auth"},
+					Key:                  "user",
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "auth"},
+		Data:       map[string][]byte{"user": []byte("alice")},
+	}
+
+	consumers := &fakeConsumers{updated: map[types.NamespacedName]kafkasource.AdapterNet{}}
+	r, client := newTestReconciler(t, consumers, src, secret)
+
+	require.Nil(t, r.Reconcile(context.TODO(), "ns/source"))
+
+	net := consumers.updated[types.NamespacedName{Namespace: "ns", Name: "source"}]
+	assert.True(t, net.SASL.Enable)
+	assert.Equal(t, "alice", net.SASL.User)
+
+	updated, err := client.SourcesV1beta1().KafkaSources("ns").Get(context.TODO(), "source", metav1.GetOptions{})
+	require.Nil(t, err)
+	assert.True(t, updated.Status.GetCondition(v1beta1.KafkaConditionConsuming).IsTrue())
+	assert.True(t, updated.Status.GetCondition(v1beta1.KafkaConditionDeployed).IsUnknown(), "the Deployed condition is set by the KafkaSource reconciler")
+}
+
+func TestReconcileNotConsuming(t *testing.T) {
+	testCases := map[string]struct {
+		secrets []interface{}
+		err     error
+	}{
+		"missing secret": {},
+		"consumer group error": {
+			secrets: []interface{}{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "auth"},
+				Data:       map[string][]byte{"user": []byte("alice")},
+			}},
+			err: errors.New("no brokers"),
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := newSharedSource()
+			src.Spec.Net.SASL.User.SecretKeyRef = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "auth"},
+				Key:                  "user",
+			}
+
+			consumers := &fakeConsumers{err: tc.err, updated: map[types.NamespacedName]kafkasource.AdapterNet{}}
+			r, client := newTestReconciler(t, consumers, append(tc.secrets, src)...)
+
+			assert.NotNil(t, r.Reconcile(context.TODO(), "ns/source"))
+
+			updated, err := client.SourcesV1beta1().KafkaSources("ns").Get(context.TODO(), "source", metav1.GetOptions{})
+			require.Nil(t, err)
+			cond := updated.Status.GetCondition(v1beta1.KafkaConditionConsuming)
+			require.NotNil(t, cond)
+			assert.True(t, cond.IsFalse())
+			assert.Equal(t, consumerGroupFailed, cond.Reason)
+		})
+	}
+}
+
+func TestReconcileRemove(t *testing.T) {
+	dedicated := newSharedSource()
+	dedicated.Name = "dedicated"
+	dedicated.Annotations = nil
+
+	noSink := newSharedSource()
+	noSink.Name = "nosink"
+	noSink.Status.MarkNoSink("NotFound", "")
+
+	consumers := &fakeConsumers{updated: map[types.NamespacedName]kafkasource.AdapterNet{}}
+	r, _ := newTestReconciler(t, consumers, dedicated, noSink)
+
+	for _, key := range []string{"ns/dedicated", "ns/nosink", "ns/deleted"} {
+		require.Nil(t, r.Reconcile(context.TODO(), key))
+	}
+	assert.Empty(t, consumers.updated)
+	assert.Equal(t, []types.NamespacedName{
+		{Namespace: "ns", Name: "dedicated"},
+		{Namespace: "ns", Name: "nosink"},
+		{Namespace: "ns", Name: "deleted"},
+	}, consumers.removed)
+}
+
+func TestReconcileRemoveClearsConsuming(t *testing.T) {
+	dedicated := newSharedSource()
+	dedicated.Annotations = nil
+	dedicated.Status.MarkConsuming()
+
+	consumers := &fakeConsumers{updated: map[types.NamespacedName]kafkasource.AdapterNet{}}
+	r, client := newTestReconciler(t, consumers, dedicated)
+
+	require.Nil(t, r.Reconcile(context.TODO(), "ns/source"))
+
+	updated, err := client.SourcesV1beta1().KafkaSources("ns").Get(context.TODO(), "source", metav1.GetOptions{})
+	require.Nil(t, err)
+	assert.Nil(t, updated.Status.GetCondition(v1beta1.KafkaConditionConsuming))
+}
@@ -35,6 +35,7 @@ import (
 	kafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client"
 	kafkainformer "knative.dev/eventing-kafka/pkg/client/injection/informers/sources/v1beta1/kafkasource"
 	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
+	"knative.dev/eventing-kafka/pkg/common/saramaconfig"
 )
 
 func NewController(
@@ -48,6 +49,15 @@ func NewController(
 		return nil
 	}
 
+	// The optional SaramaConfigSpec of the receive adapters
+	saramaConfig := os.Getenv(saramaConfigEnvVar)
+	if saramaConfig != "" {
+		if _, err := saramaconfig.Parse(saramaConfig); err != nil {
+			logging.FromContext(ctx).Errorf("invalid environment variable '%s': %v", saramaConfigEnvVar, err)
+			return nil
+		}
+	}
+
 	kafkaInformer := kafkainformer.Get(ctx)
 	deploymentInformer := deploymentinformer.Get(ctx)
 
@@ -57,6 +67,7 @@ func NewController(
 		kafkaLister:         kafkaInformer.Lister(),
 		deploymentLister:    deploymentInformer.Lister(),
 		receiveAdapterImage: raImage,
+		saramaConfig:        saramaConfig,
 		loggingContext:      ctx,
 		configs:             source.WatchConfigurations(ctx, component, cmw),
 	}
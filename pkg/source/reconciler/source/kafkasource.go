@@ -49,10 +49,12 @@ import (
 
 const (
 	raImageEnvVar                = "KAFKA_RA_IMAGE"
+	saramaConfigEnvVar           = "KAFKA_SARAMA_CONFIG"
 	kafkaSourceDeploymentCreated = "KafkaSourceDeploymentCreated"
 	kafkaSourceDeploymentUpdated = "KafkaSourceDeploymentUpdated"
 	kafkaSourceDeploymentFailed  = "KafkaSourceDeploymentUpdated"
 	component                    = "kafkasource"
+	sharedReceiveAdapterPending  = "SharedReceiveAdapterPending"
 )
 
 // newDeploymentCreated makes a new reconciler event with event type Normal, and
@@ -78,6 +80,8 @@ type Reconciler struct {
 	KubeClientSet kubernetes.Interface
 
 	receiveAdapterImage string
+	// saramaConfig is the optional SaramaConfigSpec YAML of the receive adapters.
+	saramaConfig string
 
 	kafkaLister      listers.KafkaSourceLister
 	deploymentLister appsv1listers.DeploymentLister
@@ -137,6 +141,16 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 		}
 	}
 
+	if src.IsPaused() {
+		src.Status.MarkPaused()
+	} else {
+		src.Status.MarkNotPaused()
+	}
+
+	if src.IsSharedReceiveAdapter() {
+		return r.reconcileSharedReceiveAdapter(ctx, src)
+	}
+
 	// TODO(mattmoor): create KafkaBinding for the receive adapter.
 
 	ra, err := r.createReceiveAdapter(ctx, src, sinkURI)
@@ -157,6 +171,21 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	return nil
 }
 
+// reconcileSharedReceiveAdapter hands src over to the shared receive adapter,
+// which reports in the Consuming condition whether it consumes the topics.
+// That condition is only read here, to set the Deployed condition.
+func (r *Reconciler) reconcileSharedReceiveAdapter(ctx context.Context, src *v1beta1.KafkaSource) pkgreconciler.Event {
+	err := r.deleteReceiveAdapter(ctx, src)
+	if err != nil && !apierrors.IsNotFound(err) {
+		logging.FromContext(ctx).Error("Unable to delete the dedicated receive adapter", zap.Error(err))
+		return err
+	}
+
+	src.Status.PropagateConsuming(sharedReceiveAdapterPending, "Waiting for the shared receive adapter to consume the topics")
+	src.Status.CloudEventAttributes = r.createCloudEventAttributes(src)
+	return nil
+}
+
 func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1beta1.KafkaSource, sinkURI *apis.URL) (*appsv1.Deployment, error) {
 	raArgs := resources.ReceiveAdapterArgs{
 		Image:          r.receiveAdapterImage,
@@ -164,6 +193,7 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1beta1.Kafk
 		Labels:         resources.GetLabels(src.Name),
 		SinkURI:        sinkURI.String(),
 		AdditionalEnvs: r.configs.ToEnvVars(),
+		SaramaConfig:   r.saramaConfig,
 	}
 	expected := resources.MakeReceiveAdapter(&raArgs)
 
@@ -179,18 +209,49 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1beta1.Kafk
 		return nil, err
 	} else if !metav1.IsControlledBy(ra, src) {
 		return nil, fmt.Errorf("deployment %q is not owned by KafkaSource %q", ra.Name, src.Name)
-	} else if podSpecChanged(ra.Spec.Template.Spec, expected.Spec.Template.Spec) {
+	}
+
+	if raArgs.MigrateOffsetsFrom = offsetsMigrationSource(src, ra); raArgs.MigrateOffsetsFrom != "" {
+		expected = resources.MakeReceiveAdapter(&raArgs)
+	}
+
+	if podSpecChanged(ra.Spec.Template.Spec, expected.Spec.Template.Spec) {
 		ra.Spec.Template.Spec = expected.Spec.Template.Spec
 		if ra, err = r.KubeClientSet.AppsV1().Deployments(src.Namespace).Update(ctx, ra, metav1.UpdateOptions{}); err != nil {
 			return ra, err
 		}
 		return ra, deploymentUpdated(ra.Namespace, ra.Name)
-	} else {
-		logging.FromContext(ctx).Debug("Reusing existing receive adapter", zap.Any("receiveAdapter", ra))
 	}
+	logging.FromContext(ctx).Debug("Reusing existing receive adapter", zap.Any("receiveAdapter", ra))
 	return ra, nil
 }
 
+// offsetsMigrationSource returns the consumer group the receive adapter must
+// copy the committed offsets from, or an empty string. The migration is
+// requested when the consumer group of the source no longer matches the one
+// of the receive adapter and the source has the migrate offsets annotation.
+// Once rolled out, the migration is kept so that the deployment stays stable.
+func offsetsMigrationSource(src *v1beta1.KafkaSource, ra *appsv1.Deployment) string {
+	if src.GetAnnotations()[v1beta1.KafkaMigrateOffsetsAnnotation] != "true" || len(ra.Spec.Template.Spec.Containers) == 0 {
+		return ""
+	}
+
+	var deployedGroup, deployedMigration string
+	for _, env := range ra.Spec.Template.Spec.Containers[0].Env {
+		switch env.Name {
+		case "KAFKA_CONSUMER_GROUP":
+			deployedGroup = env.Value
+		case "KAFKA_MIGRATE_OFFSETS_FROM":
+			deployedMigration = env.Value
+		}
+	}
+
+	if deployedGroup != "" && deployedGroup != src.Spec.ConsumerGroup {
+		return deployedGroup
+	}
+	return deployedMigration
+}
+
 //deleteReceiveAdapter deletes the receiver adapter deployment if any
 func (r *Reconciler) deleteReceiveAdapter(ctx context.Context, src *v1beta1.KafkaSource) error {
 	name := utils.GenerateFixedName(src, fmt.Sprintf("kafkasource-%s", src.Name))
@@ -17,10 +17,12 @@ limitations under the License.
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/rickb777/date/period"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,12 +31,22 @@ import (
 	"knative.dev/pkg/kmeta"
 )
 
+// saslMountPath is the directory the secret keys of the SASL credentials are
+// mounted in, the kubelet updating them when the secrets change so that the
+// receive adapter rotates the credentials without restarting.
+const saslMountPath = "/etc/kafka-sasl"
+
 type ReceiveAdapterArgs struct {
 	Image          string
 	Source         *v1beta1.KafkaSource
 	Labels         map[string]string
 	SinkURI        string
 	AdditionalEnvs []corev1.EnvVar
+	// MigrateOffsetsFrom is the consumer group the committed offsets are
+	// copied from before consuming, if any.
+	MigrateOffsetsFrom string
+	// SaramaConfig is the SaramaConfigSpec YAML of the sarama client, if any.
+	SaramaConfig string
 }
 
 func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
@@ -73,8 +85,66 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 		})
 	}
 
+	if args.MigrateOffsetsFrom != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_MIGRATE_OFFSETS_FROM",
+			Value: args.MigrateOffsetsFrom,
+		})
+	}
+
+	if args.Source.Spec.CloudEventMapping != nil {
+		// Marshalling a plain struct cannot fail
+		mapping, _ := json.Marshal(args.Source.Spec.CloudEventMapping)
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_CE_MAPPING",
+			Value: string(mapping),
+		})
+	}
+
+	if len(args.Source.Spec.Filters) > 0 {
+		filters, _ := json.Marshal(args.Source.Spec.Filters)
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_FILTERS",
+			Value: string(filters),
+		})
+	}
+
+	if batch := args.Source.Spec.Batch; batch != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_BATCH_MAX_SIZE",
+			Value: strconv.Itoa(int(batch.MaxSize)),
+		})
+		if batch.MaxWait != nil {
+			// Validated by the webhook
+			if maxWait, err := period.Parse(*batch.MaxWait); err == nil {
+				env = append(env, corev1.EnvVar{
+					Name:  "KAFKA_BATCH_MAX_WAIT",
+					Value: maxWait.DurationApprox().String(),
+				})
+			}
+		}
+	}
+
+	if args.SaramaConfig != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_SARAMA_CONFIG",
+			Value: args.SaramaConfig,
+		})
+	}
+
+	if args.Source.IsPaused() {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_PAUSED",
+			Value: "true",
+		})
+	}
+
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_USER", args.Source.Spec.Net.SASL.User.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_PASSWORD", args.Source.Spec.Net.SASL.Password.SecretKeyRef)
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	volumes, volumeMounts, env = appendSecretKeyVolume(volumes, volumeMounts, env, "user", "KAFKA_NET_SASL_USER_FILE", args.Source.Spec.Net.SASL.User.SecretKeyRef)
+	volumes, volumeMounts, env = appendSecretKeyVolume(volumes, volumeMounts, env, "password", "KAFKA_NET_SASL_PASSWORD_FILE", args.Source.Spec.Net.SASL.Password.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_CERT", args.Source.Spec.Net.TLS.Cert.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_KEY", args.Source.Spec.Net.TLS.Key.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_CA_CERT", args.Source.Spec.Net.TLS.CACert.SecretKeyRef)
@@ -101,11 +171,13 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 					Labels: args.Labels,
 				},
 				Spec: corev1.PodSpec{
+					Volumes: volumes,
 					Containers: []corev1.Container{
 						{
-							Name:  "receive-adapter",
-							Image: args.Image,
-							Env:   env,
+							Name:         "receive-adapter",
+							Image:        args.Image,
+							Env:          env,
+							VolumeMounts: volumeMounts,
 						},
 					},
 				},
@@ -131,3 +203,38 @@ func appendEnvFromSecretKeyRef(env []corev1.EnvVar, key string, ref *corev1.Secr
 
 	return env
 }
+
+// appendSecretKeyVolume returns volumes and volumeMounts with a volume of the
+// secret key described by ref appended, mounted as the file name in its own
+// directory of saslMountPath (so that it is updated, unlike a subPath), and env
+// with an EnvVar setting key to the path of the file.
+// If ref is nil, they are returned unchanged.
+func appendSecretKeyVolume(volumes []corev1.Volume, volumeMounts []corev1.VolumeMount, env []corev1.EnvVar, name string, key string, ref *corev1.SecretKeySelector) ([]corev1.Volume, []corev1.VolumeMount, []corev1.EnvVar) {
+	if ref == nil {
+		return volumes, volumeMounts, env
+	}
+
+	volumeName := "kafka-sasl-" + name
+	mountPath := saslMountPath + "/" + name
+	volumes = append(volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: ref.Name,
+				Items:      []corev1.KeyToPath{{Key: ref.Key, Path: name}},
+				Optional:   ref.Optional,
+			},
+		},
+	})
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+	env = append(env, corev1.EnvVar{
+		Name:  key,
+		Value: mountPath + "/" + name,
+	})
+
+	return volumes, volumeMounts, env
+}
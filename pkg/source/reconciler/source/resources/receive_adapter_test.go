@@ -19,6 +19,7 @@ package resources
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -584,3 +585,206 @@ func TestMakeReceiveAdapterKeyType(t *testing.T) {
 		t.Errorf("unexpected deploy (-want, +got) = %v", diff)
 	}
 }
+
+func TestMakeReceiveAdapterWithCloudEventMapping(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+			CloudEventMapping: &v1beta1.KafkaCloudEventMappingSpec{
+				Type: &v1beta1.KafkaAttributeMapping{
+					From: []v1beta1.KafkaAttributeSource{{Header: "type"}},
+				},
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	var mapping string
+	for _, env := range got.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "KAFKA_CE_MAPPING" {
+			mapping = env.Value
+		}
+	}
+	if want := `{"type":{"from":[{"header":"type"}]}}`; mapping != want {
+		t.Errorf("unexpected KAFKA_CE_MAPPING, want %q, got %q", want, mapping)
+	}
+}
+
+func TestMakeReceiveAdapterWithBatch(t *testing.T) {
+	maxWait := "PT0.5S"
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+			Batch: &v1beta1.KafkaSourceBatchSpec{
+				MaxSize: 50,
+				MaxWait: &maxWait,
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := make(map[string]string)
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["KAFKA_BATCH_MAX_SIZE"] != "50" {
+		t.Errorf("unexpected KAFKA_BATCH_MAX_SIZE, want %q, got %q", "50", env["KAFKA_BATCH_MAX_SIZE"])
+	}
+	if env["KAFKA_BATCH_MAX_WAIT"] != "500ms" {
+		t.Errorf("unexpected KAFKA_BATCH_MAX_WAIT, want %q, got %q", "500ms", env["KAFKA_BATCH_MAX_WAIT"])
+	}
+}
+
+func TestMakeReceiveAdapterPaused(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "source-name",
+			Namespace:   "source-namespace",
+			Annotations: map[string]string{v1beta1.KafkaPausedAnnotation: "true"},
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := make(map[string]string)
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["KAFKA_PAUSED"] != "true" {
+		t.Errorf("unexpected KAFKA_PAUSED, want %q, got %q", "true", env["KAFKA_PAUSED"])
+	}
+}
+
+func TestMakeReceiveAdapterWithSaramaConfig(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:        "test-image",
+		Source:       src,
+		SinkURI:      "sink-uri",
+		SaramaConfig: "apiVersion: v1\nkafkaVersion: 2.3.0\n",
+	})
+
+	env := make(map[string]string)
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if want := "apiVersion: v1\nkafkaVersion: 2.3.0\n"; env["KAFKA_SARAMA_CONFIG"] != want {
+		t.Errorf("unexpected KAFKA_SARAMA_CONFIG, want %q, got %q", want, env["KAFKA_SARAMA_CONFIG"])
+	}
+}
+
+func TestMakeReceiveAdapterWithSASLVolumes(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				Net: bindingsv1beta1.KafkaNetSpec{
+					SASL: bindingsv1beta1.KafkaSASLSpec{
+						Enable: true,
+						User: bindingsv1beta1.SecretValueFromSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "the-user-secret"},
+								Key:                  "user",
+							},
+						},
+						Password: bindingsv1beta1.SecretValueFromSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "the-password-secret"},
+								Key:                  "password",
+							},
+						},
+					},
+				},
+			},
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	wantVolumes := []corev1.Volume{{
+		Name: "kafka-sasl-user",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "the-user-secret",
+				Items:      []corev1.KeyToPath{{Key: "user", Path: "user"}},
+			},
+		},
+	}, {
+		Name: "kafka-sasl-password",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "the-password-secret",
+				Items:      []corev1.KeyToPath{{Key: "password", Path: "password"}},
+			},
+		},
+	}}
+	if diff := cmp.Diff(wantVolumes, got.Spec.Template.Spec.Volumes); diff != "" {
+		t.Errorf("unexpected volumes (-want, +got) = %v", diff)
+	}
+
+	wantVolumeMounts := []corev1.VolumeMount{
+		{Name: "kafka-sasl-user", MountPath: "/etc/kafka-sasl/user", ReadOnly: true},
+		{Name: "kafka-sasl-password", MountPath: "/etc/kafka-sasl/password", ReadOnly: true},
+	}
+	if diff := cmp.Diff(wantVolumeMounts, got.Spec.Template.Spec.Containers[0].VolumeMounts); diff != "" {
+		t.Errorf("unexpected volume mounts (-want, +got) = %v", diff)
+	}
+
+	env := make(map[string]string)
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if want := "/etc/kafka-sasl/user/user"; env["KAFKA_NET_SASL_USER_FILE"] != want {
+		t.Errorf("unexpected KAFKA_NET_SASL_USER_FILE, want %q, got %q", want, env["KAFKA_NET_SASL_USER_FILE"])
+	}
+	if want := "/etc/kafka-sasl/password/password"; env["KAFKA_NET_SASL_PASSWORD_FILE"] != want {
+		t.Errorf("unexpected KAFKA_NET_SASL_PASSWORD_FILE, want %q, got %q", want, env["KAFKA_NET_SASL_PASSWORD_FILE"])
+	}
+}